@@ -0,0 +1,655 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.11
+// 	protoc        v6.33.1
+// source: api/proto/auth/v1/relation.proto
+
+package authv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+// A relation tuple, e.g. object "document:readme", relation "viewer",
+// subject "user:alice" or "group:eng#member"
+type RelationTuple struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationTuple) Reset() {
+	*x = RelationTuple{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationTuple) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationTuple) ProtoMessage() {}
+
+func (x *RelationTuple) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationTuple.ProtoReflect.Descriptor instead.
+func (*RelationTuple) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *RelationTuple) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *RelationTuple) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+// A node of an expanded relation
+type RelationTree struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subjects      []string               `protobuf:"bytes,3,rep,name=subjects,proto3" json:"subjects,omitempty"`
+	Children      []*RelationTree        `protobuf:"bytes,4,rep,name=children,proto3" json:"children,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RelationTree) Reset() {
+	*x = RelationTree{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RelationTree) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RelationTree) ProtoMessage() {}
+
+func (x *RelationTree) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RelationTree.ProtoReflect.Descriptor instead.
+func (*RelationTree) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *RelationTree) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *RelationTree) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *RelationTree) GetSubjects() []string {
+	if x != nil {
+		return x.Subjects
+	}
+	return nil
+}
+
+func (x *RelationTree) GetChildren() []*RelationTree {
+	if x != nil {
+		return x.Children
+	}
+	return nil
+}
+
+type CheckRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRelationRequest) Reset() {
+	*x = CheckRelationRequest{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRelationRequest) ProtoMessage() {}
+
+func (x *CheckRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRelationRequest.ProtoReflect.Descriptor instead.
+func (*CheckRelationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CheckRelationRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *CheckRelationRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *CheckRelationRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type CheckRelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Allowed       bool                   `protobuf:"varint,1,opt,name=allowed,proto3" json:"allowed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CheckRelationResponse) Reset() {
+	*x = CheckRelationResponse{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CheckRelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CheckRelationResponse) ProtoMessage() {}
+
+func (x *CheckRelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CheckRelationResponse.ProtoReflect.Descriptor instead.
+func (*CheckRelationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{3}
+}
+
+func (x *CheckRelationResponse) GetAllowed() bool {
+	if x != nil {
+		return x.Allowed
+	}
+	return false
+}
+
+type ExpandRelationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Object        string                 `protobuf:"bytes,1,opt,name=object,proto3" json:"object,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandRelationRequest) Reset() {
+	*x = ExpandRelationRequest{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandRelationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandRelationRequest) ProtoMessage() {}
+
+func (x *ExpandRelationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandRelationRequest.ProtoReflect.Descriptor instead.
+func (*ExpandRelationRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ExpandRelationRequest) GetObject() string {
+	if x != nil {
+		return x.Object
+	}
+	return ""
+}
+
+func (x *ExpandRelationRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+type ExpandRelationResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Tree          *RelationTree          `protobuf:"bytes,1,opt,name=tree,proto3" json:"tree,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ExpandRelationResponse) Reset() {
+	*x = ExpandRelationResponse{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ExpandRelationResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ExpandRelationResponse) ProtoMessage() {}
+
+func (x *ExpandRelationResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ExpandRelationResponse.ProtoReflect.Descriptor instead.
+func (*ExpandRelationResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *ExpandRelationResponse) GetTree() *RelationTree {
+	if x != nil {
+		return x.Tree
+	}
+	return nil
+}
+
+type ListObjectsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Namespace     string                 `protobuf:"bytes,1,opt,name=namespace,proto3" json:"namespace,omitempty"`
+	Relation      string                 `protobuf:"bytes,2,opt,name=relation,proto3" json:"relation,omitempty"`
+	Subject       string                 `protobuf:"bytes,3,opt,name=subject,proto3" json:"subject,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsRequest) Reset() {
+	*x = ListObjectsRequest{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsRequest) ProtoMessage() {}
+
+func (x *ListObjectsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsRequest.ProtoReflect.Descriptor instead.
+func (*ListObjectsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *ListObjectsRequest) GetNamespace() string {
+	if x != nil {
+		return x.Namespace
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetRelation() string {
+	if x != nil {
+		return x.Relation
+	}
+	return ""
+}
+
+func (x *ListObjectsRequest) GetSubject() string {
+	if x != nil {
+		return x.Subject
+	}
+	return ""
+}
+
+type ListObjectsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Objects       []string               `protobuf:"bytes,1,rep,name=objects,proto3" json:"objects,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListObjectsResponse) Reset() {
+	*x = ListObjectsResponse{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListObjectsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListObjectsResponse) ProtoMessage() {}
+
+func (x *ListObjectsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListObjectsResponse.ProtoReflect.Descriptor instead.
+func (*ListObjectsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *ListObjectsResponse) GetObjects() []string {
+	if x != nil {
+		return x.Objects
+	}
+	return nil
+}
+
+type WriteRelationsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Writes        []*RelationTuple       `protobuf:"bytes,1,rep,name=writes,proto3" json:"writes,omitempty"`
+	Deletes       []*RelationTuple       `protobuf:"bytes,2,rep,name=deletes,proto3" json:"deletes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRelationsRequest) Reset() {
+	*x = WriteRelationsRequest{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRelationsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationsRequest) ProtoMessage() {}
+
+func (x *WriteRelationsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationsRequest.ProtoReflect.Descriptor instead.
+func (*WriteRelationsRequest) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *WriteRelationsRequest) GetWrites() []*RelationTuple {
+	if x != nil {
+		return x.Writes
+	}
+	return nil
+}
+
+func (x *WriteRelationsRequest) GetDeletes() []*RelationTuple {
+	if x != nil {
+		return x.Deletes
+	}
+	return nil
+}
+
+type WriteRelationsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WriteRelationsResponse) Reset() {
+	*x = WriteRelationsResponse{}
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WriteRelationsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WriteRelationsResponse) ProtoMessage() {}
+
+func (x *WriteRelationsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_api_proto_auth_v1_relation_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WriteRelationsResponse.ProtoReflect.Descriptor instead.
+func (*WriteRelationsResponse) Descriptor() ([]byte, []int) {
+	return file_api_proto_auth_v1_relation_proto_rawDescGZIP(), []int{9}
+}
+
+var File_api_proto_auth_v1_relation_proto protoreflect.FileDescriptor
+
+const file_api_proto_auth_v1_relation_proto_rawDesc = "" +
+	"\n" +
+	" api/proto/auth/v1/relation.proto\x12\aauth.v1\"]\n" +
+	"\rRelationTuple\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\"\x91\x01\n" +
+	"\fRelationTree\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x1a\n" +
+	"\bsubjects\x18\x03 \x03(\tR\bsubjects\x121\n" +
+	"\bchildren\x18\x04 \x03(\v2\x15.auth.v1.RelationTreeR\bchildren\"d\n" +
+	"\x14CheckRelationRequest\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\"1\n" +
+	"\x15CheckRelationResponse\x12\x18\n" +
+	"\aallowed\x18\x01 \x01(\bR\aallowed\"K\n" +
+	"\x15ExpandRelationRequest\x12\x16\n" +
+	"\x06object\x18\x01 \x01(\tR\x06object\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\"C\n" +
+	"\x16ExpandRelationResponse\x12)\n" +
+	"\x04tree\x18\x01 \x01(\v2\x15.auth.v1.RelationTreeR\x04tree\"h\n" +
+	"\x12ListObjectsRequest\x12\x1c\n" +
+	"\tnamespace\x18\x01 \x01(\tR\tnamespace\x12\x1a\n" +
+	"\brelation\x18\x02 \x01(\tR\brelation\x12\x18\n" +
+	"\asubject\x18\x03 \x01(\tR\asubject\"/\n" +
+	"\x13ListObjectsResponse\x12\x18\n" +
+	"\aobjects\x18\x01 \x03(\tR\aobjects\"y\n" +
+	"\x15WriteRelationsRequest\x12.\n" +
+	"\x06writes\x18\x01 \x03(\v2\x16.auth.v1.RelationTupleR\x06writes\x120\n" +
+	"\adeletes\x18\x02 \x03(\v2\x16.auth.v1.RelationTupleR\adeletes\"\x18\n" +
+	"\x16WriteRelationsResponse2\xb8\x02\n" +
+	"\x0fRelationService\x12F\n" +
+	"\x05Check\x12\x1d.auth.v1.CheckRelationRequest\x1a\x1e.auth.v1.CheckRelationResponse\x12I\n" +
+	"\x06Expand\x12\x1e.auth.v1.ExpandRelationRequest\x1a\x1f.auth.v1.ExpandRelationResponse\x12H\n" +
+	"\vListObjects\x12\x1b.auth.v1.ListObjectsRequest\x1a\x1c.auth.v1.ListObjectsResponse\x12H\n" +
+	"\x05Write\x12\x1e.auth.v1.WriteRelationsRequest\x1a\x1f.auth.v1.WriteRelationsResponseB'Z%auth-service/api/proto/auth/v1;authv1b\x06proto3"
+
+var (
+	file_api_proto_auth_v1_relation_proto_rawDescOnce sync.Once
+	file_api_proto_auth_v1_relation_proto_rawDescData []byte
+)
+
+func file_api_proto_auth_v1_relation_proto_rawDescGZIP() []byte {
+	file_api_proto_auth_v1_relation_proto_rawDescOnce.Do(func() {
+		file_api_proto_auth_v1_relation_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_api_proto_auth_v1_relation_proto_rawDesc), len(file_api_proto_auth_v1_relation_proto_rawDesc)))
+	})
+	return file_api_proto_auth_v1_relation_proto_rawDescData
+}
+
+var file_api_proto_auth_v1_relation_proto_msgTypes = make([]protoimpl.MessageInfo, 10)
+var file_api_proto_auth_v1_relation_proto_goTypes = []any{
+	(*RelationTuple)(nil),          // 0: auth.v1.RelationTuple
+	(*RelationTree)(nil),           // 1: auth.v1.RelationTree
+	(*CheckRelationRequest)(nil),   // 2: auth.v1.CheckRelationRequest
+	(*CheckRelationResponse)(nil),  // 3: auth.v1.CheckRelationResponse
+	(*ExpandRelationRequest)(nil),  // 4: auth.v1.ExpandRelationRequest
+	(*ExpandRelationResponse)(nil), // 5: auth.v1.ExpandRelationResponse
+	(*ListObjectsRequest)(nil),     // 6: auth.v1.ListObjectsRequest
+	(*ListObjectsResponse)(nil),    // 7: auth.v1.ListObjectsResponse
+	(*WriteRelationsRequest)(nil),  // 8: auth.v1.WriteRelationsRequest
+	(*WriteRelationsResponse)(nil), // 9: auth.v1.WriteRelationsResponse
+}
+var file_api_proto_auth_v1_relation_proto_depIdxs = []int32{
+	1, // 0: auth.v1.RelationTree.children:type_name -> auth.v1.RelationTree
+	1, // 1: auth.v1.ExpandRelationResponse.tree:type_name -> auth.v1.RelationTree
+	0, // 2: auth.v1.WriteRelationsRequest.writes:type_name -> auth.v1.RelationTuple
+	0, // 3: auth.v1.WriteRelationsRequest.deletes:type_name -> auth.v1.RelationTuple
+	2, // 4: auth.v1.RelationService.Check:input_type -> auth.v1.CheckRelationRequest
+	4, // 5: auth.v1.RelationService.Expand:input_type -> auth.v1.ExpandRelationRequest
+	6, // 6: auth.v1.RelationService.ListObjects:input_type -> auth.v1.ListObjectsRequest
+	8, // 7: auth.v1.RelationService.Write:input_type -> auth.v1.WriteRelationsRequest
+	3, // 8: auth.v1.RelationService.Check:output_type -> auth.v1.CheckRelationResponse
+	5, // 9: auth.v1.RelationService.Expand:output_type -> auth.v1.ExpandRelationResponse
+	7, // 10: auth.v1.RelationService.ListObjects:output_type -> auth.v1.ListObjectsResponse
+	9, // 11: auth.v1.RelationService.Write:output_type -> auth.v1.WriteRelationsResponse
+	8, // [8:12] is the sub-list for method output_type
+	4, // [4:8] is the sub-list for method input_type
+	4, // [4:4] is the sub-list for extension type_name
+	4, // [4:4] is the sub-list for extension extendee
+	0, // [0:4] is the sub-list for field type_name
+}
+
+func init() { file_api_proto_auth_v1_relation_proto_init() }
+func file_api_proto_auth_v1_relation_proto_init() {
+	if File_api_proto_auth_v1_relation_proto != nil {
+		return
+	}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_api_proto_auth_v1_relation_proto_rawDesc), len(file_api_proto_auth_v1_relation_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   10,
+			NumExtensions: 0,
+			NumServices:   1,
+		},
+		GoTypes:           file_api_proto_auth_v1_relation_proto_goTypes,
+		DependencyIndexes: file_api_proto_auth_v1_relation_proto_depIdxs,
+		MessageInfos:      file_api_proto_auth_v1_relation_proto_msgTypes,
+	}.Build()
+	File_api_proto_auth_v1_relation_proto = out.File
+	file_api_proto_auth_v1_relation_proto_goTypes = nil
+	file_api_proto_auth_v1_relation_proto_depIdxs = nil
+}
@@ -1,25 +1,60 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
+	"net"
+	nethttp "net/http"
+	"os"
+	"os/signal"
+	"syscall"
+	// Embedded zone database, so profile timezones validate in minimal images
+	_ "time/tzdata"
 
+	apikeyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/apikey/usecases"
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	auditusecases "github.com/EduardoPPCaldas/auth-service/internal/application/audit/usecases"
+	groupusecases "github.com/EduardoPPCaldas/auth-service/internal/application/group/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	orgservices "github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	orgusecases "github.com/EduardoPPCaldas/auth-service/internal/application/organization/usecases"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	outboxusecases "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/usecases"
+	policyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/policy/usecases"
+	relationusecases "github.com/EduardoPPCaldas/auth-service/internal/application/relation/usecases"
 	roleusecases "github.com/EduardoPPCaldas/auth-service/internal/application/role/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	webhookusecases "github.com/EduardoPPCaldas/auth-service/internal/application/webhook/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/bootstrap"
 	"github.com/EduardoPPCaldas/auth-service/internal/config"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	tokenDomain "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
-	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	mailer "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/mail"
 	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/oauth/google"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/policy/celeval"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/leader"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/migrations"
 	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/scheduler"
+	webhookinfra "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/lifecycle"
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+	grpcserver "github.com/EduardoPPCaldas/auth-service/internal/presentation/grpc"
 	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
 	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
 	"github.com/go-playground/validator/v10"
 	"github.com/joho/godotenv"
 	"github.com/labstack/echo/v4"
-	"gorm.io/driver/postgres"
+	"google.golang.org/grpc"
 	"gorm.io/gorm"
 )
 
@@ -32,45 +67,205 @@ func main() {
 
 	cfg := config.Load()
 
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		if err := runMigrate(cfg, os.Args[2:]); err != nil {
+			log.Fatalf("Migration failed: %v", err)
+		}
+		return
+	}
+
+	shutdownTracing, err := tracing.Setup(context.Background(), cfg.TracingExporter, cfg.TracingSampleRatio)
+	if err != nil {
+		log.Fatalf("Failed to set up tracing: %v", err)
+	}
+
 	// Initialize database
-	db, err := initDatabase(cfg.DatabaseURL)
+	db, migrator, err := initDatabase(cfg.DatabaseURL, cfg.MigrateOnStart)
 	if err != nil {
 		log.Fatalf("Failed to connect to database: %v", err)
 	}
+	if err := db.Use(metrics.GormPlugin{}); err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
+	}
+	if err := db.Use(tracing.GormPlugin{}); err != nil {
+		log.Fatalf("Failed to instrument database: %v", err)
+	}
 
 	// Initialize repositories
 	userRepo := postgresRepo.NewUserRepository(db)
 	roleRepo := postgresRepo.NewRoleRepository(db)
 	refreshTokenRepo := postgresRepo.NewRefreshTokenRepository(db)
+	policyRepo := postgresRepo.NewPolicyRepository(db)
+	relationRepo := postgresRepo.NewRelationTupleRepository(db)
+	organizationRepo := postgresRepo.NewOrganizationRepository(db)
+	invitationRepo := postgresRepo.NewInvitationRepository(db)
+	groupRepo := postgresRepo.NewGroupRepository(db)
+	impersonationRepo := postgresRepo.NewImpersonationRepository(db)
+	deviceRepo := postgresRepo.NewDeviceRepository(db)
+	personalAccessTokenRepo := postgresRepo.NewPersonalAccessTokenRepository(db)
+	apiKeyRepo := postgresRepo.NewAPIKeyRepository(db)
+	passwordlessRepo := postgresRepo.NewPasswordlessRepository(db)
+	passwordHistoryRepo := postgresRepo.NewPasswordHistoryRepository(db)
+	auditRepo := postgresRepo.NewAuditRepository(db)
+	outboxRepo := postgresRepo.NewOutboxRepository(db)
+	webhookRepo := postgresRepo.NewWebhookRepository(db)
 
 	// Initialize services
 	googleValidator := google.NewGoogleTokenValidator(cfg.GoogleClientID)
 	tokenGenerator := token.NewTokenGenerator()
 	googleOAuthService := google.NewGoogleOAuthChallengeService(cfg.GoogleClientID, cfg.GoogleClientSecret, cfg.GoogleRedirectURI)
 
+	policyEvaluator, err := celeval.NewEvaluator()
+	if err != nil {
+		log.Fatalf("Failed to initialize policy evaluator: %v", err)
+	}
+
+	relationSchema, err := loadRelationSchema(cfg.RelationNamespacesFile)
+	if err != nil {
+		log.Fatalf("Failed to load relation namespaces: %v", err)
+	}
+
+	exchangePolicy, err := loadExchangePolicy(cfg.TokenExchangePolicyFile)
+	if err != nil {
+		log.Fatalf("Failed to load token exchange policy: %v", err)
+	}
+
+	passwordHasher, err := bootstrap.NewPasswordHasher(cfg)
+	if err != nil {
+		log.Fatalf("Failed to initialize password hasher: %v", err)
+	}
+	passwordHasher = metrics.InstrumentHasher(passwordHasher, password.Algorithm(cfg.PasswordHashAlgorithm))
+
+	passwordPolicy, err := bootstrap.NewPasswordPolicy(cfg, passwordHasher)
+	if err != nil {
+		log.Fatalf("Failed to initialize password policy: %v", err)
+	}
+
 	// Initialize additional services
 	refreshTokenService := token.NewRefreshTokenService(refreshTokenRepo, userRepo, cfg.JWTRefreshExpiry)
+	invitationSigner := orgservices.NewInvitationSigner(cfg.JWTSecret, cfg.JWTPreviousSecrets...)
+	emailChangeSigner := token.NewEmailChangeSigner(cfg.JWTSecret, cfg.JWTPreviousSecrets...)
+	mailSender := newMailer(cfg)
+	auditRecorder := auditservices.NewRecorder(auditRepo, func(event *audit.Event, err error) {
+		log.Printf("Failed to record audit event %s: %v", event.Action, err)
+	})
+	transactor := postgresRepo.NewTransactor(db)
+	publisher := outboxservices.NewPublisher(transactor, outboxRepo)
 
-	// Initialize auth middleware
-	authMiddleware, err := auth.NewAuthMiddleware(auth.WithJWTSecret(cfg.JWTSecret))
+	// Initialize auth middleware; personal access tokens are introspected in-process
+	introspectTokenUseCase := usecases.NewIntrospectTokenUseCase(personalAccessTokenRepo, userRepo)
+	authMiddleware, err := auth.NewAuthMiddleware(
+		auth.WithJWTSecret(cfg.JWTSecret),
+		auth.WithPreviousJWTSecrets(cfg.JWTPreviousSecrets...),
+		auth.WithTokenValidation(auth.TokenValidation{AcceptedAudience: cfg.TokenAudience}),
+		auth.WithPersonalAccessTokens(introspectTokenUseCase),
+		auth.WithRejectionObserver(metrics.ObserveRejection),
+	)
+	if err != nil {
+		log.Fatalf("Failed to initialize auth middleware: %v", err)
+	}
+	// A service may exchange a token it was given for another audience again
+	subjectTokenValidator, err := auth.NewAuthMiddleware(
+		auth.WithJWTSecret(cfg.JWTSecret),
+		auth.WithPreviousJWTSecrets(cfg.JWTPreviousSecrets...),
+	)
 	if err != nil {
 		log.Fatalf("Failed to initialize auth middleware: %v", err)
 	}
 
 	// Initialize use cases
-	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, roleRepo, tokenGenerator)
-	loginUserUseCase := usecases.NewLoginUserUseCase(userRepo, tokenGenerator)
-	loginWithGoogleUseCase := usecases.NewLoginWithGoogleUseCase(userRepo, roleRepo, tokenGenerator, googleValidator)
+	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, roleRepo, tokenGenerator, passwordHasher, passwordPolicy, auditRecorder, publisher)
+	loginUserUseCase := usecases.NewLoginUserUseCase(userRepo, tokenGenerator, passwordHasher, auditRecorder)
+	loginWithGoogleUseCase := usecases.NewLoginWithGoogleUseCase(userRepo, roleRepo, tokenGenerator, googleValidator, auditRecorder, publisher)
 	refreshTokenUseCase := usecases.NewRefreshTokenUseCase(userRepo, tokenGenerator, refreshTokenService, cfg.JWTAccessExpiry)
-	logoutUseCase := usecases.NewLogoutUseCase(userRepo, refreshTokenService)
+	logoutUseCase := usecases.NewLogoutUseCase(userRepo, refreshTokenService, auditRecorder, publisher)
+	listUsersUseCase := usecases.NewListUsersUseCase(userRepo)
+	getUserUseCase := usecases.NewGetUserUseCase(userRepo)
+	disableUserUseCase := usecases.NewDisableUserUseCase(userRepo, refreshTokenService, auditRecorder, publisher)
+	enableUserUseCase := usecases.NewEnableUserUseCase(userRepo, auditRecorder, publisher)
+	deleteUserUseCase := usecases.NewDeleteUserUseCase(userRepo, auditRecorder, publisher)
+	updateProfileUseCase := usecases.NewUpdateProfileUseCase(userRepo)
+	changePasswordUseCase := usecases.NewChangePasswordUseCase(userRepo, passwordHistoryRepo, tokenGenerator, refreshTokenService, cfg.JWTAccessExpiry, passwordHasher, passwordPolicy, auditRecorder, publisher)
+	requestEmailChangeUseCase := usecases.NewRequestEmailChangeUseCase(userRepo, emailChangeSigner, mailSender, cfg.EmailChangeConfirmURL, cfg.EmailChangeExpiry, passwordHasher)
+	confirmEmailChangeUseCase := usecases.NewConfirmEmailChangeUseCase(userRepo, emailChangeSigner, auditRecorder, publisher)
+	impersonateUserUseCase := usecases.NewImpersonateUserUseCase(userRepo, impersonationRepo, tokenGenerator, cfg.ImpersonationTokenExpiry, auditRecorder)
+	listImpersonationSessionsUseCase := usecases.NewListImpersonationSessionsUseCase(impersonationRepo)
+	exchangeTokenUseCase := usecases.NewExchangeTokenUseCase(userRepo, tokenGenerator, subjectTokenValidator, exchangePolicy, cfg.TokenExchangeExpiry)
+	requestDeviceAuthorizationUseCase := usecases.NewRequestDeviceAuthorizationUseCase(deviceRepo, cfg.DeviceVerificationURL, cfg.DeviceCodeExpiry, cfg.DevicePollInterval)
+	pollDeviceTokenUseCase := usecases.NewPollDeviceTokenUseCase(deviceRepo, userRepo, tokenGenerator, refreshTokenService, cfg.JWTAccessExpiry)
+	getDeviceAuthorizationUseCase := usecases.NewGetDeviceAuthorizationUseCase(deviceRepo)
+	decideDeviceAuthorizationUseCase := usecases.NewDecideDeviceAuthorizationUseCase(deviceRepo)
+	createPersonalAccessTokenUseCase := usecases.NewCreatePersonalAccessTokenUseCase(userRepo, personalAccessTokenRepo)
+	listPersonalAccessTokensUseCase := usecases.NewListPersonalAccessTokensUseCase(personalAccessTokenRepo)
+	revokePersonalAccessTokenUseCase := usecases.NewRevokePersonalAccessTokenUseCase(personalAccessTokenRepo)
+	startPasswordlessLoginUseCase := usecases.NewStartPasswordlessLoginUseCase(passwordlessRepo, userRepo, mailSender, cfg.PasswordlessLinkURL, cfg.PasswordlessExpiry, cfg.PasswordlessResendInterval, cfg.PasswordlessSignup)
+	verifyPasswordlessLoginUseCase := usecases.NewVerifyPasswordlessLoginUseCase(passwordlessRepo, userRepo, roleRepo, tokenGenerator, refreshTokenService, cfg.JWTAccessExpiry, cfg.PasswordlessMaxAttempts, cfg.PasswordlessSignup, auditRecorder, publisher)
 
 	// Initialize role management use cases
-	createRoleUseCase := roleusecases.NewCreateRoleUseCase(roleRepo, userRepo)
-	updateRoleUseCase := roleusecases.NewUpdateRoleUseCase(roleRepo, userRepo)
-	deleteRoleUseCase := roleusecases.NewDeleteRoleUseCase(roleRepo, userRepo)
+	createRoleUseCase := roleusecases.NewCreateRoleUseCase(roleRepo, userRepo, auditRecorder)
+	updateRoleUseCase := roleusecases.NewUpdateRoleUseCase(roleRepo, userRepo, auditRecorder)
+	deleteRoleUseCase := roleusecases.NewDeleteRoleUseCase(roleRepo, userRepo, auditRecorder)
 	listRolesUseCase := roleusecases.NewListRolesUseCase(roleRepo)
 	getRoleUseCase := roleusecases.NewGetRoleUseCase(roleRepo)
-	assignRoleToUserUseCase := roleusecases.NewAssignRoleToUserUseCase(roleRepo, userRepo)
+	assignRoleToUserUseCase := roleusecases.NewAssignRoleToUserUseCase(roleRepo, userRepo, auditRecorder, publisher)
+
+	// Initialize group use cases
+	createGroupUseCase := groupusecases.NewCreateGroupUseCase(groupRepo, userRepo)
+	listGroupsUseCase := groupusecases.NewListGroupsUseCase(groupRepo)
+	getGroupUseCase := groupusecases.NewGetGroupUseCase(groupRepo)
+	deleteGroupUseCase := groupusecases.NewDeleteGroupUseCase(groupRepo, userRepo)
+	addGroupMemberUseCase := groupusecases.NewAddGroupMemberUseCase(groupRepo, userRepo)
+	removeGroupMemberUseCase := groupusecases.NewRemoveGroupMemberUseCase(groupRepo, userRepo)
+	listGroupMembersUseCase := groupusecases.NewListGroupMembersUseCase(userRepo)
+	assignRoleToGroupUseCase := groupusecases.NewAssignRoleToGroupUseCase(groupRepo, roleRepo, userRepo)
+	removeRoleFromGroupUseCase := groupusecases.NewRemoveRoleFromGroupUseCase(groupRepo, userRepo)
+	listEffectiveRolesUseCase := groupusecases.NewListEffectiveRolesUseCase(userRepo)
+
+	// Initialize policy use cases
+	createPolicyUseCase := policyusecases.NewCreatePolicyUseCase(policyRepo, userRepo, policyEvaluator)
+	updatePolicyUseCase := policyusecases.NewUpdatePolicyUseCase(policyRepo, userRepo, policyEvaluator)
+	deletePolicyUseCase := policyusecases.NewDeletePolicyUseCase(policyRepo, userRepo)
+	listPoliciesUseCase := policyusecases.NewListPoliciesUseCase(policyRepo)
+	getPolicyUseCase := policyusecases.NewGetPolicyUseCase(policyRepo)
+	checkAuthorizationUseCase := policyusecases.NewCheckAuthorizationUseCase(policyRepo, userRepo, policyEvaluator)
+
+	// Initialize relation use cases
+	checkRelationUseCase := relationusecases.NewCheckRelationUseCase(relationRepo, relationSchema)
+	expandRelationUseCase := relationusecases.NewExpandRelationUseCase(relationRepo, relationSchema)
+	listObjectsUseCase := relationusecases.NewListObjectsUseCase(relationRepo, relationSchema)
+	writeRelationsUseCase := relationusecases.NewWriteRelationsUseCase(relationRepo, relationSchema)
+
+	// Initialize organization use cases
+	createOrganizationUseCase := orgusecases.NewCreateOrganizationUseCase(organizationRepo, userRepo)
+	listOrganizationsUseCase := orgusecases.NewListOrganizationsUseCase(organizationRepo)
+	switchOrganizationUseCase := orgusecases.NewSwitchOrganizationUseCase(organizationRepo, userRepo, tokenGenerator)
+	addMemberUseCase := orgusecases.NewAddMemberUseCase(organizationRepo, userRepo, roleRepo)
+	removeMemberUseCase := orgusecases.NewRemoveMemberUseCase(organizationRepo)
+	listMembersUseCase := orgusecases.NewListMembersUseCase(organizationRepo)
+	createOrganizationRoleUseCase := orgusecases.NewCreateOrganizationRoleUseCase(organizationRepo, roleRepo)
+	listOrganizationRolesUseCase := orgusecases.NewListOrganizationRolesUseCase(roleRepo)
+	createInvitationUseCase := orgusecases.NewCreateInvitationUseCase(organizationRepo, invitationRepo, userRepo, roleRepo, invitationSigner, mailSender, cfg.InvitationAcceptURL, cfg.InvitationExpiry)
+	listInvitationsUseCase := orgusecases.NewListInvitationsUseCase(invitationRepo)
+	revokeInvitationUseCase := orgusecases.NewRevokeInvitationUseCase(invitationRepo)
+	acceptInvitationUseCase := orgusecases.NewAcceptInvitationUseCase(invitationRepo, organizationRepo, userRepo, createUserUseCase, invitationSigner)
+
+	// Initialize API key use cases
+	createAPIKeyUseCase := apikeyusecases.NewCreateAPIKeyUseCase(apiKeyRepo, organizationRepo, auditRecorder)
+	listAPIKeysUseCase := apikeyusecases.NewListAPIKeysUseCase(apiKeyRepo)
+	revokeAPIKeyUseCase := apikeyusecases.NewRevokeAPIKeyUseCase(apiKeyRepo, auditRecorder)
+	verifyAPIKeyUseCase := apikeyusecases.NewVerifyAPIKeyUseCase(apiKeyRepo)
+
+	// Initialize audit use cases
+	listAuditEventsUseCase := auditusecases.NewListEventsUseCase(auditRepo)
+	exportAuditEventsUseCase := auditusecases.NewExportEventsUseCase(auditRepo)
+	verifyAuditChainUseCase := auditusecases.NewVerifyChainUseCase(auditRepo)
+
+	// Initialize webhook use cases
+	createWebhookUseCase := webhookusecases.NewCreateWebhookUseCase(webhookRepo, auditRecorder)
+	listWebhooksUseCase := webhookusecases.NewListWebhooksUseCase(webhookRepo)
+	deleteWebhookUseCase := webhookusecases.NewDeleteWebhookUseCase(webhookRepo, auditRecorder)
+	listWebhookDeliveriesUseCase := webhookusecases.NewListDeliveriesUseCase(webhookRepo)
+	replayWebhookDeliveryUseCase := webhookusecases.NewReplayDeliveryUseCase(webhookRepo, auditRecorder)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(
@@ -82,6 +277,53 @@ func main() {
 		googleOAuthService,
 	)
 
+	userHandler := handlers.NewUserHandler(
+		listUsersUseCase,
+		getUserUseCase,
+		disableUserUseCase,
+		enableUserUseCase,
+		deleteUserUseCase,
+	)
+
+	profileHandler := handlers.NewProfileHandler(
+		getUserUseCase,
+		updateProfileUseCase,
+		changePasswordUseCase,
+		requestEmailChangeUseCase,
+		confirmEmailChangeUseCase,
+		createPersonalAccessTokenUseCase,
+		listPersonalAccessTokensUseCase,
+		revokePersonalAccessTokenUseCase,
+	)
+
+	impersonationHandler := handlers.NewImpersonationHandler(
+		impersonateUserUseCase,
+		listImpersonationSessionsUseCase,
+	)
+
+	oauthHandler := handlers.NewOAuthHandler(
+		exchangeTokenUseCase,
+		requestDeviceAuthorizationUseCase,
+		pollDeviceTokenUseCase,
+		getDeviceAuthorizationUseCase,
+		decideDeviceAuthorizationUseCase,
+		introspectTokenUseCase,
+		authMiddleware,
+	)
+
+	apiKeyHandler := handlers.NewAPIKeyHandler(
+		createAPIKeyUseCase,
+		listAPIKeysUseCase,
+		revokeAPIKeyUseCase,
+		verifyAPIKeyUseCase,
+		authMiddleware,
+	)
+
+	passwordlessHandler := handlers.NewPasswordlessHandler(
+		startPasswordlessLoginUseCase,
+		verifyPasswordlessLoginUseCase,
+	)
+
 	roleHandler := handlers.NewRoleHandler(
 		createRoleUseCase,
 		updateRoleUseCase,
@@ -91,44 +333,334 @@ func main() {
 		assignRoleToUserUseCase,
 	)
 
+	groupHandler := handlers.NewGroupHandler(
+		createGroupUseCase,
+		listGroupsUseCase,
+		getGroupUseCase,
+		deleteGroupUseCase,
+		addGroupMemberUseCase,
+		removeGroupMemberUseCase,
+		listGroupMembersUseCase,
+		assignRoleToGroupUseCase,
+		removeRoleFromGroupUseCase,
+		listEffectiveRolesUseCase,
+	)
+
+	policyHandler := handlers.NewPolicyHandler(
+		createPolicyUseCase,
+		updatePolicyUseCase,
+		deletePolicyUseCase,
+		listPoliciesUseCase,
+		getPolicyUseCase,
+		checkAuthorizationUseCase,
+	)
+
+	relationHandler := handlers.NewRelationHandler(
+		checkRelationUseCase,
+		expandRelationUseCase,
+		listObjectsUseCase,
+		writeRelationsUseCase,
+	)
+
+	organizationHandler := handlers.NewOrganizationHandler(
+		createOrganizationUseCase,
+		listOrganizationsUseCase,
+		switchOrganizationUseCase,
+		addMemberUseCase,
+		removeMemberUseCase,
+		listMembersUseCase,
+		createOrganizationRoleUseCase,
+		listOrganizationRolesUseCase,
+	)
+
+	invitationHandler := handlers.NewInvitationHandler(
+		createInvitationUseCase,
+		listInvitationsUseCase,
+		revokeInvitationUseCase,
+		acceptInvitationUseCase,
+	)
+
+	auditHandler := handlers.NewAuditHandler(
+		listAuditEventsUseCase,
+		exportAuditEventsUseCase,
+		verifyAuditChainUseCase,
+	)
+
+	webhookHandler := handlers.NewWebhookHandler(
+		createWebhookUseCase,
+		listWebhooksUseCase,
+		deleteWebhookUseCase,
+		listWebhookDeliveriesUseCase,
+		replayWebhookDeliveryUseCase,
+	)
+
+	// Background jobs; every replica schedules them, and the one holding the lock runs them
+	var jobScheduler *scheduler.Scheduler
+	var jobHandler *handlers.JobHandler
+	if cfg.SchedulerEnabled {
+		jobScheduler, err = newScheduler(cfg, db, refreshTokenRepo, deviceRepo, passwordlessRepo, impersonationRepo, auditRepo, outboxRepo, webhookRepo, transactor)
+		if err != nil {
+			log.Fatalf("Failed to initialize job scheduler: %v", err)
+		}
+		jobHandler = handlers.NewJobHandler(jobScheduler)
+	}
+
+	relationServer := grpcserver.NewRelationServer(
+		checkRelationUseCase,
+		expandRelationUseCase,
+		listObjectsUseCase,
+		writeRelationsUseCase,
+	)
+
 	// Initialize Echo
 	e := echo.New()
+	e.IPExtractor, err = http.IPExtractor(cfg.TrustedProxies)
+	if err != nil {
+		log.Fatalf("Failed to configure client IP extraction: %v", err)
+	}
 
 	// Middleware
 	http.SetupMiddleware(e)
 	e.Validator = &CustomValidator{validator: validator.New()}
 
 	// Routes
-	http.SetupRoutes(
-		e,
-		authHandler,
-		roleHandler,
-		func() echo.MiddlewareFunc { return authMiddleware.EchoMiddleware() },
-		func() echo.MiddlewareFunc { return authMiddleware.EchoRequireRole(role.RoleAdmin) },
-	)
+	http.SetupRoutes(e, http.Routes{
+		Auth:                   authHandler,
+		Role:                   roleHandler,
+		Policy:                 policyHandler,
+		Relation:               relationHandler,
+		Organization:           organizationHandler,
+		Invitation:             invitationHandler,
+		Group:                  groupHandler,
+		User:                   userHandler,
+		Profile:                profileHandler,
+		Impersonation:          impersonationHandler,
+		OAuth:                  oauthHandler,
+		APIKey:                 apiKeyHandler,
+		Passwordless:           passwordlessHandler,
+		Job:                    jobHandler,
+		Audit:                  auditHandler,
+		Webhook:                webhookHandler,
+		AuthMiddleware:         func() echo.MiddlewareFunc { return authMiddleware.EchoMiddleware() },
+		AdminMiddleware:        func() echo.MiddlewareFunc { return authMiddleware.EchoRequireRole(role.RoleAdmin) },
+		RequireMiddleware:      authMiddleware.EchoRequire,
+		OrganizationMiddleware: authMiddleware.EchoRequireOrganization,
+	})
+
+	// gRPC server
+	grpcServer := grpcserver.NewServer(authMiddleware, relationServer)
+	listener, err := net.Listen("tcp", ":"+cfg.GRPCPort)
+	if err != nil {
+		log.Fatalf("Failed to listen on gRPC port: %v", err)
+	}
 
-	log.Printf("Server starting on port %s", cfg.Port)
-	if err := e.Start(":" + cfg.Port); err != nil {
-		log.Fatalf("Failed to start server: %v", err)
+	// Lifecycle; components stop in reverse, so the servers drain before the jobs stop and the pool closes
+	sqlDB, err := db.DB()
+	if err != nil {
+		log.Fatalf("Failed to get database pool: %v", err)
+	}
+	manager := lifecycle.NewManager(cfg.ShutdownDrainDelay, cfg.ShutdownTimeout)
+	manager.AddReadinessCheck("database", sqlDB.PingContext)
+	manager.AddReadinessCheck("migrations", func(ctx context.Context) error {
+		pending, err := migrator.Pending(ctx)
+		if err != nil {
+			return err
+		}
+		if len(pending) > 0 {
+			return fmt.Errorf("%d pending", len(pending))
+		}
+		return nil
+	})
+	http.SetupHealthRoutes(e, handlers.NewHealthHandler(manager))
+	if cfg.MetricsEnabled {
+		http.SetupMetricsRoutes(e)
+	}
+
+	// Added first so it stops last, flushing the spans of the shutdown itself
+	manager.Add(lifecycle.Component{
+		Name:  "tracing",
+		Start: func() error { return nil },
+		Stop:  shutdownTracing,
+	})
+	// Components start together, so the database is opened above; it's added
+	// here to be closed after everything using it
+	manager.Add(lifecycle.Component{
+		Name:  "database",
+		Start: func() error { return nil },
+		Stop:  func(context.Context) error { return sqlDB.Close() },
+	})
+	if jobScheduler != nil {
+		manager.Add(lifecycle.Component{
+			Name: "job scheduler",
+			Start: func() error {
+				jobScheduler.Start(context.Background())
+				return nil
+			},
+			Stop: jobScheduler.Stop,
+		})
 	}
+	manager.Add(lifecycle.Component{
+		Name: "gRPC server",
+		Start: func() error {
+			log.Printf("gRPC server starting on port %s", cfg.GRPCPort)
+			return grpcServer.Serve(listener)
+		},
+		Stop: func(ctx context.Context) error { return stopGRPCServer(ctx, grpcServer) },
+	})
+	manager.Add(lifecycle.Component{
+		Name: "HTTP server",
+		Start: func() error {
+			log.Printf("Server starting on port %s", cfg.Port)
+			if err := e.Start(":" + cfg.Port); !errors.Is(err, nethttp.ErrServerClosed) {
+				return err
+			}
+			return nil
+		},
+		Stop: e.Shutdown,
+	})
+
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	if err := manager.Run(ctx); err != nil {
+		log.Fatalf("Server stopped with errors: %v", err)
+	}
+	log.Println("Server stopped")
 }
 
-func initDatabase(dbURL string) (*gorm.DB, error) {
-	if dbURL == "" {
-		return nil, fmt.Errorf("DATABASE_URL environment variable is not set")
+// stopGRPCServer lets in-flight calls finish, cutting them off when ctx is done.
+func stopGRPCServer(ctx context.Context, server *grpc.Server) error {
+	done := make(chan struct{})
+	go func() {
+		server.GracefulStop()
+		close(done)
+	}()
+
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		server.Stop()
+		<-done
+		return ctx.Err()
 	}
+}
+
+// jobsLockKey is the advisory lock held by the replica running background jobs
+const jobsLockKey int64 = 0x61757468_6a6f6273 // "authjobs"
 
-	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+// initDatabase connects and applies pending migrations when migrate is set.
+// The migrator is returned either way, for the readiness check.
+func initDatabase(dbURL string, migrate bool) (*gorm.DB, *migrations.Migrator, error) {
+	db, err := bootstrap.OpenDatabase(dbURL)
+	if err != nil {
+		return nil, nil, err
+	}
+	migrator, err := bootstrap.NewMigrator(db)
 	if err != nil {
-		return nil, fmt.Errorf("failed to open database: %w", err)
+		return nil, nil, err
+	}
+	if !migrate {
+		return db, migrator, nil
 	}
 
-	// Auto-migrate entities
-	if err := db.AutoMigrate(&user.User{}, &tokenDomain.RefreshToken{}, &role.Role{}, &role.Permission{}); err != nil {
-		return nil, fmt.Errorf("failed to auto-migrate: %w", err)
+	applied, err := migrator.Up(context.Background())
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to migrate: %w", err)
+	}
+	for _, m := range applied {
+		log.Printf("Applied migration %d_%s", m.Version, m.Name)
 	}
 
-	return db, nil
+	return db, migrator, nil
+}
+
+// newScheduler schedules the housekeeping jobs; a job with a zero interval doesn't run.
+func newScheduler(
+	cfg *config.Config,
+	db *gorm.DB,
+	refreshTokenRepo tokenDomain.Repository,
+	deviceRepo *postgresRepo.DeviceRepository,
+	passwordlessRepo *postgresRepo.PasswordlessRepository,
+	impersonationRepo *postgresRepo.ImpersonationRepository,
+	auditRepo *postgresRepo.AuditRepository,
+	outboxRepo *postgresRepo.OutboxRepository,
+	webhookRepo *postgresRepo.WebhookRepository,
+	transactor *postgresRepo.Transactor,
+) (*scheduler.Scheduler, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+
+	cleanRefreshTokens := usecases.NewCleanRefreshTokensUseCase(refreshTokenRepo, cfg.RefreshTokenRevokedRetention)
+	expireCodes := usecases.NewExpireCodesUseCase(deviceRepo, passwordlessRepo)
+	pruneImpersonations := usecases.NewPruneImpersonationSessionsUseCase(impersonationRepo, cfg.AuditRetention)
+	pruneAuditEvents := auditusecases.NewPruneEventsUseCase(auditRepo, cfg.AuditRetention)
+	dispatchWebhooks := webhookusecases.NewDispatchWebhooksUseCase(outboxRepo, webhookRepo, transactor, webhookinfra.NewHTTPSender(cfg.WebhookTimeout), webhook.RetryPolicy{
+		MaxAttempts: cfg.WebhookMaxAttempts,
+		BaseDelay:   cfg.WebhookRetryBaseDelay,
+		MaxDelay:    cfg.WebhookRetryMaxDelay,
+	})
+	pruneOutbox := outboxusecases.NewPruneMessagesUseCase(outboxRepo, cfg.OutboxRetention)
+
+	jobs := []scheduler.Job{
+		{Name: "refresh-token-cleanup", Interval: cfg.JobRefreshTokenCleanupInterval, Run: cleanRefreshTokens.Execute},
+		{Name: "code-expiry", Interval: cfg.JobCodeExpiryInterval, Run: expireCodes.Execute},
+		{Name: "webhook-dispatch", Interval: cfg.JobWebhookDispatchInterval, Run: dispatchWebhooks.Execute},
+	}
+	if cfg.OutboxRetention > 0 {
+		jobs = append(jobs, scheduler.Job{Name: "outbox-prune", Interval: cfg.JobOutboxPruneInterval, Run: pruneOutbox.Execute})
+	}
+	// Without a retention, audit records are kept forever
+	if cfg.AuditRetention > 0 {
+		jobs = append(jobs, scheduler.Job{Name: "audit-prune", Interval: cfg.JobAuditPruneInterval, Run: func(ctx context.Context) (int64, error) {
+			sessions, err := pruneImpersonations.Execute(ctx)
+			if err != nil {
+				return sessions, err
+			}
+			events, err := pruneAuditEvents.Execute(ctx)
+			return sessions + events, err
+		}})
+	}
+
+	return scheduler.New(leader.NewAdvisoryLock(sqlDB, jobsLockKey), jobs...), nil
+}
+
+// loadRelationSchema reads the namespace config from path, falling back to the built-in namespaces.
+func loadRelationSchema(path string) (*relation.Schema, error) {
+	if path == "" {
+		return relation.NewSchema(relation.DefaultNamespaces())
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return relation.ParseSchema(data)
+}
+
+// loadExchangePolicy reads the token exchange policy from path; without one no service may exchange tokens.
+func loadExchangePolicy(path string) (*tokenDomain.ExchangePolicy, error) {
+	if path == "" {
+		return tokenDomain.NewExchangePolicy(nil)
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+
+	return tokenDomain.ParseExchangePolicy(data)
+}
+
+// newMailer sends mail through SMTP when a host is configured and logs it otherwise.
+func newMailer(cfg *config.Config) mail.Mailer {
+	if cfg.SMTPHost == "" {
+		log.Println("Warning: SMTP_HOST not set, outgoing mail will be logged instead of sent")
+		return mailer.NewLogMailer()
+	}
+	return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailFrom)
 }
 
 // CustomValidator is a custom validator for Echo
@@ -0,0 +1,239 @@
+// Command authctl bootstraps and operates the auth service from the command
+// line. It reads the same environment as the api server and works on the
+// same database through the same repositories.
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"os"
+	"strings"
+	"text/tabwriter"
+	"time"
+
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	roleusecases "github.com/EduardoPPCaldas/auth-service/internal/application/role/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/bootstrap"
+	"github.com/EduardoPPCaldas/auth-service/internal/config"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	"github.com/google/uuid"
+	"github.com/joho/godotenv"
+	"gorm.io/gorm"
+)
+
+const usage = `usage: authctl <command> [flags]
+
+commands:
+  seed-roles                          create the default admin, user and moderator roles
+  create-admin -email EMAIL           create a user, or use an existing one, and make them admin
+               [-password-stdin]      read the new user's password from stdin
+  sessions list -email EMAIL          list a user's active sessions
+  sessions revoke -email EMAIL        revoke all of a user's sessions
+  sessions revoke -id SESSION_ID      revoke one session
+  rotate-signing-key                  generate a new JWT_SECRET, keeping the current one as JWT_SECRET_PREVIOUS
+               [-revoke-sessions]     also drop the current secret and revoke every session, signing everyone out
+  purge-expired                       delete expired and long-revoked refresh tokens, device codes and login codes
+`
+
+func main() {
+	// .env is optional, as for the server
+	_ = godotenv.Load()
+
+	if len(os.Args) < 2 || os.Args[1] == "-h" || os.Args[1] == "help" {
+		fmt.Fprint(os.Stderr, usage)
+		os.Exit(2)
+	}
+
+	cfg := config.Load()
+	db, err := bootstrap.OpenDatabase(cfg.DatabaseURL)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "authctl:", err)
+		os.Exit(1)
+	}
+
+	if err := run(context.Background(), cfg, db, os.Args[1], os.Args[2:]); err != nil {
+		fmt.Fprintln(os.Stderr, "authctl:", err)
+		os.Exit(1)
+	}
+}
+
+func run(ctx context.Context, cfg *config.Config, db *gorm.DB, command string, args []string) error {
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	refreshTokenRepo := postgresRepo.NewRefreshTokenRepository(db)
+
+	switch command {
+	case "seed-roles":
+		return seedRoles(ctx, roleusecases.NewSeedDefaultRolesUseCase(roleRepo))
+
+	case "create-admin":
+		hasher, err := bootstrap.NewPasswordHasher(cfg)
+		if err != nil {
+			return err
+		}
+		policy, err := bootstrap.NewPasswordPolicy(cfg, hasher)
+		if err != nil {
+			return err
+		}
+		publisher := outboxservices.NewPublisher(postgresRepo.NewTransactor(db), postgresRepo.NewOutboxRepository(db))
+		return createAdmin(ctx, usecases.NewBootstrapAdminUseCase(userRepo, roleRepo, hasher, policy, publisher), args)
+
+	case "sessions":
+		refreshTokenService := token.NewRefreshTokenService(refreshTokenRepo, userRepo, cfg.JWTRefreshExpiry)
+		return sessions(ctx,
+			usecases.NewListSessionsUseCase(userRepo, refreshTokenRepo),
+			usecases.NewRevokeSessionsUseCase(userRepo, refreshTokenService),
+			args,
+		)
+
+	case "rotate-signing-key":
+		return rotateSigningKey(ctx, usecases.NewRotateSigningKeyUseCase(refreshTokenRepo, cfg.JWTSecret), args)
+
+	case "purge-expired":
+		return purgeExpired(ctx,
+			usecases.NewCleanRefreshTokensUseCase(refreshTokenRepo, cfg.RefreshTokenRevokedRetention),
+			usecases.NewExpireCodesUseCase(postgresRepo.NewDeviceRepository(db), postgresRepo.NewPasswordlessRepository(db)),
+		)
+	}
+	return fmt.Errorf("unknown command %q\n\n%s", command, usage)
+}
+
+func seedRoles(ctx context.Context, useCase *roleusecases.SeedDefaultRolesUseCase) error {
+	created, err := useCase.Execute(ctx)
+	for _, r := range created {
+		fmt.Printf("created role %s\n", r.Name)
+	}
+	if err == nil && len(created) == 0 {
+		fmt.Println("default roles already exist")
+	}
+	return err
+}
+
+func createAdmin(ctx context.Context, useCase *usecases.BootstrapAdminUseCase, args []string) error {
+	flags := flag.NewFlagSet("create-admin", flag.ContinueOnError)
+	email := flags.String("email", "", "email of the admin")
+	passwordStdin := flags.Bool("password-stdin", false, "read the password for a new user from stdin")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+	if *email == "" {
+		return errors.New("-email is required")
+	}
+
+	var password string
+	if *passwordStdin {
+		line, err := bufio.NewReader(os.Stdin).ReadString('\n')
+		if err != nil && line == "" {
+			return fmt.Errorf("failed to read password: %w", err)
+		}
+		password = strings.TrimRight(line, "\r\n")
+	}
+
+	admin, created, err := useCase.Execute(ctx, *email, password)
+	if errors.Is(err, usecases.ErrPasswordRequired) {
+		return fmt.Errorf("%s does not exist yet; pass -password-stdin to create it", *email)
+	}
+	if err != nil {
+		return err
+	}
+
+	if created {
+		fmt.Printf("created admin %s (%s)\n", admin.Email, admin.ID)
+	} else {
+		fmt.Printf("promoted %s (%s) to admin\n", admin.Email, admin.ID)
+	}
+	return nil
+}
+
+func sessions(ctx context.Context, list *usecases.ListSessionsUseCase, revoke *usecases.RevokeSessionsUseCase, args []string) error {
+	if len(args) == 0 {
+		return errors.New("sessions needs a subcommand: list or revoke")
+	}
+
+	flags := flag.NewFlagSet("sessions "+args[0], flag.ContinueOnError)
+	email := flags.String("email", "", "email of the user")
+	id := flags.String("id", "", "session to revoke")
+	if err := flags.Parse(args[1:]); err != nil {
+		return err
+	}
+
+	switch args[0] {
+	case "list":
+		if *email == "" {
+			return errors.New("-email is required")
+		}
+		active, err := list.Execute(ctx, *email)
+		if err != nil {
+			return err
+		}
+		w := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+		fmt.Fprintln(w, "ID\tCREATED\tEXPIRES")
+		for _, session := range active {
+			fmt.Fprintf(w, "%s\t%s\t%s\n", session.ID, session.CreatedAt.Format(time.RFC3339), session.ExpiresAt.Format(time.RFC3339))
+		}
+		return w.Flush()
+
+	case "revoke":
+		if (*email == "") == (*id == "") {
+			return errors.New("pass either -email or -id")
+		}
+		var input usecases.RevokeSessionsInput
+		if *id != "" {
+			sessionID, err := uuid.Parse(*id)
+			if err != nil {
+				return fmt.Errorf("invalid session ID: %w", err)
+			}
+			input.SessionID = sessionID
+		} else {
+			input.Email = *email
+		}
+		if err := revoke.Execute(ctx, input); err != nil {
+			return err
+		}
+		fmt.Println("revoked")
+		return nil
+	}
+	return fmt.Errorf("unknown sessions subcommand %q", args[0])
+}
+
+func rotateSigningKey(ctx context.Context, useCase *usecases.RotateSigningKeyUseCase, args []string) error {
+	flags := flag.NewFlagSet("rotate-signing-key", flag.ContinueOnError)
+	revokeSessions := flags.Bool("revoke-sessions", false, "revoke every session so all users sign in again")
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	result, err := useCase.Execute(ctx, *revokeSessions)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("JWT_SECRET=%s\n", result.Secret)
+	fmt.Printf("JWT_SECRET_PREVIOUS=%s\n", result.PreviousSecret)
+	fmt.Fprintln(os.Stderr, "Set both on every server and service validating its tokens, then restart them.")
+	if *revokeSessions {
+		fmt.Fprintln(os.Stderr, "Access tokens signed with the old secret stop working.")
+		fmt.Fprintf(os.Stderr, "Revoked %d sessions.\n", result.RevokedSessions)
+	} else {
+		fmt.Fprintln(os.Stderr, "Access tokens signed with the old secret keep working until they expire;")
+		fmt.Fprintln(os.Stderr, "unset JWT_SECRET_PREVIOUS after JWT_ACCESS_EXPIRY has passed.")
+	}
+	return nil
+}
+
+func purgeExpired(ctx context.Context, refreshTokens *usecases.CleanRefreshTokensUseCase, codes *usecases.ExpireCodesUseCase) error {
+	tokens, err := refreshTokens.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	expired, err := codes.Execute(ctx)
+	if err != nil {
+		return err
+	}
+	fmt.Printf("deleted %d refresh tokens and %d device and passwordless codes\n", tokens, expired)
+	return nil
+}
@@ -0,0 +1,79 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+// CreateAPIKeyRequest represents the request body for creating an API key;
+// exactly one of service_account and organization_id is set
+type CreateAPIKeyRequest struct {
+	Name           string     `json:"name" validate:"required,max=100"`
+	ServiceAccount string     `json:"service_account" validate:"max=100"`
+	OrganizationID *string    `json:"organization_id" validate:"omitempty,uuid"`
+	Permissions    []string   `json:"permissions" validate:"required,min=1"`
+	AllowedIPs     []string   `json:"allowed_ips"`
+	RateLimit      int        `json:"rate_limit" validate:"min=0"`
+	ExpiresAt      *time.Time `json:"expires_at"`
+}
+
+// VerifyAPIKeyRequest represents the request body for verifying an API key
+type VerifyAPIKeyRequest struct {
+	Key string `json:"key" validate:"required"`
+}
+
+// APIKeyResponse represents an API key; Key is only set when it is created
+type APIKeyResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	Key            string   `json:"key,omitempty"`
+	ServiceAccount string   `json:"service_account,omitempty"`
+	OrganizationID string   `json:"organization_id,omitempty"`
+	Permissions    []string `json:"permissions"`
+	AllowedIPs     []string `json:"allowed_ips"`
+	RateLimit      int      `json:"rate_limit"`
+	ExpiresAt      string   `json:"expires_at,omitempty"`
+	LastUsedAt     string   `json:"last_used_at,omitempty"`
+	CreatedBy      string   `json:"created_by"`
+	CreatedAt      string   `json:"created_at"`
+	RevokedAt      string   `json:"revoked_at,omitempty"`
+}
+
+func ToAPIKeyResponse(k *apikey.APIKey) APIKeyResponse {
+	response := APIKeyResponse{
+		ID:             k.ID.String(),
+		Name:           k.Name,
+		ServiceAccount: k.ServiceAccount,
+		Permissions:    k.Permissions,
+		AllowedIPs:     k.AllowedIPs,
+		RateLimit:      k.RateLimit,
+		CreatedBy:      k.CreatedBy.String(),
+		CreatedAt:      k.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if k.OrganizationID != nil {
+		response.OrganizationID = k.OrganizationID.String()
+	}
+	if k.ExpiresAt != nil {
+		response.ExpiresAt = k.ExpiresAt.Format("2006-01-02T15:04:05Z")
+	}
+	if k.LastUsedAt != nil {
+		response.LastUsedAt = k.LastUsedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if k.RevokedAt != nil {
+		response.RevokedAt = k.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return response
+}
+
+// ToAPIKeyStore returns the usable keys in the format auth.ParseAPIKeyStore reads
+func ToAPIKeyStore(keys []apikey.APIKey) []auth.StoredAPIKey {
+	store := make([]auth.StoredAPIKey, 0, len(keys))
+	for i := range keys {
+		if keys[i].IsValid() {
+			store = append(store, auth.StoredAPIKey{APIKey: *keys[i].Grant(), Hash: keys[i].KeyHash})
+		}
+	}
+	return store
+}
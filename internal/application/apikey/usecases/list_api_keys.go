@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListAPIKeysUseCase struct {
+	apiKeyRepository apikey.Repository
+}
+
+func NewListAPIKeysUseCase(apiKeyRepository apikey.Repository) *ListAPIKeysUseCase {
+	return &ListAPIKeysUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+func (u *ListAPIKeysUseCase) Execute(ctx context.Context) (_ []apikey.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "ListAPIKeysUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	return u.apiKeyRepository.List(ctx)
+}
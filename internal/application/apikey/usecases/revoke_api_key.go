@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type RevokeAPIKeyUseCase struct {
+	apiKeyRepository apikey.Repository
+	auditRecorder    *auditservices.Recorder
+}
+
+func NewRevokeAPIKeyUseCase(apiKeyRepository apikey.Repository, auditRecorder *auditservices.Recorder) *RevokeAPIKeyUseCase {
+	return &RevokeAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+		auditRecorder:    auditRecorder,
+	}
+}
+
+type RevokeAPIKeyInput struct {
+	AdminUserID uuid.UUID
+	ID          uuid.UUID
+}
+
+func (u *RevokeAPIKeyUseCase) Execute(ctx context.Context, input RevokeAPIKeyInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokeAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionAPIKeyRevoke,
+			TargetType: audit.TargetAPIKey,
+			TargetID:   input.ID.String(),
+		}).Fail(err))
+	}()
+
+	return u.apiKeyRepository.Revoke(ctx, input.ID)
+}
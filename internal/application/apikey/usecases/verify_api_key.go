@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+// lastUsedPrecision limits how often using a key writes its last-used time.
+const lastUsedPrecision = time.Minute
+
+type VerifyAPIKeyUseCase struct {
+	apiKeyRepository apikey.Repository
+}
+
+func NewVerifyAPIKeyUseCase(apiKeyRepository apikey.Repository) *VerifyAPIKeyUseCase {
+	return &VerifyAPIKeyUseCase{
+		apiKeyRepository: apiKeyRepository,
+	}
+}
+
+// Execute returns what a key grants. Unknown, revoked and expired keys fail
+// with apikey.ErrAPIKeyNotFound. The IP allow-list and rate limit are
+// enforced by the calling service's auth.APIKeyMiddleware.
+func (u *VerifyAPIKeyUseCase) Execute(ctx context.Context, key string) (_ *auth.APIKey, err error) {
+	ctx, span := tracing.Start(ctx, "VerifyAPIKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	stored, err := u.apiKeyRepository.FindByKeyHash(ctx, auth.HashAPIKey(key))
+	if err != nil {
+		return nil, err
+	}
+	if !stored.IsValid() {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+
+	now := time.Now()
+	if stored.LastUsedAt == nil || now.Sub(*stored.LastUsedAt) >= lastUsedPrecision {
+		if err := u.apiKeyRepository.TouchLastUsed(ctx, stored.ID, now); err != nil {
+			return nil, fmt.Errorf("error recording API key use: %w", err)
+		}
+	}
+
+	return stored.Grant(), nil
+}
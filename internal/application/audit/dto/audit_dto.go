@@ -0,0 +1,72 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+)
+
+// AuditEventResponse represents an audit event. OccurredAt keeps the
+// microseconds the hash covers, so the chain can be checked from an export.
+type AuditEventResponse struct {
+	ID         string            `json:"id"`
+	Sequence   int64             `json:"sequence"`
+	OccurredAt string            `json:"occurred_at"`
+	ActorID    string            `json:"actor_id,omitempty"`
+	ActorEmail string            `json:"actor_email,omitempty"`
+	Action     string            `json:"action"`
+	TargetType string            `json:"target_type,omitempty"`
+	TargetID   string            `json:"target_id,omitempty"`
+	IP         string            `json:"ip,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	Outcome    string            `json:"outcome"`
+	Metadata   map[string]string `json:"metadata,omitempty"`
+	PrevHash   string            `json:"prev_hash"`
+	Hash       string            `json:"hash"`
+}
+
+// AuditEventPageResponse represents a page of audit events; pass next_before
+// as before to fetch the next page
+type AuditEventPageResponse struct {
+	Events     []AuditEventResponse `json:"events"`
+	NextBefore int64                `json:"next_before,omitempty"`
+}
+
+// AuditChainResponse reports the result of verifying the audit log. The head
+// is the newest event checked, to keep as the anchor for later checks.
+type AuditChainResponse struct {
+	Valid          bool   `json:"valid"`
+	Checked        int64  `json:"checked"`
+	HeadSequence   int64  `json:"head_sequence,omitempty"`
+	HeadHash       string `json:"head_hash,omitempty"`
+	BrokenSequence int64  `json:"broken_sequence,omitempty"`
+	Reason         string `json:"reason,omitempty"`
+}
+
+// AuditCSVHeader is the header row of a CSV export
+var AuditCSVHeader = []string{
+	"id", "sequence", "occurred_at", "actor_id", "actor_email", "action", "target_type", "target_id",
+	"ip", "user_agent", "outcome", "metadata", "prev_hash", "hash",
+}
+
+func ToAuditEventResponse(e *audit.Event) AuditEventResponse {
+	response := AuditEventResponse{
+		ID:         e.ID.String(),
+		Sequence:   e.Sequence,
+		OccurredAt: e.OccurredAt.UTC().Format(time.RFC3339Nano),
+		ActorEmail: e.ActorEmail,
+		Action:     e.Action,
+		TargetType: e.TargetType,
+		TargetID:   e.TargetID,
+		IP:         e.IP,
+		UserAgent:  e.UserAgent,
+		Outcome:    e.Outcome,
+		Metadata:   e.Metadata,
+		PrevHash:   e.PrevHash,
+		Hash:       e.Hash,
+	}
+	if e.ActorID != nil {
+		response.ActorID = e.ActorID.String()
+	}
+	return response
+}
@@ -0,0 +1,45 @@
+package services
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/google/uuid"
+)
+
+// Recorder appends events to the audit log on behalf of the use cases. A nil
+// *Recorder records nothing, for use cases built without an audit log.
+type Recorder struct {
+	repository audit.Repository
+	onError    func(event *audit.Event, err error)
+}
+
+// NewRecorder creates a recorder. A failed write doesn't fail the action
+// being audited; it is passed to onError instead.
+func NewRecorder(repository audit.Repository, onError func(event *audit.Event, err error)) *Recorder {
+	return &Recorder{repository: repository, onError: onError}
+}
+
+// Record stamps the event with an ID, the time and the client of the
+// request in ctx, and appends it. It is written even when ctx has been
+// cancelled, so an aborted request still leaves its trace.
+func (r *Recorder) Record(ctx context.Context, event *audit.Event) {
+	if r == nil {
+		return
+	}
+
+	event.ID = uuid.New()
+	event.OccurredAt = time.Now().UTC().Truncate(time.Microsecond)
+	if client, ok := ClientFromContext(ctx); ok {
+		event.IP = client.IP
+		event.UserAgent = client.UserAgent
+	}
+	if event.Outcome == "" {
+		event.Outcome = audit.OutcomeSuccess
+	}
+
+	if err := r.repository.Append(context.WithoutCancel(ctx), event); err != nil && r.onError != nil {
+		r.onError(event, err)
+	}
+}
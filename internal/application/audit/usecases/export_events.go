@@ -0,0 +1,27 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ExportEventsUseCase struct {
+	auditRepository audit.Repository
+}
+
+func NewExportEventsUseCase(auditRepository audit.Repository) *ExportEventsUseCase {
+	return &ExportEventsUseCase{auditRepository: auditRepository}
+}
+
+// Execute passes every matching event to write, oldest first, without
+// holding them all in memory.
+func (u *ExportEventsUseCase) Execute(ctx context.Context, filter audit.Filter, write func(audit.Event) error) (err error) {
+	ctx, span := tracing.Start(ctx, "ExportEventsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	filter.BeforeSequence = 0
+	filter.Limit = 0
+	return u.auditRepository.Walk(ctx, filter, write)
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+// maxListLimit caps a page of events; exports aren't capped
+const maxListLimit = 500
+
+type ListEventsUseCase struct {
+	auditRepository audit.Repository
+}
+
+func NewListEventsUseCase(auditRepository audit.Repository) *ListEventsUseCase {
+	return &ListEventsUseCase{auditRepository: auditRepository}
+}
+
+// EventPage is a page of events, newest first. NextBefore is the
+// BeforeSequence of the next page, or zero on the last one.
+type EventPage struct {
+	Events     []audit.Event
+	NextBefore int64
+}
+
+// Execute returns a page of the events matching the filter.
+func (u *ListEventsUseCase) Execute(ctx context.Context, filter audit.Filter) (_ *EventPage, err error) {
+	ctx, span := tracing.Start(ctx, "ListEventsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if filter.Limit <= 0 || filter.Limit > maxListLimit {
+		filter.Limit = maxListLimit
+	}
+
+	events, err := u.auditRepository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing audit events: %w", err)
+	}
+
+	page := &EventPage{Events: events}
+	if len(events) == filter.Limit {
+		page.NextBefore = events[len(events)-1].Sequence
+	}
+	return page, nil
+}
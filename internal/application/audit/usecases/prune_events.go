@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type PruneEventsUseCase struct {
+	auditRepository audit.Repository
+	retention       time.Duration
+}
+
+func NewPruneEventsUseCase(auditRepository audit.Repository, retention time.Duration) *PruneEventsUseCase {
+	return &PruneEventsUseCase{
+		auditRepository: auditRepository,
+		retention:       retention,
+	}
+}
+
+// Execute deletes events older than the retention and returns how many it
+// deleted. The oldest remaining event still verifies, as the chain is
+// checked from wherever it starts.
+func (u *PruneEventsUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "PruneEventsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	deleted, err := u.auditRepository.DeleteBefore(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return deleted, fmt.Errorf("error pruning audit events: %w", err)
+	}
+	return deleted, nil
+}
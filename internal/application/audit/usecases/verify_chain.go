@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type VerifyChainUseCase struct {
+	auditRepository audit.Repository
+}
+
+func NewVerifyChainUseCase(auditRepository audit.Repository) *VerifyChainUseCase {
+	return &VerifyChainUseCase{auditRepository: auditRepository}
+}
+
+// VerifyChainResult reports how many events were checked, the newest of them
+// and, when the chain is broken, where.
+type VerifyChainResult struct {
+	Checked int64
+	Head    *audit.Anchor
+	Broken  *audit.ChainError
+}
+
+// Execute walks the whole log and checks every event against the chain and,
+// when given, the anchor.
+func (u *VerifyChainUseCase) Execute(ctx context.Context, anchor *audit.Anchor) (_ *VerifyChainResult, err error) {
+	ctx, span := tracing.Start(ctx, "VerifyChainUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	verifier := audit.Verifier{Anchor: anchor}
+	err = u.auditRepository.Walk(ctx, audit.Filter{}, verifier.Check)
+	if err == nil {
+		err = verifier.Finish()
+	}
+
+	result := &VerifyChainResult{Checked: verifier.Checked, Head: verifier.Head()}
+	if errors.As(err, &result.Broken) {
+		return result, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error reading audit events: %w", err)
+	}
+	return result, nil
+}
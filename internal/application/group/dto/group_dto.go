@@ -0,0 +1,87 @@
+package dto
+
+import (
+	roledto "github.com/EduardoPPCaldas/auth-service/internal/application/role/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+)
+
+const (
+	SourceDirect = "direct"
+	SourceGroup  = "group"
+)
+
+type CreateGroupRequest struct {
+	Name        string `json:"name" validate:"required,min=2,max=100"`
+	Description string `json:"description" validate:"max=500"`
+}
+
+type AddGroupMemberRequest struct {
+	UserID string `json:"user_id" validate:"required,uuid"`
+}
+
+type AssignGroupRoleRequest struct {
+	RoleID string `json:"role_id" validate:"required,uuid"`
+}
+
+type GroupResponse struct {
+	ID          string                 `json:"id"`
+	Name        string                 `json:"name"`
+	Description string                 `json:"description"`
+	Roles       []roledto.RoleResponse `json:"roles"`
+	CreatedAt   string                 `json:"created_at"`
+	UpdatedAt   string                 `json:"updated_at"`
+}
+
+type GroupMemberResponse struct {
+	UserID string `json:"user_id"`
+	Email  string `json:"email"`
+}
+
+// EffectiveRoleResponse is one way a user holds a role. GroupID and GroupName
+// are set when Source is "group".
+type EffectiveRoleResponse struct {
+	RoleID      string   `json:"role_id"`
+	Role        string   `json:"role"`
+	Permissions []string `json:"permissions"`
+	Source      string   `json:"source"`
+	GroupID     string   `json:"group_id,omitempty"`
+	GroupName   string   `json:"group_name,omitempty"`
+}
+
+func ToGroupResponse(g *group.Group) GroupResponse {
+	roles := make([]roledto.RoleResponse, len(g.Roles))
+	for i := range g.Roles {
+		roles[i] = roledto.ToRoleResponse(&g.Roles[i])
+	}
+	return GroupResponse{
+		ID:          g.ID.String(),
+		Name:        g.Name,
+		Description: g.Description,
+		Roles:       roles,
+		CreatedAt:   g.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:   g.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func ToGroupMemberResponse(u *user.User) GroupMemberResponse {
+	return GroupMemberResponse{
+		UserID: u.ID.String(),
+		Email:  u.Email,
+	}
+}
+
+func ToEffectiveRoleResponse(grant user.RoleGrant) EffectiveRoleResponse {
+	response := EffectiveRoleResponse{
+		RoleID:      grant.Role.ID.String(),
+		Role:        grant.Role.Name,
+		Permissions: grant.Role.GetPermissionStrings(),
+		Source:      SourceDirect,
+	}
+	if grant.Group != nil {
+		response.Source = SourceGroup
+		response.GroupID = grant.Group.ID.String()
+		response.GroupName = grant.Group.Name
+	}
+	return response
+}
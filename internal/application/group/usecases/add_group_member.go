@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type AddGroupMemberUseCase struct {
+	groupRepository group.Repository
+	userRepository  user.UserRepository
+}
+
+func NewAddGroupMemberUseCase(groupRepository group.Repository, userRepository user.UserRepository) *AddGroupMemberUseCase {
+	return &AddGroupMemberUseCase{
+		groupRepository: groupRepository,
+		userRepository:  userRepository,
+	}
+}
+
+type GroupMemberInput struct {
+	AdminUserID uuid.UUID
+	GroupID     uuid.UUID
+	UserID      uuid.UUID
+}
+
+func (u *AddGroupMemberUseCase) Execute(ctx context.Context, input GroupMemberInput) (err error) {
+	ctx, span := tracing.Start(ctx, "AddGroupMemberUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	if _, err := u.groupRepository.FindByID(ctx, input.GroupID); err != nil {
+		return fmt.Errorf("group not found: %w", err)
+	}
+
+	if _, err := u.userRepository.FindByID(ctx, input.UserID); err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	isMember, err := u.groupRepository.IsMember(ctx, input.GroupID, input.UserID)
+	if err != nil {
+		return fmt.Errorf("error checking group membership: %w", err)
+	}
+	if isMember {
+		return fmt.Errorf("user is already a member of the group")
+	}
+
+	if err := u.groupRepository.AddMember(ctx, input.GroupID, input.UserID); err != nil {
+		return fmt.Errorf("error adding group member: %w", err)
+	}
+
+	return nil
+}
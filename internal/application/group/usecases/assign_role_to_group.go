@@ -0,0 +1,67 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type AssignRoleToGroupUseCase struct {
+	groupRepository group.Repository
+	roleRepository  role.Repository
+	userRepository  user.UserRepository
+}
+
+func NewAssignRoleToGroupUseCase(groupRepository group.Repository, roleRepository role.Repository, userRepository user.UserRepository) *AssignRoleToGroupUseCase {
+	return &AssignRoleToGroupUseCase{
+		groupRepository: groupRepository,
+		roleRepository:  roleRepository,
+		userRepository:  userRepository,
+	}
+}
+
+type GroupRoleInput struct {
+	AdminUserID uuid.UUID
+	GroupID     uuid.UUID
+	RoleID      uuid.UUID
+}
+
+// Execute grants a global role to every member of the group. Organization
+// roles are granted through organization memberships instead.
+func (u *AssignRoleToGroupUseCase) Execute(ctx context.Context, input GroupRoleInput) (err error) {
+	ctx, span := tracing.Start(ctx, "AssignRoleToGroupUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	g, err := u.groupRepository.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return fmt.Errorf("group not found: %w", err)
+	}
+
+	ro, err := u.roleRepository.FindByID(ctx, input.RoleID)
+	if err != nil {
+		return fmt.Errorf("role not found: %w", err)
+	}
+
+	if ro.OrganizationID != nil {
+		return fmt.Errorf("organization roles cannot be assigned to groups")
+	}
+
+	if g.HasRole(ro.ID) {
+		return fmt.Errorf("group already has this role assigned")
+	}
+
+	if err := u.groupRepository.AssignRole(ctx, g.ID, ro.ID); err != nil {
+		return fmt.Errorf("error assigning role to group: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,92 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	groupmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/group/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestAssignRoleToGroupUseCase_Execute_Success(t *testing.T) {
+	mockGroupRepo := new(groupmocks.MockGroupRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewAssignRoleToGroupUseCase(mockGroupRepo, mockRoleRepo, mockUserRepo)
+
+	// Admin rights inherited from a group count as well
+	admins := group.New("admins", "")
+	admins.Roles = []role.Role{*role.NewAdminRole()}
+	adminUser := &user.User{ID: uuid.New(), Groups: []group.Group{*admins}}
+	engineering := group.New("engineering", "")
+	moderator := role.NewModeratorRole()
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, adminUser.ID).Return(adminUser, nil)
+	mockGroupRepo.On("FindByID", ctx, engineering.ID).Return(engineering, nil)
+	mockRoleRepo.On("FindByID", ctx, moderator.ID).Return(moderator, nil)
+	mockGroupRepo.On("AssignRole", ctx, engineering.ID, moderator.ID).Return(nil)
+
+	err := useCase.Execute(ctx, GroupRoleInput{
+		AdminUserID: adminUser.ID,
+		GroupID:     engineering.ID,
+		RoleID:      moderator.ID,
+	})
+
+	assert.NoError(t, err)
+	mockGroupRepo.AssertExpectations(t)
+}
+
+func TestAssignRoleToGroupUseCase_Execute_RejectsOrganizationRole(t *testing.T) {
+	mockGroupRepo := new(groupmocks.MockGroupRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewAssignRoleToGroupUseCase(mockGroupRepo, mockRoleRepo, mockUserRepo)
+
+	adminUser := &user.User{ID: uuid.New(), Role: role.NewAdminRole()}
+	engineering := group.New("engineering", "")
+	orgRole := role.NewOrganizationRole(uuid.New(), "owner", []string{"org:*"})
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, adminUser.ID).Return(adminUser, nil)
+	mockGroupRepo.On("FindByID", ctx, engineering.ID).Return(engineering, nil)
+	mockRoleRepo.On("FindByID", ctx, orgRole.ID).Return(orgRole, nil)
+
+	err := useCase.Execute(ctx, GroupRoleInput{
+		AdminUserID: adminUser.ID,
+		GroupID:     engineering.ID,
+		RoleID:      orgRole.ID,
+	})
+
+	assert.Error(t, err)
+	mockGroupRepo.AssertNotCalled(t, "AssignRole", mock.Anything, mock.Anything, mock.Anything)
+}
+
+func TestAssignRoleToGroupUseCase_Execute_NotAdmin(t *testing.T) {
+	mockGroupRepo := new(groupmocks.MockGroupRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewAssignRoleToGroupUseCase(mockGroupRepo, mockRoleRepo, mockUserRepo)
+
+	regularUser := &user.User{ID: uuid.New(), Role: role.NewUserRole()}
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, regularUser.ID).Return(regularUser, nil)
+
+	err := useCase.Execute(ctx, GroupRoleInput{
+		AdminUserID: regularUser.ID,
+		GroupID:     uuid.New(),
+		RoleID:      uuid.New(),
+	})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "admin privileges")
+	mockGroupRepo.AssertNotCalled(t, "FindByID", mock.Anything, mock.Anything)
+}
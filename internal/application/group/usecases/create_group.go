@@ -0,0 +1,73 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateGroupUseCase struct {
+	groupRepository group.Repository
+	userRepository  user.UserRepository
+}
+
+func NewCreateGroupUseCase(groupRepository group.Repository, userRepository user.UserRepository) *CreateGroupUseCase {
+	return &CreateGroupUseCase{
+		groupRepository: groupRepository,
+		userRepository:  userRepository,
+	}
+}
+
+type CreateGroupInput struct {
+	AdminUserID uuid.UUID
+	Name        string
+	Description string
+}
+
+func (u *CreateGroupUseCase) Execute(ctx context.Context, input CreateGroupInput) (_ *group.Group, err error) {
+	ctx, span := tracing.Start(ctx, "CreateGroupUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.groupRepository.FindByName(ctx, input.Name)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing group: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("group with name '%s' already exists", input.Name)
+	}
+
+	newGroup := group.New(input.Name, input.Description)
+	if err := u.groupRepository.Create(ctx, newGroup); err != nil {
+		return nil, fmt.Errorf("error creating group: %w", err)
+	}
+
+	return newGroup, nil
+}
+
+func verifyAdmin(ctx context.Context, userRepository user.UserRepository, adminUserID uuid.UUID) error {
+	adminUser, err := userRepository.FindByID(ctx, adminUserID)
+	if err != nil {
+		return fmt.Errorf("admin user not found: %w", err)
+	}
+
+	if !adminUser.HasRole(role.RoleAdmin) {
+		return fmt.Errorf("user does not have admin privileges")
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
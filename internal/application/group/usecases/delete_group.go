@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type DeleteGroupUseCase struct {
+	groupRepository group.Repository
+	userRepository  user.UserRepository
+}
+
+func NewDeleteGroupUseCase(groupRepository group.Repository, userRepository user.UserRepository) *DeleteGroupUseCase {
+	return &DeleteGroupUseCase{
+		groupRepository: groupRepository,
+		userRepository:  userRepository,
+	}
+}
+
+type DeleteGroupInput struct {
+	AdminUserID uuid.UUID
+	GroupID     uuid.UUID
+}
+
+// Execute deletes the group; its members keep their own roles but lose the group's.
+func (u *DeleteGroupUseCase) Execute(ctx context.Context, input DeleteGroupInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteGroupUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	if _, err := u.groupRepository.FindByID(ctx, input.GroupID); err != nil {
+		return fmt.Errorf("group not found: %w", err)
+	}
+
+	if err := u.groupRepository.Delete(ctx, input.GroupID); err != nil {
+		return fmt.Errorf("error deleting group: %w", err)
+	}
+
+	return nil
+}
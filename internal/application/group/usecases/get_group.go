@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type GetGroupUseCase struct {
+	groupRepository group.Repository
+}
+
+func NewGetGroupUseCase(groupRepository group.Repository) *GetGroupUseCase {
+	return &GetGroupUseCase{
+		groupRepository: groupRepository,
+	}
+}
+
+func (u *GetGroupUseCase) Execute(ctx context.Context, groupID uuid.UUID) (_ *group.Group, err error) {
+	ctx, span := tracing.Start(ctx, "GetGroupUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	g, err := u.groupRepository.FindByID(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("group not found: %w", err)
+	}
+	return g, nil
+}
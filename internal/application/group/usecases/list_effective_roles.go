@@ -0,0 +1,33 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListEffectiveRolesUseCase struct {
+	userRepository user.UserRepository
+}
+
+func NewListEffectiveRolesUseCase(userRepository user.UserRepository) *ListEffectiveRolesUseCase {
+	return &ListEffectiveRolesUseCase{
+		userRepository: userRepository,
+	}
+}
+
+// Execute returns every grant behind the user's effective roles, so a role
+// held both directly and through a group is listed once per source.
+func (u *ListEffectiveRolesUseCase) Execute(ctx context.Context, userID uuid.UUID) (_ []user.RoleGrant, err error) {
+	ctx, span := tracing.Start(ctx, "ListEffectiveRolesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := u.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	return target.RoleGrants(), nil
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListEffectiveRolesUseCase_Execute_ReportsSources(t *testing.T) {
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewListEffectiveRolesUseCase(mockUserRepo)
+
+	userRole := role.NewUserRole()
+	moderator := role.NewModeratorRole()
+	engineering := group.New("engineering", "")
+	engineering.Roles = []role.Role{*moderator, *userRole}
+	target := &user.User{ID: uuid.New(), Role: userRole, Groups: []group.Group{*engineering}}
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, target.ID).Return(target, nil)
+
+	grants, err := useCase.Execute(ctx, target.ID)
+
+	require.NoError(t, err)
+	require.Len(t, grants, 3)
+	assert.Equal(t, role.RoleUser, grants[0].Role.Name)
+	assert.Nil(t, grants[0].Group)
+	assert.Equal(t, role.RoleModerator, grants[1].Role.Name)
+	assert.Equal(t, "engineering", grants[1].Group.Name)
+	assert.Equal(t, role.RoleUser, grants[2].Role.Name)
+	assert.Equal(t, "engineering", grants[2].Group.Name)
+	assert.Equal(t, []string{role.RoleUser, role.RoleModerator}, target.EffectiveRoleNames())
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListGroupMembersUseCase struct {
+	userRepository user.UserRepository
+}
+
+func NewListGroupMembersUseCase(userRepository user.UserRepository) *ListGroupMembersUseCase {
+	return &ListGroupMembersUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (u *ListGroupMembersUseCase) Execute(ctx context.Context, groupID uuid.UUID) (_ []user.User, err error) {
+	ctx, span := tracing.Start(ctx, "ListGroupMembersUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	members, err := u.userRepository.ListByGroup(ctx, groupID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing group members: %w", err)
+	}
+	return members, nil
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListGroupsUseCase struct {
+	groupRepository group.Repository
+}
+
+func NewListGroupsUseCase(groupRepository group.Repository) *ListGroupsUseCase {
+	return &ListGroupsUseCase{
+		groupRepository: groupRepository,
+	}
+}
+
+func (u *ListGroupsUseCase) Execute(ctx context.Context) (_ []group.Group, err error) {
+	ctx, span := tracing.Start(ctx, "ListGroupsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	groups, err := u.groupRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing groups: %w", err)
+	}
+	return groups, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type RemoveGroupMemberUseCase struct {
+	groupRepository group.Repository
+	userRepository  user.UserRepository
+}
+
+func NewRemoveGroupMemberUseCase(groupRepository group.Repository, userRepository user.UserRepository) *RemoveGroupMemberUseCase {
+	return &RemoveGroupMemberUseCase{
+		groupRepository: groupRepository,
+		userRepository:  userRepository,
+	}
+}
+
+func (u *RemoveGroupMemberUseCase) Execute(ctx context.Context, input GroupMemberInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RemoveGroupMemberUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	isMember, err := u.groupRepository.IsMember(ctx, input.GroupID, input.UserID)
+	if err != nil {
+		return fmt.Errorf("error checking group membership: %w", err)
+	}
+	if !isMember {
+		return fmt.Errorf("user is not a member of the group")
+	}
+
+	if err := u.groupRepository.RemoveMember(ctx, input.GroupID, input.UserID); err != nil {
+		return fmt.Errorf("error removing group member: %w", err)
+	}
+
+	return nil
+}
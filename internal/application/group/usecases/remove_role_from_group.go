@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type RemoveRoleFromGroupUseCase struct {
+	groupRepository group.Repository
+	userRepository  user.UserRepository
+}
+
+func NewRemoveRoleFromGroupUseCase(groupRepository group.Repository, userRepository user.UserRepository) *RemoveRoleFromGroupUseCase {
+	return &RemoveRoleFromGroupUseCase{
+		groupRepository: groupRepository,
+		userRepository:  userRepository,
+	}
+}
+
+func (u *RemoveRoleFromGroupUseCase) Execute(ctx context.Context, input GroupRoleInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RemoveRoleFromGroupUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	g, err := u.groupRepository.FindByID(ctx, input.GroupID)
+	if err != nil {
+		return fmt.Errorf("group not found: %w", err)
+	}
+
+	if !g.HasRole(input.RoleID) {
+		return fmt.Errorf("group does not have this role assigned")
+	}
+
+	if err := u.groupRepository.UnassignRole(ctx, g.ID, input.RoleID); err != nil {
+		return fmt.Errorf("error removing role from group: %w", err)
+	}
+
+	return nil
+}
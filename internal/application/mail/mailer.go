@@ -0,0 +1,15 @@
+package mail
+
+import "context"
+
+type Message struct {
+	To      string
+	Subject string
+	Body    string
+}
+
+// Mailer delivers plain-text messages. Implementations live in
+// internal/infrastructure/mail.
+type Mailer interface {
+	Send(ctx context.Context, message Message) error
+}
@@ -0,0 +1,100 @@
+package dto
+
+import (
+	roledto "github.com/EduardoPPCaldas/auth-service/internal/application/role/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+)
+
+type CreateOrganizationRequest struct {
+	Name string `json:"name" validate:"required,min=2,max=100"`
+	Slug string `json:"slug" validate:"required,min=2,max=63"`
+}
+
+type OrganizationResponse struct {
+	ID        string `json:"id"`
+	Name      string `json:"name"`
+	Slug      string `json:"slug"`
+	CreatedAt string `json:"created_at"`
+}
+
+type AddMemberRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required"`
+}
+
+type MemberResponse struct {
+	UserID    string `json:"user_id"`
+	Email     string `json:"email,omitempty"`
+	Role      string `json:"role"`
+	CreatedAt string `json:"created_at"`
+}
+
+type CreateOrganizationRoleRequest = roledto.CreateRoleRequest
+
+type OrganizationTokenResponse struct {
+	AccessToken    string `json:"access_token"`
+	TokenType      string `json:"token_type"`
+	OrganizationID string `json:"organization_id"`
+}
+
+func ToOrganizationResponse(o *organization.Organization) OrganizationResponse {
+	return OrganizationResponse{
+		ID:        o.ID.String(),
+		Name:      o.Name,
+		Slug:      o.Slug,
+		CreatedAt: o.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func ToMemberResponse(m *organization.Membership) MemberResponse {
+	response := MemberResponse{
+		UserID:    m.UserID.String(),
+		Role:      m.RoleName(),
+		CreatedAt: m.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if m.User != nil {
+		response.Email = m.User.Email
+	}
+	return response
+}
+
+type CreateInvitationRequest struct {
+	Email string `json:"email" validate:"required,email"`
+	Role  string `json:"role" validate:"required"`
+}
+
+type InvitationResponse struct {
+	ID             string `json:"id"`
+	OrganizationID string `json:"organization_id"`
+	Email          string `json:"email"`
+	Role           string `json:"role"`
+	InviterID      string `json:"inviter_id"`
+	Status         string `json:"status"`
+	ExpiresAt      string `json:"expires_at"`
+	CreatedAt      string `json:"created_at"`
+}
+
+type AcceptInvitationRequest struct {
+	Token    string `json:"token" validate:"required"`
+	Password string `json:"password"`
+}
+
+type AcceptInvitationResponse struct {
+	OrganizationID string `json:"organization_id"`
+	UserID         string `json:"user_id"`
+	Role           string `json:"role"`
+	AccessToken    string `json:"access_token,omitempty"`
+}
+
+func ToInvitationResponse(i *organization.Invitation) InvitationResponse {
+	return InvitationResponse{
+		ID:             i.ID.String(),
+		OrganizationID: i.OrganizationID.String(),
+		Email:          i.Email,
+		Role:           i.RoleName(),
+		InviterID:      i.InviterID.String(),
+		Status:         i.Status(),
+		ExpiresAt:      i.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt:      i.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
@@ -0,0 +1,78 @@
+package services
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/google/uuid"
+)
+
+// InvitationSigner issues the tokens embedded in invitation links. A token is
+// "<invitation id>.<signature>", where the signature is an HMAC over the
+// invitation's ID, email and expiry, so links cannot be guessed from IDs and
+// stop verifying if the invitation is altered.
+type InvitationSigner struct {
+	secrets [][]byte
+}
+
+// NewInvitationSigner signs with secret and also accepts tokens signed with
+// previous secrets, so links sent before a rotation keep working.
+func NewInvitationSigner(secret string, previous ...string) *InvitationSigner {
+	s := &InvitationSigner{secrets: [][]byte{[]byte(secret)}}
+	for _, p := range previous {
+		if p != "" {
+			s.secrets = append(s.secrets, []byte(p))
+		}
+	}
+	return s
+}
+
+func (s *InvitationSigner) Sign(invitation *organization.Invitation) string {
+	return invitation.ID.String() + "." + base64.RawURLEncoding.EncodeToString(mac(s.secrets[0], invitation))
+}
+
+// InvitationID returns the invitation a token refers to without verifying it.
+func (s *InvitationSigner) InvitationID(token string) (uuid.UUID, error) {
+	id, _, ok := strings.Cut(token, ".")
+	if !ok {
+		return uuid.Nil, fmt.Errorf("malformed invitation token")
+	}
+	invitationID, err := uuid.Parse(id)
+	if err != nil {
+		return uuid.Nil, fmt.Errorf("malformed invitation token")
+	}
+	return invitationID, nil
+}
+
+// Verify checks that token was signed for invitation.
+func (s *InvitationSigner) Verify(token string, invitation *organization.Invitation) error {
+	id, sig, ok := strings.Cut(token, ".")
+	if !ok || id != invitation.ID.String() {
+		return fmt.Errorf("invalid invitation token")
+	}
+	signature, err := base64.RawURLEncoding.DecodeString(sig)
+	if err != nil {
+		return fmt.Errorf("invalid invitation token")
+	}
+	for _, secret := range s.secrets {
+		if hmac.Equal(signature, mac(secret, invitation)) {
+			return nil
+		}
+	}
+	return fmt.Errorf("invalid invitation token")
+}
+
+func mac(secret []byte, invitation *organization.Invitation) []byte {
+	h := hmac.New(sha256.New, secret)
+	h.Write([]byte(invitation.ID.String()))
+	h.Write([]byte{0})
+	h.Write([]byte(invitation.Email))
+	h.Write([]byte{0})
+	h.Write([]byte(strconv.FormatInt(invitation.ExpiresAt.Unix(), 10)))
+	return h.Sum(nil)
+}
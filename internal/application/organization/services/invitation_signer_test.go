@@ -0,0 +1,64 @@
+package services
+
+import (
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestInvitation() *organization.Invitation {
+	organizationID := uuid.New()
+	r := role.NewOrganizationRole(organizationID, organization.RoleMember, organization.MemberPermissions)
+	return organization.NewInvitation(organizationID, uuid.New(), "invitee@example.com", r, time.Hour)
+}
+
+func TestInvitationSigner_SignAndVerify(t *testing.T) {
+	signer := NewInvitationSigner("secret")
+	invitation := newTestInvitation()
+
+	token := signer.Sign(invitation)
+
+	id, err := signer.InvitationID(token)
+	require.NoError(t, err)
+	assert.Equal(t, invitation.ID, id)
+	assert.NoError(t, signer.Verify(token, invitation))
+}
+
+func TestInvitationSigner_VerifyWithPreviousSecret(t *testing.T) {
+	invitation := newTestInvitation()
+	token := NewInvitationSigner("old").Sign(invitation)
+
+	assert.NoError(t, NewInvitationSigner("new", "old").Verify(token, invitation))
+	assert.Error(t, NewInvitationSigner("new").Verify(token, invitation))
+}
+
+func TestInvitationSigner_Verify_Rejects(t *testing.T) {
+	signer := NewInvitationSigner("secret")
+	invitation := newTestInvitation()
+	token := signer.Sign(invitation)
+
+	t.Run("other secret", func(t *testing.T) {
+		assert.Error(t, NewInvitationSigner("other").Verify(token, invitation))
+	})
+
+	t.Run("changed email", func(t *testing.T) {
+		changed := *invitation
+		changed.Email = "attacker@example.com"
+		assert.Error(t, signer.Verify(token, &changed))
+	})
+
+	t.Run("other invitation", func(t *testing.T) {
+		assert.Error(t, signer.Verify(token, newTestInvitation()))
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := signer.InvitationID("not-a-token")
+		assert.Error(t, err)
+		assert.Error(t, signer.Verify(invitation.ID.String()+".!!", invitation))
+	})
+}
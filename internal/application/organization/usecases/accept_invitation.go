@@ -0,0 +1,118 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+// UserRegistrar creates accounts for invitees who are not registered yet.
+// It is satisfied by the user package's CreateUserUseCase.
+type UserRegistrar interface {
+	Execute(ctx context.Context, email, password string) (string, error)
+}
+
+type AcceptInvitationUseCase struct {
+	invitationRepository   organization.InvitationRepository
+	organizationRepository organization.Repository
+	userRepository         user.UserRepository
+	userRegistrar          UserRegistrar
+	signer                 *services.InvitationSigner
+}
+
+func NewAcceptInvitationUseCase(
+	invitationRepository organization.InvitationRepository,
+	organizationRepository organization.Repository,
+	userRepository user.UserRepository,
+	userRegistrar UserRegistrar,
+	signer *services.InvitationSigner,
+) *AcceptInvitationUseCase {
+	return &AcceptInvitationUseCase{
+		invitationRepository:   invitationRepository,
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		userRegistrar:          userRegistrar,
+		signer:                 signer,
+	}
+}
+
+type AcceptInvitationInput struct {
+	Token string
+	// Password is required when the invited email has no account yet.
+	Password string
+}
+
+type AcceptInvitationOutput struct {
+	Membership *organization.Membership
+	// AccessToken is set when accepting created the account.
+	AccessToken string
+}
+
+// Execute verifies the invitation token and adds the invitee to the
+// organization, registering them first if the email has no account.
+func (u *AcceptInvitationUseCase) Execute(ctx context.Context, input AcceptInvitationInput) (_ *AcceptInvitationOutput, err error) {
+	ctx, span := tracing.Start(ctx, "AcceptInvitationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	invitationID, err := u.signer.InvitationID(input.Token)
+	if err != nil {
+		return nil, err
+	}
+
+	invitation, err := u.invitationRepository.FindByID(ctx, invitationID)
+	if err != nil {
+		return nil, fmt.Errorf("invalid invitation token")
+	}
+
+	if err := u.signer.Verify(input.Token, invitation); err != nil {
+		return nil, err
+	}
+
+	if !invitation.IsPending() {
+		return nil, organization.ErrInvitationNotPending
+	}
+	if invitation.Role == nil {
+		return nil, fmt.Errorf("the invited role no longer exists")
+	}
+
+	output := &AcceptInvitationOutput{}
+
+	invitee, err := u.userRepository.FindByEmail(ctx, invitation.Email)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing user: %w", err)
+	}
+	if err != nil {
+		if input.Password == "" {
+			return nil, fmt.Errorf("password is required to create an account")
+		}
+		output.AccessToken, err = u.userRegistrar.Execute(ctx, invitation.Email, input.Password)
+		if err != nil {
+			return nil, err
+		}
+		invitee, err = u.userRepository.FindByEmail(ctx, invitation.Email)
+		if err != nil {
+			return nil, fmt.Errorf("error loading created user: %w", err)
+		}
+	}
+
+	existing, err := u.organizationRepository.FindMembership(ctx, invitation.OrganizationID, invitee.ID)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing membership: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("user is already a member of the organization")
+	}
+
+	membership := organization.NewMembership(invitation.OrganizationID, invitee.ID, invitation.Role)
+	if err := u.invitationRepository.Accept(ctx, invitation, membership); err != nil {
+		return nil, fmt.Errorf("error accepting invitation: %w", err)
+	}
+	membership.User = invitee
+	output.Membership = membership
+
+	return output, nil
+}
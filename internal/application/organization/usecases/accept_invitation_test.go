@@ -0,0 +1,137 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	orgmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/organization/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type stubRegistrar struct {
+	email, password string
+}
+
+func (r *stubRegistrar) Execute(_ context.Context, email, password string) (string, error) {
+	r.email, r.password = email, password
+	return "access-token", nil
+}
+
+func TestAcceptInvitationUseCase_Execute_RegistersNewUser(t *testing.T) {
+	mockInvitationRepo := new(orgmocks.MockInvitationRepository)
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	registrar := &stubRegistrar{}
+	signer := services.NewInvitationSigner("secret")
+	useCase := NewAcceptInvitationUseCase(mockInvitationRepo, mockOrgRepo, mockUserRepo, registrar, signer)
+
+	org, roles := newTestOrganization(t)
+	invitation := organization.NewInvitation(org.ID, uuid.New(), "new@example.com", roles[organization.RoleMember], time.Hour)
+	created := user.New(invitation.Email, nil)
+	ctx := context.Background()
+
+	mockInvitationRepo.On("FindByID", ctx, invitation.ID).Return(invitation, nil)
+	mockUserRepo.On("FindByEmail", ctx, invitation.Email).Return(nil, gorm.ErrRecordNotFound).Once()
+	mockUserRepo.On("FindByEmail", ctx, invitation.Email).Return(created, nil).Once()
+	mockOrgRepo.On("FindMembership", ctx, org.ID, created.ID).Return(nil, gorm.ErrRecordNotFound)
+	mockInvitationRepo.On("Accept", ctx, invitation, mock.AnythingOfType("*organization.Membership")).Return(nil)
+
+	output, err := useCase.Execute(ctx, AcceptInvitationInput{
+		Token:    signer.Sign(invitation),
+		Password: "password123",
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, "access-token", output.AccessToken)
+	assert.Equal(t, created.ID, output.Membership.UserID)
+	assert.Equal(t, organization.RoleMember, output.Membership.RoleName())
+	assert.Equal(t, invitation.Email, registrar.email)
+	mockInvitationRepo.AssertExpectations(t)
+}
+
+func TestAcceptInvitationUseCase_Execute_ExistingUser(t *testing.T) {
+	mockInvitationRepo := new(orgmocks.MockInvitationRepository)
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	registrar := &stubRegistrar{}
+	signer := services.NewInvitationSigner("secret")
+	useCase := NewAcceptInvitationUseCase(mockInvitationRepo, mockOrgRepo, mockUserRepo, registrar, signer)
+
+	org, roles := newTestOrganization(t)
+	existing := user.New("existing@example.com", nil)
+	invitation := organization.NewInvitation(org.ID, uuid.New(), existing.Email, roles[organization.RoleAdmin], time.Hour)
+	ctx := context.Background()
+
+	mockInvitationRepo.On("FindByID", ctx, invitation.ID).Return(invitation, nil)
+	mockUserRepo.On("FindByEmail", ctx, existing.Email).Return(existing, nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, existing.ID).Return(nil, gorm.ErrRecordNotFound)
+	mockInvitationRepo.On("Accept", ctx, invitation, mock.AnythingOfType("*organization.Membership")).Return(nil)
+
+	output, err := useCase.Execute(ctx, AcceptInvitationInput{Token: signer.Sign(invitation)})
+
+	require.NoError(t, err)
+	assert.Empty(t, output.AccessToken)
+	assert.Equal(t, existing.ID, output.Membership.UserID)
+	assert.Empty(t, registrar.email)
+}
+
+func TestAcceptInvitationUseCase_Execute_Rejects(t *testing.T) {
+	org, roles := newTestOrganization(t)
+	signer := services.NewInvitationSigner("secret")
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		setup func(invitation *organization.Invitation) string
+	}{
+		{
+			name: "forged signature",
+			setup: func(invitation *organization.Invitation) string {
+				return services.NewInvitationSigner("other").Sign(invitation)
+			},
+		},
+		{
+			name: "revoked",
+			setup: func(invitation *organization.Invitation) string {
+				now := time.Now()
+				invitation.RevokedAt = &now
+				return signer.Sign(invitation)
+			},
+		},
+		{
+			name: "expired",
+			setup: func(invitation *organization.Invitation) string {
+				token := signer.Sign(invitation)
+				invitation.ExpiresAt = time.Now().Add(-time.Minute)
+				return token
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockInvitationRepo := new(orgmocks.MockInvitationRepository)
+			mockUserRepo := new(usermocks.MockUserRepository)
+			useCase := NewAcceptInvitationUseCase(mockInvitationRepo, new(orgmocks.MockOrganizationRepository), mockUserRepo, &stubRegistrar{}, signer)
+
+			invitation := organization.NewInvitation(org.ID, uuid.New(), "invitee@example.com", roles[organization.RoleMember], time.Hour)
+			token := tt.setup(invitation)
+			mockInvitationRepo.On("FindByID", ctx, invitation.ID).Return(invitation, nil)
+
+			output, err := useCase.Execute(ctx, AcceptInvitationInput{Token: token, Password: "password123"})
+
+			assert.Error(t, err)
+			assert.Nil(t, output)
+			mockInvitationRepo.AssertNotCalled(t, "Accept", mock.Anything, mock.Anything, mock.Anything)
+		})
+	}
+}
@@ -0,0 +1,69 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type AddMemberUseCase struct {
+	organizationRepository organization.Repository
+	userRepository         user.UserRepository
+	roleRepository         role.Repository
+}
+
+func NewAddMemberUseCase(organizationRepository organization.Repository, userRepository user.UserRepository, roleRepository role.Repository) *AddMemberUseCase {
+	return &AddMemberUseCase{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		roleRepository:         roleRepository,
+	}
+}
+
+type AddMemberInput struct {
+	ActorID        uuid.UUID
+	OrganizationID uuid.UUID
+	Email          string
+	Role           string
+}
+
+// Execute adds an existing user to the organization with one of its roles.
+func (u *AddMemberUseCase) Execute(ctx context.Context, input AddMemberInput) (_ *organization.Membership, err error) {
+	ctx, span := tracing.Start(ctx, "AddMemberUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	r, err := u.roleRepository.FindByNameInOrganization(ctx, input.OrganizationID, input.Role)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s' not found in organization: %w", input.Role, err)
+	}
+
+	if err := verifyCanGrant(ctx, u.organizationRepository, input.OrganizationID, input.ActorID, r); err != nil {
+		return nil, err
+	}
+
+	member, err := u.userRepository.FindByEmail(ctx, input.Email)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	existing, err := u.organizationRepository.FindMembership(ctx, input.OrganizationID, member.ID)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing membership: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("user is already a member of the organization")
+	}
+
+	membership := organization.NewMembership(input.OrganizationID, member.ID, r)
+	if err := u.organizationRepository.AddMember(ctx, membership); err != nil {
+		return nil, fmt.Errorf("error adding member: %w", err)
+	}
+	membership.User = member
+
+	return membership, nil
+}
@@ -0,0 +1,86 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	orgmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/organization/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func newTestOrganization(t *testing.T) (*organization.Organization, map[string]*role.Role) {
+	org, err := organization.New("Acme", "acme")
+	if err != nil {
+		t.Fatalf("Failed to create organization: %v", err)
+	}
+	roles := map[string]*role.Role{}
+	for _, r := range org.DefaultRoles() {
+		roles[r.Name] = r
+	}
+	return org, roles
+}
+
+func TestAddMemberUseCase_Execute_Success(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	useCase := NewAddMemberUseCase(mockOrgRepo, mockUserRepo, mockRoleRepo)
+
+	org, roles := newTestOrganization(t)
+	actorID := uuid.New()
+	invitee := user.New("member@example.com", nil)
+	ctx := context.Background()
+
+	mockRoleRepo.On("FindByNameInOrganization", ctx, org.ID, organization.RoleMember).Return(roles[organization.RoleMember], nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, actorID).Return(organization.NewMembership(org.ID, actorID, roles[organization.RoleAdmin]), nil)
+	mockUserRepo.On("FindByEmail", ctx, invitee.Email).Return(invitee, nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, invitee.ID).Return(nil, gorm.ErrRecordNotFound)
+	mockOrgRepo.On("AddMember", ctx, mock.AnythingOfType("*organization.Membership")).Return(nil)
+
+	membership, err := useCase.Execute(ctx, AddMemberInput{
+		ActorID:        actorID,
+		OrganizationID: org.ID,
+		Email:          invitee.Email,
+		Role:           organization.RoleMember,
+	})
+
+	assert.NoError(t, err)
+	assert.Equal(t, invitee.ID, membership.UserID)
+	assert.Equal(t, organization.RoleMember, membership.RoleName())
+	mockOrgRepo.AssertExpectations(t)
+	mockUserRepo.AssertExpectations(t)
+}
+
+func TestAddMemberUseCase_Execute_CannotGrantOwnerAsAdmin(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	useCase := NewAddMemberUseCase(mockOrgRepo, mockUserRepo, mockRoleRepo)
+
+	org, roles := newTestOrganization(t)
+	actorID := uuid.New()
+	ctx := context.Background()
+
+	mockRoleRepo.On("FindByNameInOrganization", ctx, org.ID, organization.RoleOwner).Return(roles[organization.RoleOwner], nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, actorID).Return(organization.NewMembership(org.ID, actorID, roles[organization.RoleAdmin]), nil)
+
+	membership, err := useCase.Execute(ctx, AddMemberInput{
+		ActorID:        actorID,
+		OrganizationID: org.ID,
+		Email:          "member@example.com",
+		Role:           organization.RoleOwner,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, membership)
+	assert.Contains(t, err.Error(), "does not hold")
+	mockUserRepo.AssertNotCalled(t, "FindByEmail", mock.Anything, mock.Anything)
+}
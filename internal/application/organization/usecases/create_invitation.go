@@ -0,0 +1,134 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type CreateInvitationUseCase struct {
+	organizationRepository organization.Repository
+	invitationRepository   organization.InvitationRepository
+	userRepository         user.UserRepository
+	roleRepository         role.Repository
+	signer                 *services.InvitationSigner
+	mailer                 mail.Mailer
+	acceptURL              string
+	invitationExpiry       time.Duration
+}
+
+func NewCreateInvitationUseCase(
+	organizationRepository organization.Repository,
+	invitationRepository organization.InvitationRepository,
+	userRepository user.UserRepository,
+	roleRepository role.Repository,
+	signer *services.InvitationSigner,
+	mailer mail.Mailer,
+	acceptURL string,
+	invitationExpiry time.Duration,
+) *CreateInvitationUseCase {
+	return &CreateInvitationUseCase{
+		organizationRepository: organizationRepository,
+		invitationRepository:   invitationRepository,
+		userRepository:         userRepository,
+		roleRepository:         roleRepository,
+		signer:                 signer,
+		mailer:                 mailer,
+		acceptURL:              acceptURL,
+		invitationExpiry:       invitationExpiry,
+	}
+}
+
+type CreateInvitationInput struct {
+	ActorID        uuid.UUID
+	OrganizationID uuid.UUID
+	Email          string
+	Role           string
+}
+
+// Execute records an invitation to one of the organization's roles and mails
+// the signed accept link to the invitee.
+func (u *CreateInvitationUseCase) Execute(ctx context.Context, input CreateInvitationInput) (_ *organization.Invitation, err error) {
+	ctx, span := tracing.Start(ctx, "CreateInvitationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	org, err := u.organizationRepository.FindByID(ctx, input.OrganizationID)
+	if err != nil {
+		return nil, fmt.Errorf("organization not found: %w", err)
+	}
+
+	r, err := u.roleRepository.FindByNameInOrganization(ctx, input.OrganizationID, input.Role)
+	if err != nil {
+		return nil, fmt.Errorf("role '%s' not found in organization: %w", input.Role, err)
+	}
+
+	if err := verifyCanGrant(ctx, u.organizationRepository, input.OrganizationID, input.ActorID, r); err != nil {
+		return nil, err
+	}
+
+	invitee, err := u.userRepository.FindByEmail(ctx, input.Email)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing user: %w", err)
+	}
+	if err == nil && invitee != nil {
+		existing, err := u.organizationRepository.FindMembership(ctx, input.OrganizationID, invitee.ID)
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("error checking existing membership: %w", err)
+		}
+		if existing != nil {
+			return nil, fmt.Errorf("user is already a member of the organization")
+		}
+	}
+
+	pending, err := u.invitationRepository.FindPending(ctx, input.OrganizationID, input.Email)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking pending invitations: %w", err)
+	}
+	if pending != nil {
+		return nil, fmt.Errorf("a pending invitation for '%s' already exists", input.Email)
+	}
+
+	invitation := organization.NewInvitation(input.OrganizationID, input.ActorID, input.Email, r, u.invitationExpiry)
+	if err := u.invitationRepository.Create(ctx, invitation); err != nil {
+		return nil, fmt.Errorf("error creating invitation: %w", err)
+	}
+
+	link, err := u.acceptLink(u.signer.Sign(invitation))
+	if err != nil {
+		return nil, err
+	}
+
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      invitation.Email,
+		Subject: fmt.Sprintf("You have been invited to join %s", org.Name),
+		Body: fmt.Sprintf(
+			"You have been invited to join %s as %s.\n\nAccept the invitation: %s\n\nThis link expires on %s.\n",
+			org.Name, r.Name, link, invitation.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("error sending invitation: %w", err)
+	}
+
+	return invitation, nil
+}
+
+func (u *CreateInvitationUseCase) acceptLink(token string) (string, error) {
+	link, err := url.Parse(u.acceptURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid invitation accept URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
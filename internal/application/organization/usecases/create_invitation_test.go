@@ -0,0 +1,109 @@
+package usecases
+
+import (
+	"context"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	orgmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/organization/mocks"
+	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type recordingMailer struct {
+	messages []mail.Message
+}
+
+func (m *recordingMailer) Send(_ context.Context, message mail.Message) error {
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+func TestCreateInvitationUseCase_Execute_SendsSignedLink(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockInvitationRepo := new(orgmocks.MockInvitationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mailer := &recordingMailer{}
+	signer := services.NewInvitationSigner("secret")
+	useCase := NewCreateInvitationUseCase(mockOrgRepo, mockInvitationRepo, mockUserRepo, mockRoleRepo, signer, mailer, "https://app.example.com/invitations/accept", time.Hour)
+
+	org, roles := newTestOrganization(t)
+	actorID := uuid.New()
+	email := "invitee@example.com"
+	ctx := context.Background()
+
+	mockOrgRepo.On("FindByID", ctx, org.ID).Return(org, nil)
+	mockRoleRepo.On("FindByNameInOrganization", ctx, org.ID, organization.RoleMember).Return(roles[organization.RoleMember], nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, actorID).Return(organization.NewMembership(org.ID, actorID, roles[organization.RoleAdmin]), nil)
+	mockUserRepo.On("FindByEmail", ctx, email).Return(nil, gorm.ErrRecordNotFound)
+	mockInvitationRepo.On("FindPending", ctx, org.ID, email).Return(nil, gorm.ErrRecordNotFound)
+	mockInvitationRepo.On("Create", ctx, mock.AnythingOfType("*organization.Invitation")).Return(nil)
+
+	invitation, err := useCase.Execute(ctx, CreateInvitationInput{
+		ActorID:        actorID,
+		OrganizationID: org.ID,
+		Email:          email,
+		Role:           organization.RoleMember,
+	})
+
+	require.NoError(t, err)
+	assert.Equal(t, actorID, invitation.InviterID)
+	assert.Equal(t, organization.InvitationPending, invitation.Status())
+	require.Len(t, mailer.messages, 1)
+	assert.Equal(t, email, mailer.messages[0].To)
+
+	var link string
+	for _, field := range strings.Fields(mailer.messages[0].Body) {
+		if strings.HasPrefix(field, "https://") {
+			link = field
+		}
+	}
+	parsed, err := url.Parse(link)
+	require.NoError(t, err)
+	assert.NoError(t, signer.Verify(parsed.Query().Get("token"), invitation))
+	mockInvitationRepo.AssertExpectations(t)
+}
+
+func TestCreateInvitationUseCase_Execute_AlreadyMember(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockInvitationRepo := new(orgmocks.MockInvitationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mailer := &recordingMailer{}
+	useCase := NewCreateInvitationUseCase(mockOrgRepo, mockInvitationRepo, mockUserRepo, mockRoleRepo, services.NewInvitationSigner("secret"), mailer, "https://app.example.com/invitations/accept", time.Hour)
+
+	org, roles := newTestOrganization(t)
+	actorID := uuid.New()
+	member := user.New("member@example.com", nil)
+	ctx := context.Background()
+
+	mockOrgRepo.On("FindByID", ctx, org.ID).Return(org, nil)
+	mockRoleRepo.On("FindByNameInOrganization", ctx, org.ID, organization.RoleMember).Return(roles[organization.RoleMember], nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, actorID).Return(organization.NewMembership(org.ID, actorID, roles[organization.RoleOwner]), nil)
+	mockUserRepo.On("FindByEmail", ctx, member.Email).Return(member, nil)
+	mockOrgRepo.On("FindMembership", ctx, org.ID, member.ID).Return(organization.NewMembership(org.ID, member.ID, roles[organization.RoleMember]), nil)
+
+	invitation, err := useCase.Execute(ctx, CreateInvitationInput{
+		ActorID:        actorID,
+		OrganizationID: org.ID,
+		Email:          member.Email,
+		Role:           organization.RoleMember,
+	})
+
+	assert.Error(t, err)
+	assert.Nil(t, invitation)
+	assert.Empty(t, mailer.messages)
+	mockInvitationRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -0,0 +1,88 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreateOrganizationUseCase struct {
+	organizationRepository organization.Repository
+	userRepository         user.UserRepository
+}
+
+func NewCreateOrganizationUseCase(organizationRepository organization.Repository, userRepository user.UserRepository) *CreateOrganizationUseCase {
+	return &CreateOrganizationUseCase{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+	}
+}
+
+type CreateOrganizationInput struct {
+	OwnerID uuid.UUID
+	Name    string
+	Slug    string
+}
+
+// Execute creates the organization with its default roles and makes the caller its owner.
+func (u *CreateOrganizationUseCase) Execute(ctx context.Context, input CreateOrganizationInput) (_ *organization.Organization, err error) {
+	ctx, span := tracing.Start(ctx, "CreateOrganizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if _, err := u.userRepository.FindByID(ctx, input.OwnerID); err != nil {
+		return nil, fmt.Errorf("owner not found: %w", err)
+	}
+
+	existing, err := u.organizationRepository.FindBySlug(ctx, input.Slug)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing organization: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("organization with slug '%s' already exists", input.Slug)
+	}
+
+	org, err := organization.New(input.Name, input.Slug)
+	if err != nil {
+		return nil, err
+	}
+
+	roles := org.DefaultRoles()
+	owner := organization.NewMembership(org.ID, input.OwnerID, roles[0])
+
+	if err := u.organizationRepository.Create(ctx, org, roles, owner); err != nil {
+		return nil, fmt.Errorf("error creating organization: %w", err)
+	}
+
+	return org, nil
+}
+
+// verifyCanGrant checks that the actor is a member of the organization and
+// holds every organization-management permission r grants, so admins cannot
+// hand out ownership or create roles more powerful than their own.
+func verifyCanGrant(ctx context.Context, organizationRepository organization.Repository, organizationID, actorID uuid.UUID, r *role.Role) error {
+	actor, err := organizationRepository.FindMembership(ctx, organizationID, actorID)
+	if err != nil {
+		return fmt.Errorf("actor is not a member of the organization: %w", err)
+	}
+
+	granted := actor.Permissions()
+	for _, permission := range r.GetPermissionStrings() {
+		if organization.ManagesOrganization(permission) && !auth.HasPermission(granted, permission) {
+			return fmt.Errorf("role '%s' grants %s, which the actor does not hold", r.Name, permission)
+		}
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
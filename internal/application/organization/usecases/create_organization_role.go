@@ -0,0 +1,54 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type CreateOrganizationRoleUseCase struct {
+	organizationRepository organization.Repository
+	roleRepository         role.Repository
+}
+
+func NewCreateOrganizationRoleUseCase(organizationRepository organization.Repository, roleRepository role.Repository) *CreateOrganizationRoleUseCase {
+	return &CreateOrganizationRoleUseCase{
+		organizationRepository: organizationRepository,
+		roleRepository:         roleRepository,
+	}
+}
+
+type CreateOrganizationRoleInput struct {
+	ActorID        uuid.UUID
+	OrganizationID uuid.UUID
+	Name           string
+	Permissions    []string
+}
+
+func (u *CreateOrganizationRoleUseCase) Execute(ctx context.Context, input CreateOrganizationRoleInput) (_ *role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "CreateOrganizationRoleUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	existing, err := u.roleRepository.FindByNameInOrganization(ctx, input.OrganizationID, input.Name)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing role: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("role with name '%s' already exists in the organization", input.Name)
+	}
+
+	newRole := role.NewOrganizationRole(input.OrganizationID, input.Name, input.Permissions)
+	if err := verifyCanGrant(ctx, u.organizationRepository, input.OrganizationID, input.ActorID, newRole); err != nil {
+		return nil, err
+	}
+
+	if err := u.roleRepository.Create(ctx, newRole); err != nil {
+		return nil, fmt.Errorf("error creating role: %w", err)
+	}
+
+	return newRole, nil
+}
@@ -0,0 +1,56 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	orgmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/organization/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"gorm.io/gorm"
+)
+
+func TestCreateOrganizationUseCase_Execute_SlugTaken(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewCreateOrganizationUseCase(mockOrgRepo, mockUserRepo)
+
+	existing, _ := newTestOrganization(t)
+	owner := user.New("owner@example.com", nil)
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, owner.ID).Return(owner, nil)
+	mockOrgRepo.On("FindBySlug", ctx, "acme").Return(existing, nil)
+
+	org, err := useCase.Execute(ctx, CreateOrganizationInput{OwnerID: owner.ID, Name: "Acme", Slug: "acme"})
+
+	assert.Error(t, err)
+	assert.Nil(t, org)
+	assert.Contains(t, err.Error(), "already exists")
+}
+
+func TestCreateOrganizationUseCase_Execute_OwnerMembership(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	mockUserRepo := new(usermocks.MockUserRepository)
+	useCase := NewCreateOrganizationUseCase(mockOrgRepo, mockUserRepo)
+
+	owner := user.New("owner@example.com", nil)
+	ctx := context.Background()
+
+	mockUserRepo.On("FindByID", ctx, owner.ID).Return(owner, nil)
+	mockOrgRepo.On("FindBySlug", ctx, "acme").Return(nil, gorm.ErrRecordNotFound)
+	mockOrgRepo.On("Create", ctx, mock.AnythingOfType("*organization.Organization"), mock.Anything,
+		mock.MatchedBy(func(m *organization.Membership) bool {
+			return m.UserID == owner.ID && m.RoleName() == organization.RoleOwner
+		}),
+	).Return(nil)
+
+	org, err := useCase.Execute(ctx, CreateOrganizationInput{OwnerID: owner.ID, Name: "Acme", Slug: "acme"})
+
+	assert.NoError(t, err)
+	assert.Equal(t, "acme", org.Slug)
+	mockOrgRepo.AssertExpectations(t)
+}
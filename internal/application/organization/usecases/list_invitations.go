@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListInvitationsUseCase struct {
+	invitationRepository organization.InvitationRepository
+}
+
+func NewListInvitationsUseCase(invitationRepository organization.InvitationRepository) *ListInvitationsUseCase {
+	return &ListInvitationsUseCase{
+		invitationRepository: invitationRepository,
+	}
+}
+
+func (u *ListInvitationsUseCase) Execute(ctx context.Context, organizationID uuid.UUID) (_ []organization.Invitation, err error) {
+	ctx, span := tracing.Start(ctx, "ListInvitationsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	invitations, err := u.invitationRepository.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing invitations: %w", err)
+	}
+	return invitations, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListMembersUseCase struct {
+	organizationRepository organization.Repository
+}
+
+func NewListMembersUseCase(organizationRepository organization.Repository) *ListMembersUseCase {
+	return &ListMembersUseCase{
+		organizationRepository: organizationRepository,
+	}
+}
+
+func (u *ListMembersUseCase) Execute(ctx context.Context, organizationID uuid.UUID) (_ []organization.Membership, err error) {
+	ctx, span := tracing.Start(ctx, "ListMembersUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	members, err := u.organizationRepository.ListMembers(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing members: %w", err)
+	}
+	return members, nil
+}
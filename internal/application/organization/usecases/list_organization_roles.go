@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListOrganizationRolesUseCase struct {
+	roleRepository role.Repository
+}
+
+func NewListOrganizationRolesUseCase(roleRepository role.Repository) *ListOrganizationRolesUseCase {
+	return &ListOrganizationRolesUseCase{
+		roleRepository: roleRepository,
+	}
+}
+
+func (u *ListOrganizationRolesUseCase) Execute(ctx context.Context, organizationID uuid.UUID) (_ []role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "ListOrganizationRolesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	roles, err := u.roleRepository.ListByOrganization(ctx, organizationID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing roles: %w", err)
+	}
+	return roles, nil
+}
@@ -0,0 +1,32 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListOrganizationsUseCase struct {
+	organizationRepository organization.Repository
+}
+
+func NewListOrganizationsUseCase(organizationRepository organization.Repository) *ListOrganizationsUseCase {
+	return &ListOrganizationsUseCase{
+		organizationRepository: organizationRepository,
+	}
+}
+
+// Execute lists the organizations the user is a member of.
+func (u *ListOrganizationsUseCase) Execute(ctx context.Context, userID uuid.UUID) (_ []organization.Organization, err error) {
+	ctx, span := tracing.Start(ctx, "ListOrganizationsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	orgs, err := u.organizationRepository.ListByUser(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing organizations: %w", err)
+	}
+	return orgs, nil
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type RemoveMemberUseCase struct {
+	organizationRepository organization.Repository
+}
+
+func NewRemoveMemberUseCase(organizationRepository organization.Repository) *RemoveMemberUseCase {
+	return &RemoveMemberUseCase{
+		organizationRepository: organizationRepository,
+	}
+}
+
+type RemoveMemberInput struct {
+	ActorID        uuid.UUID
+	OrganizationID uuid.UUID
+	UserID         uuid.UUID
+}
+
+// Execute removes a member. Members holding permissions the actor lacks
+// cannot be removed by them, and the last owner always stays.
+func (u *RemoveMemberUseCase) Execute(ctx context.Context, input RemoveMemberInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RemoveMemberUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	membership, err := u.organizationRepository.FindMembership(ctx, input.OrganizationID, input.UserID)
+	if err != nil {
+		return fmt.Errorf("membership not found: %w", err)
+	}
+
+	if membership.Role != nil {
+		if err := verifyCanGrant(ctx, u.organizationRepository, input.OrganizationID, input.ActorID, membership.Role); err != nil {
+			return err
+		}
+	}
+
+	if membership.RoleName() == organization.RoleOwner {
+		owners, err := u.organizationRepository.CountMembersWithRole(ctx, input.OrganizationID, membership.RoleID)
+		if err != nil {
+			return fmt.Errorf("error counting owners: %w", err)
+		}
+		if owners <= 1 {
+			return fmt.Errorf("cannot remove the last owner of the organization")
+		}
+	}
+
+	if err := u.organizationRepository.RemoveMember(ctx, input.OrganizationID, input.UserID); err != nil {
+		return fmt.Errorf("error removing member: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	orgmocks "github.com/EduardoPPCaldas/auth-service/internal/domain/organization/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestRemoveMemberUseCase_Execute_LastOwner(t *testing.T) {
+	mockOrgRepo := new(orgmocks.MockOrganizationRepository)
+	useCase := NewRemoveMemberUseCase(mockOrgRepo)
+
+	org, roles := newTestOrganization(t)
+	ownerID := uuid.New()
+	owner := organization.NewMembership(org.ID, ownerID, roles[organization.RoleOwner])
+	ctx := context.Background()
+
+	mockOrgRepo.On("FindMembership", ctx, org.ID, ownerID).Return(owner, nil)
+	mockOrgRepo.On("CountMembersWithRole", ctx, org.ID, owner.RoleID).Return(int64(1), nil)
+
+	err := useCase.Execute(ctx, RemoveMemberInput{ActorID: ownerID, OrganizationID: org.ID, UserID: ownerID})
+
+	assert.Error(t, err)
+	assert.Contains(t, err.Error(), "last owner")
+	mockOrgRepo.AssertNotCalled(t, "RemoveMember", mock.Anything, mock.Anything, mock.Anything)
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type RevokeInvitationUseCase struct {
+	invitationRepository organization.InvitationRepository
+}
+
+func NewRevokeInvitationUseCase(invitationRepository organization.InvitationRepository) *RevokeInvitationUseCase {
+	return &RevokeInvitationUseCase{
+		invitationRepository: invitationRepository,
+	}
+}
+
+type RevokeInvitationInput struct {
+	OrganizationID uuid.UUID
+	InvitationID   uuid.UUID
+}
+
+// Execute revokes a pending invitation so its link can no longer be accepted.
+func (u *RevokeInvitationUseCase) Execute(ctx context.Context, input RevokeInvitationInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokeInvitationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	invitation, err := u.invitationRepository.FindByID(ctx, input.InvitationID)
+	if err != nil || invitation.OrganizationID != input.OrganizationID {
+		return fmt.Errorf("invitation not found")
+	}
+
+	if !invitation.IsPending() {
+		return organization.ErrInvitationNotPending
+	}
+
+	if err := u.invitationRepository.Revoke(ctx, invitation.ID); err != nil {
+		return fmt.Errorf("error revoking invitation: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,48 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type SwitchOrganizationUseCase struct {
+	organizationRepository organization.Repository
+	userRepository         user.UserRepository
+	tokenGenerator         token.TokenGenerator
+}
+
+func NewSwitchOrganizationUseCase(organizationRepository organization.Repository, userRepository user.UserRepository, tokenGenerator token.TokenGenerator) *SwitchOrganizationUseCase {
+	return &SwitchOrganizationUseCase{
+		organizationRepository: organizationRepository,
+		userRepository:         userRepository,
+		tokenGenerator:         tokenGenerator,
+	}
+}
+
+// Execute issues an access token carrying the organization and the user's permissions in it.
+// Disabled users get user.ErrUserDisabled.
+func (u *SwitchOrganizationUseCase) Execute(ctx context.Context, userID, organizationID uuid.UUID) (_ string, err error) {
+	ctx, span := tracing.Start(ctx, "SwitchOrganizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	membership, err := u.organizationRepository.FindMembership(ctx, organizationID, userID)
+	if err != nil {
+		return "", fmt.Errorf("user is not a member of the organization: %w", err)
+	}
+
+	usr, err := u.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return "", fmt.Errorf("user not found: %w", err)
+	}
+	if usr.IsDisabled() {
+		return "", user.ErrUserDisabled
+	}
+
+	return u.tokenGenerator.GenerateOrganizationToken(usr, membership)
+}
@@ -0,0 +1,39 @@
+package services
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+)
+
+// Publisher commits changes together with the events describing them
+type Publisher struct {
+	transactor outbox.Transactor
+	repository outbox.Repository
+}
+
+func NewPublisher(transactor outbox.Transactor, repository outbox.Repository) *Publisher {
+	return &Publisher{transactor: transactor, repository: repository}
+}
+
+// Publish runs change in a transaction and adds the event to the outbox in
+// the same one, so the event is published if and only if the change is
+// committed. change must make its repository calls with the context it is
+// given. A nil *Publisher runs change alone, for use cases built without an
+// outbox.
+func (p *Publisher) Publish(ctx context.Context, eventType string, data any, change func(ctx context.Context) error) error {
+	if p == nil {
+		return change(ctx)
+	}
+
+	message, err := outbox.NewMessage(eventType, data)
+	if err != nil {
+		return err
+	}
+	return p.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		if err := change(ctx); err != nil {
+			return err
+		}
+		return p.repository.Add(ctx, message)
+	})
+}
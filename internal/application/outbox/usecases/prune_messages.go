@@ -0,0 +1,36 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type PruneMessagesUseCase struct {
+	outboxRepository outbox.Repository
+	retention        time.Duration
+}
+
+func NewPruneMessagesUseCase(outboxRepository outbox.Repository, retention time.Duration) *PruneMessagesUseCase {
+	return &PruneMessagesUseCase{
+		outboxRepository: outboxRepository,
+		retention:        retention,
+	}
+}
+
+// Execute deletes messages dispatched longer ago than the retention, with
+// their delivery history, and returns how many it deleted. Messages still
+// being delivered are kept, so their retries can go on.
+func (u *PruneMessagesUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "PruneMessagesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	deleted, err := u.outboxRepository.DeleteDispatchedBefore(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return deleted, fmt.Errorf("error pruning outbox messages: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,79 @@
+package dto
+
+import "github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+
+type CreatePolicyRequest struct {
+	Name         string `json:"name" validate:"required,min=2,max=100"`
+	Description  string `json:"description" validate:"max=500"`
+	Action       string `json:"action" validate:"required"`
+	ResourceType string `json:"resource_type"`
+	Effect       string `json:"effect" validate:"required,oneof=allow deny"`
+	Condition    string `json:"condition"`
+	Enabled      *bool  `json:"enabled"`
+}
+
+type UpdatePolicyRequest struct {
+	Name         *string `json:"name" validate:"omitempty,min=2,max=100"`
+	Description  *string `json:"description" validate:"omitempty,max=500"`
+	Action       *string `json:"action" validate:"omitempty,min=1"`
+	ResourceType *string `json:"resource_type"`
+	Effect       *string `json:"effect" validate:"omitempty,oneof=allow deny"`
+	Condition    *string `json:"condition"`
+	Enabled      *bool   `json:"enabled"`
+}
+
+type PolicyResponse struct {
+	ID           string `json:"id"`
+	Name         string `json:"name"`
+	Description  string `json:"description"`
+	Action       string `json:"action"`
+	ResourceType string `json:"resource_type"`
+	Effect       string `json:"effect"`
+	Condition    string `json:"condition"`
+	Enabled      bool   `json:"enabled"`
+	CreatedAt    string `json:"created_at"`
+	UpdatedAt    string `json:"updated_at"`
+}
+
+func ToPolicyResponse(p *policy.Policy) PolicyResponse {
+	return PolicyResponse{
+		ID:           p.ID.String(),
+		Name:         p.Name,
+		Description:  p.Description,
+		Action:       p.Action,
+		ResourceType: p.ResourceType,
+		Effect:       string(p.Effect),
+		Condition:    p.Condition,
+		Enabled:      p.Enabled,
+		CreatedAt:    p.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt:    p.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+// CheckRequest asks whether a subject may perform an action on a resource.
+// When Subject is omitted the caller is the subject.
+type CheckRequest struct {
+	Subject     *SubjectRequest `json:"subject"`
+	Action      string          `json:"action" validate:"required"`
+	Resource    ResourceRequest `json:"resource"`
+	Environment map[string]any  `json:"environment"`
+}
+
+type SubjectRequest struct {
+	ID         string         `json:"id" validate:"omitempty,uuid"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+type ResourceRequest struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id"`
+	Attributes map[string]any `json:"attributes"`
+}
+
+type CheckResponse struct {
+	Allowed    bool   `json:"allowed"`
+	Decision   string `json:"decision"`
+	PolicyID   string `json:"policy_id,omitempty"`
+	PolicyName string `json:"policy_name,omitempty"`
+	Reason     string `json:"reason"`
+}
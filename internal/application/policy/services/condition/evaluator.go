@@ -0,0 +1,15 @@
+package condition
+
+// Input holds the attributes a policy condition is evaluated against.
+type Input struct {
+	Subject  map[string]any
+	Resource map[string]any
+	Action   string
+	Env      map[string]any
+}
+
+type Evaluator interface {
+	// Compile checks that the condition is a valid boolean expression.
+	Compile(condition string) error
+	Evaluate(condition string, input Input) (bool, error)
+}
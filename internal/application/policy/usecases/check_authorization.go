@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/services/condition"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+)
+
+type CheckAuthorizationUseCase struct {
+	policyRepository policy.Repository
+	userRepository   user.UserRepository
+	evaluator        condition.Evaluator
+	now              func() time.Time
+}
+
+func NewCheckAuthorizationUseCase(policyRepository policy.Repository, userRepository user.UserRepository, evaluator condition.Evaluator) *CheckAuthorizationUseCase {
+	return &CheckAuthorizationUseCase{
+		policyRepository: policyRepository,
+		userRepository:   userRepository,
+		evaluator:        evaluator,
+		now:              time.Now,
+	}
+}
+
+// CheckAuthorizationInput describes the request to decide. OrganizationID is
+// the organization the subject's token was issued for, uuid.Nil when none;
+// SubjectAttributes must only come from callers trusted to supply them.
+type CheckAuthorizationInput struct {
+	SubjectID          uuid.UUID
+	OrganizationID     uuid.UUID
+	SubjectAttributes  map[string]any
+	Action             string
+	ResourceType       string
+	ResourceID         string
+	ResourceAttributes map[string]any
+	Environment        map[string]any
+}
+
+type Decision struct {
+	Allowed bool
+	Effect  policy.Effect
+	Policy  *policy.Policy
+	Reason  string
+}
+
+// Execute decides whether the subject may perform the action on the resource.
+// Deny policies override allow policies, and allow policies extend the
+// subject's role permissions, including roles inherited from groups. When no
+// policy applies the role permissions decide. A deny policy whose condition fails to evaluate denies the request.
+func (u *CheckAuthorizationUseCase) Execute(ctx context.Context, input CheckAuthorizationInput) (_ *Decision, err error) {
+	ctx, span := tracing.Start(ctx, "CheckAuthorizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	subject, err := u.userRepository.FindByID(ctx, input.SubjectID)
+	if err != nil {
+		return nil, fmt.Errorf("subject not found: %w", err)
+	}
+
+	policies, err := u.policyRepository.ListEnabled(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error loading policies: %w", err)
+	}
+
+	evalInput := condition.Input{
+		Subject:  subjectAttributes(subject, input.OrganizationID, input.SubjectAttributes),
+		Resource: resourceAttributes(input),
+		Action:   input.Action,
+		Env:      u.environment(input.Environment),
+	}
+
+	var allowedBy *policy.Policy
+	for i := range policies {
+		p := &policies[i]
+		if !p.AppliesTo(input.Action, input.ResourceType) {
+			continue
+		}
+
+		matched, err := u.evaluator.Evaluate(p.Condition, evalInput)
+		if err != nil {
+			if p.Effect == policy.EffectDeny {
+				return &Decision{Effect: policy.EffectDeny, Policy: p, Reason: fmt.Sprintf("deny policy '%s' could not be evaluated", p.Name)}, nil
+			}
+			continue
+		}
+		if !matched {
+			continue
+		}
+
+		if p.Effect == policy.EffectDeny {
+			return &Decision{Effect: policy.EffectDeny, Policy: p, Reason: fmt.Sprintf("denied by policy '%s'", p.Name)}, nil
+		}
+		if allowedBy == nil {
+			allowedBy = p
+		}
+	}
+
+	if allowedBy != nil {
+		return &Decision{Allowed: true, Effect: policy.EffectAllow, Policy: allowedBy, Reason: fmt.Sprintf("allowed by policy '%s'", allowedBy.Name)}, nil
+	}
+
+	if auth.HasPermission(subject.EffectivePermissions(), input.Action) {
+		return &Decision{Allowed: true, Effect: policy.EffectAllow, Reason: "granted by role permissions"}, nil
+	}
+
+	return &Decision{Effect: policy.EffectDeny, Reason: "no policy or role permission grants this action"}, nil
+}
+
+// subjectAttributes merges caller-supplied attributes with the stored identity.
+// Stored values win so a caller cannot claim a role, group or organization the
+// subject does not have.
+func subjectAttributes(u *user.User, orgID uuid.UUID, extra map[string]any) map[string]any {
+	attrs := map[string]any{}
+	maps.Copy(attrs, extra)
+
+	attrs["id"] = u.ID.String()
+	attrs["email"] = u.Email
+	attrs["groups"] = u.GroupNames()
+	if orgID != uuid.Nil {
+		attrs["org_id"] = orgID.String()
+	}
+	attrs["roles"] = append([]string{}, u.EffectiveRoleNames()...)
+	attrs["permissions"] = append([]string{}, u.EffectivePermissions()...)
+
+	return attrs
+}
+
+func resourceAttributes(input CheckAuthorizationInput) map[string]any {
+	attrs := map[string]any{}
+	maps.Copy(attrs, input.ResourceAttributes)
+	attrs["type"] = input.ResourceType
+	attrs["id"] = input.ResourceID
+	return attrs
+}
+
+func (u *CheckAuthorizationUseCase) environment(extra map[string]any) map[string]any {
+	env := map[string]any{}
+	maps.Copy(env, extra)
+	env["time"] = u.now().UTC()
+	return env
+}
@@ -0,0 +1,204 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	policymocks "github.com/EduardoPPCaldas/auth-service/internal/domain/policy/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/policy/celeval"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckAuthorizationUseCase_Execute(t *testing.T) {
+	orgID := uuid.NewString()
+	businessHours := time.Date(2026, 3, 10, 14, 0, 0, 0, time.UTC)
+	afterHours := time.Date(2026, 3, 10, 22, 0, 0, 0, time.UTC)
+
+	moderatorsInOrg := *policy.New(
+		"moderators-delete-own-org",
+		"moderators can delete posts in their org during business hours",
+		"posts:delete",
+		"post",
+		policy.EffectAllow,
+		`"moderator" in subject.roles && subject.org_id == resource.org_id && env.time.getHours() >= 9 && env.time.getHours() < 17`,
+	)
+	denyBlockedIP := *policy.New("deny-blocked-ip", "", "*", "", policy.EffectDeny, `env.ip == "10.0.0.66"`)
+	denyBroken := *policy.New("deny-broken", "", "posts:archive", "", policy.EffectDeny, `resource.missing == "x"`)
+	disabled := *policy.New("disabled-allow-all", "", "*", "", policy.EffectAllow, "")
+	disabled.Enabled = false
+
+	moderator := &user.User{ID: uuid.New(), Email: "mod@example.com", Role: role.New(role.RoleModerator, []string{"posts:read"})}
+	regular := &user.User{ID: uuid.New(), Email: "user@example.com", Role: role.NewUserRole()}
+	groupModerator := &user.User{ID: uuid.New(), Email: "group-mod@example.com", Role: role.NewUserRole(), Groups: []group.Group{
+		{Name: "moderators", Roles: []role.Role{*role.New(role.RoleModerator, []string{"posts:archive"})}},
+	}}
+
+	tests := []struct {
+		name       string
+		subject    *user.User
+		policies   []policy.Policy
+		now        time.Time
+		input      CheckAuthorizationInput
+		allowed    bool
+		policyName string
+	}{
+		{
+			name:     "moderator deletes post in own org during business hours",
+			subject:  moderator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      businessHours,
+			input: CheckAuthorizationInput{
+				SubjectAttributes:  map[string]any{"org_id": orgID},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": orgID},
+			},
+			allowed:    true,
+			policyName: moderatorsInOrg.Name,
+		},
+		{
+			name:     "moderator deletes post in own org after hours",
+			subject:  moderator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      afterHours,
+			input: CheckAuthorizationInput{
+				SubjectAttributes:  map[string]any{"org_id": orgID},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": orgID},
+			},
+			allowed: false,
+		},
+		{
+			name:     "moderator deletes post in another org",
+			subject:  moderator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      businessHours,
+			input: CheckAuthorizationInput{
+				SubjectAttributes:  map[string]any{"org_id": orgID},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": uuid.NewString()},
+			},
+			allowed: false,
+		},
+		{
+			name:     "caller cannot claim a role through attributes",
+			subject:  regular,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      businessHours,
+			input: CheckAuthorizationInput{
+				SubjectAttributes:  map[string]any{"org_id": orgID, "roles": []string{"moderator"}},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": orgID},
+			},
+			allowed: false,
+		},
+		{
+			name:     "token organization overrides the org_id attribute",
+			subject:  moderator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      businessHours,
+			input: CheckAuthorizationInput{
+				OrganizationID:     uuid.New(),
+				SubjectAttributes:  map[string]any{"org_id": orgID},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": orgID},
+			},
+			allowed: false,
+		},
+		{
+			name:     "role permission applies when no policy matches",
+			subject:  moderator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      afterHours,
+			input:    CheckAuthorizationInput{Action: "posts:read", ResourceType: "post"},
+			allowed:  true,
+		},
+		{
+			name:     "group role satisfies a policy",
+			subject:  groupModerator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      businessHours,
+			input: CheckAuthorizationInput{
+				SubjectAttributes:  map[string]any{"org_id": orgID},
+				Action:             "posts:delete",
+				ResourceType:       "post",
+				ResourceAttributes: map[string]any{"org_id": orgID},
+			},
+			allowed:    true,
+			policyName: moderatorsInOrg.Name,
+		},
+		{
+			name:     "group-granted permission applies when no policy matches",
+			subject:  groupModerator,
+			policies: []policy.Policy{moderatorsInOrg},
+			now:      afterHours,
+			input:    CheckAuthorizationInput{Action: "posts:archive", ResourceType: "post"},
+			allowed:  true,
+		},
+		{
+			name:       "deny overrides role permission",
+			subject:    moderator,
+			policies:   []policy.Policy{denyBlockedIP},
+			now:        businessHours,
+			input:      CheckAuthorizationInput{Action: "posts:read", ResourceType: "post", Environment: map[string]any{"ip": "10.0.0.66"}},
+			allowed:    false,
+			policyName: denyBlockedIP.Name,
+		},
+		{
+			name:       "deny policy that fails to evaluate denies",
+			subject:    moderator,
+			policies:   []policy.Policy{denyBroken},
+			now:        businessHours,
+			input:      CheckAuthorizationInput{Action: "posts:archive", ResourceType: "post"},
+			allowed:    false,
+			policyName: denyBroken.Name,
+		},
+		{
+			name:     "disabled policy is ignored",
+			subject:  regular,
+			policies: []policy.Policy{disabled},
+			now:      businessHours,
+			input:    CheckAuthorizationInput{Action: "users:delete", ResourceType: "user"},
+			allowed:  false,
+		},
+	}
+
+	evaluator, err := celeval.NewEvaluator()
+	require.NoError(t, err)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicyRepo := new(policymocks.MockPolicyRepository)
+			mockUserRepo := new(usermocks.MockUserRepository)
+			useCase := NewCheckAuthorizationUseCase(mockPolicyRepo, mockUserRepo, evaluator)
+			useCase.now = func() time.Time { return tt.now }
+
+			ctx := context.Background()
+			tt.input.SubjectID = tt.subject.ID
+
+			mockUserRepo.On("FindByID", ctx, tt.subject.ID).Return(tt.subject, nil)
+			mockPolicyRepo.On("ListEnabled", ctx).Return(tt.policies, nil)
+
+			decision, err := useCase.Execute(ctx, tt.input)
+
+			require.NoError(t, err)
+			assert.Equal(t, tt.allowed, decision.Allowed, decision.Reason)
+			if tt.policyName != "" {
+				require.NotNil(t, decision.Policy)
+				assert.Equal(t, tt.policyName, decision.Policy.Name)
+			}
+		})
+	}
+}
@@ -0,0 +1,93 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/services/condition"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type CreatePolicyUseCase struct {
+	policyRepository policy.Repository
+	userRepository   user.UserRepository
+	evaluator        condition.Evaluator
+}
+
+func NewCreatePolicyUseCase(policyRepository policy.Repository, userRepository user.UserRepository, evaluator condition.Evaluator) *CreatePolicyUseCase {
+	return &CreatePolicyUseCase{
+		policyRepository: policyRepository,
+		userRepository:   userRepository,
+		evaluator:        evaluator,
+	}
+}
+
+type CreatePolicyInput struct {
+	AdminUserID  uuid.UUID
+	Name         string
+	Description  string
+	Action       string
+	ResourceType string
+	Effect       policy.Effect
+	Condition    string
+	Enabled      *bool
+}
+
+func (u *CreatePolicyUseCase) Execute(ctx context.Context, input CreatePolicyInput) (_ *policy.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "CreatePolicyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return nil, err
+	}
+
+	if !input.Effect.IsValid() {
+		return nil, fmt.Errorf("invalid effect '%s'", input.Effect)
+	}
+
+	if err := u.evaluator.Compile(input.Condition); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.policyRepository.FindByName(ctx, input.Name)
+	if err != nil && !isNotFound(err) {
+		return nil, fmt.Errorf("error checking existing policy: %w", err)
+	}
+	if existing != nil {
+		return nil, fmt.Errorf("policy with name '%s' already exists", input.Name)
+	}
+
+	newPolicy := policy.New(input.Name, input.Description, input.Action, input.ResourceType, input.Effect, input.Condition)
+	if input.Enabled != nil {
+		newPolicy.Enabled = *input.Enabled
+	}
+
+	if err := u.policyRepository.Create(ctx, newPolicy); err != nil {
+		return nil, fmt.Errorf("error creating policy: %w", err)
+	}
+
+	return newPolicy, nil
+}
+
+func verifyAdmin(ctx context.Context, userRepository user.UserRepository, adminUserID uuid.UUID) error {
+	adminUser, err := userRepository.FindByID(ctx, adminUserID)
+	if err != nil {
+		return fmt.Errorf("admin user not found: %w", err)
+	}
+
+	if !adminUser.HasRole(role.RoleAdmin) {
+		return fmt.Errorf("user does not have admin privileges")
+	}
+
+	return nil
+}
+
+func isNotFound(err error) bool {
+	return errors.Is(err, gorm.ErrRecordNotFound)
+}
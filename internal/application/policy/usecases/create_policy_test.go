@@ -0,0 +1,75 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	policymocks "github.com/EduardoPPCaldas/auth-service/internal/domain/policy/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/policy/celeval"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestCreatePolicyUseCase_Execute(t *testing.T) {
+	evaluator, err := celeval.NewEvaluator()
+	require.NoError(t, err)
+
+	adminUser := &user.User{ID: uuid.New(), Role: role.NewAdminRole()}
+	regularUser := &user.User{ID: uuid.New(), Role: role.NewUserRole()}
+
+	tests := []struct {
+		name      string
+		caller    *user.User
+		condition string
+		effect    policy.Effect
+		wantErr   string
+	}{
+		{"valid condition", adminUser, `subject.org_id == resource.org_id`, policy.EffectAllow, ""},
+		{"empty condition", adminUser, "", policy.EffectDeny, ""},
+		{"syntax error", adminUser, `subject.org_id ==`, policy.EffectAllow, "invalid condition"},
+		{"non boolean condition", adminUser, `action + "x"`, policy.EffectAllow, "must evaluate to a boolean"},
+		{"invalid effect", adminUser, "", policy.Effect("maybe"), "invalid effect"},
+		{"not admin", regularUser, "", policy.EffectAllow, "admin privileges"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mockPolicyRepo := new(policymocks.MockPolicyRepository)
+			mockUserRepo := new(usermocks.MockUserRepository)
+			useCase := NewCreatePolicyUseCase(mockPolicyRepo, mockUserRepo, evaluator)
+
+			ctx := context.Background()
+			input := CreatePolicyInput{
+				AdminUserID: tt.caller.ID,
+				Name:        "org-scoped",
+				Action:      "posts:delete",
+				Effect:      tt.effect,
+				Condition:   tt.condition,
+			}
+
+			mockUserRepo.On("FindByID", ctx, tt.caller.ID).Return(tt.caller, nil)
+			mockPolicyRepo.On("FindByName", ctx, input.Name).Return(nil, gorm.ErrRecordNotFound)
+			mockPolicyRepo.On("Create", ctx, mock.AnythingOfType("*policy.Policy")).Return(nil)
+
+			result, err := useCase.Execute(ctx, input)
+
+			if tt.wantErr != "" {
+				assert.ErrorContains(t, err, tt.wantErr)
+				mockPolicyRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Equal(t, input.Name, result.Name)
+			assert.True(t, result.Enabled)
+			mockPolicyRepo.AssertExpectations(t)
+		})
+	}
+}
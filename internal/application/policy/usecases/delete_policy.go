@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type DeletePolicyUseCase struct {
+	policyRepository policy.Repository
+	userRepository   user.UserRepository
+}
+
+func NewDeletePolicyUseCase(policyRepository policy.Repository, userRepository user.UserRepository) *DeletePolicyUseCase {
+	return &DeletePolicyUseCase{
+		policyRepository: policyRepository,
+		userRepository:   userRepository,
+	}
+}
+
+type DeletePolicyInput struct {
+	AdminUserID uuid.UUID
+	PolicyID    uuid.UUID
+}
+
+func (u *DeletePolicyUseCase) Execute(ctx context.Context, input DeletePolicyInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DeletePolicyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return err
+	}
+
+	if _, err := u.policyRepository.FindByID(ctx, input.PolicyID); err != nil {
+		return fmt.Errorf("policy not found: %w", err)
+	}
+
+	if err := u.policyRepository.Delete(ctx, input.PolicyID); err != nil {
+		return fmt.Errorf("error deleting policy: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type GetPolicyUseCase struct {
+	policyRepository policy.Repository
+}
+
+func NewGetPolicyUseCase(policyRepository policy.Repository) *GetPolicyUseCase {
+	return &GetPolicyUseCase{
+		policyRepository: policyRepository,
+	}
+}
+
+func (u *GetPolicyUseCase) Execute(ctx context.Context, policyID uuid.UUID) (_ *policy.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "GetPolicyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	p, err := u.policyRepository.FindByID(ctx, policyID)
+	if err != nil {
+		return nil, fmt.Errorf("policy not found: %w", err)
+	}
+	return p, nil
+}
@@ -0,0 +1,30 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListPoliciesUseCase struct {
+	policyRepository policy.Repository
+}
+
+func NewListPoliciesUseCase(policyRepository policy.Repository) *ListPoliciesUseCase {
+	return &ListPoliciesUseCase{
+		policyRepository: policyRepository,
+	}
+}
+
+func (u *ListPoliciesUseCase) Execute(ctx context.Context) (_ []policy.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "ListPoliciesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	policies, err := u.policyRepository.List(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("error listing policies: %w", err)
+	}
+	return policies, nil
+}
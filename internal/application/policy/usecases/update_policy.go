@@ -0,0 +1,99 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/services/condition"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type UpdatePolicyUseCase struct {
+	policyRepository policy.Repository
+	userRepository   user.UserRepository
+	evaluator        condition.Evaluator
+}
+
+func NewUpdatePolicyUseCase(policyRepository policy.Repository, userRepository user.UserRepository, evaluator condition.Evaluator) *UpdatePolicyUseCase {
+	return &UpdatePolicyUseCase{
+		policyRepository: policyRepository,
+		userRepository:   userRepository,
+		evaluator:        evaluator,
+	}
+}
+
+type UpdatePolicyInput struct {
+	AdminUserID  uuid.UUID
+	PolicyID     uuid.UUID
+	Name         *string
+	Description  *string
+	Action       *string
+	ResourceType *string
+	Effect       *policy.Effect
+	Condition    *string
+	Enabled      *bool
+}
+
+func (u *UpdatePolicyUseCase) Execute(ctx context.Context, input UpdatePolicyInput) (_ *policy.Policy, err error) {
+	ctx, span := tracing.Start(ctx, "UpdatePolicyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if err := verifyAdmin(ctx, u.userRepository, input.AdminUserID); err != nil {
+		return nil, err
+	}
+
+	existing, err := u.policyRepository.FindByID(ctx, input.PolicyID)
+	if err != nil {
+		return nil, fmt.Errorf("policy not found: %w", err)
+	}
+
+	if input.Name != nil && *input.Name != existing.Name {
+		withName, err := u.policyRepository.FindByName(ctx, *input.Name)
+		if err != nil && !isNotFound(err) {
+			return nil, fmt.Errorf("error checking existing policy: %w", err)
+		}
+		if withName != nil {
+			return nil, fmt.Errorf("policy with name '%s' already exists", *input.Name)
+		}
+		existing.Name = *input.Name
+	}
+
+	if input.Effect != nil {
+		if !input.Effect.IsValid() {
+			return nil, fmt.Errorf("invalid effect '%s'", *input.Effect)
+		}
+		existing.Effect = *input.Effect
+	}
+
+	if input.Condition != nil {
+		if err := u.evaluator.Compile(*input.Condition); err != nil {
+			return nil, err
+		}
+		existing.Condition = *input.Condition
+	}
+
+	if input.Description != nil {
+		existing.Description = *input.Description
+	}
+	if input.Action != nil {
+		existing.Action = *input.Action
+	}
+	if input.ResourceType != nil {
+		existing.ResourceType = *input.ResourceType
+	}
+	if input.Enabled != nil {
+		existing.Enabled = *input.Enabled
+	}
+
+	existing.UpdatedAt = time.Now()
+
+	if err := u.policyRepository.Update(ctx, existing); err != nil {
+		return nil, fmt.Errorf("error updating policy: %w", err)
+	}
+
+	return existing, nil
+}
@@ -0,0 +1,69 @@
+package dto
+
+import "github.com/EduardoPPCaldas/auth-service/internal/application/relation/usecases"
+
+type TupleRequest struct {
+	Object   string `json:"object" validate:"required"`
+	Relation string `json:"relation" validate:"required"`
+	Subject  string `json:"subject" validate:"required"`
+}
+
+type CheckRelationRequest struct {
+	Object   string `json:"object" validate:"required"`
+	Relation string `json:"relation" validate:"required"`
+	Subject  string `json:"subject" validate:"required"`
+}
+
+type CheckRelationResponse struct {
+	Allowed bool `json:"allowed"`
+}
+
+type ExpandRelationRequest struct {
+	Object   string `json:"object" validate:"required"`
+	Relation string `json:"relation" validate:"required"`
+}
+
+type RelationTreeResponse struct {
+	Object   string                  `json:"object"`
+	Relation string                  `json:"relation"`
+	Subjects []string                `json:"subjects"`
+	Children []*RelationTreeResponse `json:"children,omitempty"`
+}
+
+type ListObjectsRequest struct {
+	Namespace string `json:"namespace" validate:"required"`
+	Relation  string `json:"relation" validate:"required"`
+	Subject   string `json:"subject" validate:"required"`
+}
+
+type ListObjectsResponse struct {
+	Objects []string `json:"objects"`
+}
+
+type WriteRelationsRequest struct {
+	Writes  []TupleRequest `json:"writes" validate:"dive"`
+	Deletes []TupleRequest `json:"deletes" validate:"dive"`
+}
+
+func ToRelationTreeResponse(tree *usecases.Tree) *RelationTreeResponse {
+	response := &RelationTreeResponse{
+		Object:   tree.Object.String(),
+		Relation: tree.Relation,
+		Subjects: make([]string, len(tree.Subjects)),
+	}
+	for i, s := range tree.Subjects {
+		response.Subjects[i] = s.String()
+	}
+	for _, child := range tree.Children {
+		response.Children = append(response.Children, ToRelationTreeResponse(child))
+	}
+	return response
+}
+
+func ToTupleInputs(requests []TupleRequest) []usecases.TupleInput {
+	inputs := make([]usecases.TupleInput, len(requests))
+	for i, r := range requests {
+		inputs[i] = usecases.TupleInput{Object: r.Object, Relation: r.Relation, Subject: r.Subject}
+	}
+	return inputs
+}
@@ -0,0 +1,41 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type CheckRelationUseCase struct {
+	resolver *resolver
+}
+
+func NewCheckRelationUseCase(repository relation.Repository, schema *relation.Schema) *CheckRelationUseCase {
+	return &CheckRelationUseCase{
+		resolver: &resolver{repository: repository, schema: schema},
+	}
+}
+
+type CheckRelationInput struct {
+	Object   string
+	Relation string
+	Subject  string
+}
+
+func (u *CheckRelationUseCase) Execute(ctx context.Context, input CheckRelationInput) (_ bool, err error) {
+	ctx, span := tracing.Start(ctx, "CheckRelationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	object, err := relation.ParseObject(input.Object)
+	if err != nil {
+		return false, err
+	}
+
+	subject, err := relation.ParseSubject(input.Subject)
+	if err != nil {
+		return false, err
+	}
+
+	return u.resolver.check(ctx, object, input.Relation, subject, map[string]bool{}, 0)
+}
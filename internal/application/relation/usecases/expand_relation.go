@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ExpandRelationUseCase struct {
+	resolver *resolver
+}
+
+func NewExpandRelationUseCase(repository relation.Repository, schema *relation.Schema) *ExpandRelationUseCase {
+	return &ExpandRelationUseCase{
+		resolver: &resolver{repository: repository, schema: schema},
+	}
+}
+
+type ExpandRelationInput struct {
+	Object   string
+	Relation string
+}
+
+func (u *ExpandRelationUseCase) Execute(ctx context.Context, input ExpandRelationInput) (_ *Tree, err error) {
+	ctx, span := tracing.Start(ctx, "ExpandRelationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	object, err := relation.ParseObject(input.Object)
+	if err != nil {
+		return nil, err
+	}
+
+	return u.resolver.expand(ctx, object, input.Relation, map[string]bool{}, 0)
+}
@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListObjectsUseCase struct {
+	resolver *resolver
+}
+
+func NewListObjectsUseCase(repository relation.Repository, schema *relation.Schema) *ListObjectsUseCase {
+	return &ListObjectsUseCase{
+		resolver: &resolver{repository: repository, schema: schema},
+	}
+}
+
+type ListObjectsInput struct {
+	Namespace string
+	Relation  string
+	Subject   string
+}
+
+// Execute checks every object of the namespace that has tuples, so its cost
+// grows with the namespace size rather than with the subject's access.
+func (u *ListObjectsUseCase) Execute(ctx context.Context, input ListObjectsInput) (_ []string, err error) {
+	ctx, span := tracing.Start(ctx, "ListObjectsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if _, ok := u.resolver.schema.Relation(input.Namespace, input.Relation); !ok {
+		return nil, fmt.Errorf("unknown relation %s#%s", input.Namespace, input.Relation)
+	}
+
+	subject, err := relation.ParseSubject(input.Subject)
+	if err != nil {
+		return nil, err
+	}
+
+	ids, err := u.resolver.repository.ListObjectIDs(ctx, input.Namespace)
+	if err != nil {
+		return nil, fmt.Errorf("error listing objects: %w", err)
+	}
+
+	objects := []string{}
+	for _, id := range ids {
+		object := relation.Object{Namespace: input.Namespace, ID: id}
+		ok, err := u.resolver.check(ctx, object, input.Relation, subject, map[string]bool{}, 0)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			objects = append(objects, object.String())
+		}
+	}
+
+	return objects, nil
+}
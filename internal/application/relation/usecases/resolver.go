@@ -0,0 +1,168 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+)
+
+// maxDepth bounds rewrite and userset traversal so deep graphs terminate.
+const maxDepth = 25
+
+var ErrMaxDepthExceeded = errors.New("relation graph exceeds maximum depth")
+
+// Tree is the expansion of object#relation: the subjects holding it directly
+// and one child per userset, implied relation or inherited relation.
+type Tree struct {
+	Object   relation.Object
+	Relation string
+	Subjects []relation.Subject
+	Children []*Tree
+}
+
+type resolver struct {
+	repository relation.Repository
+	schema     *relation.Schema
+}
+
+func (r *resolver) relation(object relation.Object, name string) (*relation.Relation, error) {
+	rel, ok := r.schema.Relation(object.Namespace, name)
+	if !ok {
+		return nil, fmt.Errorf("unknown relation %s#%s", object.Namespace, name)
+	}
+	return rel, nil
+}
+
+// check reports whether subject holds object#name. visited holds every
+// object#relation already checked for subject; a revisit is answered false,
+// since the first visit is still looking for or already ruled out the subject.
+// This ends cycles and stops shared subgraphs from being loaded twice.
+func (r *resolver) check(ctx context.Context, object relation.Object, name string, subject relation.Subject, visited map[string]bool, depth int) (bool, error) {
+	if depth > maxDepth {
+		return false, ErrMaxDepthExceeded
+	}
+
+	rel, err := r.relation(object, name)
+	if err != nil {
+		return false, err
+	}
+
+	key := object.String() + "#" + name
+	if visited[key] {
+		return false, nil
+	}
+	visited[key] = true
+
+	tuples, err := r.repository.FindByObjectRelation(ctx, object, name)
+	if err != nil {
+		return false, fmt.Errorf("error loading tuples for %s: %w", key, err)
+	}
+
+	for _, t := range tuples {
+		tupleSubject := t.Subject()
+		if tupleSubject == subject {
+			return true, nil
+		}
+		if !tupleSubject.IsUserset() {
+			continue
+		}
+		ok, err := r.check(ctx, tupleSubject.Object(), tupleSubject.Relation, subject, visited, depth+1)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	for _, implied := range rel.ImpliedBy {
+		ok, err := r.check(ctx, object, implied, subject, visited, depth+1)
+		if err != nil || ok {
+			return ok, err
+		}
+	}
+
+	for _, inherit := range rel.Inherits {
+		related, err := r.repository.FindByObjectRelation(ctx, object, inherit.Through)
+		if err != nil {
+			return false, fmt.Errorf("error loading tuples for %s#%s: %w", object, inherit.Through, err)
+		}
+		for _, t := range related {
+			parent := t.Subject().Object()
+			if _, ok := r.schema.Relation(parent.Namespace, inherit.Relation); !ok {
+				continue
+			}
+			ok, err := r.check(ctx, parent, inherit.Relation, subject, visited, depth+1)
+			if err != nil || ok {
+				return ok, err
+			}
+		}
+	}
+
+	return false, nil
+}
+
+func (r *resolver) expand(ctx context.Context, object relation.Object, name string, visiting map[string]bool, depth int) (*Tree, error) {
+	if depth > maxDepth {
+		return nil, ErrMaxDepthExceeded
+	}
+
+	rel, err := r.relation(object, name)
+	if err != nil {
+		return nil, err
+	}
+
+	tree := &Tree{Object: object, Relation: name}
+
+	key := object.String() + "#" + name
+	if visiting[key] {
+		return tree, nil
+	}
+	visiting[key] = true
+	defer delete(visiting, key)
+
+	tuples, err := r.repository.FindByObjectRelation(ctx, object, name)
+	if err != nil {
+		return nil, fmt.Errorf("error loading tuples for %s: %w", key, err)
+	}
+
+	for _, t := range tuples {
+		subject := t.Subject()
+		tree.Subjects = append(tree.Subjects, subject)
+		if !subject.IsUserset() {
+			continue
+		}
+		child, err := r.expand(ctx, subject.Object(), subject.Relation, visiting, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	for _, implied := range rel.ImpliedBy {
+		child, err := r.expand(ctx, object, implied, visiting, depth+1)
+		if err != nil {
+			return nil, err
+		}
+		tree.Children = append(tree.Children, child)
+	}
+
+	for _, inherit := range rel.Inherits {
+		related, err := r.repository.FindByObjectRelation(ctx, object, inherit.Through)
+		if err != nil {
+			return nil, fmt.Errorf("error loading tuples for %s#%s: %w", object, inherit.Through, err)
+		}
+		for _, t := range related {
+			parent := t.Subject().Object()
+			if _, ok := r.schema.Relation(parent.Namespace, inherit.Relation); !ok {
+				continue
+			}
+			child, err := r.expand(ctx, parent, inherit.Relation, visiting, depth+1)
+			if err != nil {
+				return nil, err
+			}
+			tree.Children = append(tree.Children, child)
+		}
+	}
+
+	return tree, nil
+}
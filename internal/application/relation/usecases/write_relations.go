@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type WriteRelationsUseCase struct {
+	repository relation.Repository
+	schema     *relation.Schema
+}
+
+func NewWriteRelationsUseCase(repository relation.Repository, schema *relation.Schema) *WriteRelationsUseCase {
+	return &WriteRelationsUseCase{
+		repository: repository,
+		schema:     schema,
+	}
+}
+
+type TupleInput struct {
+	Object   string
+	Relation string
+	Subject  string
+}
+
+type WriteRelationsInput struct {
+	Writes  []TupleInput
+	Deletes []TupleInput
+}
+
+func (u *WriteRelationsUseCase) Execute(ctx context.Context, input WriteRelationsInput) (err error) {
+	ctx, span := tracing.Start(ctx, "WriteRelationsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if len(input.Writes) == 0 && len(input.Deletes) == 0 {
+		return fmt.Errorf("no tuples to write or delete")
+	}
+
+	writes, err := u.parseTuples(input.Writes)
+	if err != nil {
+		return err
+	}
+
+	deletes, err := u.parseTuples(input.Deletes)
+	if err != nil {
+		return err
+	}
+
+	if err := u.repository.Write(ctx, writes, deletes); err != nil {
+		return fmt.Errorf("error writing relation tuples: %w", err)
+	}
+
+	return nil
+}
+
+func (u *WriteRelationsUseCase) parseTuples(inputs []TupleInput) ([]*relation.Tuple, error) {
+	tuples := make([]*relation.Tuple, 0, len(inputs))
+	for _, in := range inputs {
+		object, err := relation.ParseObject(in.Object)
+		if err != nil {
+			return nil, err
+		}
+		subject, err := relation.ParseSubject(in.Subject)
+		if err != nil {
+			return nil, err
+		}
+
+		t := relation.NewTuple(object, in.Relation, subject)
+		if err := u.schema.ValidateTuple(t); err != nil {
+			return nil, err
+		}
+		tuples = append(tuples, t)
+	}
+	return tuples, nil
+}
@@ -4,20 +4,29 @@ import (
 	"context"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 )
 
 type AssignRoleToUserUseCase struct {
 	roleRepository role.Repository
 	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
+	publisher      *outboxservices.Publisher
 }
 
-func NewAssignRoleToUserUseCase(roleRepository role.Repository, userRepository user.UserRepository) *AssignRoleToUserUseCase {
+func NewAssignRoleToUserUseCase(roleRepository role.Repository, userRepository user.UserRepository, auditRecorder *auditservices.Recorder, publisher *outboxservices.Publisher) *AssignRoleToUserUseCase {
 	return &AssignRoleToUserUseCase{
 		roleRepository: roleRepository,
 		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
+		publisher:      publisher,
 	}
 }
 
@@ -27,7 +36,20 @@ type AssignRoleToUserInput struct {
 	RoleID      uuid.UUID
 }
 
-func (u *AssignRoleToUserUseCase) Execute(ctx context.Context, input AssignRoleToUserInput) error {
+func (u *AssignRoleToUserUseCase) Execute(ctx context.Context, input AssignRoleToUserInput) (err error) {
+	ctx, span := tracing.Start(ctx, "AssignRoleToUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionRoleAssign,
+			TargetType: audit.TargetUser,
+			TargetID:   input.UserID.String(),
+			Metadata:   map[string]string{"role_id": input.RoleID.String()},
+		}).Fail(err))
+	}()
+
 	if err := u.verifyAdmin(ctx, input.AdminUserID); err != nil {
 		return err
 	}
@@ -46,11 +68,13 @@ func (u *AssignRoleToUserUseCase) Execute(ctx context.Context, input AssignRoleT
 		return fmt.Errorf("user already has this role assigned")
 	}
 
-	if err := u.userRepository.UpdateRole(ctx, input.UserID, &ro.ID); err != nil {
-		return fmt.Errorf("error assigning role to user: %w", err)
-	}
-
-	return nil
+	assigned := outbox.RoleAssignedEvent{UserID: input.UserID, RoleID: ro.ID, RoleName: ro.Name}
+	return u.publisher.Publish(ctx, outbox.EventRoleAssigned, assigned, func(ctx context.Context) error {
+		if err := u.userRepository.UpdateRole(ctx, input.UserID, &ro.ID); err != nil {
+			return fmt.Errorf("error assigning role to user: %w", err)
+		}
+		return nil
+	})
 }
 
 func (u *AssignRoleToUserUseCase) verifyAdmin(ctx context.Context, adminUserID uuid.UUID) error {
@@ -59,7 +83,7 @@ func (u *AssignRoleToUserUseCase) verifyAdmin(ctx context.Context, adminUserID u
 		return fmt.Errorf("admin user not found: %w", err)
 	}
 
-	if adminUser.Role == nil || adminUser.Role.Name != role.RoleAdmin {
+	if !adminUser.HasRole(role.RoleAdmin) {
 		return fmt.Errorf("user does not have admin privileges")
 	}
 
@@ -3,9 +3,13 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strings"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
 )
@@ -13,12 +17,14 @@ import (
 type CreateRoleUseCase struct {
 	roleRepository role.Repository
 	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
 }
 
-func NewCreateRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository) *CreateRoleUseCase {
+func NewCreateRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository, auditRecorder *auditservices.Recorder) *CreateRoleUseCase {
 	return &CreateRoleUseCase{
 		roleRepository: roleRepository,
 		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
 	}
 }
 
@@ -28,7 +34,23 @@ type CreateRoleInput struct {
 	Permissions []string
 }
 
-func (u *CreateRoleUseCase) Execute(ctx context.Context, input CreateRoleInput) (*role.Role, error) {
+func (u *CreateRoleUseCase) Execute(ctx context.Context, input CreateRoleInput) (created *role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "CreateRoleUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		event := &audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionRoleCreate,
+			TargetType: audit.TargetRole,
+			Metadata:   map[string]string{"name": input.Name, "permissions": strings.Join(input.Permissions, ",")},
+		}
+		if created != nil {
+			event.TargetID = created.ID.String()
+		}
+		u.auditRecorder.Record(ctx, event.Fail(err))
+	}()
+
 	if err := u.verifyAdmin(ctx, input.AdminUserID); err != nil {
 		return nil, err
 	}
@@ -55,7 +77,7 @@ func (u *CreateRoleUseCase) verifyAdmin(ctx context.Context, adminUserID uuid.UU
 		return fmt.Errorf("admin user not found: %w", err)
 	}
 
-	if adminUser.Role == nil || adminUser.Role.Name != role.RoleAdmin {
+	if !adminUser.HasRole(role.RoleAdmin) {
 		return fmt.Errorf("user does not have admin privileges")
 	}
 
@@ -18,7 +18,7 @@ import (
 func TestCreateRoleUseCase_Execute_Success(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockUserRepo := new(usermocks.MockUserRepository)
-	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo)
+	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo, nil)
 
 	adminUser := &user.User{
 		ID:   uuid.New(),
@@ -49,7 +49,7 @@ func TestCreateRoleUseCase_Execute_Success(t *testing.T) {
 func TestCreateRoleUseCase_Execute_NotAdmin(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockUserRepo := new(usermocks.MockUserRepository)
-	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo)
+	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo, nil)
 
 	regularUser := &user.User{
 		ID:   uuid.New(),
@@ -76,7 +76,7 @@ func TestCreateRoleUseCase_Execute_NotAdmin(t *testing.T) {
 func TestCreateRoleUseCase_Execute_RoleAlreadyExists(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockUserRepo := new(usermocks.MockUserRepository)
-	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo)
+	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo, nil)
 
 	adminUser := &user.User{
 		ID:   uuid.New(),
@@ -107,7 +107,7 @@ func TestCreateRoleUseCase_Execute_RoleAlreadyExists(t *testing.T) {
 func TestCreateRoleUseCase_Execute_AdminUserNotFound(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockUserRepo := new(usermocks.MockUserRepository)
-	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo)
+	useCase := NewCreateRoleUseCase(mockRoleRepo, mockUserRepo, nil)
 
 	adminUserID := uuid.New()
 
@@ -4,20 +4,25 @@ import (
 	"context"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 )
 
 type DeleteRoleUseCase struct {
 	roleRepository role.Repository
 	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
 }
 
-func NewDeleteRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository) *DeleteRoleUseCase {
+func NewDeleteRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository, auditRecorder *auditservices.Recorder) *DeleteRoleUseCase {
 	return &DeleteRoleUseCase{
 		roleRepository: roleRepository,
 		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
 	}
 }
 
@@ -26,7 +31,19 @@ type DeleteRoleInput struct {
 	RoleID      uuid.UUID
 }
 
-func (u *DeleteRoleUseCase) Execute(ctx context.Context, input DeleteRoleInput) error {
+func (u *DeleteRoleUseCase) Execute(ctx context.Context, input DeleteRoleInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteRoleUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionRoleDelete,
+			TargetType: audit.TargetRole,
+			TargetID:   input.RoleID.String(),
+		}).Fail(err))
+	}()
+
 	if err := u.verifyAdmin(ctx, input.AdminUserID); err != nil {
 		return err
 	}
@@ -57,7 +74,7 @@ func (u *DeleteRoleUseCase) verifyAdmin(ctx context.Context, adminUserID uuid.UU
 		return fmt.Errorf("admin user not found: %w", err)
 	}
 
-	if adminUser.Role == nil || adminUser.Role.Name != role.RoleAdmin {
+	if !adminUser.HasRole(role.RoleAdmin) {
 		return fmt.Errorf("user does not have admin privileges")
 	}
 
@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 )
 
@@ -18,7 +19,10 @@ func NewGetRoleUseCase(roleRepository role.Repository) *GetRoleUseCase {
 	}
 }
 
-func (u *GetRoleUseCase) Execute(ctx context.Context, roleID uuid.UUID) (*role.Role, error) {
+func (u *GetRoleUseCase) Execute(ctx context.Context, roleID uuid.UUID) (_ *role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "GetRoleUseCase.Execute")
+	defer tracing.End(span, &err)
+
 	ro, err := u.roleRepository.FindByID(ctx, roleID)
 	if err != nil {
 		return nil, fmt.Errorf("role not found: %w", err)
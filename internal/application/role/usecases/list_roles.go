@@ -5,6 +5,7 @@ import (
 	"fmt"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 )
 
 type ListRolesUseCase struct {
@@ -17,7 +18,10 @@ func NewListRolesUseCase(roleRepository role.Repository) *ListRolesUseCase {
 	}
 }
 
-func (u *ListRolesUseCase) Execute(ctx context.Context) ([]role.Role, error) {
+func (u *ListRolesUseCase) Execute(ctx context.Context) (_ []role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "ListRolesUseCase.Execute")
+	defer tracing.End(span, &err)
+
 	roles, err := u.roleRepository.List(ctx)
 	if err != nil {
 		return nil, fmt.Errorf("error listing roles: %w", err)
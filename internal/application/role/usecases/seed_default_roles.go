@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+// SeedDefaultRolesUseCase creates the built-in admin, user and moderator
+// roles. Seeding turns RBAC on, since IsRBACEnabled checks whether any
+// global role exists.
+type SeedDefaultRolesUseCase struct {
+	roleRepository role.Repository
+}
+
+func NewSeedDefaultRolesUseCase(roleRepository role.Repository) *SeedDefaultRolesUseCase {
+	return &SeedDefaultRolesUseCase{
+		roleRepository: roleRepository,
+	}
+}
+
+// Execute creates the missing default roles and returns them; roles that
+// already exist are left as they are.
+func (u *SeedDefaultRolesUseCase) Execute(ctx context.Context) (_ []*role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "SeedDefaultRolesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	var created []*role.Role
+	for _, defaultRole := range []*role.Role{role.NewAdminRole(), role.NewUserRole(), role.NewModeratorRole()} {
+		existingRole, err := u.roleRepository.FindByName(ctx, defaultRole.Name)
+		if err != nil && !isNotFound(err) {
+			return created, fmt.Errorf("error checking existing role: %w", err)
+		}
+		if existingRole != nil {
+			continue
+		}
+
+		if err := u.roleRepository.Create(ctx, defaultRole); err != nil {
+			return created, fmt.Errorf("error creating role '%s': %w", defaultRole.Name, err)
+		}
+		created = append(created, defaultRole)
+	}
+	return created, nil
+}
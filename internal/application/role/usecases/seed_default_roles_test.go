@@ -0,0 +1,47 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestSeedDefaultRolesUseCase_Execute_CreatesMissingRoles(t *testing.T) {
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	useCase := NewSeedDefaultRolesUseCase(mockRoleRepo)
+	ctx := context.Background()
+
+	mockRoleRepo.On("FindByName", ctx, role.RoleAdmin).Return(role.NewAdminRole(), nil)
+	mockRoleRepo.On("FindByName", ctx, role.RoleUser).Return(nil, gorm.ErrRecordNotFound)
+	mockRoleRepo.On("FindByName", ctx, role.RoleModerator).Return(nil, gorm.ErrRecordNotFound)
+	mockRoleRepo.On("Create", ctx, mock.AnythingOfType("*role.Role")).Return(nil)
+
+	created, err := useCase.Execute(ctx)
+
+	require.NoError(t, err)
+	require.Len(t, created, 2)
+	assert.Equal(t, role.RoleUser, created[0].Name)
+	assert.Equal(t, role.UserPermissions, created[0].GetPermissionStrings())
+	assert.Equal(t, role.RoleModerator, created[1].Name)
+	mockRoleRepo.AssertNumberOfCalls(t, "Create", 2)
+}
+
+func TestSeedDefaultRolesUseCase_Execute_AlreadySeeded(t *testing.T) {
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	useCase := NewSeedDefaultRolesUseCase(mockRoleRepo)
+	ctx := context.Background()
+
+	mockRoleRepo.On("FindByName", ctx, mock.Anything).Return(role.NewUserRole(), nil)
+
+	created, err := useCase.Execute(ctx)
+
+	assert.NoError(t, err)
+	assert.Empty(t, created)
+	mockRoleRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
@@ -3,22 +3,28 @@ package usecases
 import (
 	"context"
 	"fmt"
+	"strings"
 	"time"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 )
 
 type UpdateRoleUseCase struct {
 	roleRepository role.Repository
 	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
 }
 
-func NewUpdateRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository) *UpdateRoleUseCase {
+func NewUpdateRoleUseCase(roleRepository role.Repository, userRepository user.UserRepository, auditRecorder *auditservices.Recorder) *UpdateRoleUseCase {
 	return &UpdateRoleUseCase{
 		roleRepository: roleRepository,
 		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
 	}
 }
 
@@ -29,7 +35,27 @@ type UpdateRoleInput struct {
 	Permissions []string
 }
 
-func (u *UpdateRoleUseCase) Execute(ctx context.Context, input UpdateRoleInput) (*role.Role, error) {
+func (u *UpdateRoleUseCase) Execute(ctx context.Context, input UpdateRoleInput) (updated *role.Role, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateRoleUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		event := &audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionRoleUpdate,
+			TargetType: audit.TargetRole,
+			TargetID:   input.RoleID.String(),
+			Metadata:   map[string]string{},
+		}
+		if input.Name != nil {
+			event.Metadata["name"] = *input.Name
+		}
+		if input.Permissions != nil {
+			event.Metadata["permissions"] = strings.Join(input.Permissions, ",")
+		}
+		u.auditRecorder.Record(ctx, event.Fail(err))
+	}()
+
 	if err := u.verifyAdmin(ctx, input.AdminUserID); err != nil {
 		return nil, err
 	}
@@ -80,7 +106,7 @@ func (u *UpdateRoleUseCase) verifyAdmin(ctx context.Context, adminUserID uuid.UU
 		return fmt.Errorf("admin user not found: %w", err)
 	}
 
-	if adminUser.Role == nil || adminUser.Role.Name != role.RoleAdmin {
+	if !adminUser.HasRole(role.RoleAdmin) {
 		return fmt.Errorf("user does not have admin privileges")
 	}
 
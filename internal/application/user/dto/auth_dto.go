@@ -7,7 +7,7 @@ import (
 // CreateUserRequest represents the request body for user registration
 type CreateUserRequest struct {
 	Email    string `json:"email" validate:"required,email"`
-	Password string `json:"password" validate:"required,min=8"`
+	Password string `json:"password" validate:"required"`
 }
 
 // LoginUserRequest represents the request body for user login
@@ -45,3 +45,54 @@ type RefreshTokenResponse struct {
 type GoogleOAuthChallengeResponse struct {
 	AuthURL string `json:"auth_url"`
 }
+
+// OAuthTokenResponse represents a token issued by the OAuth token endpoint
+type OAuthTokenResponse struct {
+	AccessToken     string `json:"access_token"`
+	IssuedTokenType string `json:"issued_token_type,omitempty"`
+	RefreshToken    string `json:"refresh_token,omitempty"`
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	Scope           string `json:"scope,omitempty"`
+}
+
+// OAuthErrorResponse represents an error from the OAuth endpoints
+type OAuthErrorResponse struct {
+	Error            string `json:"error"`
+	ErrorDescription string `json:"error_description,omitempty"`
+}
+
+// DeviceAuthorizationResponse represents a started device login (RFC 8628)
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceUserCodeRequest represents the request body for approving or denying a device login
+type DeviceUserCodeRequest struct {
+	UserCode string `json:"user_code" validate:"required"`
+}
+
+// DeviceAuthorizationInfoResponse represents a pending device login shown to the user before approval
+type DeviceAuthorizationInfoResponse struct {
+	UserCode  string `json:"user_code"`
+	ClientID  string `json:"client_id"`
+	ExpiresAt string `json:"expires_at"`
+}
+
+// PasswordlessStartRequest represents the request body for emailing a sign-in link or code
+type PasswordlessStartRequest struct {
+	Email  string `json:"email" validate:"required,email"`
+	Method string `json:"method" validate:"required,oneof=link code"`
+}
+
+// PasswordlessVerifyRequest represents the request body for redeeming a sign-in link's token, or an email and code
+type PasswordlessVerifyRequest struct {
+	Token string `json:"token" validate:"required_without=Email"`
+	Email string `json:"email" validate:"omitempty,email"`
+	Code  string `json:"code" validate:"required_with=Email"`
+}
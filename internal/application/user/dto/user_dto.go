@@ -0,0 +1,177 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+)
+
+// UserResponse represents a user as seen by administrators
+type UserResponse struct {
+	ID         string   `json:"id"`
+	Email      string   `json:"email"`
+	Role       string   `json:"role,omitempty"`
+	Groups     []string `json:"groups,omitempty"`
+	Status     string   `json:"status"`
+	DisabledAt string   `json:"disabled_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+	UpdatedAt  string   `json:"updated_at"`
+}
+
+// ProfileResponse represents the authenticated user's own account
+type ProfileResponse struct {
+	ID          string   `json:"id"`
+	Email       string   `json:"email"`
+	DisplayName string   `json:"display_name"`
+	AvatarURL   string   `json:"avatar_url"`
+	Locale      string   `json:"locale"`
+	Timezone    string   `json:"timezone"`
+	Roles       []string `json:"roles"`
+	Groups      []string `json:"groups"`
+	HasPassword bool     `json:"has_password"`
+	CreatedAt   string   `json:"created_at"`
+}
+
+// UpdateProfileRequest represents a partial profile update; omitted fields are left unchanged
+type UpdateProfileRequest struct {
+	DisplayName *string `json:"display_name" validate:"omitempty,max=100"`
+	AvatarURL   *string `json:"avatar_url" validate:"omitempty,max=2048"`
+	Locale      *string `json:"locale" validate:"omitempty,max=35"`
+	Timezone    *string `json:"timezone" validate:"omitempty,max=64"`
+}
+
+// ChangePasswordRequest represents the request body for changing the password
+type ChangePasswordRequest struct {
+	CurrentPassword string `json:"current_password" validate:"required"`
+	NewPassword     string `json:"new_password" validate:"required"`
+}
+
+// ChangeEmailRequest represents the request body for changing the email address
+type ChangeEmailRequest struct {
+	NewEmail        string `json:"new_email" validate:"required,email"`
+	CurrentPassword string `json:"current_password"`
+}
+
+// ConfirmEmailChangeRequest represents the request body for confirming an email change
+type ConfirmEmailChangeRequest struct {
+	Token string `json:"token" validate:"required"`
+}
+
+// ImpersonateUserRequest represents the request body for impersonating a user
+type ImpersonateUserRequest struct {
+	Reason string `json:"reason" validate:"required,min=3,max=500"`
+}
+
+// ImpersonationResponse represents a non-refreshable token for acting as another user
+type ImpersonationResponse struct {
+	AccessToken string `json:"access_token"`
+	TokenType   string `json:"token_type"`
+	ExpiresIn   int    `json:"expires_in"`
+	SessionID   string `json:"session_id"`
+}
+
+// ImpersonationSessionResponse represents a recorded impersonation session
+type ImpersonationSessionResponse struct {
+	ID         string `json:"id"`
+	ActorID    string `json:"actor_id"`
+	ActorEmail string `json:"actor_email"`
+	UserID     string `json:"user_id"`
+	UserEmail  string `json:"user_email"`
+	Reason     string `json:"reason"`
+	Active     bool   `json:"active"`
+	ExpiresAt  string `json:"expires_at"`
+	CreatedAt  string `json:"created_at"`
+}
+
+// CreatePersonalAccessTokenRequest represents the request body for creating a personal access token
+type CreatePersonalAccessTokenRequest struct {
+	Name      string     `json:"name" validate:"required,max=100"`
+	Scopes    []string   `json:"scopes" validate:"required,min=1"`
+	ExpiresAt *time.Time `json:"expires_at"`
+}
+
+// PersonalAccessTokenResponse represents a personal access token; Token is only set when it is created
+type PersonalAccessTokenResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	Token      string   `json:"token,omitempty"`
+	Scopes     []string `json:"scopes"`
+	ExpiresAt  string   `json:"expires_at,omitempty"`
+	LastUsedAt string   `json:"last_used_at,omitempty"`
+	RevokedAt  string   `json:"revoked_at,omitempty"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// ListUsersResponse represents one page of users; NextCursor is empty on the last page
+type ListUsersResponse struct {
+	Users      []UserResponse `json:"users"`
+	NextCursor string         `json:"next_cursor,omitempty"`
+}
+
+func ToUserResponse(u *user.User) UserResponse {
+	response := UserResponse{
+		ID:        u.ID.String(),
+		Email:     u.Email,
+		Groups:    u.GroupNames(),
+		Status:    u.Status(),
+		CreatedAt: u.CreatedAt.Format("2006-01-02T15:04:05Z"),
+		UpdatedAt: u.UpdatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if u.Role != nil {
+		response.Role = u.Role.Name
+	}
+	if u.DisabledAt != nil {
+		response.DisabledAt = u.DisabledAt.Format("2006-01-02T15:04:05Z")
+	}
+	return response
+}
+
+func ToProfileResponse(u *user.User) ProfileResponse {
+	return ProfileResponse{
+		ID:          u.ID.String(),
+		Email:       u.Email,
+		DisplayName: u.Profile.DisplayName,
+		AvatarURL:   u.Profile.AvatarURL,
+		Locale:      u.Profile.Locale,
+		Timezone:    u.Profile.Timezone,
+		Roles:       u.EffectiveRoleNames(),
+		Groups:      u.GroupNames(),
+		HasPassword: u.HasPassword(),
+		CreatedAt:   u.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func ToImpersonationSessionResponse(s *impersonation.Session) ImpersonationSessionResponse {
+	return ImpersonationSessionResponse{
+		ID:         s.ID.String(),
+		ActorID:    s.ActorID.String(),
+		ActorEmail: s.ActorEmail,
+		UserID:     s.UserID.String(),
+		UserEmail:  s.UserEmail,
+		Reason:     s.Reason,
+		Active:     s.IsActive(),
+		ExpiresAt:  s.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+		CreatedAt:  s.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+}
+
+func ToPersonalAccessTokenResponse(t *token.PersonalAccessToken) PersonalAccessTokenResponse {
+	response := PersonalAccessTokenResponse{
+		ID:        t.ID.String(),
+		Name:      t.Name,
+		Scopes:    t.Scopes,
+		CreatedAt: t.CreatedAt.Format("2006-01-02T15:04:05Z"),
+	}
+	if t.ExpiresAt != nil {
+		response.ExpiresAt = t.ExpiresAt.Format("2006-01-02T15:04:05Z")
+	}
+	if t.LastUsedAt != nil {
+		response.LastUsedAt = t.LastUsedAt.Format("2006-01-02T15:04:05Z")
+	}
+	if t.RevokedAt != nil {
+		response.RevokedAt = t.RevokedAt.Format("2006-01-02T15:04:05Z")
+	}
+	return response
+}
@@ -3,8 +3,10 @@ package oauth
 import "context"
 
 type GoogleUser struct {
-	Email string
-	Name  string
+	Email   string
+	Name    string
+	Picture string
+	Locale  string
 }
 
 type GoogleTokenValidator interface {
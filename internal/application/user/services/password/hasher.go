@@ -0,0 +1,266 @@
+package password
+
+import (
+	"crypto/pbkdf2"
+	"crypto/rand"
+	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"hash"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+	"golang.org/x/crypto/scrypt"
+)
+
+type Algorithm string
+
+const (
+	Argon2id Algorithm = "argon2id"
+	Bcrypt   Algorithm = "bcrypt"
+	Scrypt   Algorithm = "scrypt"
+	PBKDF2   Algorithm = "pbkdf2-sha256"
+)
+
+// ErrUnsupportedHash is returned for stored hashes in an unknown or malformed format.
+var ErrUnsupportedHash = errors.New("unsupported password hash format")
+
+const (
+	saltLength = 16
+	keyLength  = 32
+)
+
+// Params selects the algorithm new hashes use and the parameters of each
+// algorithm. Hashes are verified with the parameters stored in them, so
+// changing these only affects new hashes and what NeedsRehash reports.
+type Params struct {
+	Algorithm Algorithm
+
+	// Argon2Memory is in KiB.
+	Argon2Memory      uint32
+	Argon2Iterations  uint32
+	Argon2Parallelism uint8
+
+	BcryptCost int
+
+	// ScryptLogN is log2 of the CPU/memory cost N.
+	ScryptLogN uint8
+	ScryptR    int
+	ScryptP    int
+
+	PBKDF2Iterations int
+}
+
+// DefaultParams returns Argon2id with the OWASP recommended minimums, and
+// those recommendations for the other algorithms.
+func DefaultParams() Params {
+	return Params{
+		Algorithm:         Argon2id,
+		Argon2Memory:      19 * 1024,
+		Argon2Iterations:  2,
+		Argon2Parallelism: 1,
+		BcryptCost:        bcrypt.DefaultCost,
+		ScryptLogN:        17,
+		ScryptR:           8,
+		ScryptP:           1,
+		PBKDF2Iterations:  600_000,
+	}
+}
+
+// PasswordHasher hashes passwords into PHC strings and verifies them.
+// bcrypt hashes keep bcrypt's own "$2a$" format, so hashes created before
+// the hasher existed still verify.
+type PasswordHasher interface {
+	Hash(password string) (string, error)
+	// Verify reports whether password matches encoded, whichever supported
+	// algorithm encoded uses.
+	Verify(password, encoded string) (bool, error)
+	// NeedsRehash reports whether encoded was made with another algorithm or
+	// other parameters than Hash uses now.
+	NeedsRehash(encoded string) bool
+}
+
+type passwordHasher struct {
+	params Params
+}
+
+func NewPasswordHasher(params Params) (PasswordHasher, error) {
+	switch params.Algorithm {
+	case Argon2id:
+		if params.Argon2Memory == 0 || params.Argon2Iterations == 0 || params.Argon2Parallelism == 0 {
+			return nil, fmt.Errorf("argon2id memory, iterations and parallelism must be positive")
+		}
+	case Bcrypt:
+		if params.BcryptCost < bcrypt.MinCost || params.BcryptCost > bcrypt.MaxCost {
+			return nil, fmt.Errorf("bcrypt cost must be between %d and %d", bcrypt.MinCost, bcrypt.MaxCost)
+		}
+	case Scrypt:
+		if params.ScryptLogN == 0 || params.ScryptLogN > 30 || params.ScryptR <= 0 || params.ScryptP <= 0 {
+			return nil, fmt.Errorf("invalid scrypt parameters")
+		}
+	case PBKDF2:
+		if params.PBKDF2Iterations <= 0 {
+			return nil, fmt.Errorf("PBKDF2 iterations must be positive")
+		}
+	default:
+		return nil, fmt.Errorf("unknown password hash algorithm %q", params.Algorithm)
+	}
+	return &passwordHasher{params: params}, nil
+}
+
+func (h *passwordHasher) Hash(password string) (string, error) {
+	if h.params.Algorithm == Bcrypt {
+		hashed, err := bcrypt.GenerateFromPassword([]byte(password), h.params.BcryptCost)
+		if err != nil {
+			return "", fmt.Errorf("error hashing password: %w", err)
+		}
+		return string(hashed), nil
+	}
+
+	salt := make([]byte, saltLength)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("error generating salt: %w", err)
+	}
+
+	var phc *phcHash
+	switch h.params.Algorithm {
+	case Argon2id:
+		phc = &phcHash{
+			id:      string(Argon2id),
+			version: argon2.Version,
+			params: []phcParam{
+				{"m", int(h.params.Argon2Memory)},
+				{"t", int(h.params.Argon2Iterations)},
+				{"p", int(h.params.Argon2Parallelism)},
+			},
+			salt: salt,
+		}
+	case Scrypt:
+		phc = &phcHash{
+			id: string(Scrypt),
+			params: []phcParam{
+				{"ln", int(h.params.ScryptLogN)},
+				{"r", h.params.ScryptR},
+				{"p", h.params.ScryptP},
+			},
+			salt: salt,
+		}
+	case PBKDF2:
+		phc = &phcHash{
+			id:     string(PBKDF2),
+			params: []phcParam{{"i", h.params.PBKDF2Iterations}},
+			salt:   salt,
+		}
+	}
+
+	key, err := derive(phc, password, keyLength)
+	if err != nil {
+		return "", err
+	}
+	phc.hash = key
+	return phc.String(), nil
+}
+
+func (h *passwordHasher) Verify(password, encoded string) (bool, error) {
+	if isBcrypt(encoded) {
+		err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password))
+		if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+			return false, nil
+		}
+		if err != nil {
+			return false, fmt.Errorf("%w: %v", ErrUnsupportedHash, err)
+		}
+		return true, nil
+	}
+
+	phc, err := parsePHC(encoded)
+	if err != nil {
+		return false, err
+	}
+	key, err := derive(phc, password, len(phc.hash))
+	if err != nil {
+		return false, err
+	}
+	return subtle.ConstantTimeCompare(key, phc.hash) == 1, nil
+}
+
+func (h *passwordHasher) NeedsRehash(encoded string) bool {
+	if isBcrypt(encoded) {
+		if h.params.Algorithm != Bcrypt {
+			return true
+		}
+		cost, err := bcrypt.Cost([]byte(encoded))
+		return err != nil || cost != h.params.BcryptCost
+	}
+
+	phc, err := parsePHC(encoded)
+	if err != nil || phc.id != string(h.params.Algorithm) {
+		return true
+	}
+	switch h.params.Algorithm {
+	case Argon2id:
+		return phc.version != argon2.Version ||
+			phc.param("m") != int(h.params.Argon2Memory) ||
+			phc.param("t") != int(h.params.Argon2Iterations) ||
+			phc.param("p") != int(h.params.Argon2Parallelism)
+	case Scrypt:
+		return phc.param("ln") != int(h.params.ScryptLogN) ||
+			phc.param("r") != h.params.ScryptR ||
+			phc.param("p") != h.params.ScryptP
+	case PBKDF2:
+		return phc.param("i") != h.params.PBKDF2Iterations
+	}
+	return true
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
+
+// derive computes the key for password with the algorithm, parameters and
+// salt of phc.
+func derive(phc *phcHash, password string, length int) ([]byte, error) {
+	switch phc.id {
+	case string(Argon2id):
+		memory, iterations, parallelism := phc.param("m"), phc.param("t"), phc.param("p")
+		if phc.version != argon2.Version || memory <= 0 || iterations <= 0 || parallelism <= 0 || parallelism > 255 {
+			return nil, ErrUnsupportedHash
+		}
+		return argon2.IDKey([]byte(password), phc.salt, uint32(iterations), uint32(memory), uint8(parallelism), uint32(length)), nil
+	case string(Scrypt):
+		logN, r, p := phc.param("ln"), phc.param("r"), phc.param("p")
+		if logN <= 0 || logN > 30 || r <= 0 || p <= 0 {
+			return nil, ErrUnsupportedHash
+		}
+		key, err := scrypt.Key([]byte(password), phc.salt, 1<<logN, r, p, length)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedHash, err)
+		}
+		return key, nil
+	case "pbkdf2-sha1", "pbkdf2-sha256", "pbkdf2-sha512":
+		iterations := phc.param("i")
+		if iterations <= 0 {
+			return nil, ErrUnsupportedHash
+		}
+		key, err := pbkdf2.Key(pbkdf2Digest(phc.id), password, phc.salt, iterations, length)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %v", ErrUnsupportedHash, err)
+		}
+		return key, nil
+	}
+	return nil, ErrUnsupportedHash
+}
+
+func pbkdf2Digest(id string) func() hash.Hash {
+	switch id {
+	case "pbkdf2-sha1":
+		return sha1.New
+	case "pbkdf2-sha512":
+		return sha512.New
+	}
+	return sha256.New
+}
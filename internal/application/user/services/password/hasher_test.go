@@ -0,0 +1,124 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+// fastParams keeps every algorithm cheap enough for tests.
+func fastParams(algorithm Algorithm) Params {
+	return Params{
+		Algorithm:         algorithm,
+		Argon2Memory:      64,
+		Argon2Iterations:  1,
+		Argon2Parallelism: 1,
+		BcryptCost:        bcrypt.MinCost,
+		ScryptLogN:        4,
+		ScryptR:           8,
+		ScryptP:           1,
+		PBKDF2Iterations:  10,
+	}
+}
+
+func TestPasswordHasher_HashAndVerify(t *testing.T) {
+	tests := map[Algorithm]string{
+		Argon2id: "$argon2id$v=19$m=64,t=1,p=1$",
+		Bcrypt:   "$2a$04$",
+		Scrypt:   "$scrypt$ln=4,r=8,p=1$",
+		PBKDF2:   "$pbkdf2-sha256$i=10$",
+	}
+	for algorithm, prefix := range tests {
+		t.Run(string(algorithm), func(t *testing.T) {
+			hasher, err := NewPasswordHasher(fastParams(algorithm))
+			require.NoError(t, err)
+
+			encoded, err := hasher.Hash("correct horse")
+			require.NoError(t, err)
+			assert.True(t, strings.HasPrefix(encoded, prefix), encoded)
+
+			ok, err := hasher.Verify("correct horse", encoded)
+			require.NoError(t, err)
+			assert.True(t, ok)
+
+			ok, err = hasher.Verify("battery staple", encoded)
+			require.NoError(t, err)
+			assert.False(t, ok)
+
+			other, err := hasher.Hash("correct horse")
+			require.NoError(t, err)
+			assert.NotEqual(t, encoded, other, "hashes are salted")
+			assert.False(t, hasher.NeedsRehash(encoded))
+		})
+	}
+}
+
+// The imported hashes were made with Python's hashlib.
+func TestPasswordHasher_VerifiesImportedHashes(t *testing.T) {
+	hasher, err := NewPasswordHasher(fastParams(Argon2id))
+	require.NoError(t, err)
+
+	legacyBcrypt, err := bcrypt.GenerateFromPassword([]byte("correct horse"), bcrypt.MinCost)
+	require.NoError(t, err)
+
+	for _, encoded := range []string{
+		string(legacyBcrypt),
+		"$pbkdf2-sha512$i=1000$bGVnYWN5LXNhbHQtMTIzNA$MWU9/xuHZV6Rzm5R8YbaVXGA6+I27Z/SzXPjMMgw5ikxUo7j93gnLHKy+uNFaW1PJXjnL7QLUEZ5MmIATYWQrA",
+		"$scrypt$ln=10,r=8,p=1$bGVnYWN5LXNhbHQtMTIzNA$nsrac9nkmbivcvZwbNaQZ3hVxDAcXHIqKOocbWvTZ64",
+	} {
+		ok, err := hasher.Verify("correct horse", encoded)
+		require.NoError(t, err, encoded)
+		assert.True(t, ok, encoded)
+		assert.True(t, hasher.NeedsRehash(encoded), encoded)
+	}
+}
+
+func TestPasswordHasher_NeedsRehashWhenParametersChange(t *testing.T) {
+	hasher, err := NewPasswordHasher(fastParams(Argon2id))
+	require.NoError(t, err)
+	encoded, err := hasher.Hash("correct horse")
+	require.NoError(t, err)
+
+	stronger := fastParams(Argon2id)
+	stronger.Argon2Iterations = 2
+	upgraded, err := NewPasswordHasher(stronger)
+	require.NoError(t, err)
+	assert.True(t, upgraded.NeedsRehash(encoded))
+
+	ok, err := upgraded.Verify("correct horse", encoded)
+	require.NoError(t, err)
+	assert.True(t, ok, "old parameters still verify")
+}
+
+func TestPasswordHasher_RejectsMalformedHashes(t *testing.T) {
+	hasher, err := NewPasswordHasher(fastParams(Argon2id))
+	require.NoError(t, err)
+
+	for _, encoded := range []string{
+		"",
+		"plaintext",
+		"$md5$salt$hash",
+		"$argon2id$v=19$m=64,t=1,p=1$c2FsdA",
+		"$argon2id$v=19$m=64,t=x,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=16$m=64,t=1,p=1$c2FsdA$aGFzaA",
+	} {
+		_, err := hasher.Verify("correct horse", encoded)
+		assert.ErrorIs(t, err, ErrUnsupportedHash, encoded)
+	}
+}
+
+func TestNewPasswordHasher_RejectsInvalidParams(t *testing.T) {
+	unknown := fastParams("md5")
+	weakBcrypt := fastParams(Bcrypt)
+	weakBcrypt.BcryptCost = 2
+	noArgonMemory := fastParams(Argon2id)
+	noArgonMemory.Argon2Memory = 0
+
+	for _, params := range []Params{unknown, weakBcrypt, noArgonMemory} {
+		_, err := NewPasswordHasher(params)
+		assert.Error(t, err)
+	}
+}
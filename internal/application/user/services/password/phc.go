@@ -0,0 +1,96 @@
+package password
+
+import (
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// phcHash is a hash in the PHC string format:
+// $<id>[$v=<version>][$<param>=<value>(,<param>=<value>)*]$<salt>$<hash>
+type phcHash struct {
+	id      string
+	version int
+	params  []phcParam
+	salt    []byte
+	hash    []byte
+}
+
+type phcParam struct {
+	name  string
+	value int
+}
+
+func (p *phcHash) param(name string) int {
+	for _, param := range p.params {
+		if param.name == name {
+			return param.value
+		}
+	}
+	return 0
+}
+
+func (p *phcHash) String() string {
+	var b strings.Builder
+	b.WriteString("$" + p.id)
+	if p.version != 0 {
+		fmt.Fprintf(&b, "$v=%d", p.version)
+	}
+	params := make([]string, 0, len(p.params))
+	for _, param := range p.params {
+		params = append(params, fmt.Sprintf("%s=%d", param.name, param.value))
+	}
+	b.WriteString("$" + strings.Join(params, ","))
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(p.salt))
+	b.WriteString("$" + base64.RawStdEncoding.EncodeToString(p.hash))
+	return b.String()
+}
+
+// parsePHC parses a PHC string. Padded base64 is accepted too, as some
+// exporters write it.
+func parsePHC(encoded string) (*phcHash, error) {
+	fields := strings.Split(encoded, "$")
+	if len(fields) < 4 || fields[0] != "" || fields[1] == "" {
+		return nil, ErrUnsupportedHash
+	}
+	phc := &phcHash{id: fields[1]}
+	fields = fields[2:]
+
+	if version, ok := strings.CutPrefix(fields[0], "v="); ok {
+		v, err := strconv.Atoi(version)
+		if err != nil {
+			return nil, ErrUnsupportedHash
+		}
+		phc.version = v
+		fields = fields[1:]
+	}
+	if len(fields) != 3 {
+		return nil, ErrUnsupportedHash
+	}
+
+	for _, pair := range strings.Split(fields[0], ",") {
+		name, value, ok := strings.Cut(pair, "=")
+		if !ok {
+			return nil, ErrUnsupportedHash
+		}
+		n, err := strconv.Atoi(value)
+		if err != nil {
+			return nil, ErrUnsupportedHash
+		}
+		phc.params = append(phc.params, phcParam{name, n})
+	}
+
+	var err error
+	if phc.salt, err = decodeB64(fields[1]); err != nil {
+		return nil, ErrUnsupportedHash
+	}
+	if phc.hash, err = decodeB64(fields[2]); err != nil || len(phc.hash) == 0 {
+		return nil, ErrUnsupportedHash
+	}
+	return phc, nil
+}
+
+func decodeB64(s string) ([]byte, error) {
+	return base64.RawStdEncoding.DecodeString(strings.TrimRight(s, "="))
+}
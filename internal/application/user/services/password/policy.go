@@ -0,0 +1,190 @@
+package password
+
+import (
+	"context"
+	"crypto/sha1"
+	"encoding/hex"
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"go.opentelemetry.io/otel/attribute"
+)
+
+// Rule names a password policy rule in PolicyError violations.
+type Rule string
+
+const (
+	RuleMinLength     Rule = "min_length"
+	RuleMaxLength     Rule = "max_length"
+	RuleLowercase     Rule = "lowercase"
+	RuleUppercase     Rule = "uppercase"
+	RuleDigit         Rule = "digit"
+	RuleSymbol        Rule = "symbol"
+	RuleContainsEmail Rule = "contains_email"
+	RuleContainsName  Rule = "contains_name"
+	RuleBreached      Rule = "breached"
+	RuleReused        Rule = "reused"
+)
+
+// Violation is one rule a password breaks.
+type Violation struct {
+	Rule    Rule   `json:"rule"`
+	Message string `json:"message"`
+}
+
+// PolicyError lists every rule a password breaks.
+type PolicyError struct {
+	Violations []Violation
+}
+
+func (e *PolicyError) Error() string {
+	messages := make([]string, 0, len(e.Violations))
+	for _, v := range e.Violations {
+		messages = append(messages, v.Message)
+	}
+	return "password rejected: " + strings.Join(messages, "; ")
+}
+
+// BreachedPasswordRange looks up breached passwords by k-anonymity, as the
+// Pwned Passwords range API does: only the first 5 hex digits of the
+// password's SHA-1 are sent, and the source returns the remaining 35 digits
+// of every breached hash sharing them.
+type BreachedPasswordRange interface {
+	Range(ctx context.Context, prefix string) ([]string, error)
+}
+
+// PolicyConfig sets the password policy. Zero values disable a rule.
+type PolicyConfig struct {
+	MinLength int
+	// MaxBytes caps the UTF-8 length; bcrypt ignores everything past 72 bytes.
+	MaxBytes         int
+	RequireLowercase bool
+	RequireUppercase bool
+	RequireDigit     bool
+	RequireSymbol    bool
+	// RejectPersonalInfo refuses passwords containing the email's local part
+	// or part of the user's name.
+	RejectPersonalInfo bool
+	// HistorySize is how many previous passwords can't be reused.
+	HistorySize int
+}
+
+// Policy checks new passwords. The breach check is skipped when breached is nil.
+type Policy struct {
+	config   PolicyConfig
+	hasher   PasswordHasher
+	breached BreachedPasswordRange
+}
+
+func NewPolicy(config PolicyConfig, hasher PasswordHasher, breached BreachedPasswordRange) *Policy {
+	return &Policy{config: config, hasher: hasher, breached: breached}
+}
+
+// HistorySize is how many previous password hashes the policy compares against.
+func (p *Policy) HistorySize() int {
+	return p.config.HistorySize
+}
+
+// Account is who a password is for.
+type Account struct {
+	Email string
+	Name  string
+	// PreviousHashes are the current and earlier password hashes, newest first.
+	PreviousHashes []string
+}
+
+// Check returns a *PolicyError listing every rule password breaks, or another
+// error when the breach lookup fails.
+func (p *Policy) Check(ctx context.Context, password string, account Account) error {
+	var violations []Violation
+	add := func(rule Rule, format string, args ...any) {
+		violations = append(violations, Violation{Rule: rule, Message: fmt.Sprintf(format, args...)})
+	}
+
+	if p.config.MinLength > 0 && utf8.RuneCountInString(password) < p.config.MinLength {
+		add(RuleMinLength, "must be at least %d characters", p.config.MinLength)
+	}
+	if p.config.MaxBytes > 0 && len(password) > p.config.MaxBytes {
+		add(RuleMaxLength, "must be at most %d bytes", p.config.MaxBytes)
+	}
+
+	if p.config.RequireLowercase && !strings.ContainsFunc(password, unicode.IsLower) {
+		add(RuleLowercase, "must contain a lowercase letter")
+	}
+	if p.config.RequireUppercase && !strings.ContainsFunc(password, unicode.IsUpper) {
+		add(RuleUppercase, "must contain an uppercase letter")
+	}
+	if p.config.RequireDigit && !strings.ContainsFunc(password, unicode.IsDigit) {
+		add(RuleDigit, "must contain a digit")
+	}
+	if p.config.RequireSymbol && !strings.ContainsFunc(password, isSymbol) {
+		add(RuleSymbol, "must contain a symbol")
+	}
+
+	if p.config.RejectPersonalInfo {
+		lower := strings.ToLower(password)
+		if local, _, _ := strings.Cut(strings.ToLower(account.Email), "@"); len(local) >= 3 && strings.Contains(lower, local) {
+			add(RuleContainsEmail, "must not contain your email address")
+		}
+		for _, part := range strings.FieldsFunc(strings.ToLower(account.Name), isNameSeparator) {
+			if len(part) >= 3 && strings.Contains(lower, part) {
+				add(RuleContainsName, "must not contain your name")
+				break
+			}
+		}
+	}
+
+	if p.config.HistorySize > 0 {
+		previous := account.PreviousHashes[:min(len(account.PreviousHashes), p.config.HistorySize)]
+		_, span := tracing.Start(ctx, "password.VerifyHistory", attribute.Int("password.history_size", len(previous)))
+		for _, hash := range previous {
+			if ok, err := p.hasher.Verify(password, hash); err == nil && ok {
+				add(RuleReused, "must not be one of your last %d passwords", p.config.HistorySize)
+				break
+			}
+		}
+		span.End()
+	}
+
+	if p.breached != nil {
+		breached, err := p.isBreached(ctx, password)
+		if err != nil {
+			return fmt.Errorf("error checking breached passwords: %w", err)
+		}
+		if breached {
+			add(RuleBreached, "appears in a known data breach")
+		}
+	}
+
+	if len(violations) > 0 {
+		return &PolicyError{Violations: violations}
+	}
+	return nil
+}
+
+func (p *Policy) isBreached(ctx context.Context, password string) (bool, error) {
+	sum := sha1.Sum([]byte(password))
+	hash := strings.ToUpper(hex.EncodeToString(sum[:]))
+
+	suffixes, err := p.breached.Range(ctx, hash[:5])
+	if err != nil {
+		return false, err
+	}
+	for _, suffix := range suffixes {
+		if strings.EqualFold(suffix, hash[5:]) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func isSymbol(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r) && !unicode.IsSpace(r)
+}
+
+func isNameSeparator(r rune) bool {
+	return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+}
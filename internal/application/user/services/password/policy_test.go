@@ -0,0 +1,81 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// stubRange serves a fixed range response for every prefix.
+type stubRange struct {
+	suffixes []string
+	err      error
+	prefixes []string
+}
+
+func (r *stubRange) Range(_ context.Context, prefix string) ([]string, error) {
+	r.prefixes = append(r.prefixes, prefix)
+	return r.suffixes, r.err
+}
+
+func violatedRules(t *testing.T, err error) []Rule {
+	var policyErr *PolicyError
+	require.ErrorAs(t, err, &policyErr)
+	rules := make([]Rule, len(policyErr.Violations))
+	for i, violation := range policyErr.Violations {
+		rules[i] = violation.Rule
+	}
+	return rules
+}
+
+func TestPolicy_Check(t *testing.T) {
+	policy := NewPolicy(PolicyConfig{
+		MinLength:          8,
+		MaxBytes:           72,
+		RequireUppercase:   true,
+		RequireDigit:       true,
+		RejectPersonalInfo: true,
+	}, nil, nil)
+	account := Account{Email: "wonderland@example.com", Name: "Alice Liddell"}
+
+	assert.NoError(t, policy.Check(t.Context(), "Correct-horse-9", account))
+	assert.Equal(t, []Rule{RuleMinLength, RuleUppercase, RuleDigit}, violatedRules(t, policy.Check(t.Context(), "short", account)))
+	assert.Equal(t, []Rule{RuleContainsEmail}, violatedRules(t, policy.Check(t.Context(), "Wonderland-2024", account)))
+	assert.Equal(t, []Rule{RuleContainsName}, violatedRules(t, policy.Check(t.Context(), "MyNameIsAlice1", account)))
+	assert.Equal(t, []Rule{RuleMaxLength}, violatedRules(t, policy.Check(t.Context(), "Aa1"+string(make([]byte, 70)), account)))
+}
+
+func TestPolicy_Check_History(t *testing.T) {
+	hasher, err := NewPasswordHasher(fastParams(Bcrypt))
+	require.NoError(t, err)
+	oldest, err := hasher.Hash("first-password")
+	require.NoError(t, err)
+	newest, err := hasher.Hash("second-password")
+	require.NoError(t, err)
+
+	policy := NewPolicy(PolicyConfig{HistorySize: 1}, hasher, nil)
+	account := Account{PreviousHashes: []string{newest, oldest}}
+
+	assert.Equal(t, []Rule{RuleReused}, violatedRules(t, policy.Check(t.Context(), "second-password", account)))
+	assert.NoError(t, policy.Check(t.Context(), "first-password", account), "only the last HistorySize hashes count")
+}
+
+func TestPolicy_Check_Breached(t *testing.T) {
+	// SHA-1("password") = 5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8
+	breached := &stubRange{suffixes: []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}}
+	policy := NewPolicy(PolicyConfig{}, nil, breached)
+
+	assert.Equal(t, []Rule{RuleBreached}, violatedRules(t, policy.Check(t.Context(), "password", Account{})))
+	assert.NoError(t, policy.Check(t.Context(), "not-in-the-list", Account{}))
+	assert.Equal(t, "5BAA6", breached.prefixes[0], "only the prefix leaves the process")
+
+	// Lookup failures reject the password rather than skipping the check
+	breached.err = errors.New("connection refused")
+	err := policy.Check(t.Context(), "not-in-the-list", Account{})
+	require.Error(t, err)
+	var policyErr *PolicyError
+	assert.False(t, errors.As(err, &policyErr))
+}
@@ -0,0 +1,61 @@
+package token
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// userCodeAlphabet has no vowels, so user codes don't spell words, and no
+// characters that are easily confused when read aloud.
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+const userCodeLength = 8
+
+// GenerateDeviceCode returns a device code for the device to poll with and
+// the hash to store in its place.
+func GenerateDeviceCode() (code, hash string, err error) {
+	code, err = generateRandomToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate device code: %w", err)
+	}
+	return code, HashDeviceCode(code), nil
+}
+
+func HashDeviceCode(code string) string {
+	return hashToken(code)
+}
+
+// GenerateUserCode returns a code for the user to type, e.g. "WDJB-MJHT".
+func GenerateUserCode() (string, error) {
+	var b strings.Builder
+	max := big.NewInt(int64(len(userCodeAlphabet)))
+	for i := range userCodeLength {
+		if i == userCodeLength/2 {
+			b.WriteByte('-')
+		}
+		n, err := rand.Int(rand.Reader, max)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate user code: %w", err)
+		}
+		b.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return b.String(), nil
+}
+
+// NormalizeUserCode formats a user code as typed by the user, ignoring case,
+// spaces and dashes, the way GenerateUserCode produced it.
+func NormalizeUserCode(input string) string {
+	var b strings.Builder
+	for _, r := range strings.ToUpper(input) {
+		if r == '-' || r == ' ' {
+			continue
+		}
+		if b.Len() == userCodeLength/2 {
+			b.WriteByte('-')
+		}
+		b.WriteRune(r)
+	}
+	return b.String()
+}
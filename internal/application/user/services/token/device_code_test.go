@@ -0,0 +1,30 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateUserCode(t *testing.T) {
+	code, err := GenerateUserCode()
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^[BCDFGHJKLMNPQRSTVWXZ]{4}-[BCDFGHJKLMNPQRSTVWXZ]{4}$`, code)
+	assert.Equal(t, code, NormalizeUserCode(code))
+}
+
+func TestNormalizeUserCode(t *testing.T) {
+	for _, input := range []string{"WDJB-MJHT", "wdjbmjht", " wdjb mjht ", "WdJb-MjHt"} {
+		assert.Equal(t, "WDJB-MJHT", NormalizeUserCode(input), input)
+	}
+}
+
+func TestGenerateDeviceCode(t *testing.T) {
+	code, hash, err := GenerateDeviceCode()
+	require.NoError(t, err)
+
+	assert.NotEqual(t, code, hash)
+	assert.Equal(t, hash, HashDeviceCode(code))
+}
@@ -0,0 +1,75 @@
+package token
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newTestEmailChange() EmailChange {
+	return EmailChange{
+		UserID:       uuid.New(),
+		CurrentEmail: "old@example.com",
+		NewEmail:     "new@example.com",
+		ExpiresAt:    time.Now().Add(time.Hour).Truncate(time.Second),
+	}
+}
+
+func TestEmailChangeSigner_SignAndVerify(t *testing.T) {
+	signer := NewEmailChangeSigner("secret")
+	change := newTestEmailChange()
+
+	verified, err := signer.Verify(signer.Sign(change))
+
+	require.NoError(t, err)
+	assert.Equal(t, change.UserID, verified.UserID)
+	assert.Equal(t, change.CurrentEmail, verified.CurrentEmail)
+	assert.Equal(t, change.NewEmail, verified.NewEmail)
+	assert.True(t, change.ExpiresAt.Equal(verified.ExpiresAt))
+}
+
+func TestEmailChangeSigner_VerifyWithPreviousSecret(t *testing.T) {
+	change := newTestEmailChange()
+	token := NewEmailChangeSigner("old").Sign(change)
+
+	_, err := NewEmailChangeSigner("new", "old").Verify(token)
+	assert.NoError(t, err)
+	_, err = NewEmailChangeSigner("new").Verify(token)
+	assert.Error(t, err)
+}
+
+func TestEmailChangeSigner_Verify_Rejects(t *testing.T) {
+	signer := NewEmailChangeSigner("secret")
+	change := newTestEmailChange()
+	token := signer.Sign(change)
+
+	t.Run("other secret", func(t *testing.T) {
+		_, err := NewEmailChangeSigner("other").Verify(token)
+		assert.Error(t, err)
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		tampered := change
+		tampered.NewEmail = "attacker@example.com"
+		_, signature, _ := strings.Cut(token, ".")
+		forged, _, _ := strings.Cut(signer.Sign(tampered), ".")
+		_, err := signer.Verify(forged + "." + signature)
+		assert.Error(t, err)
+	})
+
+	t.Run("expired", func(t *testing.T) {
+		expired := change
+		expired.ExpiresAt = time.Now().Add(-time.Minute)
+		_, err := signer.Verify(signer.Sign(expired))
+		assert.Error(t, err)
+	})
+
+	t.Run("malformed", func(t *testing.T) {
+		_, err := signer.Verify("not-a-token")
+		assert.Error(t, err)
+	})
+}
@@ -1,6 +1,9 @@
 package mocks
 
 import (
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/mock"
@@ -16,6 +19,21 @@ func (m *MockTokenGenerator) GenerateToken(u *user.User) (string, error) {
 	return args.String(0), args.Error(1)
 }
 
+func (m *MockTokenGenerator) GenerateOrganizationToken(u *user.User, membership *organization.Membership) (string, error) {
+	args := m.Called(u, membership)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenGenerator) GenerateImpersonationToken(u *user.User, session *impersonation.Session) (string, error) {
+	args := m.Called(u, session)
+	return args.String(0), args.Error(1)
+}
+
+func (m *MockTokenGenerator) GenerateDelegatedToken(delegation *token.Delegation) (string, error) {
+	args := m.Called(delegation)
+	return args.String(0), args.Error(1)
+}
+
 func (m *MockTokenGenerator) ExtractUserID(tokenString string) (uuid.UUID, error) {
 	args := m.Called(tokenString)
 	if args.Get(0) == nil {
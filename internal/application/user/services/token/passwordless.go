@@ -0,0 +1,40 @@
+package token
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+
+	"github.com/google/uuid"
+)
+
+const loginCodeDigits = 6
+
+// GenerateLoginLinkToken returns the token for a passwordless sign-in link
+// and the hash to store in its place.
+func GenerateLoginLinkToken() (token, hash string, err error) {
+	token, err = generateRandomToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate sign-in link: %w", err)
+	}
+	return token, HashLoginLinkToken(token), nil
+}
+
+func HashLoginLinkToken(token string) string {
+	return hashToken(token)
+}
+
+// GenerateLoginCode returns a 6-digit sign-in code, e.g. "042917".
+func GenerateLoginCode() (string, error) {
+	n, err := rand.Int(rand.Reader, big.NewInt(1_000_000))
+	if err != nil {
+		return "", fmt.Errorf("failed to generate sign-in code: %w", err)
+	}
+	return fmt.Sprintf("%0*d", loginCodeDigits, n.Int64()), nil
+}
+
+// HashLoginCode hashes a code together with its challenge, since codes alone
+// are neither unique nor hard to guess.
+func HashLoginCode(challengeID uuid.UUID, code string) string {
+	return hashToken(challengeID.String() + ":" + code)
+}
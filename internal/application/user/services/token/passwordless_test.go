@@ -0,0 +1,24 @@
+package token
+
+import (
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateLoginCode(t *testing.T) {
+	code, err := GenerateLoginCode()
+	require.NoError(t, err)
+
+	assert.Regexp(t, `^[0-9]{6}$`, code)
+}
+
+func TestHashLoginCode_DependsOnChallenge(t *testing.T) {
+	first, second := uuid.New(), uuid.New()
+
+	assert.Equal(t, HashLoginCode(first, "123456"), HashLoginCode(first, "123456"))
+	assert.NotEqual(t, HashLoginCode(first, "123456"), HashLoginCode(second, "123456"))
+	assert.NotEqual(t, HashLoginCode(first, "123456"), HashLoginCode(first, "123457"))
+}
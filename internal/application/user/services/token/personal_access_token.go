@@ -0,0 +1,22 @@
+package token
+
+import (
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+// GeneratePersonalAccessToken returns a new personal access token and the
+// hash to store in its place.
+func GeneratePersonalAccessToken() (token, hash string, err error) {
+	secret, err := generateRandomToken()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate personal access token: %w", err)
+	}
+	token = auth.PersonalAccessTokenPrefix + secret
+	return token, HashPersonalAccessToken(token), nil
+}
+
+func HashPersonalAccessToken(token string) string {
+	return hashToken(token)
+}
@@ -65,7 +65,10 @@ func (s *service) ValidateRefreshToken(ctx context.Context, tokenString string)
 		return nil, fmt.Errorf("refresh token not found: %w", err)
 	}
 
-	if !refreshToken.IsValid() {
+	if refreshToken.IsRevoked() {
+		return nil, token.ErrRefreshTokenReused
+	}
+	if refreshToken.IsExpired() {
 		return nil, fmt.Errorf("refresh token is invalid or expired")
 	}
 
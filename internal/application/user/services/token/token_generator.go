@@ -3,9 +3,13 @@ package token
 import (
 	"fmt"
 	"os"
+	"strings"
 	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
 	"github.com/golang-jwt/jwt/v5"
 	"github.com/google/uuid"
 )
@@ -13,8 +17,29 @@ import (
 type tokenGenerator struct {
 }
 
+// Delegation describes a token obtained through RFC 8693 token exchange.
+type Delegation struct {
+	UserID         uuid.UUID
+	Audience       string
+	Permissions    []string
+	OrgID          *uuid.UUID
+	OrgPermissions []string
+	// Actor is the calling service, with the subject token's actors nested below it.
+	Actor     *auth.Actor
+	ExpiresAt time.Time
+}
+
 type TokenGenerator interface {
 	GenerateToken(user *user.User) (string, error)
+	// GenerateOrganizationToken issues a token scoped to the membership's organization.
+	GenerateOrganizationToken(user *user.User, membership *organization.Membership) (string, error)
+	// GenerateImpersonationToken issues a token for the session's user that
+	// names the impersonating admin in the RFC 8693 "act" claim and expires
+	// with the session.
+	GenerateImpersonationToken(user *user.User, session *impersonation.Session) (string, error)
+	// GenerateDelegatedToken issues a down-scoped token for another service;
+	// it carries only the delegation's permissions, no roles.
+	GenerateDelegatedToken(delegation *Delegation) (string, error)
 	ExtractUserID(tokenString string) (uuid.UUID, error)
 }
 
@@ -23,20 +48,74 @@ func NewTokenGenerator() TokenGenerator {
 }
 
 func (t *tokenGenerator) GenerateToken(user *user.User) (string, error) {
-	secret := os.Getenv("JWT_SECRET")
-	if secret == "" {
-		return "", fmt.Errorf("JWT_SECRET environment variable is not set")
+	return t.sign(userClaims(user))
+}
+
+func (t *tokenGenerator) GenerateOrganizationToken(user *user.User, membership *organization.Membership) (string, error) {
+	claims := userClaims(user)
+	claims["org_id"] = membership.OrganizationID.String()
+	claims["org_role"] = membership.RoleName()
+	claims["org_permissions"] = membership.Permissions()
+
+	return t.sign(claims)
+}
+
+func (t *tokenGenerator) GenerateImpersonationToken(user *user.User, session *impersonation.Session) (string, error) {
+	claims := userClaims(user)
+	claims["exp"] = session.ExpiresAt.Unix()
+	claims["jti"] = session.ID.String()
+	claims["act"] = map[string]any{"sub": session.ActorID.String()}
+
+	return t.sign(claims)
+}
+
+func (t *tokenGenerator) GenerateDelegatedToken(delegation *Delegation) (string, error) {
+	claims := jwt.MapClaims{
+		"sub": delegation.UserID.String(),
+		"aud": []string{delegation.Audience},
+		"exp": delegation.ExpiresAt.Unix(),
+		"iat": time.Now().Unix(),
+		"jti": uuid.NewString(),
+		"act": delegation.Actor,
+	}
+	if len(delegation.Permissions) > 0 {
+		claims["permissions"] = delegation.Permissions
+	}
+	if delegation.OrgID != nil {
+		claims["org_id"] = delegation.OrgID.String()
+		claims["org_permissions"] = delegation.OrgPermissions
 	}
 
+	return t.sign(claims)
+}
+
+func userClaims(user *user.User) jwt.MapClaims {
 	claims := jwt.MapClaims{
 		"sub": user.ID.String(),
 		"exp": time.Now().Add(time.Hour * 24).Unix(),
 	}
 
-	// Only add role and permissions if user has a role (RBAC is enabled)
+	// Only add role and permissions if user has a role (RBAC is enabled).
+	// "role" is the directly assigned role; "roles" and "permissions" also
+	// cover the roles inherited from the user's groups.
 	if user.Role != nil {
 		claims["role"] = user.Role.Name
-		claims["permissions"] = user.Role.GetPermissionStrings()
+	}
+	if roles := user.EffectiveRoleNames(); len(roles) > 0 {
+		claims["roles"] = roles
+		claims["permissions"] = user.EffectivePermissions()
+	}
+	if groups := user.GroupNames(); len(groups) > 0 {
+		claims["groups"] = groups
+	}
+
+	return claims
+}
+
+func (t *tokenGenerator) sign(claims jwt.MapClaims) (string, error) {
+	secret := os.Getenv("JWT_SECRET")
+	if secret == "" {
+		return "", fmt.Errorf("JWT_SECRET environment variable is not set")
 	}
 
 	token := jwt.NewWithClaims(jwt.SigningMethodHS256, claims)
@@ -60,7 +139,15 @@ func (t *tokenGenerator) ExtractUserID(tokenString string) (uuid.UUID, error) {
 		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 			return nil, fmt.Errorf("unexpected signing method: %v", token.Header["alg"])
 		}
-		return []byte(secret), nil
+		previous := previousJWTSecrets()
+		if len(previous) == 0 {
+			return []byte(secret), nil
+		}
+		keys := jwt.VerificationKeySet{Keys: []jwt.VerificationKey{[]byte(secret)}}
+		for _, p := range previous {
+			keys.Keys = append(keys.Keys, []byte(p))
+		}
+		return keys, nil
 	})
 
 	if err != nil {
@@ -83,3 +170,15 @@ func (t *tokenGenerator) ExtractUserID(tokenString string) (uuid.UUID, error) {
 
 	return uuid.Nil, fmt.Errorf("invalid token")
 }
+
+// previousJWTSecrets returns the comma-separated secrets in JWT_SECRET_PREVIOUS,
+// which tokens signed before a rotation are still verified with.
+func previousJWTSecrets() []string {
+	var secrets []string
+	for secret := range strings.SplitSeq(os.Getenv("JWT_SECRET_PREVIOUS"), ",") {
+		if secret = strings.TrimSpace(secret); secret != "" {
+			secrets = append(secrets, secret)
+		}
+	}
+	return secrets
+}
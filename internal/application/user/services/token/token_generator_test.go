@@ -3,9 +3,16 @@ package token
 import (
 	"os"
 	"testing"
+	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
 	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -87,3 +94,149 @@ func TestTokenGenerator_GenerateToken_DifferentUsers(t *testing.T) {
 	assert.Equal(t, user1.ID.String(), claims1["sub"])
 	assert.Equal(t, user2.ID.String(), claims2["sub"])
 }
+
+func TestTokenGenerator_GenerateOrganizationToken(t *testing.T) {
+	// Arrange
+	secret := "test-secret-key-for-jwt"
+	os.Setenv("JWT_SECRET", secret)
+	defer os.Unsetenv("JWT_SECRET")
+
+	generator := NewTokenGenerator()
+	user := user.New("test@example.com", nil)
+	org, err := organization.New("Acme", "acme")
+	require.NoError(t, err)
+	membership := organization.NewMembership(org.ID, user.ID, org.DefaultRoles()[0])
+
+	// Act
+	tokenString, err := generator.GenerateOrganizationToken(user, membership)
+
+	// Assert
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	require.NoError(t, err)
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, org.ID.String(), claims["org_id"])
+	assert.Equal(t, organization.RoleOwner, claims["org_role"])
+	assert.Equal(t, []any{"org:*"}, claims["org_permissions"])
+}
+
+func TestTokenGenerator_GenerateImpersonationToken(t *testing.T) {
+	// Arrange
+	secret := "test-secret-key-for-jwt"
+	os.Setenv("JWT_SECRET", secret)
+	defer os.Unsetenv("JWT_SECRET")
+
+	generator := NewTokenGenerator()
+	target := user.New("user@example.com", nil)
+	adminID := uuid.New()
+	session := impersonation.NewSession(adminID, "admin@example.com", target.ID, target.Email, "ticket #42", 15*time.Minute)
+
+	// Act
+	tokenString, err := generator.GenerateImpersonationToken(target, session)
+
+	// Assert
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	require.NoError(t, err)
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, target.ID.String(), claims["sub"])
+	assert.Equal(t, session.ID.String(), claims["jti"])
+	assert.Equal(t, map[string]any{"sub": adminID.String()}, claims["act"])
+	assert.Equal(t, float64(session.ExpiresAt.Unix()), claims["exp"])
+}
+
+func TestTokenGenerator_GenerateToken_IncludesGroupRoles(t *testing.T) {
+	// Arrange
+	secret := "test-secret-key-for-jwt"
+	os.Setenv("JWT_SECRET", secret)
+	defer os.Unsetenv("JWT_SECRET")
+
+	generator := NewTokenGenerator()
+	user := user.New("test@example.com", nil)
+	user.Role = role.NewUserRole()
+	engineering := group.New("engineering", "")
+	engineering.Roles = []role.Role{*role.NewModeratorRole(), *user.Role}
+	user.Groups = []group.Group{*engineering}
+
+	// Act
+	tokenString, err := generator.GenerateToken(user)
+
+	// Assert
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	require.NoError(t, err)
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, role.RoleUser, claims["role"])
+	assert.Equal(t, []any{role.RoleUser, role.RoleModerator}, claims["roles"])
+	assert.Equal(t, []any{"engineering"}, claims["groups"])
+	assert.Contains(t, claims["permissions"], "posts:delete")
+	assert.Contains(t, claims["permissions"], "users:write:self")
+}
+
+func TestTokenGenerator_GenerateDelegatedToken(t *testing.T) {
+	// Arrange
+	secret := "test-secret-key-for-jwt"
+	os.Setenv("JWT_SECRET", secret)
+	defer os.Unsetenv("JWT_SECRET")
+
+	generator := NewTokenGenerator()
+	delegation := &Delegation{
+		UserID:      uuid.New(),
+		Audience:    "orders-service",
+		Permissions: []string{"orders:read"},
+		Actor:       &auth.Actor{Subject: "api-gateway"},
+		ExpiresAt:   time.Now().Add(5 * time.Minute),
+	}
+
+	// Act
+	tokenString, err := generator.GenerateDelegatedToken(delegation)
+
+	// Assert
+	require.NoError(t, err)
+
+	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (interface{}, error) {
+		return []byte(secret), nil
+	})
+	require.NoError(t, err)
+
+	claims, ok := token.Claims.(jwt.MapClaims)
+	require.True(t, ok)
+	assert.Equal(t, delegation.UserID.String(), claims["sub"])
+	assert.Equal(t, []any{"orders-service"}, claims["aud"])
+	assert.Equal(t, []any{"orders:read"}, claims["permissions"])
+	assert.Equal(t, map[string]any{"sub": "api-gateway"}, claims["act"])
+	assert.NotContains(t, claims, "roles")
+	assert.NotContains(t, claims, "org_id")
+}
+
+func TestTokenGenerator_ExtractUserID_AcceptsPreviousSecret(t *testing.T) {
+	generator := NewTokenGenerator()
+	user := user.New("test@example.com", nil)
+	t.Setenv("JWT_SECRET", "old-secret")
+	tokenString, err := generator.GenerateToken(user)
+	require.NoError(t, err)
+
+	t.Setenv("JWT_SECRET", "new-secret")
+	_, err = generator.ExtractUserID(tokenString)
+	assert.Error(t, err)
+
+	t.Setenv("JWT_SECRET_PREVIOUS", "older-secret, old-secret")
+	userID, err := generator.ExtractUserID(tokenString)
+	require.NoError(t, err)
+	assert.Equal(t, user.ID, userID)
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/samber/lo"
+	"gorm.io/gorm"
+)
+
+var (
+	ErrAdminRoleMissing = errors.New("the admin role does not exist, seed the default roles first")
+	ErrPasswordRequired = errors.New("a password is required to create the user")
+)
+
+// BootstrapAdminUseCase makes a user an admin without an acting admin, for
+// setting up the first one from the command line.
+type BootstrapAdminUseCase struct {
+	userRepository user.UserRepository
+	roleRepository role.Repository
+	passwordHasher password.PasswordHasher
+	passwordPolicy *password.Policy
+	publisher      *outboxservices.Publisher
+}
+
+func NewBootstrapAdminUseCase(userRepository user.UserRepository, roleRepository role.Repository, passwordHasher password.PasswordHasher, passwordPolicy *password.Policy, publisher *outboxservices.Publisher) *BootstrapAdminUseCase {
+	return &BootstrapAdminUseCase{
+		userRepository: userRepository,
+		roleRepository: roleRepository,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		publisher:      publisher,
+	}
+}
+
+// Execute promotes the user with email to admin. When there is no such user
+// it creates one with plainPassword, which must then satisfy the password
+// policy; existing users keep their password. It reports whether the user
+// was created. Like the API, it publishes user.created for a new user and
+// role.assigned unless the user already was an admin.
+func (u *BootstrapAdminUseCase) Execute(ctx context.Context, email, plainPassword string) (_ *user.User, _ bool, err error) {
+	ctx, span := tracing.Start(ctx, "BootstrapAdminUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	adminRole, err := u.roleRepository.FindByName(ctx, role.RoleAdmin)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, ErrAdminRoleMissing
+	}
+	if err != nil {
+		return nil, false, fmt.Errorf("error finding admin role: %w", err)
+	}
+
+	existingUser, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, false, fmt.Errorf("error finding user: %w", err)
+	}
+	if existingUser != nil {
+		if existingUser.RoleID != nil && *existingUser.RoleID == adminRole.ID {
+			return existingUser, false, nil
+		}
+		err := u.publisher.Publish(ctx, outbox.EventRoleAssigned, roleAssigned(existingUser, adminRole), func(ctx context.Context) error {
+			return u.userRepository.UpdateRole(ctx, existingUser.ID, &adminRole.ID)
+		})
+		if err != nil {
+			return nil, false, fmt.Errorf("error assigning admin role: %w", err)
+		}
+		existingUser.RoleID = &adminRole.ID
+		existingUser.Role = adminRole
+		return existingUser, false, nil
+	}
+
+	if plainPassword == "" {
+		return nil, false, ErrPasswordRequired
+	}
+	if err := u.passwordPolicy.Check(ctx, plainPassword, password.Account{Email: email}); err != nil {
+		return nil, false, err
+	}
+	hashedPassword, err := hashPassword(ctx, u.passwordHasher, plainPassword)
+	if err != nil {
+		return nil, false, fmt.Errorf("error hashing password: %w", err)
+	}
+
+	newUser := user.New(email, lo.ToPtr(hashedPassword))
+	newUser.RoleID = &adminRole.ID
+	newUser.Role = adminRole
+	// Messages are stamped when published, so user.created is dispatched first
+	err = u.publisher.Publish(ctx, outbox.EventUserCreated, userEvent(newUser), func(ctx context.Context) error {
+		return u.publisher.Publish(ctx, outbox.EventRoleAssigned, roleAssigned(newUser, adminRole), func(ctx context.Context) error {
+			return u.userRepository.Create(ctx, newUser)
+		})
+	})
+	if err != nil {
+		return nil, false, fmt.Errorf("error creating user: %w", err)
+	}
+	return newUser, true, nil
+}
+
+// roleAssigned is the payload of role.assigned for ro being given to u
+func roleAssigned(u *user.User, ro *role.Role) outbox.RoleAssignedEvent {
+	return outbox.RoleAssignedEvent{UserID: u.ID, RoleID: ro.ID, RoleName: ro.Name}
+}
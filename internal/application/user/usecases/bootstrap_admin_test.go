@@ -0,0 +1,91 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	usermocks "github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+func TestBootstrapAdminUseCase_Execute_CreatesAdmin(t *testing.T) {
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	hasher := newBcryptHasher(t)
+	useCase := NewBootstrapAdminUseCase(mockUserRepo, mockRoleRepo, hasher, newTestPolicy(t), nil)
+	ctx := context.Background()
+	adminRole := role.NewAdminRole()
+
+	mockRoleRepo.On("FindByName", ctx, role.RoleAdmin).Return(adminRole, nil)
+	mockUserRepo.On("FindByEmail", ctx, "admin@example.com").Return(nil, gorm.ErrRecordNotFound)
+	mockUserRepo.On("Create", ctx, mock.AnythingOfType("*user.User")).Return(nil)
+
+	admin, created, err := useCase.Execute(ctx, "admin@example.com", "correct-horse-battery")
+
+	require.NoError(t, err)
+	assert.True(t, created)
+	assert.Equal(t, adminRole.ID, *admin.RoleID)
+	ok, err := hasher.Verify("correct-horse-battery", *admin.Password)
+	require.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestBootstrapAdminUseCase_Execute_PromotesExistingUser(t *testing.T) {
+	mockUserRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	useCase := NewBootstrapAdminUseCase(mockUserRepo, mockRoleRepo, newBcryptHasher(t), newTestPolicy(t), nil)
+	ctx := context.Background()
+	adminRole := role.NewAdminRole()
+	existing := user.New("alice@example.com", nil)
+
+	mockRoleRepo.On("FindByName", ctx, role.RoleAdmin).Return(adminRole, nil)
+	mockUserRepo.On("FindByEmail", ctx, existing.Email).Return(existing, nil)
+	mockUserRepo.On("UpdateRole", ctx, existing.ID, &adminRole.ID).Return(nil)
+
+	admin, created, err := useCase.Execute(ctx, existing.Email, "")
+
+	require.NoError(t, err)
+	assert.False(t, created)
+	assert.True(t, admin.HasRole(role.RoleAdmin))
+	mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
+func TestBootstrapAdminUseCase_Execute_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("roles not seeded", func(t *testing.T) {
+		mockRoleRepo := new(rolemocks.MockRoleRepository)
+		useCase := NewBootstrapAdminUseCase(new(usermocks.MockUserRepository), mockRoleRepo, newBcryptHasher(t), newTestPolicy(t), nil)
+		mockRoleRepo.On("FindByName", ctx, role.RoleAdmin).Return(nil, gorm.ErrRecordNotFound)
+
+		_, _, err := useCase.Execute(ctx, "admin@example.com", "correct-horse-battery")
+		assert.ErrorIs(t, err, ErrAdminRoleMissing)
+	})
+
+	for name, plainPassword := range map[string]string{"no password": "", "weak password": "short"} {
+		t.Run(name, func(t *testing.T) {
+			mockUserRepo := new(usermocks.MockUserRepository)
+			mockRoleRepo := new(rolemocks.MockRoleRepository)
+			useCase := NewBootstrapAdminUseCase(mockUserRepo, mockRoleRepo, newBcryptHasher(t), newTestPolicy(t), nil)
+			mockRoleRepo.On("FindByName", ctx, role.RoleAdmin).Return(role.NewAdminRole(), nil)
+			mockUserRepo.On("FindByEmail", ctx, "admin@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+			_, _, err := useCase.Execute(ctx, "admin@example.com", plainPassword)
+			require.Error(t, err)
+			if plainPassword == "" {
+				assert.ErrorIs(t, err, ErrPasswordRequired)
+			} else {
+				var policyErr *password.PolicyError
+				assert.ErrorAs(t, err, &policyErr)
+			}
+			mockUserRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+		})
+	}
+}
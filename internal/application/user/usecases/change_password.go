@@ -0,0 +1,148 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ChangePasswordUseCase struct {
+	userRepository      user.UserRepository
+	historyRepository   user.PasswordHistoryRepository
+	tokenGenerator      token.TokenGenerator
+	refreshTokenService token.Service
+	accessTokenExpiry   time.Duration
+	passwordHasher      password.PasswordHasher
+	passwordPolicy      *password.Policy
+	auditRecorder       *auditservices.Recorder
+	publisher           *outboxservices.Publisher
+}
+
+func NewChangePasswordUseCase(
+	userRepository user.UserRepository,
+	historyRepository user.PasswordHistoryRepository,
+	tokenGenerator token.TokenGenerator,
+	refreshTokenService token.Service,
+	accessTokenExpiry time.Duration,
+	passwordHasher password.PasswordHasher,
+	passwordPolicy *password.Policy,
+	auditRecorder *auditservices.Recorder,
+	publisher *outboxservices.Publisher,
+) *ChangePasswordUseCase {
+	return &ChangePasswordUseCase{
+		userRepository:      userRepository,
+		historyRepository:   historyRepository,
+		tokenGenerator:      tokenGenerator,
+		refreshTokenService: refreshTokenService,
+		accessTokenExpiry:   accessTokenExpiry,
+		passwordHasher:      passwordHasher,
+		passwordPolicy:      passwordPolicy,
+		auditRecorder:       auditRecorder,
+		publisher:           publisher,
+	}
+}
+
+type ChangePasswordInput struct {
+	UserID          uuid.UUID
+	CurrentPassword string
+	NewPassword     string
+}
+
+// Execute replaces the password after checking the current one. Every refresh
+// token of the user is revoked and a new pair is returned, so the caller stays
+// signed in while all other sessions end. A new password the policy rejects,
+// including a recent one, fails with a *password.PolicyError.
+func (u *ChangePasswordUseCase) Execute(ctx context.Context, input ChangePasswordInput) (response *RefreshTokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "ChangePasswordUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.UserID,
+			Action:     audit.ActionPasswordChange,
+			TargetType: audit.TargetUser,
+			TargetID:   input.UserID.String(),
+		}).Fail(err))
+	}()
+
+	target, err := u.userRepository.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if target.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+	if !target.HasPassword() {
+		return nil, fmt.Errorf("account has no password; sign in with Google instead")
+	}
+
+	if ok, err := verifyPassword(ctx, u.passwordHasher, input.CurrentPassword, *target.Password); err != nil || !ok {
+		return nil, fmt.Errorf("current password is incorrect")
+	}
+
+	account := password.Account{
+		Email:          target.Email,
+		Name:           target.Profile.DisplayName,
+		PreviousHashes: []string{*target.Password},
+	}
+	historySize := u.passwordPolicy.HistorySize()
+	if historySize > 0 {
+		history, err := u.historyRepository.ListRecent(ctx, target.ID, historySize)
+		if err != nil {
+			return nil, fmt.Errorf("error loading password history: %w", err)
+		}
+		account.PreviousHashes = append(account.PreviousHashes, history...)
+	}
+	if err := u.passwordPolicy.Check(ctx, input.NewPassword, account); err != nil {
+		return nil, err
+	}
+
+	hashedPassword, err := hashPassword(ctx, u.passwordHasher, input.NewPassword)
+	if err != nil {
+		return nil, fmt.Errorf("error hashing password: %w", err)
+	}
+	revoked := outbox.SessionRevokedEvent{UserID: target.ID, Reason: outbox.RevokedByPasswordChange}
+	err = u.publisher.Publish(ctx, outbox.EventSessionRevoked, revoked, func(ctx context.Context) error {
+		if err := u.userRepository.UpdatePassword(ctx, target.ID, hashedPassword); err != nil {
+			return fmt.Errorf("error updating password: %w", err)
+		}
+		if historySize > 0 {
+			if err := u.historyRepository.Add(ctx, target.ID, *target.Password, historySize); err != nil {
+				return fmt.Errorf("error recording password history: %w", err)
+			}
+		}
+
+		if err := u.refreshTokenService.RevokeAllUserTokens(ctx, target.ID); err != nil {
+			return fmt.Errorf("failed to revoke other sessions: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	accessToken, err := u.tokenGenerator.GenerateToken(target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	refreshToken, err := u.refreshTokenService.GenerateRefreshToken(ctx, target)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(u.accessTokenExpiry),
+	}, nil
+}
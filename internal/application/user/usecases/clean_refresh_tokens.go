@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type CleanRefreshTokensUseCase struct {
+	tokenRepository  domaintoken.Repository
+	revokedRetention time.Duration
+}
+
+// NewCleanRefreshTokensUseCase creates the refresh token cleanup. Revoked
+// tokens are kept for revokedRetention before they are deleted, or until
+// they expire when revokedRetention isn't positive.
+func NewCleanRefreshTokensUseCase(tokenRepository domaintoken.Repository, revokedRetention time.Duration) *CleanRefreshTokensUseCase {
+	return &CleanRefreshTokensUseCase{
+		tokenRepository:  tokenRepository,
+		revokedRetention: revokedRetention,
+	}
+}
+
+// Execute deletes expired and long-revoked refresh tokens and returns how many it deleted.
+func (u *CleanRefreshTokensUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "CleanRefreshTokensUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	deleted, err := u.tokenRepository.CleanExpired(ctx)
+	if err != nil {
+		return deleted, fmt.Errorf("error deleting expired refresh tokens: %w", err)
+	}
+	if u.revokedRetention <= 0 {
+		return deleted, nil
+	}
+
+	revoked, err := u.tokenRepository.DeleteRevokedBefore(ctx, time.Now().Add(-u.revokedRetention))
+	deleted += revoked
+	if err != nil {
+		return deleted, fmt.Errorf("error deleting revoked refresh tokens: %w", err)
+	}
+	return deleted, nil
+}
@@ -0,0 +1,78 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ConfirmEmailChangeUseCase struct {
+	userRepository user.UserRepository
+	signer         *token.EmailChangeSigner
+	auditRecorder  *auditservices.Recorder
+	publisher      *outboxservices.Publisher
+}
+
+func NewConfirmEmailChangeUseCase(userRepository user.UserRepository, signer *token.EmailChangeSigner, auditRecorder *auditservices.Recorder, publisher *outboxservices.Publisher) *ConfirmEmailChangeUseCase {
+	return &ConfirmEmailChangeUseCase{
+		userRepository: userRepository,
+		signer:         signer,
+		auditRecorder:  auditRecorder,
+		publisher:      publisher,
+	}
+}
+
+// Execute applies the email change carried by a confirmation token.
+func (u *ConfirmEmailChangeUseCase) Execute(ctx context.Context, confirmationToken string) (updated *user.User, err error) {
+	ctx, span := tracing.Start(ctx, "ConfirmEmailChangeUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	change, err := u.signer.Verify(confirmationToken)
+	if err != nil {
+		return nil, err
+	}
+	// Only tokens this service signed are recorded
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &change.UserID,
+			ActorEmail: change.CurrentEmail,
+			Action:     audit.ActionEmailChange,
+			TargetType: audit.TargetUser,
+			TargetID:   change.UserID.String(),
+			Metadata:   map[string]string{"new_email": change.NewEmail},
+		}).Fail(err))
+	}()
+
+	target, err := u.userRepository.FindByID(ctx, change.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	// Also rejects a link that was already used
+	if target.Email != change.CurrentEmail {
+		return nil, fmt.Errorf("email change token is no longer valid")
+	}
+
+	if err := ensureEmailAvailable(ctx, u.userRepository, change.NewEmail); err != nil {
+		return nil, err
+	}
+	changed := outbox.UserEvent{UserID: target.ID, Email: change.NewEmail, PreviousEmail: target.Email}
+	err = u.publisher.Publish(ctx, outbox.EventUserEmailChanged, changed, func(ctx context.Context) error {
+		if err := u.userRepository.UpdateEmail(ctx, target.ID, change.NewEmail); err != nil {
+			return fmt.Errorf("error updating email: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	target.Email = change.NewEmail
+	return target, nil
+}
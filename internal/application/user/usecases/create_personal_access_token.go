@@ -0,0 +1,80 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+)
+
+type CreatePersonalAccessTokenUseCase struct {
+	userRepository  user.UserRepository
+	tokenRepository domaintoken.PersonalAccessTokenRepository
+}
+
+func NewCreatePersonalAccessTokenUseCase(userRepository user.UserRepository, tokenRepository domaintoken.PersonalAccessTokenRepository) *CreatePersonalAccessTokenUseCase {
+	return &CreatePersonalAccessTokenUseCase{
+		userRepository:  userRepository,
+		tokenRepository: tokenRepository,
+	}
+}
+
+type CreatePersonalAccessTokenInput struct {
+	UserID    uuid.UUID
+	Name      string
+	Scopes    []string
+	ExpiresAt *time.Time
+}
+
+type CreatePersonalAccessTokenOutput struct {
+	// Token is the secret; it can't be retrieved again.
+	Token               string
+	PersonalAccessToken *domaintoken.PersonalAccessToken
+}
+
+// Execute creates a personal access token. Every scope must be covered by the
+// user's current permissions.
+func (u *CreatePersonalAccessTokenUseCase) Execute(ctx context.Context, input CreatePersonalAccessTokenInput) (_ *CreatePersonalAccessTokenOutput, err error) {
+	ctx, span := tracing.Start(ctx, "CreatePersonalAccessTokenUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	name := strings.TrimSpace(input.Name)
+	if name == "" {
+		return nil, fmt.Errorf("name is required")
+	}
+	if input.ExpiresAt != nil && !input.ExpiresAt.After(time.Now()) {
+		return nil, fmt.Errorf("expires_at must be in the future")
+	}
+
+	owner, err := u.userRepository.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	if owner.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+	permissions := owner.EffectivePermissions()
+	for _, scope := range input.Scopes {
+		if !auth.HasPermission(permissions, scope) {
+			return nil, fmt.Errorf("scope %s is not among your permissions", scope)
+		}
+	}
+
+	secret, hash, err := token.GeneratePersonalAccessToken()
+	if err != nil {
+		return nil, err
+	}
+	pat := domaintoken.NewPersonalAccessToken(owner.ID, name, hash, input.Scopes, input.ExpiresAt)
+	if err := u.tokenRepository.Create(ctx, pat); err != nil {
+		return nil, fmt.Errorf("error creating personal access token: %w", err)
+	}
+
+	return &CreatePersonalAccessTokenOutput{Token: secret, PersonalAccessToken: pat}, nil
+}
@@ -5,11 +5,16 @@ import (
 	"errors"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/samber/lo"
-	"golang.org/x/crypto/bcrypt"
 	"gorm.io/gorm"
 )
 
@@ -17,17 +22,41 @@ type CreateUserUseCase struct {
 	userRepository user.UserRepository
 	roleRepository role.Repository
 	tokenGenerator token.TokenGenerator
+	passwordHasher password.PasswordHasher
+	passwordPolicy *password.Policy
+	auditRecorder  *auditservices.Recorder
+	publisher      *outboxservices.Publisher
 }
 
-func NewCreateUserUseCase(userRepository user.UserRepository, roleRepository role.Repository, tokenGenerator token.TokenGenerator) *CreateUserUseCase {
+func NewCreateUserUseCase(
+	userRepository user.UserRepository,
+	roleRepository role.Repository,
+	tokenGenerator token.TokenGenerator,
+	passwordHasher password.PasswordHasher,
+	passwordPolicy *password.Policy,
+	auditRecorder *auditservices.Recorder,
+	publisher *outboxservices.Publisher,
+) *CreateUserUseCase {
 	return &CreateUserUseCase{
 		userRepository: userRepository,
 		roleRepository: roleRepository,
 		tokenGenerator: tokenGenerator,
+		passwordHasher: passwordHasher,
+		passwordPolicy: passwordPolicy,
+		auditRecorder:  auditRecorder,
+		publisher:      publisher,
 	}
 }
 
-func (u *CreateUserUseCase) Execute(ctx context.Context, email, password string) (string, error) {
+// Execute registers a user. A password the policy rejects fails with a
+// *password.PolicyError.
+func (u *CreateUserUseCase) Execute(ctx context.Context, email, plainPassword string) (accessToken string, err error) {
+	ctx, span := tracing.Start(ctx, "CreateUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	var newUser *user.User
+	defer func() { u.auditRecorder.Record(ctx, loginEvent(audit.ActionRegister, email, newUser).Fail(err)) }()
+
 	existingUser, err := u.userRepository.FindByEmail(ctx, email)
 	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
 		return "", fmt.Errorf("error creating user: %w", err)
@@ -37,29 +66,63 @@ func (u *CreateUserUseCase) Execute(ctx context.Context, email, password string)
 		return "", fmt.Errorf("user already exists")
 	}
 
-	hashedPassword, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err := u.passwordPolicy.Check(ctx, plainPassword, password.Account{Email: email}); err != nil {
+		return "", err
+	}
+
+	hashedPassword, err := hashPassword(ctx, u.passwordHasher, plainPassword)
 	if err != nil {
 		return "", fmt.Errorf("error hashing password: %w", err)
 	}
 
-	newUser := user.New(email, lo.ToPtr(string(hashedPassword)))
-
-	// Only assign role if RBAC is enabled
-	if u.roleRepository.IsRBACEnabled(ctx) {
-		defaultRole, err := u.roleRepository.FindOrCreateDefault(ctx)
-		if err != nil {
-			return "", fmt.Errorf("error finding default role: %w", err)
-		}
-		if defaultRole != nil {
-			newUser.RoleID = &defaultRole.ID
-			newUser.Role = defaultRole
-		}
+	created := user.New(email, lo.ToPtr(hashedPassword))
+
+	if err := assignDefaultRole(ctx, u.roleRepository, created); err != nil {
+		return "", err
 	}
 
-	err = u.userRepository.Create(ctx, newUser)
-	if err != nil {
+	if err := createUser(ctx, u.userRepository, u.publisher, created); err != nil {
 		return "", fmt.Errorf("error creating user: %w", err)
 	}
+	newUser = created
 
 	return u.tokenGenerator.GenerateToken(newUser)
 }
+
+// assignDefaultRole gives a user being signed up the default role. It does
+// nothing unless RBAC is enabled.
+func assignDefaultRole(ctx context.Context, roleRepository role.Repository, newUser *user.User) error {
+	if !roleRepository.IsRBACEnabled(ctx) {
+		return nil
+	}
+
+	defaultRole, err := roleRepository.FindOrCreateDefault(ctx)
+	if err != nil {
+		return fmt.Errorf("error finding default role: %w", err)
+	}
+	if defaultRole != nil {
+		newUser.RoleID = &defaultRole.ID
+		newUser.Role = defaultRole
+	}
+	return nil
+}
+
+// hashPassword hashes plain in a span of its own; with the password hashers
+// tuned to be slow it is the bulk of many requests
+func hashPassword(ctx context.Context, hasher password.PasswordHasher, plain string) (_ string, err error) {
+	_, span := tracing.Start(ctx, "password.Hash")
+	defer tracing.End(span, &err)
+	return hasher.Hash(plain)
+}
+
+// createUser stores a new user and publishes user.created with it
+func createUser(ctx context.Context, userRepository user.UserRepository, publisher *outboxservices.Publisher, newUser *user.User) error {
+	return publisher.Publish(ctx, outbox.EventUserCreated, userEvent(newUser), func(ctx context.Context) error {
+		return userRepository.Create(ctx, newUser)
+	})
+}
+
+// userEvent is the payload of the user lifecycle events
+func userEvent(u *user.User) outbox.UserEvent {
+	return outbox.UserEvent{UserID: u.ID, Email: u.Email}
+}
@@ -5,6 +5,7 @@ import (
 	"errors"
 	"testing"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	tokenmocks "github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token/mocks"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	rolemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/role/mocks"
@@ -22,7 +23,7 @@ func TestCreateUserUseCase_Execute_Success_WithRBAC(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -53,7 +54,7 @@ func TestCreateUserUseCase_Execute_Success_WithoutRBAC(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -76,13 +77,34 @@ func TestCreateUserUseCase_Execute_Success_WithoutRBAC(t *testing.T) {
 	mockTokenGen.AssertExpectations(t)
 }
 
+func TestCreateUserUseCase_Execute_WeakPassword(t *testing.T) {
+	// Arrange
+	mockRepo := new(usermocks.MockUserRepository)
+	mockRoleRepo := new(rolemocks.MockRoleRepository)
+	mockTokenGen := new(tokenmocks.MockTokenGenerator)
+
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
+
+	ctx := context.Background()
+	mockRepo.On("FindByEmail", ctx, "test@example.com").Return(nil, gorm.ErrRecordNotFound)
+
+	// Act
+	token, err := useCase.Execute(ctx, "test@example.com", "short")
+
+	// Assert
+	var policyErr *password.PolicyError
+	assert.ErrorAs(t, err, &policyErr)
+	assert.Empty(t, token)
+	mockRepo.AssertNotCalled(t, "Create", mock.Anything, mock.Anything)
+}
+
 func TestCreateUserUseCase_Execute_UserAlreadyExists(t *testing.T) {
 	// Arrange
 	mockRepo := new(usermocks.MockUserRepository)
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -111,7 +133,7 @@ func TestCreateUserUseCase_Execute_RepositoryError(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -137,7 +159,7 @@ func TestCreateUserUseCase_Execute_CreateError_WithRBAC(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -167,7 +189,7 @@ func TestCreateUserUseCase_Execute_TokenGenerationError_WithRBAC(t *testing.T) {
 	mockRoleRepo := new(rolemocks.MockRoleRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen)
+	useCase := NewCreateUserUseCase(mockRepo, mockRoleRepo, mockTokenGen, newBcryptHasher(t), newTestPolicy(t), nil, nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
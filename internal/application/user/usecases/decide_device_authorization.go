@@ -0,0 +1,84 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type GetDeviceAuthorizationUseCase struct {
+	deviceRepository device.Repository
+}
+
+func NewGetDeviceAuthorizationUseCase(deviceRepository device.Repository) *GetDeviceAuthorizationUseCase {
+	return &GetDeviceAuthorizationUseCase{
+		deviceRepository: deviceRepository,
+	}
+}
+
+// Execute looks up a pending device authorization by the code the user typed,
+// so the user can check which client is asking before approving.
+func (u *GetDeviceAuthorizationUseCase) Execute(ctx context.Context, userCode string) (_ *device.Authorization, err error) {
+	ctx, span := tracing.Start(ctx, "GetDeviceAuthorizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	return findPendingDeviceAuthorization(ctx, u.deviceRepository, userCode)
+}
+
+type DecideDeviceAuthorizationUseCase struct {
+	deviceRepository device.Repository
+}
+
+func NewDecideDeviceAuthorizationUseCase(deviceRepository device.Repository) *DecideDeviceAuthorizationUseCase {
+	return &DecideDeviceAuthorizationUseCase{
+		deviceRepository: deviceRepository,
+	}
+}
+
+type DecideDeviceAuthorizationInput struct {
+	UserID   uuid.UUID
+	UserCode string
+	Approve  bool
+}
+
+// Execute approves or denies a pending device authorization for the user.
+func (u *DecideDeviceAuthorizationUseCase) Execute(ctx context.Context, input DecideDeviceAuthorizationInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DecideDeviceAuthorizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	authorization, err := findPendingDeviceAuthorization(ctx, u.deviceRepository, input.UserCode)
+	if err != nil {
+		return err
+	}
+
+	status := device.StatusDenied
+	if input.Approve {
+		status = device.StatusApproved
+	}
+	if err := u.deviceRepository.Decide(ctx, authorization.ID, input.UserID, status); err != nil {
+		if errors.Is(err, device.ErrAuthorizationNotFound) {
+			return fmt.Errorf("device code was already used")
+		}
+		return fmt.Errorf("error updating device authorization: %w", err)
+	}
+	return nil
+}
+
+func findPendingDeviceAuthorization(ctx context.Context, repo device.Repository, userCode string) (*device.Authorization, error) {
+	authorization, err := repo.FindByUserCode(ctx, token.NormalizeUserCode(userCode))
+	if err != nil {
+		return nil, fmt.Errorf("invalid user code: %w", err)
+	}
+	if authorization.IsExpired() {
+		return nil, fmt.Errorf("user code has expired")
+	}
+	if authorization.Status != device.StatusPending {
+		return nil, fmt.Errorf("device code was already used")
+	}
+	return authorization, nil
+}
@@ -0,0 +1,46 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type DeleteUserUseCase struct {
+	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
+	publisher      *outboxservices.Publisher
+}
+
+func NewDeleteUserUseCase(userRepository user.UserRepository, auditRecorder *auditservices.Recorder, publisher *outboxservices.Publisher) *DeleteUserUseCase {
+	return &DeleteUserUseCase{
+		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
+		publisher:      publisher,
+	}
+}
+
+func (u *DeleteUserUseCase) Execute(ctx context.Context, input ManageUserInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() { u.auditRecorder.Record(ctx, manageEvent(audit.ActionUserDelete, input).Fail(err)) }()
+
+	target, err := findManagedUser(ctx, u.userRepository, input)
+	if err != nil {
+		return err
+	}
+
+	return u.publisher.Publish(ctx, outbox.EventUserDeleted, userEvent(target), func(ctx context.Context) error {
+		if err := u.userRepository.Delete(ctx, target.ID); err != nil {
+			return fmt.Errorf("error deleting user: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,108 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type DisableUserUseCase struct {
+	userRepository      user.UserRepository
+	refreshTokenService token.Service
+	auditRecorder       *auditservices.Recorder
+	publisher           *outboxservices.Publisher
+}
+
+func NewDisableUserUseCase(userRepository user.UserRepository, refreshTokenService token.Service, auditRecorder *auditservices.Recorder, publisher *outboxservices.Publisher) *DisableUserUseCase {
+	return &DisableUserUseCase{
+		userRepository:      userRepository,
+		refreshTokenService: refreshTokenService,
+		auditRecorder:       auditRecorder,
+		publisher:           publisher,
+	}
+}
+
+// ManageUserInput identifies the admin acting and the user acted upon.
+type ManageUserInput struct {
+	AdminUserID uuid.UUID
+	UserID      uuid.UUID
+}
+
+// Execute disables the account and revokes its refresh tokens, so the user
+// is signed out once their current access token expires.
+func (u *DisableUserUseCase) Execute(ctx context.Context, input ManageUserInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DisableUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() { u.auditRecorder.Record(ctx, manageEvent(audit.ActionUserDisable, input).Fail(err)) }()
+
+	target, err := findManagedUser(ctx, u.userRepository, input)
+	if err != nil {
+		return err
+	}
+	if target.IsDisabled() {
+		return fmt.Errorf("user is already disabled")
+	}
+
+	now := time.Now()
+	return u.publisher.Publish(ctx, outbox.EventUserDisabled, userEvent(target), func(ctx context.Context) error {
+		if err := u.userRepository.SetDisabledAt(ctx, target.ID, &now); err != nil {
+			return fmt.Errorf("error disabling user: %w", err)
+		}
+
+		if err := u.refreshTokenService.RevokeAllUserTokens(ctx, target.ID); err != nil {
+			return fmt.Errorf("error revoking refresh tokens: %w", err)
+		}
+		return nil
+	})
+}
+
+// manageEvent describes an admin action on a user account
+func manageEvent(action string, input ManageUserInput) *audit.Event {
+	return &audit.Event{
+		ActorID:    &input.AdminUserID,
+		Action:     action,
+		TargetType: audit.TargetUser,
+		TargetID:   input.UserID.String(),
+	}
+}
+
+// findManagedUser checks that an admin other than the target user is acting
+// and loads the target.
+func findManagedUser(ctx context.Context, userRepository user.UserRepository, input ManageUserInput) (*user.User, error) {
+	if err := verifyAdmin(ctx, userRepository, input.AdminUserID); err != nil {
+		return nil, err
+	}
+	if input.AdminUserID == input.UserID {
+		return nil, fmt.Errorf("admins cannot manage their own account")
+	}
+
+	target, err := userRepository.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	return target, nil
+}
+
+func verifyAdmin(ctx context.Context, userRepository user.UserRepository, adminUserID uuid.UUID) error {
+	adminUser, err := userRepository.FindByID(ctx, adminUserID)
+	if err != nil {
+		return fmt.Errorf("admin user not found: %w", err)
+	}
+
+	if !adminUser.HasRole(role.RoleAdmin) {
+		return fmt.Errorf("user does not have admin privileges")
+	}
+
+	return nil
+}
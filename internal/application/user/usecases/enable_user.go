@@ -0,0 +1,49 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type EnableUserUseCase struct {
+	userRepository user.UserRepository
+	auditRecorder  *auditservices.Recorder
+	publisher      *outboxservices.Publisher
+}
+
+func NewEnableUserUseCase(userRepository user.UserRepository, auditRecorder *auditservices.Recorder, publisher *outboxservices.Publisher) *EnableUserUseCase {
+	return &EnableUserUseCase{
+		userRepository: userRepository,
+		auditRecorder:  auditRecorder,
+		publisher:      publisher,
+	}
+}
+
+func (u *EnableUserUseCase) Execute(ctx context.Context, input ManageUserInput) (err error) {
+	ctx, span := tracing.Start(ctx, "EnableUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() { u.auditRecorder.Record(ctx, manageEvent(audit.ActionUserEnable, input).Fail(err)) }()
+
+	target, err := findManagedUser(ctx, u.userRepository, input)
+	if err != nil {
+		return err
+	}
+	if !target.IsDisabled() {
+		return fmt.Errorf("user is not disabled")
+	}
+
+	return u.publisher.Publish(ctx, outbox.EventUserEnabled, userEvent(target), func(ctx context.Context) error {
+		if err := u.userRepository.SetDisabledAt(ctx, target.ID, nil); err != nil {
+			return fmt.Errorf("error enabling user: %w", err)
+		}
+		return nil
+	})
+}
@@ -0,0 +1,159 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+const (
+	GrantTypeTokenExchange = "urn:ietf:params:oauth:grant-type:token-exchange"
+	TokenTypeAccessToken   = "urn:ietf:params:oauth:token-type:access_token"
+	TokenTypeJWT           = "urn:ietf:params:oauth:token-type:jwt"
+)
+
+// TokenValidator checks the tokens presented for an exchange.
+type TokenValidator interface {
+	ValidateTokenString(tokenString string) (*auth.CustomClaims, error)
+	ValidateServiceToken(tokenString string) (serviceName string, err error)
+}
+
+type ExchangeTokenUseCase struct {
+	userRepository user.UserRepository
+	tokenGenerator token.TokenGenerator
+	validator      TokenValidator
+	policy         *domaintoken.ExchangePolicy
+	tokenExpiry    time.Duration
+}
+
+func NewExchangeTokenUseCase(
+	userRepository user.UserRepository,
+	tokenGenerator token.TokenGenerator,
+	validator TokenValidator,
+	policy *domaintoken.ExchangePolicy,
+	tokenExpiry time.Duration,
+) *ExchangeTokenUseCase {
+	return &ExchangeTokenUseCase{
+		userRepository: userRepository,
+		tokenGenerator: tokenGenerator,
+		validator:      validator,
+		policy:         policy,
+		tokenExpiry:    tokenExpiry,
+	}
+}
+
+type ExchangeTokenInput struct {
+	SubjectToken     string
+	SubjectTokenType string
+	// ActorToken is the calling service's service token.
+	ActorToken     string
+	ActorTokenType string
+	Audience       string
+	Scopes         []string
+}
+
+type ExchangeTokenOutput struct {
+	AccessToken     string
+	IssuedTokenType string
+	ExpiresIn       int
+	Scopes          []string
+}
+
+// Execute trades a user's access token for one restricted to a single
+// audience and to the requested scopes. The calling service is identified by
+// its actor token and becomes the token's "act"; actors of the subject token
+// are kept nested below it. Every requested scope must be allowed for the
+// service by the exchange policy and held by the user.
+func (u *ExchangeTokenUseCase) Execute(ctx context.Context, input ExchangeTokenInput) (_ *ExchangeTokenOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ExchangeTokenUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if input.SubjectToken == "" || input.ActorToken == "" {
+		return nil, newOAuthError(OAuthInvalidRequest, "subject_token and actor_token are required")
+	}
+	if !isJWTTokenType(input.SubjectTokenType) || !isJWTTokenType(input.ActorTokenType) {
+		return nil, newOAuthError(OAuthInvalidRequest, "unsupported token type")
+	}
+	if input.Audience == "" {
+		return nil, newOAuthError(OAuthInvalidTarget, "audience is required")
+	}
+	if len(input.Scopes) == 0 {
+		return nil, newOAuthError(OAuthInvalidScope, "scope is required")
+	}
+
+	serviceName, err := u.validator.ValidateServiceToken(input.ActorToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthInvalidClient, "invalid actor token")
+	}
+	client, ok := u.policy.Client(serviceName)
+	if !ok {
+		return nil, newOAuthError(OAuthUnauthorizedClient, "%s may not exchange tokens", serviceName)
+	}
+	if !client.AllowsAudience(input.Audience) {
+		return nil, newOAuthError(OAuthInvalidTarget, "%s may not request tokens for %s", serviceName, input.Audience)
+	}
+
+	claims, err := u.validator.ValidateTokenString(input.SubjectToken)
+	if err != nil {
+		return nil, newOAuthError(OAuthInvalidGrant, "invalid subject token")
+	}
+	subject, err := u.userRepository.FindByID(ctx, claims.UserID)
+	if err != nil {
+		return nil, newOAuthError(OAuthInvalidGrant, "subject not found")
+	}
+	if subject.IsDisabled() {
+		return nil, newOAuthError(OAuthInvalidGrant, "subject is disabled")
+	}
+
+	delegation := &token.Delegation{
+		UserID:    subject.ID,
+		Audience:  input.Audience,
+		Actor:     &auth.Actor{Subject: serviceName, Actor: claims.Actor},
+		ExpiresAt: time.Now().Add(u.tokenExpiry),
+	}
+	if claims.ExpiresAt != nil && claims.ExpiresAt.Before(delegation.ExpiresAt) {
+		delegation.ExpiresAt = claims.ExpiresAt.Time
+	}
+	delegation.OrgID = claims.OrgID
+
+	for _, scope := range input.Scopes {
+		if !client.AllowsScope(scope) {
+			return nil, newOAuthError(OAuthInvalidScope, "%s may not request scope %s", serviceName, scope)
+		}
+
+		held := false
+		if auth.HasPermission(claims.Permissions, scope) {
+			delegation.Permissions = append(delegation.Permissions, scope)
+			held = true
+		}
+		if claims.OrgID != nil && auth.HasPermission(claims.OrgPermissions, scope) {
+			delegation.OrgPermissions = append(delegation.OrgPermissions, scope)
+			held = true
+		}
+		if !held {
+			return nil, newOAuthError(OAuthInvalidScope, "subject does not hold scope %s", scope)
+		}
+	}
+
+	accessToken, err := u.tokenGenerator.GenerateDelegatedToken(delegation)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate delegated token: %w", err)
+	}
+
+	return &ExchangeTokenOutput{
+		AccessToken:     accessToken,
+		IssuedTokenType: TokenTypeAccessToken,
+		ExpiresIn:       int(time.Until(delegation.ExpiresAt).Seconds()),
+		Scopes:          input.Scopes,
+	}, nil
+}
+
+func isJWTTokenType(tokenType string) bool {
+	return tokenType == TokenTypeAccessToken || tokenType == TokenTypeJWT
+}
@@ -0,0 +1,40 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ExpireCodesUseCase struct {
+	deviceRepository       device.Repository
+	passwordlessRepository passwordless.Repository
+}
+
+func NewExpireCodesUseCase(deviceRepository device.Repository, passwordlessRepository passwordless.Repository) *ExpireCodesUseCase {
+	return &ExpireCodesUseCase{
+		deviceRepository:       deviceRepository,
+		passwordlessRepository: passwordlessRepository,
+	}
+}
+
+// Execute deletes expired device codes and passwordless login codes and
+// links, and returns how many it deleted.
+func (u *ExpireCodesUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "ExpireCodesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	devices, err := u.deviceRepository.DeleteExpired(ctx)
+	if err != nil {
+		return devices, fmt.Errorf("error deleting expired device authorizations: %w", err)
+	}
+
+	challenges, err := u.passwordlessRepository.DeleteExpired(ctx)
+	if err != nil {
+		return devices + challenges, fmt.Errorf("error deleting expired passwordless challenges: %w", err)
+	}
+	return devices + challenges, nil
+}
@@ -0,0 +1,31 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type GetUserUseCase struct {
+	userRepository user.UserRepository
+}
+
+func NewGetUserUseCase(userRepository user.UserRepository) *GetUserUseCase {
+	return &GetUserUseCase{
+		userRepository: userRepository,
+	}
+}
+
+func (u *GetUserUseCase) Execute(ctx context.Context, userID uuid.UUID) (_ *user.User, err error) {
+	ctx, span := tracing.Start(ctx, "GetUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := u.userRepository.FindByID(ctx, userID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+	return target, nil
+}
@@ -0,0 +1,95 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ImpersonateUserUseCase struct {
+	userRepository          user.UserRepository
+	impersonationRepository impersonation.Repository
+	tokenGenerator          token.TokenGenerator
+	tokenExpiry             time.Duration
+	auditRecorder           *auditservices.Recorder
+}
+
+func NewImpersonateUserUseCase(
+	userRepository user.UserRepository,
+	impersonationRepository impersonation.Repository,
+	tokenGenerator token.TokenGenerator,
+	tokenExpiry time.Duration,
+	auditRecorder *auditservices.Recorder,
+) *ImpersonateUserUseCase {
+	return &ImpersonateUserUseCase{
+		userRepository:          userRepository,
+		impersonationRepository: impersonationRepository,
+		tokenGenerator:          tokenGenerator,
+		tokenExpiry:             tokenExpiry,
+		auditRecorder:           auditRecorder,
+	}
+}
+
+type ImpersonateUserInput struct {
+	AdminUserID uuid.UUID
+	UserID      uuid.UUID
+	Reason      string
+}
+
+type ImpersonateUserOutput struct {
+	AccessToken string
+	Session     *impersonation.Session
+}
+
+// Execute records an impersonation session and issues its token. No refresh
+// token is issued, so the session ends when the token expires.
+func (u *ImpersonateUserUseCase) Execute(ctx context.Context, input ImpersonateUserInput) (output *ImpersonateUserOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ImpersonateUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		event := manageEvent(audit.ActionImpersonate, ManageUserInput{AdminUserID: input.AdminUserID, UserID: input.UserID})
+		event.Metadata = map[string]string{"reason": input.Reason}
+		u.auditRecorder.Record(ctx, event.Fail(err))
+	}()
+
+	target, err := findManagedUser(ctx, u.userRepository, ManageUserInput{
+		AdminUserID: input.AdminUserID,
+		UserID:      input.UserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if target.HasRole(role.RoleAdmin) {
+		return nil, fmt.Errorf("admins cannot be impersonated")
+	}
+	if target.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+
+	admin, err := u.userRepository.FindByID(ctx, input.AdminUserID)
+	if err != nil {
+		return nil, fmt.Errorf("admin user not found: %w", err)
+	}
+
+	session := impersonation.NewSession(admin.ID, admin.Email, target.ID, target.Email, input.Reason, u.tokenExpiry)
+	if err := u.impersonationRepository.Create(ctx, session); err != nil {
+		return nil, fmt.Errorf("error recording impersonation session: %w", err)
+	}
+
+	accessToken, err := u.tokenGenerator.GenerateImpersonationToken(target, session)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate impersonation token: %w", err)
+	}
+
+	return &ImpersonateUserOutput{AccessToken: accessToken, Session: session}, nil
+}
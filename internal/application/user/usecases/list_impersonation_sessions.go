@@ -0,0 +1,37 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+const maxImpersonationSessions = 200
+
+type ListImpersonationSessionsUseCase struct {
+	impersonationRepository impersonation.Repository
+}
+
+func NewListImpersonationSessionsUseCase(impersonationRepository impersonation.Repository) *ListImpersonationSessionsUseCase {
+	return &ListImpersonationSessionsUseCase{
+		impersonationRepository: impersonationRepository,
+	}
+}
+
+// Execute returns the latest sessions matching the filter, at most 200.
+func (u *ListImpersonationSessionsUseCase) Execute(ctx context.Context, filter impersonation.ListFilter) (_ []impersonation.Session, err error) {
+	ctx, span := tracing.Start(ctx, "ListImpersonationSessionsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if filter.Limit <= 0 || filter.Limit > maxImpersonationSessions {
+		filter.Limit = maxImpersonationSessions
+	}
+
+	sessions, err := u.impersonationRepository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing impersonation sessions: %w", err)
+	}
+	return sessions, nil
+}
@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ListPersonalAccessTokensUseCase struct {
+	tokenRepository domaintoken.PersonalAccessTokenRepository
+}
+
+func NewListPersonalAccessTokensUseCase(tokenRepository domaintoken.PersonalAccessTokenRepository) *ListPersonalAccessTokensUseCase {
+	return &ListPersonalAccessTokensUseCase{
+		tokenRepository: tokenRepository,
+	}
+}
+
+func (u *ListPersonalAccessTokensUseCase) Execute(ctx context.Context, userID uuid.UUID) (_ []domaintoken.PersonalAccessToken, err error) {
+	ctx, span := tracing.Start(ctx, "ListPersonalAccessTokensUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	return u.tokenRepository.ListByUserID(ctx, userID)
+}
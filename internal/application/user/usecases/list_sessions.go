@@ -0,0 +1,44 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListSessionsUseCase struct {
+	userRepository  user.UserRepository
+	tokenRepository domaintoken.Repository
+}
+
+func NewListSessionsUseCase(userRepository user.UserRepository, tokenRepository domaintoken.Repository) *ListSessionsUseCase {
+	return &ListSessionsUseCase{
+		userRepository:  userRepository,
+		tokenRepository: tokenRepository,
+	}
+}
+
+// Execute returns the user's active sessions, that is their unrevoked and
+// unexpired refresh tokens, newest first.
+func (u *ListSessionsUseCase) Execute(ctx context.Context, email string) (_ []*domaintoken.RefreshToken, err error) {
+	ctx, span := tracing.Start(ctx, "ListSessionsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	tokens, err := u.tokenRepository.FindByUserID(ctx, target.ID)
+	if err != nil {
+		return nil, fmt.Errorf("error listing sessions: %w", err)
+	}
+
+	sessions := slices.DeleteFunc(tokens, func(t *domaintoken.RefreshToken) bool { return !t.IsValid() })
+	slices.SortFunc(sessions, func(a, b *domaintoken.RefreshToken) int { return b.CreatedAt.Compare(a.CreatedAt) })
+	return sessions, nil
+}
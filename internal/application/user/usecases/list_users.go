@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+const (
+	defaultUserPageSize = 50
+	maxUserPageSize     = 200
+)
+
+type ListUsersUseCase struct {
+	userRepository user.UserRepository
+}
+
+func NewListUsersUseCase(userRepository user.UserRepository) *ListUsersUseCase {
+	return &ListUsersUseCase{
+		userRepository: userRepository,
+	}
+}
+
+type ListUsersInput struct {
+	EmailPrefix   string
+	Role          string
+	Status        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// Cursor is the NextCursor of the previous page; empty starts from the beginning.
+	Cursor string
+	Limit  int
+}
+
+type ListUsersOutput struct {
+	Users []user.User
+	// NextCursor is empty on the last page.
+	NextCursor string
+}
+
+func (u *ListUsersUseCase) Execute(ctx context.Context, input ListUsersInput) (_ *ListUsersOutput, err error) {
+	ctx, span := tracing.Start(ctx, "ListUsersUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	switch input.Status {
+	case "", user.StatusActive, user.StatusDisabled:
+	default:
+		return nil, fmt.Errorf("invalid status '%s'", input.Status)
+	}
+
+	limit := input.Limit
+	if limit <= 0 {
+		limit = defaultUserPageSize
+	}
+	if limit > maxUserPageSize {
+		limit = maxUserPageSize
+	}
+
+	filter := user.ListFilter{
+		EmailPrefix:   input.EmailPrefix,
+		RoleName:      input.Role,
+		Status:        input.Status,
+		CreatedAfter:  input.CreatedAfter,
+		CreatedBefore: input.CreatedBefore,
+		// One extra row tells whether another page follows.
+		Limit: limit + 1,
+	}
+	if input.Cursor != "" {
+		cursor, err := decodeUserCursor(input.Cursor)
+		if err != nil {
+			return nil, err
+		}
+		filter.After = cursor
+	}
+
+	users, err := u.userRepository.List(ctx, filter)
+	if err != nil {
+		return nil, fmt.Errorf("error listing users: %w", err)
+	}
+
+	output := &ListUsersOutput{Users: users}
+	if len(users) > limit {
+		output.Users = users[:limit]
+		last := output.Users[limit-1]
+		output.NextCursor = encodeUserCursor(user.Cursor{CreatedAt: last.CreatedAt, ID: last.ID})
+	}
+
+	return output, nil
+}
+
+// encodeUserCursor renders a cursor as an opaque URL-safe string.
+func encodeUserCursor(c user.Cursor) string {
+	raw := fmt.Sprintf("%d:%s", c.CreatedAt.UnixNano(), c.ID)
+	return base64.RawURLEncoding.EncodeToString([]byte(raw))
+}
+
+func decodeUserCursor(s string) (*user.Cursor, error) {
+	raw, err := base64.RawURLEncoding.DecodeString(s)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	nanos, id, ok := strings.Cut(string(raw), ":")
+	if !ok {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	unixNano, err := strconv.ParseInt(nanos, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+	userID, err := uuid.Parse(id)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cursor")
+	}
+
+	return &user.Cursor{CreatedAt: time.Unix(0, unixNano).UTC(), ID: userID}, nil
+}
@@ -0,0 +1,79 @@
+package usecases
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func TestListUsersUseCase_Execute_PagesWithCursor(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockUserRepository)
+	useCase := NewListUsersUseCase(mockRepo)
+
+	ctx := context.Background()
+	createdAt := time.Date(2024, 1, 2, 3, 4, 5, 6, time.UTC)
+	users := []user.User{
+		{ID: uuid.New(), Email: "a@example.com", CreatedAt: createdAt},
+		{ID: uuid.New(), Email: "b@example.com", CreatedAt: createdAt.Add(time.Second)},
+		{ID: uuid.New(), Email: "c@example.com", CreatedAt: createdAt.Add(2 * time.Second)},
+	}
+
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f user.ListFilter) bool { return f.After == nil })).
+		Return(users, nil)
+	mockRepo.On("List", ctx, mock.MatchedBy(func(f user.ListFilter) bool {
+		return f.After != nil && f.After.ID == users[1].ID && f.After.CreatedAt.Equal(users[1].CreatedAt)
+	})).Return(users[2:], nil)
+
+	// Act
+	first, err := useCase.Execute(ctx, ListUsersInput{Limit: 2})
+	require.NoError(t, err)
+	second, err := useCase.Execute(ctx, ListUsersInput{Limit: 2, Cursor: first.NextCursor})
+	require.NoError(t, err)
+
+	// Assert
+	assert.Equal(t, users[:2], first.Users)
+	assert.NotEmpty(t, first.NextCursor)
+	assert.Equal(t, users[2:], second.Users)
+	assert.Empty(t, second.NextCursor)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListUsersUseCase_Execute_LimitsPageSize(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockUserRepository)
+	useCase := NewListUsersUseCase(mockRepo)
+
+	ctx := context.Background()
+	mockRepo.On("List", ctx, user.ListFilter{Limit: maxUserPageSize + 1}).Return([]user.User{}, nil)
+	mockRepo.On("List", ctx, user.ListFilter{Limit: defaultUserPageSize + 1}).Return([]user.User{}, nil)
+
+	// Act
+	_, errTooLarge := useCase.Execute(ctx, ListUsersInput{Limit: 10000})
+	_, errDefault := useCase.Execute(ctx, ListUsersInput{})
+
+	// Assert
+	assert.NoError(t, errTooLarge)
+	assert.NoError(t, errDefault)
+	mockRepo.AssertExpectations(t)
+}
+
+func TestListUsersUseCase_Execute_InvalidInput(t *testing.T) {
+	mockRepo := new(mocks.MockUserRepository)
+	useCase := NewListUsersUseCase(mockRepo)
+
+	_, err := useCase.Execute(context.Background(), ListUsersInput{Status: "suspended"})
+	assert.Error(t, err)
+
+	_, err = useCase.Execute(context.Background(), ListUsersInput{Cursor: "bm90LWEtY3Vyc29y"})
+	assert.Error(t, err)
+
+	mockRepo.AssertNotCalled(t, "List", mock.Anything, mock.Anything)
+}
@@ -4,33 +4,84 @@ import (
 	"context"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
-	"golang.org/x/crypto/bcrypt"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 )
 
 type LoginUserUseCase struct {
 	userRepository user.UserRepository
 	tokenGenerator token.TokenGenerator
+	passwordHasher password.PasswordHasher
+	auditRecorder  *auditservices.Recorder
 }
 
-func NewLoginUserUseCase(userRepository user.UserRepository, tokenGenerator token.TokenGenerator) *LoginUserUseCase {
+func NewLoginUserUseCase(userRepository user.UserRepository, tokenGenerator token.TokenGenerator, passwordHasher password.PasswordHasher, auditRecorder *auditservices.Recorder) *LoginUserUseCase {
 	return &LoginUserUseCase{
 		userRepository: userRepository,
 		tokenGenerator: tokenGenerator,
+		passwordHasher: passwordHasher,
+		auditRecorder:  auditRecorder,
 	}
 }
 
-func (u *LoginUserUseCase) Execute(ctx context.Context, email, password string) (string, error) {
-	user, err := u.userRepository.FindByEmail(ctx, email)
+// Execute checks the password and returns an access token. A hash made with
+// an outdated algorithm or parameters is replaced by a current one while the
+// password is at hand.
+func (u *LoginUserUseCase) Execute(ctx context.Context, email, password string) (accessToken string, err error) {
+	ctx, span := tracing.Start(ctx, "LoginUserUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	var appUser *user.User
+	defer func() { u.auditRecorder.Record(ctx, loginEvent(audit.ActionLogin, email, appUser).Fail(err)) }()
+
+	appUser, err = u.userRepository.FindByEmail(ctx, email)
 	if err != nil {
 		return "", fmt.Errorf("error finding user: %w", err)
 	}
 
-	err = bcrypt.CompareHashAndPassword([]byte(*user.Password), []byte(password))
+	if !appUser.HasPassword() {
+		return "", fmt.Errorf("invalid password: account has no password")
+	}
+	ok, err := verifyPassword(ctx, u.passwordHasher, password, *appUser.Password)
 	if err != nil {
 		return "", fmt.Errorf("invalid password: %w", err)
 	}
+	if !ok {
+		return "", fmt.Errorf("invalid password")
+	}
 
-	return u.tokenGenerator.GenerateToken(user)
+	if appUser.IsDisabled() {
+		return "", user.ErrUserDisabled
+	}
+
+	if u.passwordHasher.NeedsRehash(*appUser.Password) {
+		// The login doesn't depend on the upgrade; a failed one is retried next time
+		if rehashed, err := hashPassword(ctx, u.passwordHasher, password); err == nil {
+			_ = u.userRepository.UpdatePassword(ctx, appUser.ID, rehashed)
+		}
+	}
+
+	return u.tokenGenerator.GenerateToken(appUser)
+}
+
+// verifyPassword checks plain against encoded in a span of its own, like
+// hashPassword
+func verifyPassword(ctx context.Context, hasher password.PasswordHasher, plain, encoded string) (_ bool, err error) {
+	_, span := tracing.Start(ctx, "password.Verify")
+	defer tracing.End(span, &err)
+	return hasher.Verify(plain, encoded)
+}
+
+// loginEvent describes a sign-in attempt as email, by appUser once known
+func loginEvent(action, email string, appUser *user.User) *audit.Event {
+	event := &audit.Event{ActorEmail: email, Action: action, TargetType: audit.TargetUser}
+	if appUser != nil {
+		event.ActorID = &appUser.ID
+		event.TargetID = appUser.ID.String()
+	}
+	return event
 }
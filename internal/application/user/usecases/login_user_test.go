@@ -3,23 +3,40 @@ package usecases
 import (
 	"context"
 	"errors"
+	"strings"
 	"testing"
+	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	tokenmocks "github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token/mocks"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
 	"github.com/google/uuid"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"golang.org/x/crypto/bcrypt"
 )
 
+// newBcryptHasher hashes like the stored hashes in these tests, so logins don't rehash.
+func newBcryptHasher(t *testing.T) password.PasswordHasher {
+	params := password.DefaultParams()
+	params.Algorithm = password.Bcrypt
+	hasher, err := password.NewPasswordHasher(params)
+	require.NoError(t, err)
+	return hasher
+}
+
+func newTestPolicy(t *testing.T) *password.Policy {
+	return password.NewPolicy(password.PolicyConfig{MinLength: 8, MaxBytes: 72}, newBcryptHasher(t), nil)
+}
+
 func TestLoginUserUseCase_Execute_Success(t *testing.T) {
 	// Arrange
 	mockRepo := new(mocks.MockUserRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen)
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -52,7 +69,7 @@ func TestLoginUserUseCase_Execute_UserNotFound(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen)
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -77,7 +94,7 @@ func TestLoginUserUseCase_Execute_InvalidPassword(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen)
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -109,7 +126,7 @@ func TestLoginUserUseCase_Execute_TokenGenerationError(t *testing.T) {
 	mockRepo := new(mocks.MockUserRepository)
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 
-	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen)
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
 
 	ctx := context.Background()
 	email := "test@example.com"
@@ -136,3 +153,104 @@ func TestLoginUserUseCase_Execute_TokenGenerationError(t *testing.T) {
 	mockRepo.AssertExpectations(t)
 	mockTokenGen.AssertExpectations(t)
 }
+
+func TestLoginUserUseCase_Execute_DisabledUser(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockUserRepository)
+	mockTokenGen := new(tokenmocks.MockTokenGenerator)
+
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
+
+	ctx := context.Background()
+	email := "test@example.com"
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	hashedPasswordStr := string(hashedPassword)
+	disabledAt := time.Now()
+
+	existingUser := &user.User{
+		ID:         uuid.New(),
+		Email:      email,
+		Password:   &hashedPasswordStr,
+		DisabledAt: &disabledAt,
+	}
+
+	mockRepo.On("FindByEmail", ctx, email).Return(existingUser, nil)
+
+	// Act
+	token, err := useCase.Execute(ctx, email, password)
+
+	// Assert
+	assert.ErrorIs(t, err, user.ErrUserDisabled)
+	assert.Empty(t, token)
+	mockRepo.AssertExpectations(t)
+	mockTokenGen.AssertNotCalled(t, "GenerateToken", mock.Anything)
+}
+
+func TestLoginUserUseCase_Execute_RehashesOutdatedHash(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockUserRepository)
+	mockTokenGen := new(tokenmocks.MockTokenGenerator)
+
+	params := password.DefaultParams()
+	params.Argon2Memory = 64
+	params.Argon2Iterations = 1
+	hasher, err := password.NewPasswordHasher(params)
+	require.NoError(t, err)
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, hasher, nil)
+
+	ctx := context.Background()
+	email := "test@example.com"
+	password := "password123"
+	hashedPassword, _ := bcrypt.GenerateFromPassword([]byte(password), bcrypt.MinCost)
+	hashedPasswordStr := string(hashedPassword)
+
+	existingUser := &user.User{
+		ID:       uuid.New(),
+		Email:    email,
+		Password: &hashedPasswordStr,
+	}
+
+	var rehashed string
+	mockRepo.On("FindByEmail", ctx, email).Return(existingUser, nil)
+	mockRepo.On("UpdatePassword", ctx, existingUser.ID, mock.AnythingOfType("string")).
+		Run(func(args mock.Arguments) { rehashed = args.String(2) }).
+		Return(nil)
+	mockTokenGen.On("GenerateToken", existingUser).Return("jwt-token-here", nil)
+
+	// Act
+	_, err = useCase.Execute(ctx, email, password)
+
+	// Assert
+	assert.NoError(t, err)
+	mockRepo.AssertExpectations(t)
+	assert.True(t, strings.HasPrefix(rehashed, "$argon2id$"), rehashed)
+	ok, err := hasher.Verify(password, rehashed)
+	assert.NoError(t, err)
+	assert.True(t, ok)
+}
+
+func TestLoginUserUseCase_Execute_NoPassword(t *testing.T) {
+	// Arrange
+	mockRepo := new(mocks.MockUserRepository)
+	mockTokenGen := new(tokenmocks.MockTokenGenerator)
+
+	useCase := NewLoginUserUseCase(mockRepo, mockTokenGen, newBcryptHasher(t), nil)
+
+	ctx := context.Background()
+	email := "google@example.com"
+	existingUser := &user.User{
+		ID:    uuid.New(),
+		Email: email,
+	}
+
+	mockRepo.On("FindByEmail", ctx, email).Return(existingUser, nil)
+
+	// Act
+	token, err := useCase.Execute(ctx, email, "password123")
+
+	// Assert
+	assert.ErrorContains(t, err, "invalid password")
+	assert.Empty(t, token)
+	mockTokenGen.AssertNotCalled(t, "GenerateToken", mock.Anything)
+}
@@ -5,10 +5,14 @@ import (
 	"errors"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/oauth"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"gorm.io/gorm"
 )
 
@@ -17,6 +21,8 @@ type LoginWithGoogleUseCase struct {
 	roleRepository  role.Repository
 	tokenGenerator  token.TokenGenerator
 	googleValidator oauth.GoogleTokenValidator
+	auditRecorder   *auditservices.Recorder
+	publisher       *outboxservices.Publisher
 }
 
 func NewLoginWithGoogleUseCase(
@@ -24,40 +30,47 @@ func NewLoginWithGoogleUseCase(
 	roleRepository role.Repository,
 	tokenGenerator token.TokenGenerator,
 	googleValidator oauth.GoogleTokenValidator,
+	auditRecorder *auditservices.Recorder,
+	publisher *outboxservices.Publisher,
 ) *LoginWithGoogleUseCase {
 	return &LoginWithGoogleUseCase{
 		userRepository:  userRepository,
 		roleRepository:  roleRepository,
 		tokenGenerator:  tokenGenerator,
 		googleValidator: googleValidator,
+		auditRecorder:   auditRecorder,
+		publisher:       publisher,
 	}
 }
 
-func (u *LoginWithGoogleUseCase) Execute(ctx context.Context, idToken string) (string, error) {
+func (u *LoginWithGoogleUseCase) Execute(ctx context.Context, idToken string) (accessToken string, err error) {
+	ctx, span := tracing.Start(ctx, "LoginWithGoogleUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	var email string
+	var appUser *user.User
+	defer func() { u.auditRecorder.Record(ctx, loginEvent(audit.ActionLoginGoogle, email, appUser).Fail(err)) }()
+
 	googleUser, err := u.googleValidator.Validate(ctx, idToken)
 	if err != nil {
 		return "", fmt.Errorf("invalid google token: %w", err)
 	}
-
-	var appUser *user.User
+	email = googleUser.Email
 
 	existingUser, err := u.userRepository.FindByEmail(ctx, googleUser.Email)
 	if errors.Is(err, gorm.ErrRecordNotFound) {
 		newUser := user.New(googleUser.Email, nil)
+		newUser.Profile = user.Profile{
+			DisplayName: googleUser.Name,
+			AvatarURL:   googleUser.Picture,
+			Locale:      googleUser.Locale,
+		}
 
-		// Only assign role if RBAC is enabled
-		if u.roleRepository.IsRBACEnabled(ctx) {
-			defaultRole, err := u.roleRepository.FindOrCreateDefault(ctx)
-			if err != nil {
-				return "", fmt.Errorf("failed to find default role: %w", err)
-			}
-			if defaultRole != nil {
-				newUser.RoleID = &defaultRole.ID
-				newUser.Role = defaultRole
-			}
+		if err := assignDefaultRole(ctx, u.roleRepository, newUser); err != nil {
+			return "", err
 		}
 
-		if err := u.userRepository.Create(ctx, newUser); err != nil {
+		if err := createUser(ctx, u.userRepository, u.publisher, newUser); err != nil {
 			return "", fmt.Errorf("failed to create user: %w", err)
 		}
 		appUser = newUser
@@ -67,5 +80,9 @@ func (u *LoginWithGoogleUseCase) Execute(ctx context.Context, idToken string) (s
 		appUser = existingUser
 	}
 
+	if appUser.IsDisabled() {
+		return "", user.ErrUserDisabled
+	}
+
 	return u.tokenGenerator.GenerateToken(appUser)
 }
@@ -25,7 +25,7 @@ func TestLoginWithGoogleUseCase_Execute_NewUser_WithRBAC(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "google-id-token"
@@ -34,15 +34,19 @@ func TestLoginWithGoogleUseCase_Execute_NewUser_WithRBAC(t *testing.T) {
 	defaultRole := role.NewUserRole()
 
 	googleUser := &oauth.GoogleUser{
-		Email: email,
-		Name:  "Google User",
+		Email:   email,
+		Name:    "Google User",
+		Picture: "https://example.com/avatar.png",
+		Locale:  "pt-BR",
 	}
 
 	mockGoogleValidator.On("Validate", ctx, idToken).Return(googleUser, nil)
 	mockRepo.On("FindByEmail", ctx, email).Return(nil, gorm.ErrRecordNotFound)
 	mockRoleRepo.On("IsRBACEnabled", ctx).Return(true)
 	mockRoleRepo.On("FindOrCreateDefault", ctx).Return(defaultRole, nil)
-	mockRepo.On("Create", ctx, mock.AnythingOfType("*user.User")).Return(nil)
+	mockRepo.On("Create", ctx, mock.MatchedBy(func(u *user.User) bool {
+		return u.Profile == user.Profile{DisplayName: "Google User", AvatarURL: "https://example.com/avatar.png", Locale: "pt-BR"}
+	})).Return(nil)
 	mockTokenGen.On("GenerateToken", mock.AnythingOfType("*user.User")).Return(expectedToken, nil)
 
 	// Act
@@ -64,7 +68,7 @@ func TestLoginWithGoogleUseCase_Execute_NewUser_WithoutRBAC(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "google-id-token"
@@ -101,7 +105,7 @@ func TestLoginWithGoogleUseCase_Execute_ExistingUser(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "google-id-token"
@@ -141,7 +145,7 @@ func TestLoginWithGoogleUseCase_Execute_InvalidToken(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "invalid-token"
@@ -168,7 +172,7 @@ func TestLoginWithGoogleUseCase_Execute_CreateError_WithRBAC(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "google-id-token"
@@ -207,7 +211,7 @@ func TestLoginWithGoogleUseCase_Execute_FindByEmailError(t *testing.T) {
 	mockTokenGen := new(tokenmocks.MockTokenGenerator)
 	mockGoogleValidator := new(oauthmocks.MockGoogleTokenValidator)
 
-	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator)
+	useCase := NewLoginWithGoogleUseCase(mockRepo, mockRoleRepo, mockTokenGen, mockGoogleValidator, nil, nil)
 
 	ctx := context.Background()
 	idToken := "google-id-token"
@@ -4,8 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 	"github.com/google/uuid"
 )
 
@@ -17,40 +22,74 @@ type LogoutUseCase interface {
 type logoutUseCase struct {
 	userRepo            user.UserRepository
 	refreshTokenService token.Service
+	auditRecorder       *auditservices.Recorder
+	publisher           *outboxservices.Publisher
 }
 
 func NewLogoutUseCase(
 	userRepo user.UserRepository,
 	refreshTokenService token.Service,
+	auditRecorder *auditservices.Recorder,
+	publisher *outboxservices.Publisher,
 ) LogoutUseCase {
 	return &logoutUseCase{
 		userRepo:            userRepo,
 		refreshTokenService: refreshTokenService,
+		auditRecorder:       auditRecorder,
+		publisher:           publisher,
 	}
 }
 
-func (uc *logoutUseCase) Execute(ctx context.Context, userID string) error {
+func (uc *logoutUseCase) Execute(ctx context.Context, userID string) (err error) {
+	ctx, span := tracing.Start(ctx, "LogoutUseCase.Execute")
+	defer tracing.End(span, &err)
+
 	userUUID, err := uuid.Parse(userID)
 	if err != nil {
 		return fmt.Errorf("invalid user ID: %w", err)
 	}
+	defer func() { uc.auditRecorder.Record(ctx, logoutEvent(audit.ActionLogoutAll, userUUID).Fail(err)) }()
 
-	if err := uc.refreshTokenService.RevokeAllUserTokens(ctx, userUUID); err != nil {
-		return fmt.Errorf("failed to revoke all user tokens: %w", err)
-	}
-
-	return nil
+	revoked := outbox.SessionRevokedEvent{UserID: userUUID, Reason: outbox.RevokedByLogoutAll}
+	return uc.publisher.Publish(ctx, outbox.EventSessionRevoked, revoked, func(ctx context.Context) error {
+		if err := uc.refreshTokenService.RevokeAllUserTokens(ctx, userUUID); err != nil {
+			return fmt.Errorf("failed to revoke all user tokens: %w", err)
+		}
+		return nil
+	})
 }
 
-func (uc *logoutUseCase) LogoutSingle(ctx context.Context, refreshToken string) error {
+func (uc *logoutUseCase) LogoutSingle(ctx context.Context, refreshToken string) (err error) {
+	ctx, span := tracing.Start(ctx, "LogoutUseCase.LogoutSingle")
+	defer tracing.End(span, &err)
+
 	refreshTokenEntity, err := uc.refreshTokenService.ValidateRefreshToken(ctx, refreshToken)
 	if err != nil {
 		return fmt.Errorf("invalid refresh token: %w", err)
 	}
+	defer func() {
+		uc.auditRecorder.Record(ctx, logoutEvent(audit.ActionLogout, refreshTokenEntity.UserID).Fail(err))
+	}()
 
-	if err := uc.refreshTokenService.RevokeRefreshToken(ctx, refreshTokenEntity.ID); err != nil {
-		return fmt.Errorf("failed to revoke refresh token: %w", err)
+	revoked := outbox.SessionRevokedEvent{
+		UserID:    refreshTokenEntity.UserID,
+		SessionID: refreshTokenEntity.ID.String(),
+		Reason:    outbox.RevokedByLogout,
 	}
+	return uc.publisher.Publish(ctx, outbox.EventSessionRevoked, revoked, func(ctx context.Context) error {
+		if err := uc.refreshTokenService.RevokeRefreshToken(ctx, refreshTokenEntity.ID); err != nil {
+			return fmt.Errorf("failed to revoke refresh token: %w", err)
+		}
+		return nil
+	})
+}
 
-	return nil
+// logoutEvent describes a user signing themselves out
+func logoutEvent(action string, userID uuid.UUID) *audit.Event {
+	return &audit.Event{
+		ActorID:    &userID,
+		Action:     action,
+		TargetType: audit.TargetUser,
+		TargetID:   userID.String(),
+	}
 }
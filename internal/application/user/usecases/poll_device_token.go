@@ -0,0 +1,125 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+// slowDownStep is added to the polling interval each time a device polls too fast.
+const slowDownStep = 5 * time.Second
+
+type PollDeviceTokenUseCase struct {
+	deviceRepository    device.Repository
+	userRepository      user.UserRepository
+	tokenGenerator      token.TokenGenerator
+	refreshTokenService token.Service
+	accessTokenExpiry   time.Duration
+}
+
+func NewPollDeviceTokenUseCase(
+	deviceRepository device.Repository,
+	userRepository user.UserRepository,
+	tokenGenerator token.TokenGenerator,
+	refreshTokenService token.Service,
+	accessTokenExpiry time.Duration,
+) *PollDeviceTokenUseCase {
+	return &PollDeviceTokenUseCase{
+		deviceRepository:    deviceRepository,
+		userRepository:      userRepository,
+		tokenGenerator:      tokenGenerator,
+		refreshTokenService: refreshTokenService,
+		accessTokenExpiry:   accessTokenExpiry,
+	}
+}
+
+type PollDeviceTokenInput struct {
+	DeviceCode string
+	ClientID   string
+}
+
+// Execute answers a device's poll. Until the user decides it fails with
+// authorization_pending, or slow_down when the device polls faster than its
+// interval. Once approved it returns the same token pair as a login, and the
+// device code can't be used again.
+func (u *PollDeviceTokenUseCase) Execute(ctx context.Context, input PollDeviceTokenInput) (_ *RefreshTokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "PollDeviceTokenUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if input.DeviceCode == "" || input.ClientID == "" {
+		return nil, newOAuthError(OAuthInvalidRequest, "device_code and client_id are required")
+	}
+
+	authorization, err := u.deviceRepository.FindByDeviceCodeHash(ctx, token.HashDeviceCode(input.DeviceCode))
+	if errors.Is(err, device.ErrAuthorizationNotFound) {
+		return nil, newOAuthError(OAuthInvalidGrant, "unknown device code")
+	}
+	if err != nil {
+		return nil, fmt.Errorf("error finding device authorization: %w", err)
+	}
+	if authorization.ClientID != input.ClientID {
+		return nil, newOAuthError(OAuthInvalidGrant, "device code was issued to another client")
+	}
+	if authorization.IsExpired() {
+		return nil, newOAuthError(OAuthExpiredToken, "device code has expired")
+	}
+
+	now := time.Now()
+	interval := authorization.Interval
+	tooSoon := authorization.PolledTooSoon(now)
+	if tooSoon {
+		interval += slowDownStep
+	}
+	if err := u.deviceRepository.RecordPoll(ctx, authorization.ID, now, interval); err != nil {
+		return nil, fmt.Errorf("error recording poll: %w", err)
+	}
+	if tooSoon {
+		return nil, newOAuthError(OAuthSlowDown, "poll at most every %d seconds", int(interval.Seconds()))
+	}
+
+	switch authorization.Status {
+	case device.StatusPending:
+		return nil, newOAuthError(OAuthAuthorizationPending, "waiting for the user to approve")
+	case device.StatusDenied:
+		if err := u.deviceRepository.Delete(ctx, authorization.ID); err != nil && !errors.Is(err, device.ErrAuthorizationNotFound) {
+			return nil, fmt.Errorf("error deleting device authorization: %w", err)
+		}
+		return nil, newOAuthError(OAuthAccessDenied, "the user denied the request")
+	}
+
+	// Deleting first means a concurrent poll can't redeem the code as well
+	if err := u.deviceRepository.Delete(ctx, authorization.ID); errors.Is(err, device.ErrAuthorizationNotFound) {
+		return nil, newOAuthError(OAuthInvalidGrant, "device code was already used")
+	} else if err != nil {
+		return nil, fmt.Errorf("error deleting device authorization: %w", err)
+	}
+
+	appUser, err := u.userRepository.FindByID(ctx, *authorization.UserID)
+	if err != nil {
+		return nil, newOAuthError(OAuthInvalidGrant, "user not found")
+	}
+	if appUser.IsDisabled() {
+		return nil, newOAuthError(OAuthInvalidGrant, "user is disabled")
+	}
+
+	accessToken, err := u.tokenGenerator.GenerateToken(appUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	refreshToken, err := u.refreshTokenService.GenerateRefreshToken(ctx, appUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(u.accessTokenExpiry),
+	}, nil
+}
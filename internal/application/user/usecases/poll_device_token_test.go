@@ -0,0 +1,83 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	tokenmocks "github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	devicemocks "github.com/EduardoPPCaldas/auth-service/internal/domain/device/mocks"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user/mocks"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+func pollDeviceToken(authorization *device.Authorization, deleteErr error) (*devicemocks.MockDeviceRepository, error) {
+	mockDeviceRepo := new(devicemocks.MockDeviceRepository)
+	useCase := NewPollDeviceTokenUseCase(mockDeviceRepo, new(mocks.MockUserRepository), new(tokenmocks.MockTokenGenerator), nil, time.Hour)
+
+	ctx := context.Background()
+	mockDeviceRepo.On("FindByDeviceCodeHash", ctx, token.HashDeviceCode("device-code")).Return(authorization, nil)
+	mockDeviceRepo.On("RecordPoll", ctx, authorization.ID, mock.Anything, mock.Anything).Return(nil)
+	mockDeviceRepo.On("Delete", ctx, authorization.ID).Return(deleteErr).Maybe()
+
+	_, err := useCase.Execute(ctx, PollDeviceTokenInput{DeviceCode: "device-code", ClientID: "cli"})
+	return mockDeviceRepo, err
+}
+
+func requireOAuthError(t *testing.T, err error, code string) {
+	var oauthErr *OAuthError
+	require.True(t, errors.As(err, &oauthErr), "%v", err)
+	assert.Equal(t, code, oauthErr.Code)
+}
+
+func TestPollDeviceTokenUseCase_Execute_Pending(t *testing.T) {
+	authorization := device.NewAuthorization("hash", "WDJB-MJHT", "cli", 5*time.Second, time.Minute)
+
+	mockDeviceRepo, err := pollDeviceToken(authorization, nil)
+
+	requireOAuthError(t, err, OAuthAuthorizationPending)
+	mockDeviceRepo.AssertCalled(t, "RecordPoll", mock.Anything, authorization.ID, mock.Anything, 5*time.Second)
+}
+
+func TestPollDeviceTokenUseCase_Execute_SlowDown(t *testing.T) {
+	authorization := device.NewAuthorization("hash", "WDJB-MJHT", "cli", 5*time.Second, time.Minute)
+	lastPoll := time.Now().Add(-time.Second)
+	authorization.LastPolledAt = &lastPoll
+
+	mockDeviceRepo, err := pollDeviceToken(authorization, nil)
+
+	requireOAuthError(t, err, OAuthSlowDown)
+	mockDeviceRepo.AssertCalled(t, "RecordPoll", mock.Anything, authorization.ID, mock.Anything, 10*time.Second)
+}
+
+func TestPollDeviceTokenUseCase_Execute_Denied(t *testing.T) {
+	authorization := device.NewAuthorization("hash", "WDJB-MJHT", "cli", 5*time.Second, time.Minute)
+	authorization.Status = device.StatusDenied
+
+	mockDeviceRepo, err := pollDeviceToken(authorization, nil)
+
+	requireOAuthError(t, err, OAuthAccessDenied)
+	mockDeviceRepo.AssertCalled(t, "Delete", mock.Anything, authorization.ID)
+}
+
+func TestPollDeviceTokenUseCase_Execute_Expired(t *testing.T) {
+	authorization := device.NewAuthorization("hash", "WDJB-MJHT", "cli", 5*time.Second, -time.Second)
+
+	_, err := pollDeviceToken(authorization, nil)
+
+	requireOAuthError(t, err, OAuthExpiredToken)
+}
+
+func TestPollDeviceTokenUseCase_Execute_AlreadyRedeemed(t *testing.T) {
+	authorization := device.NewAuthorization("hash", "WDJB-MJHT", "cli", 5*time.Second, time.Minute)
+	authorization.Status = device.StatusApproved
+
+	_, err := pollDeviceToken(authorization, device.ErrAuthorizationNotFound)
+
+	requireOAuthError(t, err, OAuthInvalidGrant)
+}
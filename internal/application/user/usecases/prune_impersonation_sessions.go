@@ -0,0 +1,35 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type PruneImpersonationSessionsUseCase struct {
+	impersonationRepository impersonation.Repository
+	retention               time.Duration
+}
+
+func NewPruneImpersonationSessionsUseCase(impersonationRepository impersonation.Repository, retention time.Duration) *PruneImpersonationSessionsUseCase {
+	return &PruneImpersonationSessionsUseCase{
+		impersonationRepository: impersonationRepository,
+		retention:               retention,
+	}
+}
+
+// Execute deletes impersonation records older than the retention and
+// returns how many it deleted.
+func (u *PruneImpersonationSessionsUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "PruneImpersonationSessionsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	deleted, err := u.impersonationRepository.DeleteCreatedBefore(ctx, time.Now().Add(-u.retention))
+	if err != nil {
+		return deleted, fmt.Errorf("error pruning impersonation sessions: %w", err)
+	}
+	return deleted, nil
+}
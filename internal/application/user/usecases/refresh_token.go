@@ -7,6 +7,7 @@ import (
 
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
 )
 
 type RefreshTokenUseCase interface {
@@ -40,23 +41,29 @@ func NewRefreshTokenUseCase(
 	}
 }
 
-func (uc *refreshTokenUseCase) Execute(ctx context.Context, refreshTokenString string) (*RefreshTokenResponse, error) {
+func (uc *refreshTokenUseCase) Execute(ctx context.Context, refreshTokenString string) (_ *RefreshTokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "RefreshTokenUseCase.Execute")
+	defer tracing.End(span, &err)
+
 	refreshToken, err := uc.refreshTokenService.ValidateRefreshToken(ctx, refreshTokenString)
 	if err != nil {
 		return nil, fmt.Errorf("invalid refresh token: %w", err)
 	}
 
-	user, err := uc.userRepo.FindByID(ctx, refreshToken.UserID)
+	appUser, err := uc.userRepo.FindByID(ctx, refreshToken.UserID)
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	if appUser.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
 
-	accessToken, err := uc.tokenGenerator.GenerateToken(user)
+	accessToken, err := uc.tokenGenerator.GenerateToken(appUser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate access token: %w", err)
 	}
 
-	newRefreshToken, err := uc.refreshTokenService.GenerateRefreshToken(ctx, user)
+	newRefreshToken, err := uc.refreshTokenService.GenerateRefreshToken(ctx, appUser)
 	if err != nil {
 		return nil, fmt.Errorf("failed to generate new refresh token: %w", err)
 	}
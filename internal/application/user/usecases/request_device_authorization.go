@@ -0,0 +1,96 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+const GrantTypeDeviceCode = "urn:ietf:params:oauth:grant-type:device_code"
+
+// userCodeAttempts bounds the retries when a generated user code is taken.
+const userCodeAttempts = 5
+
+type RequestDeviceAuthorizationUseCase struct {
+	deviceRepository device.Repository
+	verificationURL  string
+	expiry           time.Duration
+	interval         time.Duration
+}
+
+func NewRequestDeviceAuthorizationUseCase(
+	deviceRepository device.Repository,
+	verificationURL string,
+	expiry time.Duration,
+	interval time.Duration,
+) *RequestDeviceAuthorizationUseCase {
+	return &RequestDeviceAuthorizationUseCase{
+		deviceRepository: deviceRepository,
+		verificationURL:  verificationURL,
+		expiry:           expiry,
+		interval:         interval,
+	}
+}
+
+type DeviceAuthorizationOutput struct {
+	DeviceCode              string
+	UserCode                string
+	VerificationURI         string
+	VerificationURIComplete string
+	ExpiresIn               int
+	Interval                int
+}
+
+// Execute starts a device login for clientID. The device shows the user code
+// and verification URI to the user and polls the token endpoint with the
+// device code until the user approves or denies it.
+func (u *RequestDeviceAuthorizationUseCase) Execute(ctx context.Context, clientID string) (_ *DeviceAuthorizationOutput, err error) {
+	ctx, span := tracing.Start(ctx, "RequestDeviceAuthorizationUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if clientID == "" {
+		return nil, newOAuthError(OAuthInvalidRequest, "client_id is required")
+	}
+
+	deviceCode, deviceCodeHash, err := token.GenerateDeviceCode()
+	if err != nil {
+		return nil, err
+	}
+
+	var userCode string
+	for attempt := 0; ; attempt++ {
+		if userCode, err = token.GenerateUserCode(); err != nil {
+			return nil, err
+		}
+		_, err = u.deviceRepository.FindByUserCode(ctx, userCode)
+		if errors.Is(err, device.ErrAuthorizationNotFound) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error checking user code: %w", err)
+		}
+		if attempt == userCodeAttempts {
+			return nil, fmt.Errorf("no free user code after %d attempts", userCodeAttempts)
+		}
+	}
+
+	authorization := device.NewAuthorization(deviceCodeHash, userCode, clientID, u.interval, u.expiry)
+	if err := u.deviceRepository.Create(ctx, authorization); err != nil {
+		return nil, fmt.Errorf("error creating device authorization: %w", err)
+	}
+
+	return &DeviceAuthorizationOutput{
+		DeviceCode:              deviceCode,
+		UserCode:                userCode,
+		VerificationURI:         u.verificationURL,
+		VerificationURIComplete: u.verificationURL + "?user_code=" + url.QueryEscape(userCode),
+		ExpiresIn:               int(u.expiry.Seconds()),
+		Interval:                int(u.interval.Seconds()),
+	}, nil
+}
@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+type RequestEmailChangeUseCase struct {
+	userRepository user.UserRepository
+	signer         *token.EmailChangeSigner
+	mailer         mail.Mailer
+	confirmURL     string
+	expiry         time.Duration
+	passwordHasher password.PasswordHasher
+}
+
+func NewRequestEmailChangeUseCase(
+	userRepository user.UserRepository,
+	signer *token.EmailChangeSigner,
+	mailer mail.Mailer,
+	confirmURL string,
+	expiry time.Duration,
+	passwordHasher password.PasswordHasher,
+) *RequestEmailChangeUseCase {
+	return &RequestEmailChangeUseCase{
+		userRepository: userRepository,
+		signer:         signer,
+		mailer:         mailer,
+		confirmURL:     confirmURL,
+		expiry:         expiry,
+		passwordHasher: passwordHasher,
+	}
+}
+
+type RequestEmailChangeInput struct {
+	UserID   uuid.UUID
+	NewEmail string
+	// CurrentPassword is required when the account has a password.
+	CurrentPassword string
+}
+
+// Execute mails a confirmation link to the new address; the email only
+// changes once that link is used. The current address is told about the
+// request.
+func (u *RequestEmailChangeUseCase) Execute(ctx context.Context, input RequestEmailChangeInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RequestEmailChangeUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := u.userRepository.FindByID(ctx, input.UserID)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+
+	if target.HasPassword() {
+		if ok, err := verifyPassword(ctx, u.passwordHasher, input.CurrentPassword, *target.Password); err != nil || !ok {
+			return fmt.Errorf("current password is incorrect")
+		}
+	}
+
+	if strings.EqualFold(target.Email, input.NewEmail) {
+		return fmt.Errorf("new email is the same as the current one")
+	}
+	if err := ensureEmailAvailable(ctx, u.userRepository, input.NewEmail); err != nil {
+		return err
+	}
+
+	change := token.EmailChange{
+		UserID:       target.ID,
+		CurrentEmail: target.Email,
+		NewEmail:     input.NewEmail,
+		ExpiresAt:    time.Now().Add(u.expiry),
+	}
+	link, err := u.confirmLink(u.signer.Sign(change))
+	if err != nil {
+		return err
+	}
+
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      change.NewEmail,
+		Subject: "Confirm your new email address",
+		Body: fmt.Sprintf(
+			"Confirm that you want to use this address for your account: %s\n\nThis link expires on %s.\n",
+			link, change.ExpiresAt.UTC().Format(time.RFC1123),
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("error sending confirmation email: %w", err)
+	}
+
+	err = u.mailer.Send(ctx, mail.Message{
+		To:      change.CurrentEmail,
+		Subject: "Your email address is being changed",
+		Body: fmt.Sprintf(
+			"A change of your account's email address to %s was requested. "+
+				"It takes effect once confirmed from the new address. "+
+				"If this wasn't you, change your password.\n",
+			change.NewEmail,
+		),
+	})
+	if err != nil {
+		return fmt.Errorf("error sending notification email: %w", err)
+	}
+
+	return nil
+}
+
+func (u *RequestEmailChangeUseCase) confirmLink(token string) (string, error) {
+	link, err := url.Parse(u.confirmURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid email confirmation URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", token)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
+
+func ensureEmailAvailable(ctx context.Context, userRepository user.UserRepository, email string) error {
+	existing, err := userRepository.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error checking email: %w", err)
+	}
+	if existing != nil {
+		return fmt.Errorf("email is already in use")
+	}
+	return nil
+}
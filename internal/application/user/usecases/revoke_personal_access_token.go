@@ -0,0 +1,26 @@
+package usecases
+
+import (
+	"context"
+
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type RevokePersonalAccessTokenUseCase struct {
+	tokenRepository domaintoken.PersonalAccessTokenRepository
+}
+
+func NewRevokePersonalAccessTokenUseCase(tokenRepository domaintoken.PersonalAccessTokenRepository) *RevokePersonalAccessTokenUseCase {
+	return &RevokePersonalAccessTokenUseCase{
+		tokenRepository: tokenRepository,
+	}
+}
+
+func (u *RevokePersonalAccessTokenUseCase) Execute(ctx context.Context, userID, tokenID uuid.UUID) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokePersonalAccessTokenUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	return u.tokenRepository.Revoke(ctx, userID, tokenID)
+}
@@ -0,0 +1,52 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type RevokeSessionsUseCase struct {
+	userRepository      user.UserRepository
+	refreshTokenService token.Service
+}
+
+func NewRevokeSessionsUseCase(userRepository user.UserRepository, refreshTokenService token.Service) *RevokeSessionsUseCase {
+	return &RevokeSessionsUseCase{
+		userRepository:      userRepository,
+		refreshTokenService: refreshTokenService,
+	}
+}
+
+// RevokeSessionsInput selects either one session or all of a user's.
+type RevokeSessionsInput struct {
+	SessionID uuid.UUID
+	Email     string
+}
+
+// Execute revokes the selected refresh tokens. Access tokens already issued
+// stay valid until they expire.
+func (u *RevokeSessionsUseCase) Execute(ctx context.Context, input RevokeSessionsInput) (err error) {
+	ctx, span := tracing.Start(ctx, "RevokeSessionsUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if input.SessionID != uuid.Nil {
+		if err := u.refreshTokenService.RevokeRefreshToken(ctx, input.SessionID); err != nil {
+			return fmt.Errorf("error revoking session: %w", err)
+		}
+		return nil
+	}
+
+	target, err := u.userRepository.FindByEmail(ctx, input.Email)
+	if err != nil {
+		return fmt.Errorf("user not found: %w", err)
+	}
+	if err := u.refreshTokenService.RevokeAllUserTokens(ctx, target.ID); err != nil {
+		return fmt.Errorf("error revoking sessions: %w", err)
+	}
+	return nil
+}
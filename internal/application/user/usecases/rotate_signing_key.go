@@ -0,0 +1,66 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"fmt"
+
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+// signingKeyBytes is the size of generated signing keys, well above the
+// 32 bytes HS256 needs.
+const signingKeyBytes = 48
+
+// RotateSigningKeyUseCase generates a replacement for JWT_SECRET. Access
+// tokens are HS256 tokens signed with that single secret; deploying the old
+// one as JWT_SECRET_PREVIOUS keeps the tokens it signed valid until they
+// expire.
+type RotateSigningKeyUseCase struct {
+	tokenRepository domaintoken.Repository
+	currentSecret   string
+}
+
+func NewRotateSigningKeyUseCase(tokenRepository domaintoken.Repository, currentSecret string) *RotateSigningKeyUseCase {
+	return &RotateSigningKeyUseCase{
+		tokenRepository: tokenRepository,
+		currentSecret:   currentSecret,
+	}
+}
+
+// RotateSigningKeyResult holds the new secret, the secret to keep verifying
+// with during the overlap, and how many sessions were revoked.
+type RotateSigningKeyResult struct {
+	Secret          string
+	PreviousSecret  string
+	RevokedSessions int64
+}
+
+// Execute returns a new random secret, with the current one as the previous
+// secret. Refresh tokens don't depend on the secret and keep working, unless
+// revokeSessions is set, which signs everyone out and drops the current
+// secret at once, as rotating after a leaked secret should.
+func (u *RotateSigningKeyUseCase) Execute(ctx context.Context, revokeSessions bool) (_ *RotateSigningKeyResult, err error) {
+	ctx, span := tracing.Start(ctx, "RotateSigningKeyUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	key := make([]byte, signingKeyBytes)
+	if _, err := rand.Read(key); err != nil {
+		return nil, fmt.Errorf("error generating signing key: %w", err)
+	}
+	result := &RotateSigningKeyResult{Secret: base64.RawURLEncoding.EncodeToString(key)}
+	if !revokeSessions {
+		result.PreviousSecret = u.currentSecret
+	}
+
+	if revokeSessions {
+		revoked, err := u.tokenRepository.RevokeAll(ctx)
+		if err != nil {
+			return nil, fmt.Errorf("error revoking sessions: %w", err)
+		}
+		result.RevokedSessions = revoked
+	}
+	return result, nil
+}
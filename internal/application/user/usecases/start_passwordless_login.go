@@ -0,0 +1,139 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"gorm.io/gorm"
+)
+
+type StartPasswordlessLoginUseCase struct {
+	challengeRepository passwordless.Repository
+	userRepository      user.UserRepository
+	mailer              mail.Mailer
+	linkURL             string
+	expiry              time.Duration
+	resendInterval      time.Duration
+	allowSignup         bool
+}
+
+func NewStartPasswordlessLoginUseCase(
+	challengeRepository passwordless.Repository,
+	userRepository user.UserRepository,
+	mailer mail.Mailer,
+	linkURL string,
+	expiry time.Duration,
+	resendInterval time.Duration,
+	allowSignup bool,
+) *StartPasswordlessLoginUseCase {
+	return &StartPasswordlessLoginUseCase{
+		challengeRepository: challengeRepository,
+		userRepository:      userRepository,
+		mailer:              mailer,
+		linkURL:             linkURL,
+		expiry:              expiry,
+		resendInterval:      resendInterval,
+		allowSignup:         allowSignup,
+	}
+}
+
+type StartPasswordlessLoginInput struct {
+	Email  string
+	Method passwordless.Method
+}
+
+// Execute mails a single-use sign-in link or code, replacing any earlier one.
+// So as not to reveal which addresses have accounts, it succeeds without
+// sending anything for disabled accounts, for unknown addresses unless
+// sign-up is allowed, and within the resend interval of the last email.
+func (u *StartPasswordlessLoginUseCase) Execute(ctx context.Context, input StartPasswordlessLoginInput) (err error) {
+	ctx, span := tracing.Start(ctx, "StartPasswordlessLoginUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if input.Method != passwordless.MethodLink && input.Method != passwordless.MethodCode {
+		return fmt.Errorf("unsupported method %q", input.Method)
+	}
+	email := strings.TrimSpace(input.Email)
+
+	appUser, err := u.userRepository.FindByEmail(ctx, email)
+	if err != nil && !errors.Is(err, gorm.ErrRecordNotFound) {
+		return fmt.Errorf("error finding user: %w", err)
+	}
+	if (appUser == nil && !u.allowSignup) || (appUser != nil && appUser.IsDisabled()) {
+		return nil
+	}
+
+	previous, err := u.challengeRepository.FindLatestByEmail(ctx, email)
+	if err != nil && !errors.Is(err, passwordless.ErrChallengeNotFound) {
+		return fmt.Errorf("error finding sign-in challenge: %w", err)
+	}
+	if previous != nil && time.Since(previous.CreatedAt) < u.resendInterval {
+		return nil
+	}
+
+	challenge := passwordless.NewChallenge(email, input.Method, u.expiry)
+	var message mail.Message
+	switch input.Method {
+	case passwordless.MethodLink:
+		linkToken, hash, err := token.GenerateLoginLinkToken()
+		if err != nil {
+			return err
+		}
+		link, err := u.signInLink(linkToken)
+		if err != nil {
+			return err
+		}
+		challenge.SecretHash = hash
+		message = mail.Message{
+			To:      email,
+			Subject: "Your sign-in link",
+			Body: fmt.Sprintf(
+				"Sign in with this link: %s\n\nIt works once and expires on %s. If you didn't ask to sign in, ignore this email.\n",
+				link, challenge.ExpiresAt.UTC().Format(time.RFC1123),
+			),
+		}
+	case passwordless.MethodCode:
+		code, err := token.GenerateLoginCode()
+		if err != nil {
+			return err
+		}
+		challenge.SecretHash = token.HashLoginCode(challenge.ID, code)
+		message = mail.Message{
+			To:      email,
+			Subject: "Your sign-in code",
+			Body: fmt.Sprintf(
+				"Your sign-in code is %s\n\nIt works once and expires on %s. If you didn't ask to sign in, ignore this email.\n",
+				code, challenge.ExpiresAt.UTC().Format(time.RFC1123),
+			),
+		}
+	}
+
+	if err := u.challengeRepository.Replace(ctx, challenge); err != nil {
+		return fmt.Errorf("error storing sign-in challenge: %w", err)
+	}
+
+	if err := u.mailer.Send(ctx, message); err != nil {
+		return fmt.Errorf("error sending sign-in email: %w", err)
+	}
+	return nil
+}
+
+func (u *StartPasswordlessLoginUseCase) signInLink(linkToken string) (string, error) {
+	link, err := url.Parse(u.linkURL)
+	if err != nil {
+		return "", fmt.Errorf("invalid sign-in link URL: %w", err)
+	}
+	query := link.Query()
+	query.Set("token", linkToken)
+	link.RawQuery = query.Encode()
+	return link.String(), nil
+}
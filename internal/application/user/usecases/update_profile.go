@@ -0,0 +1,89 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+	"golang.org/x/text/language"
+)
+
+type UpdateProfileUseCase struct {
+	userRepository user.UserRepository
+}
+
+func NewUpdateProfileUseCase(userRepository user.UserRepository) *UpdateProfileUseCase {
+	return &UpdateProfileUseCase{
+		userRepository: userRepository,
+	}
+}
+
+// UpdateProfileInput changes the profile fields that are not nil; an empty
+// string clears a field.
+type UpdateProfileInput struct {
+	UserID      uuid.UUID
+	DisplayName *string
+	AvatarURL   *string
+	Locale      *string
+	Timezone    *string
+}
+
+func (u *UpdateProfileUseCase) Execute(ctx context.Context, input UpdateProfileInput) (_ *user.User, err error) {
+	ctx, span := tracing.Start(ctx, "UpdateProfileUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	target, err := u.userRepository.FindByID(ctx, input.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("user not found: %w", err)
+	}
+
+	profile := target.Profile
+	if input.DisplayName != nil {
+		profile.DisplayName = *input.DisplayName
+	}
+	if input.AvatarURL != nil {
+		profile.AvatarURL = *input.AvatarURL
+	}
+	if input.Locale != nil {
+		profile.Locale = *input.Locale
+	}
+	if input.Timezone != nil {
+		profile.Timezone = *input.Timezone
+	}
+
+	if err := validateProfile(profile); err != nil {
+		return nil, err
+	}
+
+	if err := u.userRepository.UpdateProfile(ctx, target.ID, profile); err != nil {
+		return nil, fmt.Errorf("error updating profile: %w", err)
+	}
+
+	target.Profile = profile
+	return target, nil
+}
+
+func validateProfile(profile user.Profile) error {
+	if profile.AvatarURL != "" {
+		avatar, err := url.Parse(profile.AvatarURL)
+		if err != nil || (avatar.Scheme != "https" && avatar.Scheme != "http") || avatar.Host == "" {
+			return fmt.Errorf("avatar URL must be an absolute http(s) URL")
+		}
+	}
+	if profile.Locale != "" {
+		if _, err := language.Parse(profile.Locale); err != nil {
+			return fmt.Errorf("invalid locale '%s'", profile.Locale)
+		}
+	}
+	if profile.Timezone != "" {
+		// LoadLocation also accepts "Local", which means nothing to other machines
+		if _, err := time.LoadLocation(profile.Timezone); err != nil || profile.Timezone == "Local" {
+			return fmt.Errorf("invalid timezone '%s'", profile.Timezone)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,193 @@
+package usecases
+
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"gorm.io/gorm"
+)
+
+// ErrPasswordlessLoginFailed covers every wrong, used, expired or exhausted
+// link and code alike.
+var ErrPasswordlessLoginFailed = errors.New("invalid or expired sign-in link or code")
+
+type VerifyPasswordlessLoginUseCase struct {
+	challengeRepository passwordless.Repository
+	userRepository      user.UserRepository
+	roleRepository      role.Repository
+	tokenGenerator      token.TokenGenerator
+	refreshTokenService token.Service
+	accessTokenExpiry   time.Duration
+	maxAttempts         int
+	allowSignup         bool
+	auditRecorder       *auditservices.Recorder
+	publisher           *outboxservices.Publisher
+}
+
+func NewVerifyPasswordlessLoginUseCase(
+	challengeRepository passwordless.Repository,
+	userRepository user.UserRepository,
+	roleRepository role.Repository,
+	tokenGenerator token.TokenGenerator,
+	refreshTokenService token.Service,
+	accessTokenExpiry time.Duration,
+	maxAttempts int,
+	allowSignup bool,
+	auditRecorder *auditservices.Recorder,
+	publisher *outboxservices.Publisher,
+) *VerifyPasswordlessLoginUseCase {
+	return &VerifyPasswordlessLoginUseCase{
+		challengeRepository: challengeRepository,
+		userRepository:      userRepository,
+		roleRepository:      roleRepository,
+		tokenGenerator:      tokenGenerator,
+		refreshTokenService: refreshTokenService,
+		accessTokenExpiry:   accessTokenExpiry,
+		maxAttempts:         maxAttempts,
+		allowSignup:         allowSignup,
+		auditRecorder:       auditRecorder,
+		publisher:           publisher,
+	}
+}
+
+// VerifyPasswordlessLoginInput carries either the token from a sign-in link
+// or an email address with the code mailed to it.
+type VerifyPasswordlessLoginInput struct {
+	Token string
+	Email string
+	Code  string
+}
+
+// Execute redeems a sign-in link or code for a token pair. When sign-up is
+// allowed, an unknown address gets an account without a password and with
+// the default role.
+func (u *VerifyPasswordlessLoginUseCase) Execute(ctx context.Context, input VerifyPasswordlessLoginInput) (response *RefreshTokenResponse, err error) {
+	ctx, span := tracing.Start(ctx, "VerifyPasswordlessLoginUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	email := input.Email
+	var appUser *user.User
+	defer func() {
+		u.auditRecorder.Record(ctx, loginEvent(audit.ActionLoginPasswordless, email, appUser).Fail(err))
+	}()
+
+	challenge, err := u.redeem(ctx, input)
+	if err != nil {
+		return nil, err
+	}
+	email = challenge.Email
+
+	appUser, err = u.findOrSignUp(ctx, challenge.Email)
+	if err != nil {
+		return nil, err
+	}
+	if appUser.IsDisabled() {
+		return nil, user.ErrUserDisabled
+	}
+
+	accessToken, err := u.tokenGenerator.GenerateToken(appUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate access token: %w", err)
+	}
+	refreshToken, err := u.refreshTokenService.GenerateRefreshToken(ctx, appUser)
+	if err != nil {
+		return nil, fmt.Errorf("failed to generate refresh token: %w", err)
+	}
+
+	return &RefreshTokenResponse{
+		AccessToken:  accessToken,
+		RefreshToken: refreshToken,
+		ExpiresAt:    time.Now().Add(u.accessTokenExpiry),
+	}, nil
+}
+
+// redeem finds the challenge for the input and consumes it. Each code attempt is
+// reserved before the code is compared, and the challenge stops accepting codes
+// after maxAttempts.
+func (u *VerifyPasswordlessLoginUseCase) redeem(ctx context.Context, input VerifyPasswordlessLoginInput) (*passwordless.Challenge, error) {
+	var challenge *passwordless.Challenge
+	var err error
+
+	switch {
+	case input.Token != "":
+		challenge, err = u.challengeRepository.FindBySecretHash(ctx, token.HashLoginLinkToken(input.Token))
+		if errors.Is(err, passwordless.ErrChallengeNotFound) {
+			return nil, ErrPasswordlessLoginFailed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error finding sign-in challenge: %w", err)
+		}
+		if challenge.Method != passwordless.MethodLink {
+			return nil, ErrPasswordlessLoginFailed
+		}
+	case input.Email != "" && input.Code != "":
+		challenge, err = u.challengeRepository.FindLatestByEmail(ctx, strings.TrimSpace(input.Email))
+		if errors.Is(err, passwordless.ErrChallengeNotFound) {
+			return nil, ErrPasswordlessLoginFailed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error finding sign-in challenge: %w", err)
+		}
+		if challenge.Method != passwordless.MethodCode {
+			return nil, ErrPasswordlessLoginFailed
+		}
+		err = u.challengeRepository.ReserveAttempt(ctx, challenge.ID, u.maxAttempts)
+		if errors.Is(err, passwordless.ErrChallengeNotFound) {
+			return nil, ErrPasswordlessLoginFailed
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error reserving code attempt: %w", err)
+		}
+		hash := token.HashLoginCode(challenge.ID, strings.TrimSpace(input.Code))
+		if subtle.ConstantTimeCompare([]byte(hash), []byte(challenge.SecretHash)) != 1 {
+			return nil, ErrPasswordlessLoginFailed
+		}
+	default:
+		return nil, fmt.Errorf("token, or email and code, are required")
+	}
+
+	if challenge.IsConsumed() || challenge.IsExpired() {
+		return nil, ErrPasswordlessLoginFailed
+	}
+	if err := u.challengeRepository.Consume(ctx, challenge.ID); err != nil {
+		if errors.Is(err, passwordless.ErrChallengeNotFound) {
+			return nil, ErrPasswordlessLoginFailed
+		}
+		return nil, fmt.Errorf("error consuming sign-in challenge: %w", err)
+	}
+	return challenge, nil
+}
+
+func (u *VerifyPasswordlessLoginUseCase) findOrSignUp(ctx context.Context, email string) (*user.User, error) {
+	appUser, err := u.userRepository.FindByEmail(ctx, email)
+	if err == nil {
+		return appUser, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, fmt.Errorf("error finding user: %w", err)
+	}
+	if !u.allowSignup {
+		return nil, ErrPasswordlessLoginFailed
+	}
+
+	newUser := user.New(email, nil)
+	if err := assignDefaultRole(ctx, u.roleRepository, newUser); err != nil {
+		return nil, err
+	}
+	if err := createUser(ctx, u.userRepository, u.publisher, newUser); err != nil {
+		return nil, fmt.Errorf("error creating user: %w", err)
+	}
+	return newUser, nil
+}
@@ -0,0 +1,70 @@
+package dto
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+)
+
+// CreateWebhookRequest represents the request body for registering a
+// webhook; no event_types subscribes it to every event
+type CreateWebhookRequest struct {
+	URL        string   `json:"url" validate:"required,url,max=2048"`
+	EventTypes []string `json:"event_types"`
+}
+
+// WebhookResponse represents a webhook; Secret is only set when it is created
+type WebhookResponse struct {
+	ID         string   `json:"id"`
+	URL        string   `json:"url"`
+	EventTypes []string `json:"event_types"`
+	Secret     string   `json:"secret,omitempty"`
+	CreatedBy  string   `json:"created_by"`
+	CreatedAt  string   `json:"created_at"`
+}
+
+// WebhookDeliveryResponse represents the delivery of one event to a webhook
+type WebhookDeliveryResponse struct {
+	ID             string `json:"id"`
+	WebhookID      string `json:"webhook_id"`
+	EventID        string `json:"event_id"`
+	EventType      string `json:"event_type"`
+	Status         string `json:"status"`
+	Attempts       int    `json:"attempts"`
+	NextAttemptAt  string `json:"next_attempt_at,omitempty"`
+	LastStatusCode int    `json:"last_status_code,omitempty"`
+	LastError      string `json:"last_error,omitempty"`
+	DeliveredAt    string `json:"delivered_at,omitempty"`
+	CreatedAt      string `json:"created_at"`
+}
+
+func ToWebhookResponse(w *webhook.Webhook) WebhookResponse {
+	return WebhookResponse{
+		ID:         w.ID.String(),
+		URL:        w.URL,
+		EventTypes: w.EventTypes,
+		CreatedBy:  w.CreatedBy.String(),
+		CreatedAt:  w.CreatedAt.UTC().Format(time.RFC3339),
+	}
+}
+
+func ToWebhookDeliveryResponse(d *webhook.Delivery) WebhookDeliveryResponse {
+	response := WebhookDeliveryResponse{
+		ID:             d.ID.String(),
+		WebhookID:      d.WebhookID.String(),
+		EventID:        d.MessageID.String(),
+		EventType:      d.EventType,
+		Status:         d.Status,
+		Attempts:       d.Attempts,
+		LastStatusCode: d.LastStatusCode,
+		LastError:      d.LastError,
+		CreatedAt:      d.CreatedAt.UTC().Format(time.RFC3339),
+	}
+	if d.Status == webhook.StatusPending {
+		response.NextAttemptAt = d.NextAttemptAt.UTC().Format(time.RFC3339)
+	}
+	if d.DeliveredAt != nil {
+		response.DeliveredAt = d.DeliveredAt.UTC().Format(time.RFC3339)
+	}
+	return response
+}
@@ -0,0 +1,61 @@
+package usecases
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+	"strings"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+// SecretPrefix marks webhook signing secrets
+const SecretPrefix = "whsec_"
+
+type CreateWebhookUseCase struct {
+	webhookRepository webhook.Repository
+	auditRecorder     *auditservices.Recorder
+}
+
+func NewCreateWebhookUseCase(webhookRepository webhook.Repository, auditRecorder *auditservices.Recorder) *CreateWebhookUseCase {
+	return &CreateWebhookUseCase{
+		webhookRepository: webhookRepository,
+		auditRecorder:     auditRecorder,
+	}
+}
+
+type CreateWebhookInput struct {
+	AdminUserID uuid.UUID
+	URL         string
+	EventTypes  []string
+}
+
+// Execute registers a webhook with a new signing secret. The secret is only
+// returned here; receivers need it to verify deliveries.
+func (u *CreateWebhookUseCase) Execute(ctx context.Context, input CreateWebhookInput) (created *webhook.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "CreateWebhookUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	hook := webhook.New(strings.TrimSpace(input.URL), input.EventTypes, SecretPrefix+rand.Text(), input.AdminUserID)
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionWebhookCreate,
+			TargetType: audit.TargetWebhook,
+			TargetID:   hook.ID.String(),
+			Metadata:   map[string]string{"url": hook.URL},
+		}).Fail(err))
+	}()
+	if err := hook.Validate(); err != nil {
+		return nil, err
+	}
+
+	if err := u.webhookRepository.Create(ctx, hook); err != nil {
+		return nil, fmt.Errorf("error creating webhook: %w", err)
+	}
+	return hook, nil
+}
@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type DeleteWebhookUseCase struct {
+	webhookRepository webhook.Repository
+	auditRecorder     *auditservices.Recorder
+}
+
+func NewDeleteWebhookUseCase(webhookRepository webhook.Repository, auditRecorder *auditservices.Recorder) *DeleteWebhookUseCase {
+	return &DeleteWebhookUseCase{
+		webhookRepository: webhookRepository,
+		auditRecorder:     auditRecorder,
+	}
+}
+
+type DeleteWebhookInput struct {
+	AdminUserID uuid.UUID
+	ID          uuid.UUID
+}
+
+// Execute deletes the webhook; its pending deliveries are dropped with it.
+func (u *DeleteWebhookUseCase) Execute(ctx context.Context, input DeleteWebhookInput) (err error) {
+	ctx, span := tracing.Start(ctx, "DeleteWebhookUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionWebhookDelete,
+			TargetType: audit.TargetWebhook,
+			TargetID:   input.ID.String(),
+		}).Fail(err))
+	}()
+
+	return u.webhookRepository.Delete(ctx, input.ID)
+}
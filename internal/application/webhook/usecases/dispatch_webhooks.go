@@ -0,0 +1,187 @@
+package usecases
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+const (
+	dispatchBatchSize = 100
+	// deliveryConcurrency bounds the requests in flight, so one slow
+	// receiver doesn't hold up the others
+	deliveryConcurrency = 8
+)
+
+// Sender makes one delivery attempt. It returns the status code of the
+// response, or 0 when none was received, and an error unless the receiver
+// accepted the event. Implementations live in internal/infrastructure/webhook.
+type Sender interface {
+	Send(ctx context.Context, hook *webhook.Webhook, delivery *webhook.Delivery, message *outbox.Message) (int, error)
+}
+
+type DispatchWebhooksUseCase struct {
+	outboxRepository  outbox.Repository
+	webhookRepository webhook.Repository
+	transactor        outbox.Transactor
+	sender            Sender
+	retryPolicy       webhook.RetryPolicy
+}
+
+func NewDispatchWebhooksUseCase(
+	outboxRepository outbox.Repository,
+	webhookRepository webhook.Repository,
+	transactor outbox.Transactor,
+	sender Sender,
+	retryPolicy webhook.RetryPolicy,
+) *DispatchWebhooksUseCase {
+	return &DispatchWebhooksUseCase{
+		outboxRepository:  outboxRepository,
+		webhookRepository: webhookRepository,
+		transactor:        transactor,
+		sender:            sender,
+		retryPolicy:       retryPolicy,
+	}
+}
+
+// Execute hands new outbox messages to the webhooks subscribed to them, then
+// makes the delivery attempts that are due. It returns how many attempts it
+// made. It must not run concurrently with itself; the scheduler runs it on the
+// leader only.
+func (u *DispatchWebhooksUseCase) Execute(ctx context.Context) (_ int64, err error) {
+	ctx, span := tracing.Start(ctx, "DispatchWebhooksUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	for {
+		fannedOut, err := u.fanOut(ctx)
+		if err != nil {
+			return 0, fmt.Errorf("error dispatching outbox messages: %w", err)
+		}
+		if fannedOut < dispatchBatchSize {
+			break
+		}
+	}
+
+	return u.deliverDue(ctx)
+}
+
+// fanOut creates the deliveries of a batch of undispatched messages and marks
+// them dispatched, in one transaction so each message is fanned out once.
+func (u *DispatchWebhooksUseCase) fanOut(ctx context.Context) (int, error) {
+	var fannedOut int
+	err := u.transactor.WithinTransaction(ctx, func(ctx context.Context) error {
+		messages, err := u.outboxRepository.ListUndispatched(ctx, dispatchBatchSize)
+		if err != nil || len(messages) == 0 {
+			return err
+		}
+		hooks, err := u.webhookRepository.List(ctx)
+		if err != nil {
+			return err
+		}
+
+		ids := make([]uuid.UUID, len(messages))
+		var deliveries []webhook.Delivery
+		for i, message := range messages {
+			ids[i] = message.ID
+			for _, hook := range hooks {
+				if hook.Subscribes(message.EventType) {
+					deliveries = append(deliveries, *webhook.NewDelivery(hook.ID, message.ID, message.EventType))
+				}
+			}
+		}
+		if err := u.webhookRepository.CreateDeliveries(ctx, deliveries); err != nil {
+			return err
+		}
+		fannedOut = len(messages)
+		return u.outboxRepository.MarkDispatched(ctx, ids, time.Now())
+	})
+	return fannedOut, err
+}
+
+// deliverDue attempts a batch of due deliveries
+func (u *DispatchWebhooksUseCase) deliverDue(ctx context.Context) (int64, error) {
+	deliveries, err := u.webhookRepository.ListDueDeliveries(ctx, dispatchBatchSize)
+	if err != nil {
+		return 0, fmt.Errorf("error listing due deliveries: %w", err)
+	}
+	if len(deliveries) == 0 {
+		return 0, nil
+	}
+
+	hooks, err := u.webhookRepository.List(ctx)
+	if err != nil {
+		return 0, fmt.Errorf("error listing webhooks: %w", err)
+	}
+	hooksByID := make(map[uuid.UUID]*webhook.Webhook, len(hooks))
+	for i := range hooks {
+		hooksByID[hooks[i].ID] = &hooks[i]
+	}
+
+	messageIDs := make([]uuid.UUID, len(deliveries))
+	for i, delivery := range deliveries {
+		messageIDs[i] = delivery.MessageID
+	}
+	messages, err := u.outboxRepository.FindByIDs(ctx, messageIDs)
+	if err != nil {
+		return 0, fmt.Errorf("error loading outbox messages: %w", err)
+	}
+	messagesByID := make(map[uuid.UUID]*outbox.Message, len(messages))
+	for i := range messages {
+		messagesByID[messages[i].ID] = &messages[i]
+	}
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		attempted int64
+		errs      []error
+	)
+	slots := make(chan struct{}, deliveryConcurrency)
+	for i := range deliveries {
+		delivery := &deliveries[i]
+		hook, message := hooksByID[delivery.WebhookID], messagesByID[delivery.MessageID]
+		// The webhook was deleted since the batch was listed
+		if hook == nil || message == nil {
+			continue
+		}
+
+		slots <- struct{}{}
+		wg.Go(func() {
+			defer func() { <-slots }()
+			err := u.attempt(ctx, hook, delivery, message)
+
+			mu.Lock()
+			defer mu.Unlock()
+			attempted++
+			if err != nil {
+				errs = append(errs, err)
+			}
+		})
+	}
+	wg.Wait()
+
+	return attempted, errors.Join(errs...)
+}
+
+// attempt sends a delivery and records the outcome, scheduling a retry or
+// marking it dead when the receiver doesn't accept it
+func (u *DispatchWebhooksUseCase) attempt(ctx context.Context, hook *webhook.Webhook, delivery *webhook.Delivery, message *outbox.Message) error {
+	statusCode, err := u.sender.Send(ctx, hook, delivery, message)
+	if err != nil {
+		delivery.Fail(statusCode, err.Error(), time.Now(), u.retryPolicy)
+	} else {
+		delivery.Succeed(statusCode, time.Now())
+	}
+
+	if err := u.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return fmt.Errorf("error saving delivery %s: %w", delivery.ID, err)
+	}
+	return nil
+}
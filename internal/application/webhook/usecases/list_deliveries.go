@@ -0,0 +1,45 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+const (
+	defaultDeliveryLimit = 50
+	maxDeliveryLimit     = 500
+)
+
+type ListDeliveriesUseCase struct {
+	webhookRepository webhook.Repository
+}
+
+func NewListDeliveriesUseCase(webhookRepository webhook.Repository) *ListDeliveriesUseCase {
+	return &ListDeliveriesUseCase{
+		webhookRepository: webhookRepository,
+	}
+}
+
+// Execute lists the deliveries of a webhook, newest first.
+func (u *ListDeliveriesUseCase) Execute(ctx context.Context, filter webhook.DeliveryFilter) (_ []webhook.Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "ListDeliveriesUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	if _, err := u.webhookRepository.FindByID(ctx, filter.WebhookID); err != nil {
+		return nil, err
+	}
+	switch filter.Status {
+	case "", webhook.StatusPending, webhook.StatusDelivered, webhook.StatusDead:
+	default:
+		return nil, fmt.Errorf("unknown delivery status %q", filter.Status)
+	}
+	if filter.Limit <= 0 {
+		filter.Limit = defaultDeliveryLimit
+	}
+	filter.Limit = min(filter.Limit, maxDeliveryLimit)
+
+	return u.webhookRepository.ListDeliveries(ctx, filter)
+}
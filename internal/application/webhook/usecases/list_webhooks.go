@@ -0,0 +1,25 @@
+package usecases
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+)
+
+type ListWebhooksUseCase struct {
+	webhookRepository webhook.Repository
+}
+
+func NewListWebhooksUseCase(webhookRepository webhook.Repository) *ListWebhooksUseCase {
+	return &ListWebhooksUseCase{
+		webhookRepository: webhookRepository,
+	}
+}
+
+func (u *ListWebhooksUseCase) Execute(ctx context.Context) (_ []webhook.Webhook, err error) {
+	ctx, span := tracing.Start(ctx, "ListWebhooksUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	return u.webhookRepository.List(ctx)
+}
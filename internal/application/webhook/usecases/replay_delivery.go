@@ -0,0 +1,60 @@
+package usecases
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/google/uuid"
+)
+
+type ReplayDeliveryUseCase struct {
+	webhookRepository webhook.Repository
+	auditRecorder     *auditservices.Recorder
+}
+
+func NewReplayDeliveryUseCase(webhookRepository webhook.Repository, auditRecorder *auditservices.Recorder) *ReplayDeliveryUseCase {
+	return &ReplayDeliveryUseCase{
+		webhookRepository: webhookRepository,
+		auditRecorder:     auditRecorder,
+	}
+}
+
+type ReplayDeliveryInput struct {
+	AdminUserID uuid.UUID
+	ID          uuid.UUID
+}
+
+// Execute sends a dead or delivered delivery again on the next dispatch, with
+// a fresh set of attempts.
+func (u *ReplayDeliveryUseCase) Execute(ctx context.Context, input ReplayDeliveryInput) (replayed *webhook.Delivery, err error) {
+	ctx, span := tracing.Start(ctx, "ReplayDeliveryUseCase.Execute")
+	defer tracing.End(span, &err)
+
+	defer func() {
+		u.auditRecorder.Record(ctx, (&audit.Event{
+			ActorID:    &input.AdminUserID,
+			Action:     audit.ActionWebhookReplay,
+			TargetType: audit.TargetWebhookDelivery,
+			TargetID:   input.ID.String(),
+		}).Fail(err))
+	}()
+
+	delivery, err := u.webhookRepository.FindDelivery(ctx, input.ID)
+	if err != nil {
+		return nil, err
+	}
+	if delivery.Status == webhook.StatusPending {
+		return nil, fmt.Errorf("delivery is still pending")
+	}
+
+	delivery.Replay(time.Now())
+	if err := u.webhookRepository.UpdateDelivery(ctx, delivery); err != nil {
+		return nil, fmt.Errorf("error replaying delivery: %w", err)
+	}
+	return delivery, nil
+}
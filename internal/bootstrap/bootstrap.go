@@ -0,0 +1,80 @@
+// Package bootstrap builds the infrastructure shared by the api server and
+// the authctl admin CLI from the configuration, so both talk to the same
+// database with the same password settings.
+package bootstrap
+
+import (
+	"fmt"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/config"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/migrations"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/pwned"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// OpenDatabase connects to DATABASE_URL without migrating it
+func OpenDatabase(dbURL string) (*gorm.DB, error) {
+	if dbURL == "" {
+		return nil, fmt.Errorf("DATABASE_URL environment variable is not set")
+	}
+
+	db, err := gorm.Open(postgres.Open(dbURL), &gorm.Config{})
+	if err != nil {
+		return nil, fmt.Errorf("failed to open database: %w", err)
+	}
+	return db, nil
+}
+
+// NewMigrator creates a migrator for the embedded migrations on db
+func NewMigrator(db *gorm.DB) (*migrations.Migrator, error) {
+	sqlDB, err := db.DB()
+	if err != nil {
+		return nil, err
+	}
+	return migrations.NewMigrator(sqlDB)
+}
+
+// NewPasswordHasher builds the hasher for the configured algorithm and parameters
+func NewPasswordHasher(cfg *config.Config) (password.PasswordHasher, error) {
+	params := password.DefaultParams()
+	params.Algorithm = password.Algorithm(cfg.PasswordHashAlgorithm)
+	params.Argon2Memory = uint32(cfg.PasswordArgon2Memory)
+	params.Argon2Iterations = uint32(cfg.PasswordArgon2Iterations)
+	params.Argon2Parallelism = uint8(cfg.PasswordArgon2Parallelism)
+	params.BcryptCost = cfg.PasswordBcryptCost
+	params.ScryptLogN = uint8(cfg.PasswordScryptLogN)
+	params.PBKDF2Iterations = cfg.PasswordPBKDF2Iterations
+
+	return password.NewPasswordHasher(params)
+}
+
+// NewPasswordPolicy builds the configured policy, checking breached passwords
+// against the range API or a local hash list when one is selected.
+func NewPasswordPolicy(cfg *config.Config, hasher password.PasswordHasher) (*password.Policy, error) {
+	policyConfig := password.PolicyConfig{
+		MinLength:          cfg.PasswordMinLength,
+		MaxBytes:           cfg.PasswordMaxBytes,
+		RequireLowercase:   cfg.PasswordRequireLowercase,
+		RequireUppercase:   cfg.PasswordRequireUppercase,
+		RequireDigit:       cfg.PasswordRequireDigit,
+		RequireSymbol:      cfg.PasswordRequireSymbol,
+		RejectPersonalInfo: cfg.PasswordRejectPersonalInfo,
+		HistorySize:        cfg.PasswordHistorySize,
+	}
+
+	switch cfg.PasswordBreachCheck {
+	case "":
+		return password.NewPolicy(policyConfig, hasher, nil), nil
+	case "api":
+		return password.NewPolicy(policyConfig, hasher, pwned.NewRangeClient(cfg.PasswordBreachAPIURL)), nil
+	case "file":
+		breached, err := pwned.LoadFileRange(cfg.PasswordBreachFile)
+		if err != nil {
+			return nil, err
+		}
+		return password.NewPolicy(policyConfig, hasher, breached), nil
+	}
+	return nil, fmt.Errorf("unknown PASSWORD_BREACH_CHECK %q", cfg.PasswordBreachCheck)
+}
@@ -3,21 +3,122 @@ package config
 import (
 	"fmt"
 	"os"
+	"strconv"
+	"strings"
 	"sync"
 	"time"
 )
 
 type Config struct {
 	// Database
-	DatabaseURL string
+	DatabaseURL    string
+	MigrateOnStart bool
 
-	// Server
-	Port string
+	// Server; on shutdown the instance reports unready for ShutdownDrainDelay before it stops
+	// accepting work, then gets ShutdownTimeout to finish in-flight requests and jobs
+	Port               string
+	GRPCPort           string
+	ShutdownDrainDelay time.Duration
+	ShutdownTimeout    time.Duration
 
-	// JWT
-	JWTSecret        string
-	JWTAccessExpiry  time.Duration
-	JWTRefreshExpiry time.Duration
+	// Addresses or CIDR ranges of reverse proxies whose X-Forwarded-For header is trusted for the
+	// client IP; when empty the client IP is the connection's peer address
+	TrustedProxies []string
+
+	// Prometheus metrics, served at /metrics on Port when enabled
+	MetricsEnabled bool
+
+	// OpenTelemetry tracing; TracingExporter is none, stdout or otlp, the OTLP exporter
+	// reads the standard OTEL_EXPORTER_OTLP_* variables
+	TracingExporter    string
+	TracingSampleRatio float64
+
+	// Relationship-based authorization
+	RelationNamespacesFile string
+
+	// Organization invitations
+	InvitationAcceptURL string
+	InvitationExpiry    time.Duration
+
+	// Email change confirmation links
+	EmailChangeConfirmURL string
+	EmailChangeExpiry     time.Duration
+
+	// Admin impersonation
+	ImpersonationTokenExpiry time.Duration
+
+	// Token exchange; no service may exchange tokens when the policy file is unset.
+	// Exchanged tokens for audiences other than TokenAudience are rejected here.
+	TokenExchangePolicyFile string
+	TokenExchangeExpiry     time.Duration
+	TokenAudience           string
+
+	// Device authorization grant
+	DeviceVerificationURL string
+	DeviceCodeExpiry      time.Duration
+	DevicePollInterval    time.Duration
+
+	// Passwordless login; unknown addresses only get an account when PasswordlessSignup is set
+	PasswordlessLinkURL        string
+	PasswordlessExpiry         time.Duration
+	PasswordlessResendInterval time.Duration
+	PasswordlessMaxAttempts    int
+	PasswordlessSignup         bool
+
+	// Mail; messages are logged instead of sent when SMTPHost is empty
+	SMTPHost     string
+	SMTPPort     string
+	SMTPUsername string
+	SMTPPassword string
+	MailFrom     string
+
+	// Password hashing; hashes made with other settings are upgraded on login
+	PasswordHashAlgorithm     string
+	PasswordArgon2Memory      int
+	PasswordArgon2Iterations  int
+	PasswordArgon2Parallelism int
+	PasswordBcryptCost        int
+	PasswordScryptLogN        int
+	PasswordPBKDF2Iterations  int
+
+	// Password policy; PasswordBreachCheck is "api", "file" or empty for none
+	PasswordMinLength          int
+	PasswordMaxBytes           int
+	PasswordRequireLowercase   bool
+	PasswordRequireUppercase   bool
+	PasswordRequireDigit       bool
+	PasswordRequireSymbol      bool
+	PasswordRejectPersonalInfo bool
+	PasswordHistorySize        int
+	PasswordBreachCheck        string
+	PasswordBreachAPIURL       string
+	PasswordBreachFile         string
+
+	// Background jobs; only one replica runs them at a time, and a job with a zero interval doesn't run.
+	// Revoked refresh tokens are kept for RefreshTokenRevokedRetention, or until they expire when it's zero.
+	SchedulerEnabled               bool
+	JobRefreshTokenCleanupInterval time.Duration
+	JobCodeExpiryInterval          time.Duration
+	JobAuditPruneInterval          time.Duration
+	RefreshTokenRevokedRetention   time.Duration
+	AuditRetention                 time.Duration
+
+	// Webhooks; events are delivered by the webhook-dispatch job. Failed deliveries are retried with
+	// exponential backoff and marked dead after WebhookMaxAttempts. Dispatched events are kept for
+	// OutboxRetention so deliveries can be replayed.
+	JobWebhookDispatchInterval time.Duration
+	JobOutboxPruneInterval     time.Duration
+	WebhookTimeout             time.Duration
+	WebhookMaxAttempts         int
+	WebhookRetryBaseDelay      time.Duration
+	WebhookRetryMaxDelay       time.Duration
+	OutboxRetention            time.Duration
+
+	// JWT; tokens signed with JWTPreviousSecrets still verify, for rotating JWTSecret
+	JWTSecret          string
+	JWTPreviousSecrets []string
+	JWTAccessExpiry    time.Duration
+	JWTRefreshExpiry   time.Duration
 
 	// Google OAuth
 	GoogleClientID     string
@@ -46,18 +147,108 @@ func loadConfig() *Config {
 	if dbURL == "" {
 		panic("DATABASE_URL environment variable is required")
 	}
+	migrateOnStart := getEnvOrDefault("DATABASE_MIGRATE_ON_START", "true") == "true"
 
 	// Server
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8080"
 	}
+	grpcPort := getEnvOrDefault("GRPC_PORT", "50051")
+	shutdownDrainDelay, _ := time.ParseDuration(getEnvOrDefault("SHUTDOWN_DRAIN_DELAY", "5s"))
+	shutdownTimeout, _ := time.ParseDuration(getEnvOrDefault("SHUTDOWN_TIMEOUT", "30s"))
+	trustedProxies := splitList(os.Getenv("TRUSTED_PROXIES"))
+
+	// Relationship-based authorization
+	relationNamespacesFile := os.Getenv("RELATION_NAMESPACES_FILE")
+
+	// Organization invitations
+	invitationAcceptURL := getEnvOrDefault("INVITATION_ACCEPT_URL", fmt.Sprintf("http://localhost:%s/invitations/accept", port))
+	invitationExpiry, _ := time.ParseDuration(getEnvOrDefault("INVITATION_EXPIRY", "168h"))
+
+	// Email change confirmation links
+	emailChangeConfirmURL := getEnvOrDefault("EMAIL_CHANGE_CONFIRM_URL", fmt.Sprintf("http://localhost:%s/email/confirm", port))
+	emailChangeExpiry, _ := time.ParseDuration(getEnvOrDefault("EMAIL_CHANGE_EXPIRY", "24h"))
+
+	// Admin impersonation
+	impersonationTokenExpiry, _ := time.ParseDuration(getEnvOrDefault("IMPERSONATION_TOKEN_EXPIRY", "15m"))
+
+	// Token exchange
+	tokenExchangePolicyFile := os.Getenv("TOKEN_EXCHANGE_POLICY_FILE")
+	tokenExchangeExpiry, _ := time.ParseDuration(getEnvOrDefault("TOKEN_EXCHANGE_EXPIRY", "5m"))
+	tokenAudience := getEnvOrDefault("TOKEN_AUDIENCE", "auth-service")
+
+	// Device authorization grant
+	deviceVerificationURL := getEnvOrDefault("DEVICE_VERIFICATION_URL", fmt.Sprintf("http://localhost:%s/device", port))
+	deviceCodeExpiry, _ := time.ParseDuration(getEnvOrDefault("DEVICE_CODE_EXPIRY", "10m"))
+	devicePollInterval, _ := time.ParseDuration(getEnvOrDefault("DEVICE_POLL_INTERVAL", "5s"))
+
+	// Passwordless login
+	passwordlessLinkURL := getEnvOrDefault("PASSWORDLESS_LINK_URL", fmt.Sprintf("http://localhost:%s/passwordless", port))
+	passwordlessExpiry, _ := time.ParseDuration(getEnvOrDefault("PASSWORDLESS_EXPIRY", "15m"))
+	passwordlessResendInterval, _ := time.ParseDuration(getEnvOrDefault("PASSWORDLESS_RESEND_INTERVAL", "1m"))
+	passwordlessMaxAttempts, _ := strconv.Atoi(getEnvOrDefault("PASSWORDLESS_MAX_ATTEMPTS", "5"))
+	passwordlessSignup := os.Getenv("PASSWORDLESS_SIGNUP") == "true"
+
+	// Mail
+	smtpHost := os.Getenv("SMTP_HOST")
+	smtpPort := getEnvOrDefault("SMTP_PORT", "587")
+	smtpUsername := os.Getenv("SMTP_USERNAME")
+	smtpPassword := os.Getenv("SMTP_PASSWORD")
+	mailFrom := getEnvOrDefault("MAIL_FROM", "no-reply@localhost")
+
+	// Password hashing
+	passwordHashAlgorithm := getEnvOrDefault("PASSWORD_HASH_ALGORITHM", "argon2id")
+	passwordArgon2Memory, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_ARGON2_MEMORY_KIB", "19456"))
+	passwordArgon2Iterations, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_ARGON2_ITERATIONS", "2"))
+	passwordArgon2Parallelism, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_ARGON2_PARALLELISM", "1"))
+	passwordBcryptCost, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_BCRYPT_COST", "10"))
+	passwordScryptLogN, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_SCRYPT_LOG_N", "17"))
+	passwordPBKDF2Iterations, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_PBKDF2_ITERATIONS", "600000"))
+
+	// Password policy
+	passwordMinLength, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_MIN_LENGTH", "8"))
+	passwordMaxBytes, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_MAX_BYTES", "72"))
+	passwordRequireLowercase := os.Getenv("PASSWORD_REQUIRE_LOWERCASE") == "true"
+	passwordRequireUppercase := os.Getenv("PASSWORD_REQUIRE_UPPERCASE") == "true"
+	passwordRequireDigit := os.Getenv("PASSWORD_REQUIRE_DIGIT") == "true"
+	passwordRequireSymbol := os.Getenv("PASSWORD_REQUIRE_SYMBOL") == "true"
+	passwordRejectPersonalInfo := getEnvOrDefault("PASSWORD_REJECT_PERSONAL_INFO", "true") == "true"
+	passwordHistorySize, _ := strconv.Atoi(getEnvOrDefault("PASSWORD_HISTORY_SIZE", "5"))
+	passwordBreachCheck := os.Getenv("PASSWORD_BREACH_CHECK")
+	passwordBreachAPIURL := getEnvOrDefault("PASSWORD_BREACH_API_URL", "https://api.pwnedpasswords.com/range/")
+	passwordBreachFile := os.Getenv("PASSWORD_BREACH_FILE")
+
+	// Metrics
+	metricsEnabled := getEnvOrDefault("METRICS_ENABLED", "true") == "true"
+
+	// Tracing
+	tracingExporter := getEnvOrDefault("TRACING_EXPORTER", "none")
+	tracingSampleRatio, _ := strconv.ParseFloat(getEnvOrDefault("TRACING_SAMPLE_RATIO", "1.0"), 64)
+
+	// Background jobs
+	schedulerEnabled := getEnvOrDefault("SCHEDULER_ENABLED", "true") == "true"
+	jobRefreshTokenCleanupInterval, _ := time.ParseDuration(getEnvOrDefault("JOB_REFRESH_TOKEN_CLEANUP_INTERVAL", "1h"))
+	jobCodeExpiryInterval, _ := time.ParseDuration(getEnvOrDefault("JOB_CODE_EXPIRY_INTERVAL", "15m"))
+	jobAuditPruneInterval, _ := time.ParseDuration(getEnvOrDefault("JOB_AUDIT_PRUNE_INTERVAL", "24h"))
+	refreshTokenRevokedRetention, _ := time.ParseDuration(getEnvOrDefault("REFRESH_TOKEN_REVOKED_RETENTION", "720h"))
+	auditRetention, _ := time.ParseDuration(getEnvOrDefault("AUDIT_RETENTION", "2160h"))
+
+	// Webhooks
+	jobWebhookDispatchInterval, _ := time.ParseDuration(getEnvOrDefault("JOB_WEBHOOK_DISPATCH_INTERVAL", "5s"))
+	jobOutboxPruneInterval, _ := time.ParseDuration(getEnvOrDefault("JOB_OUTBOX_PRUNE_INTERVAL", "24h"))
+	webhookTimeout, _ := time.ParseDuration(getEnvOrDefault("WEBHOOK_TIMEOUT", "10s"))
+	webhookMaxAttempts, _ := strconv.Atoi(getEnvOrDefault("WEBHOOK_MAX_ATTEMPTS", "8"))
+	webhookRetryBaseDelay, _ := time.ParseDuration(getEnvOrDefault("WEBHOOK_RETRY_BASE_DELAY", "30s"))
+	webhookRetryMaxDelay, _ := time.ParseDuration(getEnvOrDefault("WEBHOOK_RETRY_MAX_DELAY", "6h"))
+	outboxRetention, _ := time.ParseDuration(getEnvOrDefault("OUTBOX_RETENTION", "720h"))
 
 	// JWT
 	jwtSecret := os.Getenv("JWT_SECRET")
 	if jwtSecret == "" {
 		panic("JWT_SECRET environment variable is required")
 	}
+	jwtPreviousSecrets := splitList(os.Getenv("JWT_SECRET_PREVIOUS"))
 
 	accessExpiry, _ := time.ParseDuration(getEnvOrDefault("JWT_ACCESS_EXPIRY", "24h"))
 	refreshExpiry, _ := time.ParseDuration(getEnvOrDefault("JWT_REFRESH_EXPIRY", "168h"))
@@ -75,17 +266,79 @@ func loadConfig() *Config {
 	oauthState := os.Getenv("OAUTH_STATE")
 
 	return &Config{
-		DatabaseURL:        dbURL,
-		Port:               port,
-		JWTSecret:          jwtSecret,
-		JWTRefreshSecret:   jwtRefreshSecret,
-		JWTAccessExpiry:    accessExpiry,
-		JWTRefreshExpiry:   refreshExpiry,
-		GoogleClientID:     googleClientID,
-		GoogleClientSecret: googleClientSecret,
-		GoogleRedirectURI:  googleRedirectURI,
-		GoogleCallbackURL:  googleCallbackURL,
-		OAuthState:         oauthState,
+		DatabaseURL:                    dbURL,
+		MigrateOnStart:                 migrateOnStart,
+		Port:                           port,
+		GRPCPort:                       grpcPort,
+		ShutdownDrainDelay:             shutdownDrainDelay,
+		ShutdownTimeout:                shutdownTimeout,
+		TrustedProxies:                 trustedProxies,
+		MetricsEnabled:                 metricsEnabled,
+		TracingExporter:                tracingExporter,
+		TracingSampleRatio:             tracingSampleRatio,
+		RelationNamespacesFile:         relationNamespacesFile,
+		InvitationAcceptURL:            invitationAcceptURL,
+		InvitationExpiry:               invitationExpiry,
+		EmailChangeConfirmURL:          emailChangeConfirmURL,
+		EmailChangeExpiry:              emailChangeExpiry,
+		ImpersonationTokenExpiry:       impersonationTokenExpiry,
+		TokenExchangePolicyFile:        tokenExchangePolicyFile,
+		TokenExchangeExpiry:            tokenExchangeExpiry,
+		TokenAudience:                  tokenAudience,
+		DeviceVerificationURL:          deviceVerificationURL,
+		DeviceCodeExpiry:               deviceCodeExpiry,
+		DevicePollInterval:             devicePollInterval,
+		PasswordlessLinkURL:            passwordlessLinkURL,
+		PasswordlessExpiry:             passwordlessExpiry,
+		PasswordlessResendInterval:     passwordlessResendInterval,
+		PasswordlessMaxAttempts:        passwordlessMaxAttempts,
+		PasswordlessSignup:             passwordlessSignup,
+		SMTPHost:                       smtpHost,
+		SMTPPort:                       smtpPort,
+		SMTPUsername:                   smtpUsername,
+		SMTPPassword:                   smtpPassword,
+		MailFrom:                       mailFrom,
+		PasswordHashAlgorithm:          passwordHashAlgorithm,
+		PasswordArgon2Memory:           passwordArgon2Memory,
+		PasswordArgon2Iterations:       passwordArgon2Iterations,
+		PasswordArgon2Parallelism:      passwordArgon2Parallelism,
+		PasswordBcryptCost:             passwordBcryptCost,
+		PasswordScryptLogN:             passwordScryptLogN,
+		PasswordPBKDF2Iterations:       passwordPBKDF2Iterations,
+		PasswordMinLength:              passwordMinLength,
+		PasswordMaxBytes:               passwordMaxBytes,
+		PasswordRequireLowercase:       passwordRequireLowercase,
+		PasswordRequireUppercase:       passwordRequireUppercase,
+		PasswordRequireDigit:           passwordRequireDigit,
+		PasswordRequireSymbol:          passwordRequireSymbol,
+		PasswordRejectPersonalInfo:     passwordRejectPersonalInfo,
+		PasswordHistorySize:            passwordHistorySize,
+		PasswordBreachCheck:            passwordBreachCheck,
+		PasswordBreachAPIURL:           passwordBreachAPIURL,
+		PasswordBreachFile:             passwordBreachFile,
+		SchedulerEnabled:               schedulerEnabled,
+		JobRefreshTokenCleanupInterval: jobRefreshTokenCleanupInterval,
+		JobCodeExpiryInterval:          jobCodeExpiryInterval,
+		JobAuditPruneInterval:          jobAuditPruneInterval,
+		RefreshTokenRevokedRetention:   refreshTokenRevokedRetention,
+		AuditRetention:                 auditRetention,
+		JobWebhookDispatchInterval:     jobWebhookDispatchInterval,
+		JobOutboxPruneInterval:         jobOutboxPruneInterval,
+		WebhookTimeout:                 webhookTimeout,
+		WebhookMaxAttempts:             webhookMaxAttempts,
+		WebhookRetryBaseDelay:          webhookRetryBaseDelay,
+		WebhookRetryMaxDelay:           webhookRetryMaxDelay,
+		OutboxRetention:                outboxRetention,
+		JWTSecret:                      jwtSecret,
+		JWTPreviousSecrets:             jwtPreviousSecrets,
+		JWTRefreshSecret:               jwtRefreshSecret,
+		JWTAccessExpiry:                accessExpiry,
+		JWTRefreshExpiry:               refreshExpiry,
+		GoogleClientID:                 googleClientID,
+		GoogleClientSecret:             googleClientSecret,
+		GoogleRedirectURI:              googleRedirectURI,
+		GoogleCallbackURL:              googleCallbackURL,
+		OAuthState:                     oauthState,
 	}
 }
 
@@ -108,3 +361,14 @@ func getEnvOrDefault(key, defaultValue string) string {
 	}
 	return defaultValue
 }
+
+// splitList splits a comma-separated value, dropping empty entries.
+func splitList(value string) []string {
+	var items []string
+	for item := range strings.SplitSeq(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
@@ -0,0 +1,18 @@
+package apikey
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, key *APIKey) error
+	FindByKeyHash(ctx context.Context, keyHash string) (*APIKey, error)
+	// List returns keys newest first, including revoked ones.
+	List(ctx context.Context) ([]APIKey, error)
+	// Revoke returns ErrAPIKeyNotFound when the key doesn't exist or is already revoked.
+	Revoke(ctx context.Context, id uuid.UUID) error
+	TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error
+}
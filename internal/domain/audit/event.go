@@ -0,0 +1,134 @@
+package audit
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+)
+
+const (
+	ActionRegister          = "auth.register"
+	ActionLogin             = "auth.login"
+	ActionLoginGoogle       = "auth.login_google"
+	ActionLoginPasswordless = "auth.login_passwordless"
+	ActionLogout            = "auth.logout"
+	ActionLogoutAll         = "auth.logout_all"
+	ActionPasswordChange    = "user.password_change"
+	ActionEmailChange       = "user.email_change"
+	ActionUserDisable       = "user.disable"
+	ActionUserEnable        = "user.enable"
+	ActionUserDelete        = "user.delete"
+	ActionImpersonate       = "user.impersonate"
+	ActionRoleCreate        = "role.create"
+	ActionRoleUpdate        = "role.update"
+	ActionRoleDelete        = "role.delete"
+	ActionRoleAssign        = "role.assign"
+	ActionAPIKeyCreate      = "api_key.create"
+	ActionAPIKeyRevoke      = "api_key.revoke"
+	ActionWebhookCreate     = "webhook.create"
+	ActionWebhookDelete     = "webhook.delete"
+	ActionWebhookReplay     = "webhook.replay"
+)
+
+const (
+	TargetUser            = "user"
+	TargetRole            = "role"
+	TargetAPIKey          = "api_key"
+	TargetWebhook         = "webhook"
+	TargetWebhookDelivery = "webhook_delivery"
+)
+
+// GenesisHash is the previous hash of the first event in the chain
+var GenesisHash = strings.Repeat("0", 64)
+
+// Event records a security-relevant action. Events form a hash chain: each
+// one's Hash covers its fields and the Hash of the event before it, so
+// changing, removing or reordering a stored event breaks every hash after it.
+type Event struct {
+	ID         uuid.UUID         `json:"id" gorm:"type:uuid;primary_key"`
+	Sequence   int64             `json:"sequence" gorm:"not null;uniqueIndex"`
+	OccurredAt time.Time         `json:"occurred_at" gorm:"not null;index"`
+	ActorID    *uuid.UUID        `json:"actor_id,omitempty" gorm:"type:uuid;index"`
+	ActorEmail string            `json:"actor_email,omitempty"`
+	Action     string            `json:"action" gorm:"not null;index"`
+	TargetType string            `json:"target_type,omitempty"`
+	TargetID   string            `json:"target_id,omitempty" gorm:"index"`
+	IP         string            `json:"ip,omitempty"`
+	UserAgent  string            `json:"user_agent,omitempty"`
+	Outcome    string            `json:"outcome" gorm:"not null"`
+	Metadata   map[string]string `json:"metadata,omitempty" gorm:"serializer:json"`
+	PrevHash   string            `json:"prev_hash" gorm:"not null"`
+	Hash       string            `json:"hash" gorm:"not null"`
+}
+
+func (Event) TableName() string {
+	return "audit_events"
+}
+
+// Seal places the event after prev, or first when prev is nil, and computes its hash.
+func (e *Event) Seal(prev *Event) {
+	e.Sequence = 1
+	e.PrevHash = GenesisHash
+	if prev != nil {
+		e.Sequence = prev.Sequence + 1
+		e.PrevHash = prev.Hash
+	}
+	e.Hash = e.ComputeHash()
+}
+
+// ComputeHash hashes every field but Hash. Times are hashed at microsecond
+// precision in UTC, as Postgres stores them.
+func (e *Event) ComputeHash() string {
+	actorID := ""
+	if e.ActorID != nil {
+		actorID = e.ActorID.String()
+	}
+	metadata, _ := json.Marshal(e.Metadata)
+
+	h := sha256.New()
+	for _, field := range []string{
+		strconv.FormatInt(e.Sequence, 10),
+		e.ID.String(),
+		e.OccurredAt.UTC().Truncate(time.Microsecond).Format(time.RFC3339Nano),
+		actorID,
+		e.ActorEmail,
+		e.Action,
+		e.TargetType,
+		e.TargetID,
+		e.IP,
+		e.UserAgent,
+		e.Outcome,
+		string(metadata),
+		e.PrevHash,
+	} {
+		// Length-prefixed, so moving text between fields changes the hash
+		fmt.Fprintf(h, "%d:%s", len(field), field)
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// Fail marks the event as failed with the error as its reason, or as
+// succeeded when err is nil.
+func (e *Event) Fail(err error) *Event {
+	if err == nil {
+		e.Outcome = OutcomeSuccess
+		return e
+	}
+	e.Outcome = OutcomeFailure
+	if e.Metadata == nil {
+		e.Metadata = make(map[string]string)
+	}
+	e.Metadata["error"] = err.Error()
+	return e
+}
@@ -0,0 +1,34 @@
+package audit
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Repository stores the audit log. Events are never updated; only the
+// retention job deletes the oldest ones.
+type Repository interface {
+	// Append seals the event onto the end of the chain and stores it.
+	Append(ctx context.Context, event *Event) error
+	// List returns events newest first.
+	List(ctx context.Context, filter Filter) ([]Event, error)
+	// Walk calls fn with the matching events oldest first, loading them in batches.
+	Walk(ctx context.Context, filter Filter, fn func(Event) error) error
+	// DeleteBefore deletes events that occurred before the given time and returns how many it deleted.
+	DeleteBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Filter narrows an event listing. Zero-valued fields don't filter.
+type Filter struct {
+	ActorID  uuid.UUID
+	Action   string
+	TargetID string
+	Outcome  string
+	From     time.Time
+	To       time.Time
+	// BeforeSequence pages a listing: only events older than it are returned.
+	BeforeSequence int64
+	Limit          int
+}
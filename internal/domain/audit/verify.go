@@ -0,0 +1,78 @@
+package audit
+
+import "fmt"
+
+// ChainError reports the first event that doesn't fit the chain
+type ChainError struct {
+	Sequence int64
+	Reason   string
+}
+
+func (e *ChainError) Error() string {
+	return fmt.Sprintf("audit chain broken at event %d: %s", e.Sequence, e.Reason)
+}
+
+// Anchor is a chain head recorded outside the database. Since an event's hash
+// covers every event before it, a chain that still holds the anchored event
+// with the same hash wasn't rewritten, truncated or pruned past that point.
+type Anchor struct {
+	Sequence int64
+	Hash     string
+}
+
+// Verifier checks events, fed oldest first, against the hash chain. The first
+// event is trusted to follow the one before it, so a chain whose oldest
+// events were pruned by retention still verifies; an Anchor catches events
+// removed from either end.
+type Verifier struct {
+	Anchor   *Anchor
+	prev     *Event
+	anchored bool
+	Checked  int64
+}
+
+// Check verifies the next event; it returns a *ChainError when it doesn't fit.
+func (v *Verifier) Check(e Event) error {
+	if e.Hash != e.ComputeHash() {
+		return &ChainError{Sequence: e.Sequence, Reason: "hash doesn't match its contents"}
+	}
+	if v.prev != nil {
+		if e.Sequence != v.prev.Sequence+1 {
+			return &ChainError{Sequence: e.Sequence, Reason: fmt.Sprintf("follows event %d", v.prev.Sequence)}
+		}
+		if e.PrevHash != v.prev.Hash {
+			return &ChainError{Sequence: e.Sequence, Reason: "previous hash doesn't match the event before it"}
+		}
+	} else if e.Sequence == 1 && e.PrevHash != GenesisHash {
+		return &ChainError{Sequence: e.Sequence, Reason: "first event doesn't start the chain"}
+	}
+
+	if v.Anchor != nil && e.Sequence == v.Anchor.Sequence {
+		if e.Hash != v.Anchor.Hash {
+			return &ChainError{Sequence: e.Sequence, Reason: "hash doesn't match the anchor"}
+		}
+		v.anchored = true
+	}
+
+	v.prev = &e
+	v.Checked++
+	return nil
+}
+
+// Finish is called after the last event; it returns a *ChainError when the
+// anchored event wasn't among those checked.
+func (v *Verifier) Finish() error {
+	if v.Anchor != nil && !v.anchored {
+		return &ChainError{Sequence: v.Anchor.Sequence, Reason: "anchored event is missing"}
+	}
+	return nil
+}
+
+// Head returns the newest event checked as an anchor for later checks, or
+// nil when there were none.
+func (v *Verifier) Head() *Anchor {
+	if v.prev == nil {
+		return nil
+	}
+	return &Anchor{Sequence: v.prev.Sequence, Hash: v.prev.Hash}
+}
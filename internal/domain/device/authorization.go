@@ -0,0 +1,62 @@
+package device
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Status string
+
+const (
+	StatusPending  Status = "pending"
+	StatusApproved Status = "approved"
+	StatusDenied   Status = "denied"
+)
+
+var ErrAuthorizationNotFound = errors.New("device authorization not found")
+
+// Authorization is a pending device login (RFC 8628). The device polls with
+// the device code, stored hashed, while the user enters the short user code
+// on another device to approve or deny it.
+type Authorization struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	DeviceCodeHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	UserCode       string     `json:"user_code" gorm:"not null;uniqueIndex"`
+	ClientID       string     `json:"client_id" gorm:"not null"`
+	Status         Status     `json:"status" gorm:"not null"`
+	UserID         *uuid.UUID `json:"user_id,omitempty" gorm:"type:uuid"`
+	// Interval is the minimum time between polls; it grows when the device polls too fast.
+	Interval     time.Duration `json:"interval" gorm:"not null"`
+	LastPolledAt *time.Time    `json:"last_polled_at,omitempty"`
+	ExpiresAt    time.Time     `json:"expires_at" gorm:"not null;index"`
+	CreatedAt    time.Time     `json:"created_at" gorm:"not null"`
+}
+
+func (Authorization) TableName() string {
+	return "device_authorizations"
+}
+
+func NewAuthorization(deviceCodeHash, userCode, clientID string, interval, ttl time.Duration) *Authorization {
+	now := time.Now()
+	return &Authorization{
+		ID:             uuid.New(),
+		DeviceCodeHash: deviceCodeHash,
+		UserCode:       userCode,
+		ClientID:       clientID,
+		Status:         StatusPending,
+		Interval:       interval,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}
+}
+
+func (a *Authorization) IsExpired() bool {
+	return time.Now().After(a.ExpiresAt)
+}
+
+// PolledTooSoon reports whether a poll at now comes before the interval has elapsed.
+func (a *Authorization) PolledTooSoon(now time.Time) bool {
+	return a.LastPolledAt != nil && now.Sub(*a.LastPolledAt) < a.Interval
+}
@@ -0,0 +1,55 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockDeviceRepository struct {
+	mock.Mock
+}
+
+func (m *MockDeviceRepository) Create(ctx context.Context, authorization *device.Authorization) error {
+	args := m.Called(ctx, authorization)
+	return args.Error(0)
+}
+
+func (m *MockDeviceRepository) FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*device.Authorization, error) {
+	args := m.Called(ctx, deviceCodeHash)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*device.Authorization), args.Error(1)
+}
+
+func (m *MockDeviceRepository) FindByUserCode(ctx context.Context, userCode string) (*device.Authorization, error) {
+	args := m.Called(ctx, userCode)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*device.Authorization), args.Error(1)
+}
+
+func (m *MockDeviceRepository) Decide(ctx context.Context, id uuid.UUID, userID uuid.UUID, status device.Status) error {
+	args := m.Called(ctx, id, userID, status)
+	return args.Error(0)
+}
+
+func (m *MockDeviceRepository) RecordPoll(ctx context.Context, id uuid.UUID, polledAt time.Time, interval time.Duration) error {
+	args := m.Called(ctx, id, polledAt, interval)
+	return args.Error(0)
+}
+
+func (m *MockDeviceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockDeviceRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(int64), args.Error(1)
+}
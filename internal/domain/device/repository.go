@@ -0,0 +1,23 @@
+package device
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, authorization *Authorization) error
+	FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*Authorization, error)
+	FindByUserCode(ctx context.Context, userCode string) (*Authorization, error)
+	// Decide moves a pending authorization to status on behalf of userID.
+	// It returns ErrAuthorizationNotFound when the authorization is no longer pending.
+	Decide(ctx context.Context, id uuid.UUID, userID uuid.UUID, status Status) error
+	RecordPoll(ctx context.Context, id uuid.UUID, polledAt time.Time, interval time.Duration) error
+	// Delete returns ErrAuthorizationNotFound when nothing was deleted, so
+	// concurrent polls can't both redeem an approved authorization.
+	Delete(ctx context.Context, id uuid.UUID) error
+	// DeleteExpired deletes authorizations past their expiry and returns how many it deleted.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
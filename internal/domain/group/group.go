@@ -0,0 +1,49 @@
+package group
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+)
+
+// Group collects users so roles can be granted to all of them at once.
+// Members hold the group's roles in addition to their own.
+type Group struct {
+	ID          uuid.UUID   `json:"id" gorm:"type:uuid;primary_key"`
+	Name        string      `json:"name" gorm:"uniqueIndex;not null"`
+	Description string      `json:"description"`
+	Roles       []role.Role `json:"roles" gorm:"many2many:group_roles"`
+	CreatedAt   time.Time   `json:"created_at"`
+	UpdatedAt   time.Time   `json:"updated_at"`
+}
+
+// Member is a row of the group_members join table behind user.User.Groups.
+type Member struct {
+	UserID    uuid.UUID `json:"user_id" gorm:"type:uuid;primaryKey"`
+	GroupID   uuid.UUID `json:"group_id" gorm:"type:uuid;primaryKey;index"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func (Member) TableName() string {
+	return "group_members"
+}
+
+func New(name, description string) *Group {
+	return &Group{
+		ID:          uuid.New(),
+		Name:        name,
+		Description: description,
+		CreatedAt:   time.Now(),
+		UpdatedAt:   time.Now(),
+	}
+}
+
+func (g *Group) HasRole(roleID uuid.UUID) bool {
+	for _, r := range g.Roles {
+		if r.ID == roleID {
+			return true
+		}
+	}
+	return false
+}
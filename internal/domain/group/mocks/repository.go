@@ -0,0 +1,72 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockGroupRepository struct {
+	mock.Mock
+}
+
+func (m *MockGroupRepository) Create(ctx context.Context, g *group.Group) error {
+	args := m.Called(ctx, g)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) FindByID(ctx context.Context, id uuid.UUID) (*group.Group, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*group.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) FindByName(ctx context.Context, name string) (*group.Group, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*group.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) List(ctx context.Context) ([]group.Group, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]group.Group), args.Error(1)
+}
+
+func (m *MockGroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	args := m.Called(ctx, groupID, userID)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	args := m.Called(ctx, groupID, userID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *MockGroupRepository) AssignRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	args := m.Called(ctx, groupID, roleID)
+	return args.Error(0)
+}
+
+func (m *MockGroupRepository) UnassignRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	args := m.Called(ctx, groupID, roleID)
+	return args.Error(0)
+}
@@ -0,0 +1,24 @@
+package group
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, group *Group) error
+	// FindByID returns the group with its roles and their permissions preloaded.
+	FindByID(ctx context.Context, id uuid.UUID) (*Group, error)
+	FindByName(ctx context.Context, name string) (*Group, error)
+	List(ctx context.Context) ([]Group, error)
+	// Delete removes the group together with its memberships and role assignments.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	AddMember(ctx context.Context, groupID, userID uuid.UUID) error
+	RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error
+	IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error)
+
+	AssignRole(ctx context.Context, groupID, roleID uuid.UUID) error
+	UnassignRole(ctx context.Context, groupID, roleID uuid.UUID) error
+}
@@ -0,0 +1,31 @@
+package mocks
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockImpersonationRepository struct {
+	mock.Mock
+}
+
+func (m *MockImpersonationRepository) Create(ctx context.Context, session *impersonation.Session) error {
+	args := m.Called(ctx, session)
+	return args.Error(0)
+}
+
+func (m *MockImpersonationRepository) List(ctx context.Context, filter impersonation.ListFilter) ([]impersonation.Session, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]impersonation.Session), args.Error(1)
+}
+
+func (m *MockImpersonationRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	args := m.Called(ctx, before)
+	return args.Get(0).(int64), args.Error(1)
+}
@@ -0,0 +1,23 @@
+package impersonation
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, session *Session) error
+	// List returns sessions newest first.
+	List(ctx context.Context, filter ListFilter) ([]Session, error)
+	// DeleteCreatedBefore deletes sessions started before the given time and returns how many it deleted.
+	DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// ListFilter narrows a session listing. Zero-valued fields don't filter.
+type ListFilter struct {
+	ActorID uuid.UUID
+	UserID  uuid.UUID
+	Limit   int
+}
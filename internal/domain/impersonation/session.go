@@ -0,0 +1,43 @@
+package impersonation
+
+import (
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Session records an admin acting as another user. Its ID is the "jti" of
+// the impersonation token. Emails are copied so the record stays readable
+// after either account is deleted.
+type Session struct {
+	ID         uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	ActorID    uuid.UUID `json:"actor_id" gorm:"type:uuid;not null;index"`
+	ActorEmail string    `json:"actor_email" gorm:"not null"`
+	UserID     uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	UserEmail  string    `json:"user_email" gorm:"not null"`
+	Reason     string    `json:"reason" gorm:"not null"`
+	ExpiresAt  time.Time `json:"expires_at" gorm:"not null"`
+	CreatedAt  time.Time `json:"created_at" gorm:"index"`
+}
+
+func (Session) TableName() string {
+	return "impersonation_sessions"
+}
+
+func NewSession(actorID uuid.UUID, actorEmail string, userID uuid.UUID, userEmail, reason string, ttl time.Duration) *Session {
+	now := time.Now()
+	return &Session{
+		ID:         uuid.New(),
+		ActorID:    actorID,
+		ActorEmail: actorEmail,
+		UserID:     userID,
+		UserEmail:  userEmail,
+		Reason:     reason,
+		ExpiresAt:  now.Add(ttl),
+		CreatedAt:  now,
+	}
+}
+
+func (s *Session) IsActive() bool {
+	return time.Now().Before(s.ExpiresAt)
+}
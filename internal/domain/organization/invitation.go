@@ -0,0 +1,72 @@
+package organization
+
+import (
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+)
+
+const (
+	InvitationPending  = "pending"
+	InvitationAccepted = "accepted"
+	InvitationRevoked  = "revoked"
+	InvitationExpired  = "expired"
+)
+
+var ErrInvitationNotPending = errors.New("invitation is no longer pending")
+
+// Invitation offers one of the organization's roles to an email address until
+// it is accepted, revoked or expires.
+type Invitation struct {
+	ID             uuid.UUID     `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID     `json:"organization_id" gorm:"type:uuid;not null;index:idx_invitations_organization_email,priority:1"`
+	InviterID      uuid.UUID     `json:"inviter_id" gorm:"type:uuid;not null"`
+	Email          string        `json:"email" gorm:"not null;index:idx_invitations_organization_email,priority:2"`
+	RoleID         uuid.UUID     `json:"role_id" gorm:"type:uuid;not null"`
+	Role           *role.Role    `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+	Organization   *Organization `json:"organization,omitempty" gorm:"foreignKey:OrganizationID"`
+	ExpiresAt      time.Time     `json:"expires_at" gorm:"not null"`
+	AcceptedAt     *time.Time    `json:"accepted_at,omitempty"`
+	RevokedAt      *time.Time    `json:"revoked_at,omitempty"`
+	CreatedAt      time.Time     `json:"created_at"`
+}
+
+func NewInvitation(organizationID, inviterID uuid.UUID, email string, r *role.Role, ttl time.Duration) *Invitation {
+	now := time.Now()
+	return &Invitation{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		InviterID:      inviterID,
+		Email:          email,
+		RoleID:         r.ID,
+		Role:           r,
+		ExpiresAt:      now.Add(ttl),
+		CreatedAt:      now,
+	}
+}
+
+func (i *Invitation) Status() string {
+	switch {
+	case i.AcceptedAt != nil:
+		return InvitationAccepted
+	case i.RevokedAt != nil:
+		return InvitationRevoked
+	case time.Now().After(i.ExpiresAt):
+		return InvitationExpired
+	default:
+		return InvitationPending
+	}
+}
+
+func (i *Invitation) IsPending() bool {
+	return i.Status() == InvitationPending
+}
+
+func (i *Invitation) RoleName() string {
+	if i.Role == nil {
+		return ""
+	}
+	return i.Role.Name
+}
@@ -0,0 +1,52 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockInvitationRepository struct {
+	mock.Mock
+}
+
+func (m *MockInvitationRepository) Create(ctx context.Context, invitation *organization.Invitation) error {
+	args := m.Called(ctx, invitation)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*organization.Invitation, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]organization.Invitation, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]organization.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) FindPending(ctx context.Context, organizationID uuid.UUID, email string) (*organization.Invitation, error) {
+	args := m.Called(ctx, organizationID, email)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Invitation), args.Error(1)
+}
+
+func (m *MockInvitationRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockInvitationRepository) Accept(ctx context.Context, invitation *organization.Invitation, membership *organization.Membership) error {
+	args := m.Called(ctx, invitation, membership)
+	return args.Error(0)
+}
@@ -0,0 +1,74 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockOrganizationRepository struct {
+	mock.Mock
+}
+
+func (m *MockOrganizationRepository) Create(ctx context.Context, org *organization.Organization, roles []*role.Role, owner *organization.Membership) error {
+	args := m.Called(ctx, org, roles, owner)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) FindBySlug(ctx context.Context, slug string) (*organization.Organization, error) {
+	args := m.Called(ctx, slug)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]organization.Organization, error) {
+	args := m.Called(ctx, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]organization.Organization), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) AddMember(ctx context.Context, membership *organization.Membership) error {
+	args := m.Called(ctx, membership)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	args := m.Called(ctx, organizationID, userID)
+	return args.Error(0)
+}
+
+func (m *MockOrganizationRepository) FindMembership(ctx context.Context, organizationID, userID uuid.UUID) (*organization.Membership, error) {
+	args := m.Called(ctx, organizationID, userID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*organization.Membership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]organization.Membership, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]organization.Membership), args.Error(1)
+}
+
+func (m *MockOrganizationRepository) CountMembersWithRole(ctx context.Context, organizationID, roleID uuid.UUID) (int64, error) {
+	args := m.Called(ctx, organizationID, roleID)
+	return args.Get(0).(int64), args.Error(1)
+}
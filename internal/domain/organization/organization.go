@@ -0,0 +1,121 @@
+package organization
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+)
+
+type Organization struct {
+	ID        uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name      string    `json:"name" gorm:"not null"`
+	Slug      string    `json:"slug" gorm:"uniqueIndex;not null"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// Membership grants a user one of the organization's roles.
+type Membership struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID uuid.UUID  `json:"organization_id" gorm:"type:uuid;not null;uniqueIndex:idx_memberships_organization_user,priority:1"`
+	UserID         uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;uniqueIndex:idx_memberships_organization_user,priority:2;index"`
+	RoleID         uuid.UUID  `json:"role_id" gorm:"type:uuid;not null"`
+	User           *user.User `json:"user,omitempty" gorm:"foreignKey:UserID"`
+	Role           *role.Role `json:"role,omitempty" gorm:"foreignKey:RoleID"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+const (
+	RoleOwner  = "owner"
+	RoleAdmin  = "admin"
+	RoleMember = "member"
+)
+
+const (
+	PermissionRead         = "org:read"
+	PermissionMembersRead  = "org:members:read"
+	PermissionMembersWrite = "org:members:write"
+	PermissionRolesRead    = "org:roles:read"
+	PermissionRolesWrite   = "org:roles:write"
+)
+
+var (
+	OwnerPermissions = []string{"org:*"}
+
+	AdminPermissions = []string{
+		PermissionRead,
+		"org:members:*",
+		PermissionRolesRead,
+	}
+
+	MemberPermissions = []string{
+		PermissionRead,
+		PermissionMembersRead,
+	}
+)
+
+// ManagesOrganization reports whether a permission reaches the "org" namespace
+// used to administer the organization itself.
+func ManagesOrganization(permission string) bool {
+	namespace, _, _ := strings.Cut(permission, auth.PermissionSeparator)
+	return namespace == "org" || namespace == auth.PermissionWildcard
+}
+
+var slugPattern = regexp.MustCompile(`^[a-z0-9]+(?:-[a-z0-9]+)*$`)
+
+func New(name, slug string) (*Organization, error) {
+	if !slugPattern.MatchString(slug) {
+		return nil, fmt.Errorf("invalid slug %q, expected lowercase letters, digits and dashes", slug)
+	}
+
+	return &Organization{
+		ID:        uuid.New(),
+		Name:      name,
+		Slug:      slug,
+		CreatedAt: time.Now(),
+		UpdatedAt: time.Now(),
+	}, nil
+}
+
+// DefaultRoles returns the owner, admin and member roles every organization starts with.
+func (o *Organization) DefaultRoles() []*role.Role {
+	return []*role.Role{
+		role.NewOrganizationRole(o.ID, RoleOwner, OwnerPermissions),
+		role.NewOrganizationRole(o.ID, RoleAdmin, AdminPermissions),
+		role.NewOrganizationRole(o.ID, RoleMember, MemberPermissions),
+	}
+}
+
+func NewMembership(organizationID, userID uuid.UUID, r *role.Role) *Membership {
+	return &Membership{
+		ID:             uuid.New(),
+		OrganizationID: organizationID,
+		UserID:         userID,
+		RoleID:         r.ID,
+		Role:           r,
+		CreatedAt:      time.Now(),
+		UpdatedAt:      time.Now(),
+	}
+}
+
+// Permissions returns the permissions granted by the membership's role.
+func (m *Membership) Permissions() []string {
+	if m.Role == nil {
+		return nil
+	}
+	return m.Role.GetPermissionStrings()
+}
+
+func (m *Membership) RoleName() string {
+	if m.Role == nil {
+		return ""
+	}
+	return m.Role.Name
+}
@@ -0,0 +1,36 @@
+package organization
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Create stores the organization with its roles and first membership in one transaction.
+	Create(ctx context.Context, org *Organization, roles []*role.Role, owner *Membership) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Organization, error)
+	FindBySlug(ctx context.Context, slug string) (*Organization, error)
+	ListByUser(ctx context.Context, userID uuid.UUID) ([]Organization, error)
+
+	AddMember(ctx context.Context, membership *Membership) error
+	RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error
+	// FindMembership returns the membership with its role and permissions preloaded.
+	FindMembership(ctx context.Context, organizationID, userID uuid.UUID) (*Membership, error)
+	// ListMembers returns the memberships with their users and roles preloaded.
+	ListMembers(ctx context.Context, organizationID uuid.UUID) ([]Membership, error)
+	CountMembersWithRole(ctx context.Context, organizationID, roleID uuid.UUID) (int64, error)
+}
+
+type InvitationRepository interface {
+	Create(ctx context.Context, invitation *Invitation) error
+	// FindByID returns the invitation with its role and organization preloaded.
+	FindByID(ctx context.Context, id uuid.UUID) (*Invitation, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]Invitation, error)
+	FindPending(ctx context.Context, organizationID uuid.UUID, email string) (*Invitation, error)
+	Revoke(ctx context.Context, id uuid.UUID) error
+	// Accept marks a pending invitation accepted and creates the membership in
+	// one transaction, failing if the invitation was accepted or revoked meanwhile.
+	Accept(ctx context.Context, invitation *Invitation, membership *Membership) error
+}
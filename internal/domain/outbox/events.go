@@ -0,0 +1,59 @@
+package outbox
+
+import (
+	"slices"
+
+	"github.com/google/uuid"
+)
+
+// Event types published to webhooks
+const (
+	EventUserCreated      = "user.created"
+	EventUserEmailChanged = "user.email_changed"
+	EventUserDisabled     = "user.disabled"
+	EventUserEnabled      = "user.enabled"
+	EventUserDeleted      = "user.deleted"
+	EventRoleAssigned     = "role.assigned"
+	EventSessionRevoked   = "session.revoked"
+)
+
+// EventTypes lists every event type, for validating webhook subscriptions
+var EventTypes = []string{
+	EventUserCreated, EventUserEmailChanged, EventUserDisabled, EventUserEnabled, EventUserDeleted,
+	EventRoleAssigned, EventSessionRevoked,
+}
+
+// IsEventType reports whether eventType is published
+func IsEventType(eventType string) bool {
+	return slices.Contains(EventTypes, eventType)
+}
+
+// UserEvent is the data of the user.* events
+type UserEvent struct {
+	UserID uuid.UUID `json:"user_id"`
+	Email  string    `json:"email"`
+	// PreviousEmail is only set on user.email_changed.
+	PreviousEmail string `json:"previous_email,omitempty"`
+}
+
+// RoleAssignedEvent is the data of role.assigned
+type RoleAssignedEvent struct {
+	UserID   uuid.UUID `json:"user_id"`
+	RoleID   uuid.UUID `json:"role_id"`
+	RoleName string    `json:"role_name"`
+}
+
+// Reasons a session.revoked event gives
+const (
+	RevokedByLogout         = "logout"
+	RevokedByLogoutAll      = "logout_all"
+	RevokedByPasswordChange = "password_change"
+)
+
+// SessionRevokedEvent is the data of session.revoked. SessionID is the
+// revoked refresh token, or empty when every session of the user was revoked.
+type SessionRevokedEvent struct {
+	UserID    uuid.UUID `json:"user_id"`
+	SessionID string    `json:"session_id,omitempty"`
+	Reason    string    `json:"reason"`
+}
@@ -0,0 +1,39 @@
+package outbox
+
+import (
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Message is a domain event waiting in the outbox. It is written in the same
+// transaction as the change it describes, so an event is published if and
+// only if the change is committed. DispatchedAt is set once the event has
+// been handed to the webhooks subscribed to it.
+type Message struct {
+	ID           uuid.UUID       `json:"id" gorm:"type:uuid;primary_key"`
+	EventType    string          `json:"event_type" gorm:"not null"`
+	Data         json.RawMessage `json:"data" gorm:"type:jsonb;not null"`
+	OccurredAt   time.Time       `json:"occurred_at" gorm:"not null"`
+	DispatchedAt *time.Time      `json:"dispatched_at,omitempty" gorm:"index"`
+}
+
+func (Message) TableName() string {
+	return "outbox_messages"
+}
+
+// NewMessage creates a message for an event, with data encoded as JSON
+func NewMessage(eventType string, data any) (*Message, error) {
+	encoded, err := json.Marshal(data)
+	if err != nil {
+		return nil, fmt.Errorf("error encoding %s event: %w", eventType, err)
+	}
+	return &Message{
+		ID:         uuid.New(),
+		EventType:  eventType,
+		Data:       encoded,
+		OccurredAt: time.Now().UTC().Truncate(time.Microsecond),
+	}, nil
+}
@@ -0,0 +1,27 @@
+package outbox
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Add stores a message; called within the transaction of the change it describes.
+	Add(ctx context.Context, message *Message) error
+	// ListUndispatched returns messages not yet dispatched, oldest first.
+	ListUndispatched(ctx context.Context, limit int) ([]Message, error)
+	MarkDispatched(ctx context.Context, ids []uuid.UUID, dispatchedAt time.Time) error
+	FindByIDs(ctx context.Context, ids []uuid.UUID) ([]Message, error)
+	// DeleteDispatchedBefore deletes messages dispatched before the given time that have no
+	// pending deliveries, with their deliveries, and returns how many messages it deleted.
+	DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// Transactor runs a function in a transaction. Repositories called with the
+// context passed to fn take part in it, so their changes and the outbox
+// messages added are committed together.
+type Transactor interface {
+	WithinTransaction(ctx context.Context, fn func(ctx context.Context) error) error
+}
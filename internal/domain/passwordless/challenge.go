@@ -0,0 +1,58 @@
+package passwordless
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+type Method string
+
+const (
+	// MethodLink mails a single-use sign-in link.
+	MethodLink Method = "link"
+	// MethodCode mails a 6-digit code the user types in.
+	MethodCode Method = "code"
+)
+
+var ErrChallengeNotFound = errors.New("passwordless challenge not found")
+
+// Challenge is a pending passwordless sign-in for an email address. Only a
+// hash of the link token or code is stored. Starting a new sign-in replaces
+// the address's earlier challenges.
+type Challenge struct {
+	ID         uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	Email      string     `json:"email" gorm:"not null;index"`
+	Method     Method     `json:"method" gorm:"not null"`
+	SecretHash string     `json:"-" gorm:"not null;uniqueIndex"`
+	Attempts   int        `json:"attempts" gorm:"not null;default:0"`
+	ExpiresAt  time.Time  `json:"expires_at" gorm:"not null;index"`
+	ConsumedAt *time.Time `json:"consumed_at,omitempty"`
+	CreatedAt  time.Time  `json:"created_at" gorm:"not null"`
+}
+
+func (Challenge) TableName() string {
+	return "passwordless_challenges"
+}
+
+// NewChallenge creates a challenge; the caller sets SecretHash, which may
+// depend on the ID.
+func NewChallenge(email string, method Method, ttl time.Duration) *Challenge {
+	now := time.Now()
+	return &Challenge{
+		ID:        uuid.New(),
+		Email:     email,
+		Method:    method,
+		ExpiresAt: now.Add(ttl),
+		CreatedAt: now,
+	}
+}
+
+func (c *Challenge) IsExpired() bool {
+	return time.Now().After(c.ExpiresAt)
+}
+
+func (c *Challenge) IsConsumed() bool {
+	return c.ConsumedAt != nil
+}
@@ -0,0 +1,24 @@
+package passwordless
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	// Replace deletes the email's earlier challenges and stores challenge.
+	Replace(ctx context.Context, challenge *Challenge) error
+	// FindLatestByEmail returns the email's newest challenge.
+	FindLatestByEmail(ctx context.Context, email string) (*Challenge, error)
+	FindBySecretHash(ctx context.Context, secretHash string) (*Challenge, error)
+	// ReserveAttempt counts a code attempt against the challenge before the code
+	// is compared. It returns ErrChallengeNotFound when the challenge is used or
+	// has already had maxAttempts, so concurrent guesses can't exceed the limit.
+	ReserveAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) error
+	// Consume marks the challenge used. It returns ErrChallengeNotFound when it
+	// already was, so a code or link can't be redeemed twice concurrently.
+	Consume(ctx context.Context, id uuid.UUID) error
+	// DeleteExpired deletes challenges past their expiry and returns how many it deleted.
+	DeleteExpired(ctx context.Context) (int64, error)
+}
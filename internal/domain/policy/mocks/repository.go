@@ -0,0 +1,60 @@
+package mocks
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/mock"
+)
+
+type MockPolicyRepository struct {
+	mock.Mock
+}
+
+func (m *MockPolicyRepository) Create(ctx context.Context, p *policy.Policy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPolicyRepository) Update(ctx context.Context, p *policy.Policy) error {
+	args := m.Called(ctx, p)
+	return args.Error(0)
+}
+
+func (m *MockPolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	args := m.Called(ctx, id)
+	return args.Error(0)
+}
+
+func (m *MockPolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*policy.Policy, error) {
+	args := m.Called(ctx, id)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) FindByName(ctx context.Context, name string) (*policy.Policy, error) {
+	args := m.Called(ctx, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*policy.Policy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) List(ctx context.Context) ([]policy.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]policy.Policy), args.Error(1)
+}
+
+func (m *MockPolicyRepository) ListEnabled(ctx context.Context) ([]policy.Policy, error) {
+	args := m.Called(ctx)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]policy.Policy), args.Error(1)
+}
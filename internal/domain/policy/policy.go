@@ -0,0 +1,65 @@
+package policy
+
+import (
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+)
+
+type Effect string
+
+const (
+	EffectAllow Effect = "allow"
+	EffectDeny  Effect = "deny"
+)
+
+// PermissionCheckOthers lets a caller request decisions on behalf of other subjects.
+const PermissionCheckOthers = "authz:check"
+
+// Policy grants or denies an action when its condition holds. Action uses the
+// same syntax as permissions, so "posts:*" targets every post action.
+// Condition is a boolean expression over subject, resource, action and env.
+type Policy struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Name         string    `json:"name" gorm:"uniqueIndex;not null"`
+	Description  string    `json:"description"`
+	Action       string    `json:"action" gorm:"not null;index"`
+	ResourceType string    `json:"resource_type" gorm:"index"`
+	Effect       Effect    `json:"effect" gorm:"not null"`
+	Condition    string    `json:"condition"`
+	Enabled      bool      `json:"enabled" gorm:"not null;default:true"`
+	CreatedAt    time.Time `json:"created_at"`
+	UpdatedAt    time.Time `json:"updated_at"`
+}
+
+func New(name, description, action, resourceType string, effect Effect, condition string) *Policy {
+	return &Policy{
+		ID:           uuid.New(),
+		Name:         name,
+		Description:  description,
+		Action:       action,
+		ResourceType: resourceType,
+		Effect:       effect,
+		Condition:    condition,
+		Enabled:      true,
+		CreatedAt:    time.Now(),
+		UpdatedAt:    time.Now(),
+	}
+}
+
+// AppliesTo reports whether the policy targets the given action and resource type.
+// An empty ResourceType applies to every resource.
+func (p *Policy) AppliesTo(action, resourceType string) bool {
+	if !p.Enabled {
+		return false
+	}
+	if p.ResourceType != "" && p.ResourceType != resourceType {
+		return false
+	}
+	return auth.MatchPermission(p.Action, action)
+}
+
+func (e Effect) IsValid() bool {
+	return e == EffectAllow || e == EffectDeny
+}
@@ -0,0 +1,17 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, policy *Policy) error
+	Update(ctx context.Context, policy *Policy) error
+	Delete(ctx context.Context, id uuid.UUID) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Policy, error)
+	FindByName(ctx context.Context, name string) (*Policy, error)
+	List(ctx context.Context) ([]Policy, error)
+	ListEnabled(ctx context.Context) ([]Policy, error)
+}
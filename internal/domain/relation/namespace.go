@@ -0,0 +1,143 @@
+package relation
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// Namespace declares the relations available on objects of one type.
+type Namespace struct {
+	Name      string     `json:"name"`
+	Relations []Relation `json:"relations"`
+}
+
+// Relation is the union of its direct tuples and the rewrites below.
+type Relation struct {
+	Name string `json:"name"`
+	// ImpliedBy lists relations on the same object whose holders also hold
+	// this one, e.g. viewer is implied by editor.
+	ImpliedBy []string `json:"implied_by,omitempty"`
+	// Inherits follows the Through relation to other objects and includes the
+	// holders of Relation there, e.g. a document's viewers include the viewers
+	// of its parent project.
+	Inherits []Inherit `json:"inherits,omitempty"`
+}
+
+type Inherit struct {
+	Through  string `json:"through"`
+	Relation string `json:"relation"`
+}
+
+// Schema is a validated set of namespaces.
+type Schema struct {
+	namespaces map[string]*Namespace
+}
+
+func NewSchema(namespaces []Namespace) (*Schema, error) {
+	s := &Schema{namespaces: make(map[string]*Namespace, len(namespaces))}
+
+	for i := range namespaces {
+		ns := &namespaces[i]
+		if ns.Name == "" {
+			return nil, fmt.Errorf("namespace name is required")
+		}
+		if _, exists := s.namespaces[ns.Name]; exists {
+			return nil, fmt.Errorf("duplicate namespace %q", ns.Name)
+		}
+		s.namespaces[ns.Name] = ns
+	}
+
+	for _, ns := range s.namespaces {
+		seen := map[string]bool{}
+		for _, rel := range ns.Relations {
+			if rel.Name == "" {
+				return nil, fmt.Errorf("namespace %q has a relation without a name", ns.Name)
+			}
+			if seen[rel.Name] {
+				return nil, fmt.Errorf("namespace %q declares relation %q twice", ns.Name, rel.Name)
+			}
+			seen[rel.Name] = true
+		}
+		for _, rel := range ns.Relations {
+			for _, implied := range rel.ImpliedBy {
+				if !seen[implied] {
+					return nil, fmt.Errorf("relation %s#%s is implied by unknown relation %q", ns.Name, rel.Name, implied)
+				}
+			}
+			for _, inherit := range rel.Inherits {
+				if !seen[inherit.Through] {
+					return nil, fmt.Errorf("relation %s#%s inherits through unknown relation %q", ns.Name, rel.Name, inherit.Through)
+				}
+				if inherit.Relation == "" {
+					return nil, fmt.Errorf("relation %s#%s inherits an empty relation", ns.Name, rel.Name)
+				}
+			}
+		}
+	}
+
+	return s, nil
+}
+
+// ParseSchema reads namespaces from a JSON array.
+func ParseSchema(data []byte) (*Schema, error) {
+	var namespaces []Namespace
+	if err := json.Unmarshal(data, &namespaces); err != nil {
+		return nil, fmt.Errorf("invalid namespace config: %w", err)
+	}
+	return NewSchema(namespaces)
+}
+
+func (s *Schema) HasNamespace(name string) bool {
+	_, ok := s.namespaces[name]
+	return ok
+}
+
+func (s *Schema) Relation(namespace, name string) (*Relation, bool) {
+	ns, ok := s.namespaces[namespace]
+	if !ok {
+		return nil, false
+	}
+	for i := range ns.Relations {
+		if ns.Relations[i].Name == name {
+			return &ns.Relations[i], true
+		}
+	}
+	return nil, false
+}
+
+// ValidateTuple checks that the tuple only references declared namespaces and relations.
+func (s *Schema) ValidateTuple(t *Tuple) error {
+	if _, ok := s.Relation(t.Namespace, t.Relation); !ok {
+		return fmt.Errorf("unknown relation %s#%s", t.Namespace, t.Relation)
+	}
+	if !s.HasNamespace(t.SubjectNamespace) {
+		return fmt.Errorf("unknown subject namespace %q", t.SubjectNamespace)
+	}
+	if t.SubjectRelation != "" {
+		if _, ok := s.Relation(t.SubjectNamespace, t.SubjectRelation); !ok {
+			return fmt.Errorf("unknown subject relation %s#%s", t.SubjectNamespace, t.SubjectRelation)
+		}
+	}
+	return nil
+}
+
+// DefaultNamespaces covers users, groups, projects and documents shared between them.
+func DefaultNamespaces() []Namespace {
+	return []Namespace{
+		{Name: "user"},
+		{Name: "group", Relations: []Relation{
+			{Name: "member"},
+		}},
+		{Name: "project", Relations: []Relation{
+			{Name: "owner"},
+			{Name: "editor", ImpliedBy: []string{"owner"}},
+			{Name: "viewer", ImpliedBy: []string{"editor"}},
+		}},
+		{Name: "document", Relations: []Relation{
+			{Name: "parent"},
+			{Name: "owner"},
+			{Name: "editor", ImpliedBy: []string{"owner"}, Inherits: []Inherit{{Through: "parent", Relation: "editor"}}},
+			{Name: "viewer", ImpliedBy: []string{"editor"}, Inherits: []Inherit{{Through: "parent", Relation: "viewer"}}},
+		}},
+	}
+}
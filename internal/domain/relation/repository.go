@@ -0,0 +1,18 @@
+package relation
+
+import "context"
+
+type Repository interface {
+	// Write inserts and deletes tuples in a single transaction. Inserting a
+	// tuple that already exists is not an error.
+	Write(ctx context.Context, writes []*Tuple, deletes []*Tuple) error
+	// FindByObjectRelation returns the tuples for object#relation.
+	FindByObjectRelation(ctx context.Context, object Object, relation string) ([]Tuple, error)
+	// ListObjectIDs returns the distinct IDs of objects in a namespace that have any tuple.
+	ListObjectIDs(ctx context.Context, namespace string) ([]string, error)
+}
+
+const (
+	PermissionRead  = "relations:read"
+	PermissionWrite = "relations:write"
+)
@@ -0,0 +1,129 @@
+package relation
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// Object identifies a resource as "namespace:id", e.g. "document:readme".
+type Object struct {
+	Namespace string
+	ID        string
+}
+
+// Subject is either a concrete subject such as "user:alice" or a userset such
+// as "group:eng#member", meaning everyone holding member on group:eng.
+type Subject struct {
+	Namespace string
+	ID        string
+	Relation  string
+}
+
+// Tuple records that Subject holds Relation on the object, written as
+// "document:readme#viewer@user:alice".
+type Tuple struct {
+	ID               uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	Namespace        string    `json:"namespace" gorm:"not null;uniqueIndex:idx_relation_tuples_unique,priority:1;index:idx_relation_tuples_object,priority:1"`
+	ObjectID         string    `json:"object_id" gorm:"not null;uniqueIndex:idx_relation_tuples_unique,priority:2;index:idx_relation_tuples_object,priority:2"`
+	Relation         string    `json:"relation" gorm:"not null;uniqueIndex:idx_relation_tuples_unique,priority:3;index:idx_relation_tuples_object,priority:3"`
+	SubjectNamespace string    `json:"subject_namespace" gorm:"not null;uniqueIndex:idx_relation_tuples_unique,priority:4;index:idx_relation_tuples_subject,priority:1"`
+	SubjectID        string    `json:"subject_id" gorm:"not null;uniqueIndex:idx_relation_tuples_unique,priority:5;index:idx_relation_tuples_subject,priority:2"`
+	SubjectRelation  string    `json:"subject_relation" gorm:"not null;default:'';uniqueIndex:idx_relation_tuples_unique,priority:6"`
+	CreatedAt        time.Time `json:"created_at"`
+}
+
+func (Tuple) TableName() string {
+	return "relation_tuples"
+}
+
+func NewTuple(object Object, relation string, subject Subject) *Tuple {
+	return &Tuple{
+		ID:               uuid.New(),
+		Namespace:        object.Namespace,
+		ObjectID:         object.ID,
+		Relation:         relation,
+		SubjectNamespace: subject.Namespace,
+		SubjectID:        subject.ID,
+		SubjectRelation:  subject.Relation,
+		CreatedAt:        time.Now(),
+	}
+}
+
+func (t *Tuple) Object() Object {
+	return Object{Namespace: t.Namespace, ID: t.ObjectID}
+}
+
+func (t *Tuple) Subject() Subject {
+	return Subject{Namespace: t.SubjectNamespace, ID: t.SubjectID, Relation: t.SubjectRelation}
+}
+
+func (t *Tuple) String() string {
+	return t.Object().String() + "#" + t.Relation + "@" + t.Subject().String()
+}
+
+func (o Object) String() string {
+	return o.Namespace + ":" + o.ID
+}
+
+func (s Subject) String() string {
+	if s.Relation == "" {
+		return s.Namespace + ":" + s.ID
+	}
+	return s.Namespace + ":" + s.ID + "#" + s.Relation
+}
+
+// IsUserset reports whether the subject refers to the holders of a relation.
+func (s Subject) IsUserset() bool {
+	return s.Relation != ""
+}
+
+// Object returns the object a userset subject points at.
+func (s Subject) Object() Object {
+	return Object{Namespace: s.Namespace, ID: s.ID}
+}
+
+func ParseObject(s string) (Object, error) {
+	namespace, id, ok := strings.Cut(s, ":")
+	if !ok || namespace == "" || id == "" || strings.ContainsAny(id, "#@") || strings.ContainsAny(namespace, "#@") {
+		return Object{}, fmt.Errorf("invalid object %q, expected namespace:id", s)
+	}
+	return Object{Namespace: namespace, ID: id}, nil
+}
+
+func ParseSubject(s string) (Subject, error) {
+	ref, relation, hasRelation := strings.Cut(s, "#")
+	object, err := ParseObject(ref)
+	if err != nil {
+		return Subject{}, fmt.Errorf("invalid subject %q, expected namespace:id or namespace:id#relation", s)
+	}
+	if hasRelation && (relation == "" || strings.ContainsAny(relation, "#@:")) {
+		return Subject{}, fmt.Errorf("invalid subject %q, expected namespace:id or namespace:id#relation", s)
+	}
+	return Subject{Namespace: object.Namespace, ID: object.ID, Relation: relation}, nil
+}
+
+// ParseTuple parses "namespace:id#relation@subject".
+func ParseTuple(s string) (*Tuple, error) {
+	objectRelation, subjectStr, ok := strings.Cut(s, "@")
+	if !ok {
+		return nil, fmt.Errorf("invalid tuple %q, expected object#relation@subject", s)
+	}
+	objectStr, relation, ok := strings.Cut(objectRelation, "#")
+	if !ok || relation == "" {
+		return nil, fmt.Errorf("invalid tuple %q, expected object#relation@subject", s)
+	}
+
+	object, err := ParseObject(objectStr)
+	if err != nil {
+		return nil, err
+	}
+	subject, err := ParseSubject(subjectStr)
+	if err != nil {
+		return nil, err
+	}
+
+	return NewTuple(object, relation, subject), nil
+}
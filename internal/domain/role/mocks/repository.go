@@ -28,6 +28,14 @@ func (m *MockRoleRepository) FindByName(ctx context.Context, name string) (*role
 	return args.Get(0).(*role.Role), args.Error(1)
 }
 
+func (m *MockRoleRepository) FindByNameInOrganization(ctx context.Context, organizationID uuid.UUID, name string) (*role.Role, error) {
+	args := m.Called(ctx, organizationID, name)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).(*role.Role), args.Error(1)
+}
+
 func (m *MockRoleRepository) Create(ctx context.Context, r *role.Role) error {
 	args := m.Called(ctx, r)
 	return args.Error(0)
@@ -63,3 +71,11 @@ func (m *MockRoleRepository) List(ctx context.Context) ([]role.Role, error) {
 	}
 	return args.Get(0).([]role.Role), args.Error(1)
 }
+
+func (m *MockRoleRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]role.Role, error) {
+	args := m.Called(ctx, organizationID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]role.Role), args.Error(1)
+}
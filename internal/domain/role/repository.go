@@ -8,11 +8,15 @@ import (
 
 type Repository interface {
 	FindByID(ctx context.Context, id uuid.UUID) (*Role, error)
+	// FindByName finds a global role, ignoring organization roles.
 	FindByName(ctx context.Context, name string) (*Role, error)
+	FindByNameInOrganization(ctx context.Context, organizationID uuid.UUID, name string) (*Role, error)
 	Create(ctx context.Context, role *Role) error
 	Update(ctx context.Context, role *Role) error
 	Delete(ctx context.Context, id uuid.UUID) error
+	// List returns the global roles.
 	List(ctx context.Context) ([]Role, error)
+	ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]Role, error)
 	FindOrCreateDefault(ctx context.Context) (*Role, error)
 	IsRBACEnabled(ctx context.Context) bool
 }
@@ -3,15 +3,21 @@ package role
 import (
 	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
 	"github.com/google/uuid"
 )
 
+// Role is a named set of permissions. Global roles have no OrganizationID;
+// organization roles share names across organizations but not within one.
+// The two cases have separate partial indexes, since a unique index over
+// (organization_id, name) would treat every NULL organization as distinct.
 type Role struct {
-	ID          uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
-	Name        string       `json:"name" gorm:"uniqueIndex;not null"`
-	Permissions []Permission `json:"permissions" gorm:"foreignKey:RoleID"`
-	CreatedAt   time.Time    `json:"created_at"`
-	UpdatedAt   time.Time    `json:"updated_at"`
+	ID             uuid.UUID    `json:"id" gorm:"type:uuid;primary_key"`
+	OrganizationID *uuid.UUID   `json:"organization_id,omitempty" gorm:"type:uuid;uniqueIndex:idx_roles_organization_name,priority:1,where:organization_id IS NOT NULL"`
+	Name           string       `json:"name" gorm:"not null;uniqueIndex:idx_roles_global_name,where:organization_id IS NULL;uniqueIndex:idx_roles_organization_name,priority:2,where:organization_id IS NOT NULL"`
+	Permissions    []Permission `json:"permissions" gorm:"foreignKey:RoleID"`
+	CreatedAt      time.Time    `json:"created_at"`
+	UpdatedAt      time.Time    `json:"updated_at"`
 }
 
 type Permission struct {
@@ -63,6 +69,13 @@ func New(name string, permissions []string) *Role {
 	return role
 }
 
+// NewOrganizationRole creates a role that only exists within an organization.
+func NewOrganizationRole(organizationID uuid.UUID, name string, permissions []string) *Role {
+	role := New(name, permissions)
+	role.OrganizationID = &organizationID
+	return role
+}
+
 func NewAdminRole() *Role {
 	return New(RoleAdmin, AdminPermissions)
 }
@@ -88,10 +101,5 @@ func (r *Role) HasPermission(permission string) bool {
 		return true
 	}
 
-	for _, p := range r.Permissions {
-		if p.Name == permission || p.Name == "*" {
-			return true
-		}
-	}
-	return false
+	return auth.HasPermission(r.GetPermissionStrings(), permission)
 }
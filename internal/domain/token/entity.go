@@ -1,11 +1,17 @@
 package token
 
 import (
+	"errors"
 	"time"
 
 	"github.com/google/uuid"
 )
 
+// ErrRefreshTokenReused is returned for a refresh token that was already
+// rotated or revoked. A legitimate client never presents one, so it usually
+// means the token leaked.
+var ErrRefreshTokenReused = errors.New("refresh token was already used or revoked")
+
 type RefreshToken struct {
 	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
 	UserID    uuid.UUID  `json:"user_id" gorm:"type:uuid;not null;index"`
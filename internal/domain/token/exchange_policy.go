@@ -0,0 +1,73 @@
+package token
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+// ExchangeClient lists what a service may obtain through token exchange.
+type ExchangeClient struct {
+	// Client is the service name carried by the service's actor token.
+	Client string `json:"client"`
+	// Audiences the client may request tokens for.
+	Audiences []string `json:"audiences"`
+	// Scopes the client may request, as permission patterns such as "orders:*".
+	Scopes []string `json:"scopes"`
+}
+
+// ExchangePolicy is a validated set of exchange clients. The zero policy
+// allows no exchanges.
+type ExchangePolicy struct {
+	clients map[string]*ExchangeClient
+}
+
+func NewExchangePolicy(clients []ExchangeClient) (*ExchangePolicy, error) {
+	p := &ExchangePolicy{clients: make(map[string]*ExchangeClient, len(clients))}
+
+	for i := range clients {
+		client := &clients[i]
+		if client.Client == "" {
+			return nil, fmt.Errorf("exchange client name is required")
+		}
+		if _, exists := p.clients[client.Client]; exists {
+			return nil, fmt.Errorf("duplicate exchange client %q", client.Client)
+		}
+		if len(client.Audiences) == 0 {
+			return nil, fmt.Errorf("exchange client %q has no audiences", client.Client)
+		}
+		if slices.Contains(client.Audiences, "") {
+			return nil, fmt.Errorf("exchange client %q has an empty audience", client.Client)
+		}
+		p.clients[client.Client] = client
+	}
+
+	return p, nil
+}
+
+func ParseExchangePolicy(data []byte) (*ExchangePolicy, error) {
+	var clients []ExchangeClient
+	if err := json.Unmarshal(data, &clients); err != nil {
+		return nil, fmt.Errorf("invalid token exchange policy: %w", err)
+	}
+	return NewExchangePolicy(clients)
+}
+
+// Client returns the policy entry for a service.
+func (p *ExchangePolicy) Client(name string) (*ExchangeClient, bool) {
+	if p == nil || p.clients == nil {
+		return nil, false
+	}
+	client, ok := p.clients[name]
+	return client, ok
+}
+
+func (c *ExchangeClient) AllowsAudience(audience string) bool {
+	return slices.Contains(c.Audiences, audience)
+}
+
+func (c *ExchangeClient) AllowsScope(scope string) bool {
+	return auth.HasPermission(c.Scopes, scope)
+}
@@ -2,6 +2,7 @@ package token
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
@@ -12,5 +13,21 @@ type Repository interface {
 	FindByUserID(ctx context.Context, userID uuid.UUID) ([]*RefreshToken, error)
 	Revoke(ctx context.Context, tokenID uuid.UUID) error
 	RevokeByUserID(ctx context.Context, userID uuid.UUID) error
-	CleanExpired(ctx context.Context) error
+	// RevokeAll revokes every active refresh token and returns how many it revoked.
+	RevokeAll(ctx context.Context) (int64, error)
+	// CleanExpired deletes expired refresh tokens and returns how many it deleted.
+	CleanExpired(ctx context.Context) (int64, error)
+	// DeleteRevokedBefore deletes tokens revoked before the given time and returns how many it deleted.
+	DeleteRevokedBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+type PersonalAccessTokenRepository interface {
+	Create(ctx context.Context, token *PersonalAccessToken) error
+	FindByTokenHash(ctx context.Context, tokenHash string) (*PersonalAccessToken, error)
+	// ListByUserID returns the user's tokens, revoked ones included, newest first.
+	ListByUserID(ctx context.Context, userID uuid.UUID) ([]PersonalAccessToken, error)
+	// Revoke revokes one of the user's tokens; it returns
+	// ErrPersonalAccessTokenNotFound when the user has no such active token.
+	Revoke(ctx context.Context, userID, tokenID uuid.UUID) error
+	TouchLastUsed(ctx context.Context, tokenID uuid.UUID, usedAt time.Time) error
 }
@@ -2,6 +2,7 @@ package mocks
 
 import (
 	"context"
+	"time"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
 	"github.com/google/uuid"
@@ -37,3 +38,44 @@ func (m *MockUserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, r
 	args := m.Called(ctx, userID, roleID)
 	return args.Error(0)
 }
+
+func (m *MockUserRepository) ListByGroup(ctx context.Context, groupID uuid.UUID) ([]user.User, error) {
+	args := m.Called(ctx, groupID)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) List(ctx context.Context, filter user.ListFilter) ([]user.User, error) {
+	args := m.Called(ctx, filter)
+	if args.Get(0) == nil {
+		return nil, args.Error(1)
+	}
+	return args.Get(0).([]user.User), args.Error(1)
+}
+
+func (m *MockUserRepository) SetDisabledAt(ctx context.Context, userID uuid.UUID, disabledAt *time.Time) error {
+	args := m.Called(ctx, userID, disabledAt)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	args := m.Called(ctx, userID)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, profile user.Profile) error {
+	args := m.Called(ctx, userID, profile)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	args := m.Called(ctx, userID, passwordHash)
+	return args.Error(0)
+}
+
+func (m *MockUserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	args := m.Called(ctx, userID, email)
+	return args.Error(0)
+}
@@ -0,0 +1,28 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+// PasswordHistoryEntry is a password hash a user had before, kept so the
+// password policy can refuse its reuse.
+type PasswordHistoryEntry struct {
+	ID           uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	UserID       uuid.UUID `json:"user_id" gorm:"type:uuid;not null;index"`
+	PasswordHash string    `json:"-" gorm:"not null"`
+	CreatedAt    time.Time `json:"created_at" gorm:"not null"`
+}
+
+func (PasswordHistoryEntry) TableName() string {
+	return "password_history"
+}
+
+type PasswordHistoryRepository interface {
+	// Add records a replaced password hash, keeping only the user's keep newest entries.
+	Add(ctx context.Context, userID uuid.UUID, passwordHash string, keep int) error
+	// ListRecent returns the user's newest previous password hashes, newest first.
+	ListRecent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error)
+}
@@ -2,13 +2,46 @@ package user
 
 import (
 	"context"
+	"time"
 
 	"github.com/google/uuid"
 )
 
 type UserRepository interface {
 	Create(ctx context.Context, user *User) error
+	// FindByEmail and FindByID preload the user's role and groups with their
+	// permissions, so the effective roles can be computed.
 	FindByEmail(ctx context.Context, email string) (*User, error)
 	FindByID(ctx context.Context, id uuid.UUID) (*User, error)
+	// List returns one page of users matching the filter, ordered by creation
+	// time and then ID, with their direct role preloaded.
+	List(ctx context.Context, filter ListFilter) ([]User, error)
+	// ListByGroup returns the members of a group.
+	ListByGroup(ctx context.Context, groupID uuid.UUID) ([]User, error)
 	UpdateRole(ctx context.Context, userID uuid.UUID, roleID *uuid.UUID) error
+	UpdateProfile(ctx context.Context, userID uuid.UUID, profile Profile) error
+	UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error
+	UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error
+	// SetDisabledAt disables the user at the given time, or enables them when it is nil.
+	SetDisabledAt(ctx context.Context, userID uuid.UUID, disabledAt *time.Time) error
+	// Delete removes the user along with their memberships, tokens and password history.
+	Delete(ctx context.Context, userID uuid.UUID) error
+}
+
+// ListFilter narrows a user listing. Zero-valued fields don't filter.
+type ListFilter struct {
+	EmailPrefix   string
+	RoleName      string
+	Status        string
+	CreatedAfter  *time.Time
+	CreatedBefore *time.Time
+	// After resumes the listing past the user it points at.
+	After *Cursor
+	Limit int
+}
+
+// Cursor marks a position in the creation-ordered user listing.
+type Cursor struct {
+	CreatedAt time.Time
+	ID        uuid.UUID
 }
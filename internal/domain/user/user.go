@@ -1,20 +1,50 @@
 package user
 
 import (
+	"errors"
+	"slices"
 	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/google/uuid"
 )
 
 type User struct {
-	ID        uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
-	Email     string     `json:"email" gorm:"uniqueIndex;not null"`
-	Password  *string    `json:"password"`
-	RoleID    *uuid.UUID `json:"role_id" gorm:"type:uuid;index"`
-	Role      *role.Role `json:"role" gorm:"foreignKey:RoleID"`
-	CreatedAt time.Time  `json:"created_at"`
-	UpdatedAt time.Time  `json:"updated_at"`
+	ID         uuid.UUID     `json:"id" gorm:"type:uuid;primary_key"`
+	Email      string        `json:"email" gorm:"uniqueIndex;not null"`
+	Password   *string       `json:"password"`
+	RoleID     *uuid.UUID    `json:"role_id" gorm:"type:uuid;index"`
+	Role       *role.Role    `json:"role" gorm:"foreignKey:RoleID"`
+	Profile    Profile       `json:"profile" gorm:"embedded"`
+	Groups     []group.Group `json:"groups,omitempty" gorm:"many2many:group_members"`
+	DisabledAt *time.Time    `json:"disabled_at,omitempty" gorm:"index"`
+	CreatedAt  time.Time     `json:"created_at"`
+	UpdatedAt  time.Time     `json:"updated_at"`
+}
+
+// Profile holds the details users manage about themselves.
+type Profile struct {
+	DisplayName string `json:"display_name"`
+	AvatarURL   string `json:"avatar_url"`
+	Locale      string `json:"locale"`
+	Timezone    string `json:"timezone"`
+}
+
+// Account statuses, as reported by Status and accepted by ListFilter.
+const (
+	StatusActive   = "active"
+	StatusDisabled = "disabled"
+)
+
+// ErrUserDisabled is returned when a disabled account tries to authenticate.
+var ErrUserDisabled = errors.New("user account is disabled")
+
+// RoleGrant records one way a user holds a role: directly when Group is nil,
+// otherwise through membership of Group.
+type RoleGrant struct {
+	Role  *role.Role
+	Group *group.Group
 }
 
 func New(email string, password *string) *User {
@@ -26,3 +56,82 @@ func New(email string, password *string) *User {
 		UpdatedAt: time.Now(),
 	}
 }
+
+func (u *User) HasPassword() bool {
+	return u.Password != nil
+}
+
+func (u *User) IsDisabled() bool {
+	return u.DisabledAt != nil
+}
+
+func (u *User) Status() string {
+	if u.IsDisabled() {
+		return StatusDisabled
+	}
+	return StatusActive
+}
+
+// RoleGrants lists the directly assigned role followed by the roles of each
+// group. A role granted several ways appears once per grant.
+func (u *User) RoleGrants() []RoleGrant {
+	var grants []RoleGrant
+	if u.Role != nil {
+		grants = append(grants, RoleGrant{Role: u.Role})
+	}
+	for i := range u.Groups {
+		g := &u.Groups[i]
+		for j := range g.Roles {
+			grants = append(grants, RoleGrant{Role: &g.Roles[j], Group: g})
+		}
+	}
+	return grants
+}
+
+// EffectiveRoles returns the distinct roles the user holds directly or
+// through groups.
+func (u *User) EffectiveRoles() []*role.Role {
+	var roles []*role.Role
+	seen := map[uuid.UUID]bool{}
+	for _, grant := range u.RoleGrants() {
+		if !seen[grant.Role.ID] {
+			seen[grant.Role.ID] = true
+			roles = append(roles, grant.Role)
+		}
+	}
+	return roles
+}
+
+func (u *User) EffectiveRoleNames() []string {
+	roles := u.EffectiveRoles()
+	names := make([]string, len(roles))
+	for i, r := range roles {
+		names[i] = r.Name
+	}
+	return names
+}
+
+// EffectivePermissions returns the union of the permissions of every effective role.
+func (u *User) EffectivePermissions() []string {
+	var permissions []string
+	for _, r := range u.EffectiveRoles() {
+		for _, p := range r.GetPermissionStrings() {
+			if !slices.Contains(permissions, p) {
+				permissions = append(permissions, p)
+			}
+		}
+	}
+	return permissions
+}
+
+func (u *User) HasRole(name string) bool {
+	return slices.Contains(u.EffectiveRoleNames(), name)
+}
+
+func (u *User) GroupNames() []string {
+	names := make([]string, len(u.Groups))
+	for i, g := range u.Groups {
+		names[i] = g.Name
+	}
+	return names
+}
@@ -0,0 +1,87 @@
+package webhook
+
+import (
+	"errors"
+	"time"
+
+	"github.com/google/uuid"
+)
+
+var ErrDeliveryNotFound = errors.New("webhook delivery not found")
+
+// Delivery statuses
+const (
+	StatusPending   = "pending"
+	StatusDelivered = "delivered"
+	// StatusDead is a delivery that failed every attempt; only a replay sends it again.
+	StatusDead = "dead"
+)
+
+// Delivery is one event sent to one webhook, with its attempts so far
+type Delivery struct {
+	ID             uuid.UUID  `json:"id" gorm:"type:uuid;primary_key"`
+	WebhookID      uuid.UUID  `json:"webhook_id" gorm:"type:uuid;not null;index"`
+	MessageID      uuid.UUID  `json:"message_id" gorm:"type:uuid;not null;index"`
+	EventType      string     `json:"event_type" gorm:"not null"`
+	Status         string     `json:"status" gorm:"not null;index:idx_webhook_deliveries_due,priority:1"`
+	Attempts       int        `json:"attempts" gorm:"not null;default:0"`
+	NextAttemptAt  time.Time  `json:"next_attempt_at" gorm:"not null;index:idx_webhook_deliveries_due,priority:2"`
+	LastStatusCode int        `json:"last_status_code,omitempty"`
+	LastError      string     `json:"last_error,omitempty"`
+	DeliveredAt    *time.Time `json:"delivered_at,omitempty"`
+	CreatedAt      time.Time  `json:"created_at"`
+	UpdatedAt      time.Time  `json:"updated_at"`
+}
+
+func (Delivery) TableName() string {
+	return "webhook_deliveries"
+}
+
+// NewDelivery creates a delivery due now
+func NewDelivery(webhookID, messageID uuid.UUID, eventType string) *Delivery {
+	now := time.Now()
+	return &Delivery{
+		ID:            uuid.New(),
+		WebhookID:     webhookID,
+		MessageID:     messageID,
+		EventType:     eventType,
+		Status:        StatusPending,
+		NextAttemptAt: now,
+		CreatedAt:     now,
+		UpdatedAt:     now,
+	}
+}
+
+// Succeed records a successful attempt
+func (d *Delivery) Succeed(statusCode int, now time.Time) {
+	d.Attempts++
+	d.Status = StatusDelivered
+	d.LastStatusCode = statusCode
+	d.LastError = ""
+	d.DeliveredAt = &now
+	d.UpdatedAt = now
+}
+
+// Fail records a failed attempt and schedules the next one, or marks the
+// delivery dead when the policy allows no more. statusCode is 0 when no
+// response was received.
+func (d *Delivery) Fail(statusCode int, reason string, now time.Time, policy RetryPolicy) {
+	d.Attempts++
+	d.LastStatusCode = statusCode
+	d.LastError = reason
+	d.UpdatedAt = now
+	if delay, ok := policy.Backoff(d.Attempts); ok {
+		d.NextAttemptAt = now.Add(delay)
+		return
+	}
+	d.Status = StatusDead
+}
+
+// Replay makes the delivery due now with a fresh set of attempts
+func (d *Delivery) Replay(now time.Time) {
+	d.Status = StatusPending
+	d.Attempts = 0
+	d.NextAttemptAt = now
+	d.DeliveredAt = nil
+	d.UpdatedAt = now
+}
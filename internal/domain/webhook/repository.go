@@ -0,0 +1,31 @@
+package webhook
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+)
+
+type Repository interface {
+	Create(ctx context.Context, webhook *Webhook) error
+	FindByID(ctx context.Context, id uuid.UUID) (*Webhook, error)
+	// List returns webhooks newest first.
+	List(ctx context.Context) ([]Webhook, error)
+	// Delete deletes the webhook with its deliveries; it returns ErrWebhookNotFound when it doesn't exist.
+	Delete(ctx context.Context, id uuid.UUID) error
+
+	CreateDeliveries(ctx context.Context, deliveries []Delivery) error
+	// ListDueDeliveries returns pending deliveries whose next attempt is due, oldest first.
+	ListDueDeliveries(ctx context.Context, limit int) ([]Delivery, error)
+	// ListDeliveries returns deliveries newest first.
+	ListDeliveries(ctx context.Context, filter DeliveryFilter) ([]Delivery, error)
+	FindDelivery(ctx context.Context, id uuid.UUID) (*Delivery, error)
+	UpdateDelivery(ctx context.Context, delivery *Delivery) error
+}
+
+// DeliveryFilter narrows a delivery listing. Zero-valued fields don't filter.
+type DeliveryFilter struct {
+	WebhookID uuid.UUID
+	Status    string
+	Limit     int
+}
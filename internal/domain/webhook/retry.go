@@ -0,0 +1,27 @@
+package webhook
+
+import "time"
+
+// RetryPolicy spaces out the attempts of a failing delivery, doubling the
+// delay after each one up to MaxDelay.
+type RetryPolicy struct {
+	MaxAttempts int
+	BaseDelay   time.Duration
+	MaxDelay    time.Duration
+}
+
+// Backoff returns the delay before the next attempt after the given number
+// of attempts, or false once MaxAttempts have been made.
+func (p RetryPolicy) Backoff(attempts int) (time.Duration, bool) {
+	if attempts >= p.MaxAttempts {
+		return 0, false
+	}
+	delay := p.BaseDelay
+	for range attempts - 1 {
+		delay *= 2
+		if delay >= p.MaxDelay {
+			return p.MaxDelay, true
+		}
+	}
+	return min(delay, p.MaxDelay), true
+}
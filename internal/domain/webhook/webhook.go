@@ -0,0 +1,59 @@
+package webhook
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/google/uuid"
+)
+
+var ErrWebhookNotFound = errors.New("webhook not found")
+
+// Webhook is an endpoint registered by an admin to receive events. Requests
+// are signed with Secret, which is stored as is since signing needs it.
+type Webhook struct {
+	ID  uuid.UUID `json:"id" gorm:"type:uuid;primary_key"`
+	URL string    `json:"url" gorm:"not null"`
+	// EventTypes are the events delivered; empty means every event.
+	EventTypes []string  `json:"event_types" gorm:"serializer:json;not null"`
+	Secret     string    `json:"-" gorm:"not null"`
+	CreatedBy  uuid.UUID `json:"created_by" gorm:"type:uuid;not null"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func New(rawURL string, eventTypes []string, secret string, createdBy uuid.UUID) *Webhook {
+	if eventTypes == nil {
+		eventTypes = []string{}
+	}
+	return &Webhook{
+		ID:         uuid.New(),
+		URL:        rawURL,
+		EventTypes: eventTypes,
+		Secret:     secret,
+		CreatedBy:  createdBy,
+		CreatedAt:  time.Now(),
+	}
+}
+
+// Validate checks the URL and the event types
+func (w *Webhook) Validate() error {
+	u, err := url.Parse(w.URL)
+	if err != nil || (u.Scheme != "https" && u.Scheme != "http") || u.Host == "" {
+		return fmt.Errorf("webhook URL must be an absolute http or https URL")
+	}
+	for _, eventType := range w.EventTypes {
+		if !outbox.IsEventType(eventType) {
+			return fmt.Errorf("unknown event type %q", eventType)
+		}
+	}
+	return nil
+}
+
+// Subscribes reports whether the webhook receives events of the given type
+func (w *Webhook) Subscribes(eventType string) bool {
+	return len(w.EventTypes) == 0 || slices.Contains(w.EventTypes, eventType)
+}
@@ -0,0 +1,21 @@
+package mail
+
+import (
+	"context"
+	"log"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+)
+
+// LogMailer writes messages to the application log instead of sending them.
+// It is used when no SMTP server is configured.
+type LogMailer struct{}
+
+func NewLogMailer() *LogMailer {
+	return &LogMailer{}
+}
+
+func (m *LogMailer) Send(_ context.Context, message mail.Message) error {
+	log.Printf("mail to %s: %s\n%s", message.To, message.Subject, message.Body)
+	return nil
+}
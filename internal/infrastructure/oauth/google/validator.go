@@ -3,28 +3,44 @@ package google
 import (
 	"context"
 	"fmt"
+	"net/http"
 	"os"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/oauth"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"go.opentelemetry.io/contrib/instrumentation/net/http/otelhttp"
 	"google.golang.org/api/idtoken"
+	"google.golang.org/api/option"
 )
 
 type GoogleTokenValidator struct {
-	clientID string
+	clientID     string
+	validator    *idtoken.Validator
+	validatorErr error
 }
 
+// NewGoogleTokenValidator validates ID tokens against Google's certificates,
+// fetched with a traced HTTP client so the calls show up in the login's trace.
 func NewGoogleTokenValidator(clientID string) *GoogleTokenValidator {
-	return &GoogleTokenValidator{clientID: clientID}
+	client := &http.Client{Transport: otelhttp.NewTransport(http.DefaultTransport)}
+	validator, err := idtoken.NewValidator(context.Background(), option.WithHTTPClient(client))
+	return &GoogleTokenValidator{clientID: clientID, validator: validator, validatorErr: err}
 }
 
-func (v *GoogleTokenValidator) Validate(ctx context.Context, token string) (*oauth.GoogleUser, error) {
+func (v *GoogleTokenValidator) Validate(ctx context.Context, token string) (_ *oauth.GoogleUser, err error) {
+	ctx, span := tracing.Start(ctx, "google.ValidateIDToken")
+	defer tracing.End(span, &err)
+
+	if v.validatorErr != nil {
+		return nil, fmt.Errorf("failed to create google token validator: %w", v.validatorErr)
+	}
+
 	audience := v.clientID
 	if audience == "" {
 		audience = os.Getenv("GOOGLE_CLIENT_ID")
 	}
 
-
-	payload, err := idtoken.Validate(ctx, token, audience)
+	payload, err := v.validator.Validate(ctx, token, audience)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate google token: %w", err)
 	}
@@ -40,8 +56,14 @@ func (v *GoogleTokenValidator) Validate(ctx context.Context, token string) (*oau
 		name = ""
 	}
 
+	// Optional profile claims
+	picture, _ := payload.Claims["picture"].(string)
+	locale, _ := payload.Claims["locale"].(string)
+
 	return &oauth.GoogleUser{
-		Email: email,
-		Name:  name,
+		Email:   email,
+		Name:    name,
+		Picture: picture,
+		Locale:  locale,
 	}, nil
 }
@@ -0,0 +1,97 @@
+package celeval
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/services/condition"
+	"github.com/google/cel-go/cel"
+)
+
+// Evaluator evaluates policy conditions written in CEL. The expression sees
+// the variables subject, resource and env as maps and action as a string.
+// Compiled programs are cached by expression.
+type Evaluator struct {
+	env      *cel.Env
+	programs sync.Map
+}
+
+func NewEvaluator() (*Evaluator, error) {
+	env, err := cel.NewEnv(
+		cel.Variable("subject", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("resource", cel.MapType(cel.StringType, cel.DynType)),
+		cel.Variable("action", cel.StringType),
+		cel.Variable("env", cel.MapType(cel.StringType, cel.DynType)),
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create CEL environment: %w", err)
+	}
+
+	return &Evaluator{env: env}, nil
+}
+
+func (e *Evaluator) Compile(expression string) error {
+	if expression == "" {
+		return nil
+	}
+	_, err := e.program(expression)
+	return err
+}
+
+func (e *Evaluator) Evaluate(expression string, input condition.Input) (bool, error) {
+	if expression == "" {
+		return true, nil
+	}
+
+	prg, err := e.program(expression)
+	if err != nil {
+		return false, err
+	}
+
+	out, _, err := prg.Eval(map[string]any{
+		"subject":  nonNil(input.Subject),
+		"resource": nonNil(input.Resource),
+		"action":   input.Action,
+		"env":      nonNil(input.Env),
+	})
+	if err != nil {
+		return false, fmt.Errorf("failed to evaluate condition: %w", err)
+	}
+
+	result, ok := out.Value().(bool)
+	if !ok {
+		return false, fmt.Errorf("condition did not evaluate to a boolean")
+	}
+
+	return result, nil
+}
+
+func (e *Evaluator) program(expression string) (cel.Program, error) {
+	if cached, ok := e.programs.Load(expression); ok {
+		return cached.(cel.Program), nil
+	}
+
+	ast, issues := e.env.Compile(expression)
+	if issues != nil && issues.Err() != nil {
+		return nil, fmt.Errorf("invalid condition: %w", issues.Err())
+	}
+
+	if ast.OutputType() != cel.BoolType && ast.OutputType() != cel.DynType {
+		return nil, fmt.Errorf("condition must evaluate to a boolean, got %s", ast.OutputType())
+	}
+
+	prg, err := e.env.Program(ast)
+	if err != nil {
+		return nil, fmt.Errorf("failed to build condition program: %w", err)
+	}
+
+	e.programs.Store(expression, prg)
+	return prg, nil
+}
+
+func nonNil(m map[string]any) map[string]any {
+	if m == nil {
+		return map[string]any{}
+	}
+	return m
+}
@@ -0,0 +1,87 @@
+// Package leader elects one instance among replicas sharing a Postgres
+// database, using a session-level advisory lock.
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"fmt"
+	"sync"
+)
+
+// AdvisoryLock is held by at most one instance at a time. The holder keeps
+// a dedicated connection open; when it closes or dies, Postgres releases
+// the lock and another instance can take it.
+type AdvisoryLock struct {
+	db  *sql.DB
+	key int64
+
+	mu   sync.Mutex
+	conn *sql.Conn
+}
+
+// NewAdvisoryLock creates an election on the advisory lock key
+func NewAdvisoryLock(db *sql.DB, key int64) *AdvisoryLock {
+	return &AdvisoryLock{db: db, key: key}
+}
+
+// Acquire reports whether this instance holds the lock, trying to take it
+// when it doesn't
+func (l *AdvisoryLock) Acquire(ctx context.Context) (bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn != nil {
+		err := l.conn.PingContext(ctx)
+		if err == nil {
+			return true, nil
+		}
+		if ctx.Err() != nil {
+			return false, ctx.Err()
+		}
+		// The session is gone, and the lock with it
+		discard(l.conn)
+		l.conn = nil
+	}
+
+	conn, err := l.db.Conn(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get a connection: %w", err)
+	}
+	var locked bool
+	if err := conn.QueryRowContext(ctx, `SELECT pg_try_advisory_lock($1)`, l.key).Scan(&locked); err != nil {
+		discard(conn)
+		return false, fmt.Errorf("failed to try the advisory lock: %w", err)
+	}
+	if !locked {
+		conn.Close()
+		return false, nil
+	}
+	l.conn = conn
+	return true, nil
+}
+
+// Release unlocks the lock if this instance holds it
+func (l *AdvisoryLock) Release(ctx context.Context) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if l.conn == nil {
+		return nil
+	}
+	defer func() { l.conn = nil }()
+
+	if _, err := l.conn.ExecContext(ctx, `SELECT pg_advisory_unlock($1)`, l.key); err != nil {
+		discard(l.conn)
+		return fmt.Errorf("failed to release the advisory lock: %w", err)
+	}
+	return l.conn.Close()
+}
+
+// discard closes the underlying connection instead of returning it to the
+// pool, so a lock it may still hold can't linger on a pooled connection.
+func discard(conn *sql.Conn) {
+	conn.Raw(func(any) error { return driver.ErrBadConn })
+	conn.Close()
+}
@@ -0,0 +1,101 @@
+package leader
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	postgrescontainer "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+const testKey int64 = 42
+
+// openReplicas starts a Postgres container and opens one pool per replica.
+func openReplicas(t *testing.T, n int) []*sql.DB {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	postgresContainer, err := postgrescontainer.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgrescontainer.WithDatabase("testdb"),
+		postgrescontainer.WithUsername("testuser"),
+		postgrescontainer.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { postgresContainer.Terminate(ctx) })
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+
+	pools := make([]*sql.DB, n)
+	for i := range pools {
+		db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+		require.NoError(t, err)
+		pools[i], err = db.DB()
+		require.NoError(t, err)
+		t.Cleanup(func() { pools[i].Close() })
+	}
+	return pools
+}
+
+func TestAdvisoryLock_OneLeaderAtATime(t *testing.T) {
+	ctx := context.Background()
+	pools := openReplicas(t, 2)
+	first := NewAdvisoryLock(pools[0], testKey)
+	second := NewAdvisoryLock(pools[1], testKey)
+
+	leader, err := first.Acquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, leader)
+
+	leader, err = second.Acquire(ctx)
+	require.NoError(t, err)
+	assert.False(t, leader, "the lock is held by the first replica")
+
+	// The leader keeps its leadership on later turns
+	leader, err = first.Acquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, leader)
+
+	require.NoError(t, first.Release(ctx))
+	leader, err = second.Acquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, leader, "the second replica takes over once released")
+
+	leader, err = first.Acquire(ctx)
+	require.NoError(t, err)
+	assert.False(t, leader)
+	require.NoError(t, second.Release(ctx))
+}
+
+func TestAdvisoryLock_LeaderDies(t *testing.T) {
+	ctx := context.Background()
+	pools := openReplicas(t, 2)
+	first := NewAdvisoryLock(pools[0], testKey)
+	second := NewAdvisoryLock(pools[1], testKey)
+
+	leader, err := first.Acquire(ctx)
+	require.NoError(t, err)
+	require.True(t, leader)
+
+	// Closing the pool ends the leader's session, as a crash would
+	first.conn.Close()
+	pools[0].Close()
+
+	leader, err = second.Acquire(ctx)
+	require.NoError(t, err)
+	assert.True(t, leader)
+	require.NoError(t, second.Release(ctx))
+}
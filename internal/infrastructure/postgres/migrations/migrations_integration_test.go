@@ -0,0 +1,235 @@
+package migrations
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/testcontainers/testcontainers-go"
+	postgrescontainer "github.com/testcontainers/testcontainers-go/modules/postgres"
+	"github.com/testcontainers/testcontainers-go/wait"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// models are the entities the repositories persist. Adding one here without
+// a migration fails TestMigrations_MatchModels.
+var models = []any{
+	&user.User{}, &token.RefreshToken{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+	&policy.Policy{}, &relation.Tuple{}, &organization.Organization{}, &organization.Membership{},
+	&organization.Invitation{}, &impersonation.Session{}, &device.Authorization{}, &token.PersonalAccessToken{},
+	&apikey.APIKey{}, &passwordless.Challenge{}, &user.PasswordHistoryEntry{}, &audit.Event{},
+	&outbox.Message{}, &webhook.Webhook{}, &webhook.Delivery{},
+}
+
+// setupPostgres starts a Postgres container and returns its connection string.
+func setupPostgres(t *testing.T) string {
+	testcontainers.SkipIfProviderIsNotHealthy(t)
+	ctx := context.Background()
+
+	postgresContainer, err := postgrescontainer.RunContainer(ctx,
+		testcontainers.WithImage("postgres:16-alpine"),
+		postgrescontainer.WithDatabase("testdb"),
+		postgrescontainer.WithUsername("testuser"),
+		postgrescontainer.WithPassword("testpass"),
+		testcontainers.WithWaitStrategy(
+			wait.ForLog("database system is ready to accept connections").
+				WithOccurrence(2).
+				WithStartupTimeout(60*time.Second),
+		),
+	)
+	require.NoError(t, err)
+	t.Cleanup(func() { postgresContainer.Terminate(ctx) })
+
+	connStr, err := postgresContainer.ConnectionString(ctx, "sslmode=disable")
+	require.NoError(t, err)
+	return connStr
+}
+
+func openDB(t *testing.T, connStr string) (*gorm.DB, *sql.DB) {
+	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
+	require.NoError(t, err)
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	t.Cleanup(func() { sqlDB.Close() })
+	return db, sqlDB
+}
+
+// describeSchema lists the columns, indexes and constraints of the public
+// schema, leaving out the migrations' own bookkeeping table.
+func describeSchema(t *testing.T, db *gorm.DB) []string {
+	var schema []string
+	queries := []string{
+		`SELECT table_name || '.' || column_name || ' ' || data_type || ' nullable=' || is_nullable || ' default=' || COALESCE(column_default, '')
+		 FROM information_schema.columns WHERE table_schema = 'public' AND table_name <> 'schema_migrations'`,
+		`SELECT indexdef FROM pg_indexes WHERE schemaname = 'public' AND tablename <> 'schema_migrations'`,
+		`SELECT conrelid::regclass::text || ' ' || conname || ' ' || pg_get_constraintdef(oid)
+		 FROM pg_constraint WHERE connamespace = 'public'::regnamespace AND conrelid::regclass::text <> 'schema_migrations'`,
+	}
+	for _, query := range queries {
+		var rows []string
+		require.NoError(t, db.Raw(query+" ORDER BY 1").Scan(&rows).Error)
+		schema = append(schema, rows...)
+	}
+	return schema
+}
+
+func TestMigrations_MatchModels(t *testing.T) {
+	ctx := context.Background()
+	connStr := setupPostgres(t)
+
+	migratedDB, sqlDB := openDB(t, connStr)
+	migrator, err := NewMigrator(sqlDB)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, migratedDB.Exec("CREATE DATABASE models").Error)
+	modelsDB, modelsSQLDB := openDB(t, strings.Replace(connStr, "/testdb?", "/models?", 1))
+	require.NoError(t, modelsDB.AutoMigrate(models...))
+
+	assert.Equal(t, describeSchema(t, modelsDB), describeSchema(t, migratedDB))
+
+	// Databases auto-migrated by earlier releases adopt the migrations
+	modelsMigrator, err := NewMigrator(modelsSQLDB)
+	require.NoError(t, err)
+	_, err = modelsMigrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, describeSchema(t, migratedDB), describeSchema(t, modelsDB))
+}
+
+// The models of the first release, which created the schema with AutoMigrate
+type (
+	baselineUser struct {
+		ID        uuid.UUID `gorm:"type:uuid;primary_key"`
+		Email     string    `gorm:"uniqueIndex;not null"`
+		Password  *string
+		RoleID    *uuid.UUID    `gorm:"type:uuid;index"`
+		Role      *baselineRole `gorm:"foreignKey:RoleID"`
+		CreatedAt time.Time
+		UpdatedAt time.Time
+	}
+	baselineRole struct {
+		ID          uuid.UUID            `gorm:"type:uuid;primary_key"`
+		Name        string               `gorm:"uniqueIndex;not null"`
+		Permissions []baselinePermission `gorm:"foreignKey:RoleID"`
+		CreatedAt   time.Time
+		UpdatedAt   time.Time
+	}
+	baselinePermission struct {
+		ID     uuid.UUID `gorm:"type:uuid;primary_key"`
+		Name   string    `gorm:"not null"`
+		RoleID uuid.UUID `gorm:"type:uuid;index"`
+	}
+)
+
+func (baselineUser) TableName() string       { return "users" }
+func (baselineRole) TableName() string       { return "roles" }
+func (baselinePermission) TableName() string { return "permissions" }
+
+func TestMigrations_AdoptBaselineSchema(t *testing.T) {
+	ctx := context.Background()
+	connStr := setupPostgres(t)
+
+	migratedDB, sqlDB := openDB(t, connStr)
+	migrator, err := NewMigrator(sqlDB)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
+	require.NoError(t, err)
+
+	require.NoError(t, migratedDB.Exec("CREATE DATABASE baseline").Error)
+	baselineDB, baselineSQLDB := openDB(t, strings.Replace(connStr, "/testdb?", "/baseline?", 1))
+	require.NoError(t, baselineDB.AutoMigrate(&baselineUser{}, &token.RefreshToken{}, &baselineRole{}, &baselinePermission{}))
+
+	baselineMigrator, err := NewMigrator(baselineSQLDB)
+	require.NoError(t, err)
+	_, err = baselineMigrator.Up(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, describeSchema(t, migratedDB), describeSchema(t, baselineDB))
+}
+
+func TestMigrations_UpDownStatus(t *testing.T) {
+	ctx := context.Background()
+	db, sqlDB := openDB(t, setupPostgres(t))
+	migrator, err := NewMigrator(sqlDB)
+	require.NoError(t, err)
+	all, err := Load()
+	require.NoError(t, err)
+
+	// Before the first migration there's no schema_migrations table yet
+	pending, err := migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Len(t, pending, len(all))
+
+	// Replicas starting together apply each migration once
+	var wg sync.WaitGroup
+	var mu sync.Mutex
+	var applied []Migration
+	for range 3 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			done, err := migrator.Up(ctx)
+			assert.NoError(t, err)
+			mu.Lock()
+			applied = append(applied, done...)
+			mu.Unlock()
+		}()
+	}
+	wg.Wait()
+	assert.Len(t, applied, len(all))
+
+	pending, err = migrator.Pending(ctx)
+	require.NoError(t, err)
+	assert.Empty(t, pending)
+
+	statuses, err := migrator.Status(ctx)
+	require.NoError(t, err)
+	require.Len(t, statuses, len(all))
+	for _, status := range statuses {
+		assert.NotNil(t, status.AppliedAt, "%d_%s", status.Version, status.Name)
+		assert.False(t, status.Unknown)
+	}
+
+	rolledBack, err := migrator.Down(ctx, len(all))
+	require.NoError(t, err)
+	assert.Len(t, rolledBack, len(all))
+	assert.Equal(t, all[len(all)-1].Version, rolledBack[0].Version, "newest first")
+	assert.Empty(t, describeSchema(t, db))
+
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	for _, status := range statuses {
+		assert.Nil(t, status.AppliedAt, "%d_%s", status.Version, status.Name)
+	}
+
+	// Versions applied by a newer release show up as unknown
+	require.NoError(t, db.Exec(fmt.Sprintf(
+		"INSERT INTO schema_migrations (version, name) VALUES (%d, 'from_the_future')", all[len(all)-1].Version+1)).Error)
+	statuses, err = migrator.Status(ctx)
+	require.NoError(t, err)
+	last := statuses[len(statuses)-1]
+	assert.True(t, last.Unknown)
+	assert.Equal(t, "from_the_future", last.Name)
+}
@@ -0,0 +1,58 @@
+package migrations
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLoad_Embedded(t *testing.T) {
+	migrations, err := Load()
+	require.NoError(t, err)
+
+	require.NotEmpty(t, migrations)
+	assert.Equal(t, int64(1), migrations[0].Version)
+	assert.Equal(t, "initial_schema", migrations[0].Name)
+	for i := 1; i < len(migrations); i++ {
+		assert.Greater(t, migrations[i].Version, migrations[i-1].Version)
+	}
+}
+
+func TestLoad_PairsAndOrders(t *testing.T) {
+	fsys := fstest.MapFS{
+		"sql/0010_add_audit.up.sql":   {Data: []byte("CREATE TABLE audit ();")},
+		"sql/0010_add_audit.down.sql": {Data: []byte("DROP TABLE audit;")},
+		"sql/0002_initial.up.sql":     {Data: []byte("CREATE TABLE users ();")},
+		"sql/0002_initial.down.sql":   {Data: []byte("DROP TABLE users;")},
+	}
+
+	migrations, err := load(fsys, "sql")
+	require.NoError(t, err)
+	require.Len(t, migrations, 2)
+	assert.Equal(t, Migration{Version: 2, Name: "initial", Up: "CREATE TABLE users ();", Down: "DROP TABLE users;"}, migrations[0])
+	assert.Equal(t, int64(10), migrations[1].Version)
+}
+
+func TestLoad_Invalid(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"missing down": {
+			"sql/0001_initial.up.sql": {Data: []byte("SELECT 1;")},
+		},
+		"mismatched names": {
+			"sql/0001_initial.up.sql": {Data: []byte("SELECT 1;")},
+			"sql/0001_other.down.sql": {Data: []byte("SELECT 1;")},
+		},
+		"bad file name": {
+			"sql/initial.sql": {Data: []byte("SELECT 1;")},
+		},
+	}
+
+	for name, fsys := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := load(fsys, "sql")
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,18 @@
+DROP TABLE IF EXISTS "password_history";
+DROP TABLE IF EXISTS "passwordless_challenges";
+DROP TABLE IF EXISTS "api_keys";
+DROP TABLE IF EXISTS "personal_access_tokens";
+DROP TABLE IF EXISTS "device_authorizations";
+DROP TABLE IF EXISTS "impersonation_sessions";
+DROP TABLE IF EXISTS "invitations";
+DROP TABLE IF EXISTS "memberships";
+DROP TABLE IF EXISTS "organizations";
+DROP TABLE IF EXISTS "relation_tuples";
+DROP TABLE IF EXISTS "policies";
+DROP TABLE IF EXISTS "refresh_tokens";
+DROP TABLE IF EXISTS "group_roles";
+DROP TABLE IF EXISTS "group_members";
+DROP TABLE IF EXISTS "groups";
+DROP TABLE IF EXISTS "users";
+DROP TABLE IF EXISTS "permissions";
+DROP TABLE IF EXISTS "roles";
@@ -0,0 +1,254 @@
+-- Baseline schema, as AutoMigrate created it. Every statement is idempotent
+-- so databases that were auto-migrated before adopt it as version 1; the
+-- columns added since the first release are added to their existing tables
+-- before anything indexes them.
+
+CREATE TABLE IF NOT EXISTS "roles" (
+    "id" uuid,
+    "organization_id" uuid,
+    "name" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+ALTER TABLE "roles" ADD COLUMN IF NOT EXISTS "organization_id" uuid;
+-- Role names used to be unique globally; organizations now reuse them.
+-- NULLs are distinct in a unique index, so global roles get their own.
+DROP INDEX IF EXISTS "idx_roles_name";
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_roles_global_name" ON "roles" ("name") WHERE organization_id IS NULL;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_roles_organization_name" ON "roles" ("organization_id", "name") WHERE organization_id IS NOT NULL;
+
+CREATE TABLE IF NOT EXISTS "permissions" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "role_id" uuid,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_roles_permissions" FOREIGN KEY ("role_id") REFERENCES "roles" ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_permissions_role_id" ON "permissions" ("role_id");
+
+CREATE TABLE IF NOT EXISTS "users" (
+    "id" uuid,
+    "email" text NOT NULL,
+    "password" text,
+    "role_id" uuid,
+    "display_name" text,
+    "avatar_url" text,
+    "locale" text,
+    "timezone" text,
+    "disabled_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_users_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id")
+);
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "display_name" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "avatar_url" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "locale" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "timezone" text;
+ALTER TABLE "users" ADD COLUMN IF NOT EXISTS "disabled_at" timestamptz;
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_users_email" ON "users" ("email");
+CREATE INDEX IF NOT EXISTS "idx_users_role_id" ON "users" ("role_id");
+CREATE INDEX IF NOT EXISTS "idx_users_disabled_at" ON "users" ("disabled_at");
+
+CREATE TABLE IF NOT EXISTS "groups" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "description" text,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_groups_name" ON "groups" ("name");
+
+CREATE TABLE IF NOT EXISTS "group_members" (
+    "user_id" uuid,
+    "group_id" uuid,
+    "created_at" timestamptz,
+    PRIMARY KEY ("user_id", "group_id")
+);
+CREATE INDEX IF NOT EXISTS "idx_group_members_group_id" ON "group_members" ("group_id");
+
+CREATE TABLE IF NOT EXISTS "group_roles" (
+    "group_id" uuid,
+    "role_id" uuid,
+    PRIMARY KEY ("group_id", "role_id"),
+    CONSTRAINT "fk_group_roles_group" FOREIGN KEY ("group_id") REFERENCES "groups" ("id"),
+    CONSTRAINT "fk_group_roles_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "refresh_tokens" (
+    "id" uuid,
+    "user_id" uuid NOT NULL,
+    "token_hash" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_user_id" ON "refresh_tokens" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_refresh_tokens_token_hash" ON "refresh_tokens" ("token_hash");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_expires_at" ON "refresh_tokens" ("expires_at");
+CREATE INDEX IF NOT EXISTS "idx_refresh_tokens_revoked_at" ON "refresh_tokens" ("revoked_at");
+
+CREATE TABLE IF NOT EXISTS "policies" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "description" text,
+    "action" text NOT NULL,
+    "resource_type" text,
+    "effect" text NOT NULL,
+    "condition" text,
+    "enabled" boolean NOT NULL DEFAULT true,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_policies_name" ON "policies" ("name");
+CREATE INDEX IF NOT EXISTS "idx_policies_action" ON "policies" ("action");
+CREATE INDEX IF NOT EXISTS "idx_policies_resource_type" ON "policies" ("resource_type");
+
+CREATE TABLE IF NOT EXISTS "relation_tuples" (
+    "id" uuid,
+    "namespace" text NOT NULL,
+    "object_id" text NOT NULL,
+    "relation" text NOT NULL,
+    "subject_namespace" text NOT NULL,
+    "subject_id" text NOT NULL,
+    "subject_relation" text NOT NULL DEFAULT '',
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_relation_tuples_unique" ON "relation_tuples" ("namespace", "object_id", "relation", "subject_namespace", "subject_id", "subject_relation");
+CREATE INDEX IF NOT EXISTS "idx_relation_tuples_object" ON "relation_tuples" ("namespace", "object_id", "relation");
+CREATE INDEX IF NOT EXISTS "idx_relation_tuples_subject" ON "relation_tuples" ("subject_namespace", "subject_id");
+
+CREATE TABLE IF NOT EXISTS "organizations" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "slug" text NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_organizations_slug" ON "organizations" ("slug");
+
+CREATE TABLE IF NOT EXISTS "memberships" (
+    "id" uuid,
+    "organization_id" uuid NOT NULL,
+    "user_id" uuid NOT NULL,
+    "role_id" uuid NOT NULL,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_memberships_user" FOREIGN KEY ("user_id") REFERENCES "users" ("id"),
+    CONSTRAINT "fk_memberships_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_memberships_organization_user" ON "memberships" ("organization_id", "user_id");
+CREATE INDEX IF NOT EXISTS "idx_memberships_user_id" ON "memberships" ("user_id");
+
+CREATE TABLE IF NOT EXISTS "invitations" (
+    "id" uuid,
+    "organization_id" uuid NOT NULL,
+    "inviter_id" uuid NOT NULL,
+    "email" text NOT NULL,
+    "role_id" uuid NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "accepted_at" timestamptz,
+    "revoked_at" timestamptz,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id"),
+    CONSTRAINT "fk_invitations_role" FOREIGN KEY ("role_id") REFERENCES "roles" ("id"),
+    CONSTRAINT "fk_invitations_organization" FOREIGN KEY ("organization_id") REFERENCES "organizations" ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_invitations_organization_email" ON "invitations" ("organization_id", "email");
+
+CREATE TABLE IF NOT EXISTS "impersonation_sessions" (
+    "id" uuid,
+    "actor_id" uuid NOT NULL,
+    "actor_email" text NOT NULL,
+    "user_id" uuid NOT NULL,
+    "user_email" text NOT NULL,
+    "reason" text NOT NULL,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_actor_id" ON "impersonation_sessions" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_user_id" ON "impersonation_sessions" ("user_id");
+CREATE INDEX IF NOT EXISTS "idx_impersonation_sessions_created_at" ON "impersonation_sessions" ("created_at");
+
+CREATE TABLE IF NOT EXISTS "device_authorizations" (
+    "id" uuid,
+    "device_code_hash" text NOT NULL,
+    "user_code" text NOT NULL,
+    "client_id" text NOT NULL,
+    "status" text NOT NULL,
+    "user_id" uuid,
+    "interval" bigint NOT NULL,
+    "last_polled_at" timestamptz,
+    "expires_at" timestamptz NOT NULL,
+    "created_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_device_authorizations_device_code_hash" ON "device_authorizations" ("device_code_hash");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_device_authorizations_user_code" ON "device_authorizations" ("user_code");
+CREATE INDEX IF NOT EXISTS "idx_device_authorizations_expires_at" ON "device_authorizations" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "personal_access_tokens" (
+    "id" uuid,
+    "user_id" uuid NOT NULL,
+    "name" text NOT NULL,
+    "token_hash" text NOT NULL,
+    "scopes" text NOT NULL,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "created_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_personal_access_tokens_user_id" ON "personal_access_tokens" ("user_id");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_personal_access_tokens_token_hash" ON "personal_access_tokens" ("token_hash");
+
+CREATE TABLE IF NOT EXISTS "api_keys" (
+    "id" uuid,
+    "name" text NOT NULL,
+    "key_hash" text NOT NULL,
+    "service_account" text,
+    "organization_id" uuid,
+    "permissions" text NOT NULL,
+    "allowed_ips" text NOT NULL,
+    "rate_limit" bigint NOT NULL DEFAULT 0,
+    "expires_at" timestamptz,
+    "last_used_at" timestamptz,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz NOT NULL,
+    "revoked_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_api_keys_key_hash" ON "api_keys" ("key_hash");
+CREATE INDEX IF NOT EXISTS "idx_api_keys_organization_id" ON "api_keys" ("organization_id");
+
+CREATE TABLE IF NOT EXISTS "passwordless_challenges" (
+    "id" uuid,
+    "email" text NOT NULL,
+    "method" text NOT NULL,
+    "secret_hash" text NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "expires_at" timestamptz NOT NULL,
+    "consumed_at" timestamptz,
+    "created_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_passwordless_challenges_email" ON "passwordless_challenges" ("email");
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_passwordless_challenges_secret_hash" ON "passwordless_challenges" ("secret_hash");
+CREATE INDEX IF NOT EXISTS "idx_passwordless_challenges_expires_at" ON "passwordless_challenges" ("expires_at");
+
+CREATE TABLE IF NOT EXISTS "password_history" (
+    "id" uuid,
+    "user_id" uuid NOT NULL,
+    "password_hash" text NOT NULL,
+    "created_at" timestamptz NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_password_history_user_id" ON "password_history" ("user_id");
@@ -0,0 +1,33 @@
+-- Hash-chained audit log. Rows are never updated; the trigger turns an
+-- UPDATE into an error, and retention only deletes the oldest events.
+
+CREATE TABLE IF NOT EXISTS "audit_events" (
+    "id" uuid,
+    "sequence" bigint NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "actor_id" uuid,
+    "actor_email" text,
+    "action" text NOT NULL,
+    "target_type" text,
+    "target_id" text,
+    "ip" text,
+    "user_agent" text,
+    "outcome" text NOT NULL,
+    "metadata" text,
+    "prev_hash" text NOT NULL,
+    "hash" text NOT NULL,
+    PRIMARY KEY ("id")
+);
+CREATE UNIQUE INDEX IF NOT EXISTS "idx_audit_events_sequence" ON "audit_events" ("sequence");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_occurred_at" ON "audit_events" ("occurred_at");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_actor_id" ON "audit_events" ("actor_id");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_action" ON "audit_events" ("action");
+CREATE INDEX IF NOT EXISTS "idx_audit_events_target_id" ON "audit_events" ("target_id");
+
+CREATE OR REPLACE FUNCTION "audit_events_append_only"() RETURNS trigger LANGUAGE plpgsql AS $$
+BEGIN
+    RAISE EXCEPTION 'audit events are append-only';
+END
+$$;
+CREATE OR REPLACE TRIGGER "audit_events_no_update" BEFORE UPDATE ON "audit_events"
+    FOR EACH ROW EXECUTE FUNCTION "audit_events_append_only"();
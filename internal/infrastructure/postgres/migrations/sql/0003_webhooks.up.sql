@@ -0,0 +1,42 @@
+-- Transactional outbox and webhook delivery. Messages are written in the
+-- transaction of the change they describe; the dispatcher fans each one out
+-- to a delivery per subscribed webhook.
+
+CREATE TABLE IF NOT EXISTS "outbox_messages" (
+    "id" uuid,
+    "event_type" text NOT NULL,
+    "data" jsonb NOT NULL,
+    "occurred_at" timestamptz NOT NULL,
+    "dispatched_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_outbox_messages_dispatched_at" ON "outbox_messages" ("dispatched_at");
+
+CREATE TABLE IF NOT EXISTS "webhooks" (
+    "id" uuid,
+    "url" text NOT NULL,
+    "event_types" text NOT NULL,
+    "secret" text NOT NULL,
+    "created_by" uuid NOT NULL,
+    "created_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+
+CREATE TABLE IF NOT EXISTS "webhook_deliveries" (
+    "id" uuid,
+    "webhook_id" uuid NOT NULL,
+    "message_id" uuid NOT NULL,
+    "event_type" text NOT NULL,
+    "status" text NOT NULL,
+    "attempts" bigint NOT NULL DEFAULT 0,
+    "next_attempt_at" timestamptz NOT NULL,
+    "last_status_code" bigint,
+    "last_error" text,
+    "delivered_at" timestamptz,
+    "created_at" timestamptz,
+    "updated_at" timestamptz,
+    PRIMARY KEY ("id")
+);
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_webhook_id" ON "webhook_deliveries" ("webhook_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_message_id" ON "webhook_deliveries" ("message_id");
+CREATE INDEX IF NOT EXISTS "idx_webhook_deliveries_due" ON "webhook_deliveries" ("status", "next_attempt_at");
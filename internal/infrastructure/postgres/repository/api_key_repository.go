@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// APIKeyRepository implements apikey.Repository interface
+type APIKeyRepository struct {
+	db *gorm.DB
+}
+
+// NewAPIKeyRepository creates a new API key repository
+func NewAPIKeyRepository(db *gorm.DB) *APIKeyRepository {
+	return &APIKeyRepository{db: db}
+}
+
+// Create stores a new API key
+func (r *APIKeyRepository) Create(ctx context.Context, key *apikey.APIKey) error {
+	return gorm.G[apikey.APIKey](conn(ctx, r.db)).Create(ctx, key)
+}
+
+// FindByKeyHash finds an API key by its hash
+func (r *APIKeyRepository) FindByKeyHash(ctx context.Context, keyHash string) (*apikey.APIKey, error) {
+	key, err := gorm.G[apikey.APIKey](conn(ctx, r.db)).Where("key_hash = ?", keyHash).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, apikey.ErrAPIKeyNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &key, nil
+}
+
+// List returns all API keys newest first
+func (r *APIKeyRepository) List(ctx context.Context) ([]apikey.APIKey, error) {
+	return gorm.G[apikey.APIKey](conn(ctx, r.db)).Order("created_at DESC").Find(ctx)
+}
+
+// Revoke marks an active API key as revoked
+func (r *APIKeyRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	rows, err := gorm.G[apikey.APIKey](conn(ctx, r.db)).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update(ctx, "revoked_at", time.Now())
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return apikey.ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when an API key was last used
+func (r *APIKeyRepository) TouchLastUsed(ctx context.Context, id uuid.UUID, usedAt time.Time) error {
+	_, err := gorm.G[apikey.APIKey](conn(ctx, r.db)).Where("id = ?", id).Update(ctx, "last_used_at", usedAt)
+	return err
+}
@@ -0,0 +1,120 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// auditAppendLockKey serializes appends, so each event is chained to the one before it
+const auditAppendLockKey int64 = 0x61757468_61756474 // "authaudt"
+
+// auditWalkBatchSize is how many events Walk loads at a time
+const auditWalkBatchSize = 500
+
+// AuditRepository implements audit.Repository interface
+type AuditRepository struct {
+	db *gorm.DB
+}
+
+// NewAuditRepository creates a new audit event repository
+func NewAuditRepository(db *gorm.DB) *AuditRepository {
+	return &AuditRepository{db: db}
+}
+
+// Append chains the event to the latest one and stores it
+func (r *AuditRepository) Append(ctx context.Context, event *audit.Event) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Transaction-level, so it's released on commit or rollback
+		if tx.Dialector.Name() == "postgres" {
+			if err := tx.Exec("SELECT pg_advisory_xact_lock(?)", auditAppendLockKey).Error; err != nil {
+				return err
+			}
+		}
+
+		var latest []audit.Event
+		if err := tx.Order("sequence DESC").Limit(1).Find(&latest).Error; err != nil {
+			return err
+		}
+		var prev *audit.Event
+		if len(latest) > 0 {
+			prev = &latest[0]
+		}
+
+		event.Seal(prev)
+		return tx.Create(event).Error
+	})
+}
+
+// List returns audit events newest first
+func (r *AuditRepository) List(ctx context.Context, filter audit.Filter) ([]audit.Event, error) {
+	query := r.filtered(ctx, filter)
+	if filter.BeforeSequence > 0 {
+		query = query.Where("sequence < ?", filter.BeforeSequence)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var events []audit.Event
+	err := query.Order("sequence DESC").Find(&events).Error
+	return events, err
+}
+
+// Walk calls fn with the matching audit events oldest first
+func (r *AuditRepository) Walk(ctx context.Context, filter audit.Filter, fn func(audit.Event) error) error {
+	var after int64
+	for {
+		var batch []audit.Event
+		err := r.filtered(ctx, filter).
+			Where("sequence > ?", after).
+			Order("sequence ASC").
+			Limit(auditWalkBatchSize).
+			Find(&batch).Error
+		if err != nil {
+			return err
+		}
+
+		for _, event := range batch {
+			if err := fn(event); err != nil {
+				return err
+			}
+		}
+		if len(batch) < auditWalkBatchSize {
+			return nil
+		}
+		after = batch[len(batch)-1].Sequence
+	}
+}
+
+// DeleteBefore removes audit events that occurred before the given time
+func (r *AuditRepository) DeleteBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("occurred_at < ?", before).Delete(&audit.Event{})
+	return result.RowsAffected, result.Error
+}
+
+func (r *AuditRepository) filtered(ctx context.Context, filter audit.Filter) *gorm.DB {
+	query := conn(ctx, r.db).Model(&audit.Event{})
+	if filter.ActorID != uuid.Nil {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.Action != "" {
+		query = query.Where("action = ?", filter.Action)
+	}
+	if filter.TargetID != "" {
+		query = query.Where("target_id = ?", filter.TargetID)
+	}
+	if filter.Outcome != "" {
+		query = query.Where("outcome = ?", filter.Outcome)
+	}
+	if !filter.From.IsZero() {
+		query = query.Where("occurred_at >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		query = query.Where("occurred_at < ?", filter.To)
+	}
+	return query
+}
@@ -0,0 +1,86 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// DeviceRepository implements device.Repository interface
+type DeviceRepository struct {
+	db *gorm.DB
+}
+
+// NewDeviceRepository creates a new device authorization repository
+func NewDeviceRepository(db *gorm.DB) *DeviceRepository {
+	return &DeviceRepository{db: db}
+}
+
+// Create stores a new device authorization
+func (r *DeviceRepository) Create(ctx context.Context, authorization *device.Authorization) error {
+	return gorm.G[device.Authorization](conn(ctx, r.db)).Create(ctx, authorization)
+}
+
+// FindByDeviceCodeHash finds a device authorization by the hash of its device code
+func (r *DeviceRepository) FindByDeviceCodeHash(ctx context.Context, deviceCodeHash string) (*device.Authorization, error) {
+	return r.findBy(ctx, "device_code_hash = ?", deviceCodeHash)
+}
+
+// FindByUserCode finds a device authorization by its user code
+func (r *DeviceRepository) FindByUserCode(ctx context.Context, userCode string) (*device.Authorization, error) {
+	return r.findBy(ctx, "user_code = ?", userCode)
+}
+
+func (r *DeviceRepository) findBy(ctx context.Context, query string, arg string) (*device.Authorization, error) {
+	authorization, err := gorm.G[device.Authorization](conn(ctx, r.db)).Where(query, arg).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, device.ErrAuthorizationNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &authorization, nil
+}
+
+// Decide approves or denies a pending device authorization
+func (r *DeviceRepository) Decide(ctx context.Context, id uuid.UUID, userID uuid.UUID, status device.Status) error {
+	result := conn(ctx, r.db).Model(&device.Authorization{}).
+		Where("id = ? AND status = ?", id, device.StatusPending).
+		Updates(map[string]any{"status": status, "user_id": userID})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return device.ErrAuthorizationNotFound
+	}
+	return nil
+}
+
+// DeleteExpired removes authorizations past their expiry
+func (r *DeviceRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := conn(ctx, r.db).Where("expires_at < ?", time.Now()).Delete(&device.Authorization{})
+	return result.RowsAffected, result.Error
+}
+
+// RecordPoll stores the time of the device's latest poll and its polling interval
+func (r *DeviceRepository) RecordPoll(ctx context.Context, id uuid.UUID, polledAt time.Time, interval time.Duration) error {
+	return conn(ctx, r.db).Model(&device.Authorization{}).
+		Where("id = ?", id).
+		Updates(map[string]any{"last_polled_at": polledAt, "interval": interval}).Error
+}
+
+// Delete removes a device authorization
+func (r *DeviceRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	result := conn(ctx, r.db).Where("id = ?", id).Delete(&device.Authorization{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return device.ErrAuthorizationNotFound
+	}
+	return nil
+}
@@ -0,0 +1,101 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// GroupRepository implements group.Repository interface
+type GroupRepository struct {
+	db *gorm.DB
+}
+
+// NewGroupRepository creates a new group repository
+func NewGroupRepository(db *gorm.DB) *GroupRepository {
+	return &GroupRepository{db: db}
+}
+
+// Create creates a new group without touching its roles
+func (r *GroupRepository) Create(ctx context.Context, g *group.Group) error {
+	return conn(ctx, r.db).Omit("Roles").Create(g).Error
+}
+
+// FindByID finds a group with its roles and their permissions preloaded
+func (r *GroupRepository) FindByID(ctx context.Context, id uuid.UUID) (*group.Group, error) {
+	g, err := gorm.G[group.Group](conn(ctx, r.db)).
+		Preload("Roles.Permissions", nil).
+		Where("id = ?", id).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// FindByName finds a group by its name
+func (r *GroupRepository) FindByName(ctx context.Context, name string) (*group.Group, error) {
+	g, err := gorm.G[group.Group](conn(ctx, r.db)).Where("name = ?", name).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &g, nil
+}
+
+// List returns all groups with their roles preloaded
+func (r *GroupRepository) List(ctx context.Context) ([]group.Group, error) {
+	return gorm.G[group.Group](conn(ctx, r.db)).
+		Preload("Roles", nil).
+		Order("name").
+		Find(ctx)
+}
+
+// Delete removes a group with its memberships and role assignments
+func (r *GroupRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("group_id = ?", id).Delete(&group.Member{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Exec("DELETE FROM group_roles WHERE group_id = ?", id).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&group.Group{}, "id = ?", id).Error
+	})
+}
+
+// AddMember adds a user to a group; adding an existing member is a no-op
+func (r *GroupRepository) AddMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	member := &group.Member{UserID: userID, GroupID: groupID, CreatedAt: time.Now()}
+	return conn(ctx, r.db).Clauses(clause.OnConflict{DoNothing: true}).Create(member).Error
+}
+
+// RemoveMember removes a user from a group
+func (r *GroupRepository) RemoveMember(ctx context.Context, groupID, userID uuid.UUID) error {
+	return conn(ctx, r.db).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Delete(&group.Member{}).Error
+}
+
+// IsMember reports whether a user belongs to a group
+func (r *GroupRepository) IsMember(ctx context.Context, groupID, userID uuid.UUID) (bool, error) {
+	count, err := gorm.G[group.Member](conn(ctx, r.db)).
+		Where("group_id = ? AND user_id = ?", groupID, userID).
+		Count(ctx, "*")
+	return count > 0, err
+}
+
+// AssignRole grants a role to every member of a group
+func (r *GroupRepository) AssignRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	return conn(ctx, r.db).Table("group_roles").
+		Clauses(clause.OnConflict{DoNothing: true}).
+		Create(map[string]any{"group_id": groupID, "role_id": roleID}).Error
+}
+
+// UnassignRole removes a role from a group
+func (r *GroupRepository) UnassignRole(ctx context.Context, groupID, roleID uuid.UUID) error {
+	return conn(ctx, r.db).Exec("DELETE FROM group_roles WHERE group_id = ? AND role_id = ?", groupID, roleID).Error
+}
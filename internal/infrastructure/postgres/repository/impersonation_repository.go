@@ -0,0 +1,49 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// ImpersonationRepository implements impersonation.Repository interface
+type ImpersonationRepository struct {
+	db *gorm.DB
+}
+
+// NewImpersonationRepository creates a new impersonation session repository
+func NewImpersonationRepository(db *gorm.DB) *ImpersonationRepository {
+	return &ImpersonationRepository{db: db}
+}
+
+// Create records an impersonation session
+func (r *ImpersonationRepository) Create(ctx context.Context, session *impersonation.Session) error {
+	return gorm.G[impersonation.Session](conn(ctx, r.db)).Create(ctx, session)
+}
+
+// List returns impersonation sessions newest first
+func (r *ImpersonationRepository) List(ctx context.Context, filter impersonation.ListFilter) ([]impersonation.Session, error) {
+	query := conn(ctx, r.db)
+	if filter.ActorID != uuid.Nil {
+		query = query.Where("actor_id = ?", filter.ActorID)
+	}
+	if filter.UserID != uuid.Nil {
+		query = query.Where("user_id = ?", filter.UserID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var sessions []impersonation.Session
+	err := query.Order("created_at DESC").Find(&sessions).Error
+	return sessions, err
+}
+
+// DeleteCreatedBefore removes impersonation sessions started before the given time
+func (r *ImpersonationRepository) DeleteCreatedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("created_at < ?", before).Delete(&impersonation.Session{})
+	return result.RowsAffected, result.Error
+}
@@ -0,0 +1,87 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// InvitationRepository implements organization.InvitationRepository interface
+type InvitationRepository struct {
+	db *gorm.DB
+}
+
+// NewInvitationRepository creates a new invitation repository
+func NewInvitationRepository(db *gorm.DB) *InvitationRepository {
+	return &InvitationRepository{db: db}
+}
+
+// Create creates a new invitation
+func (r *InvitationRepository) Create(ctx context.Context, invitation *organization.Invitation) error {
+	return conn(ctx, r.db).Omit("Role", "Organization").Create(invitation).Error
+}
+
+// FindByID finds an invitation with its role and organization preloaded
+func (r *InvitationRepository) FindByID(ctx context.Context, id uuid.UUID) (*organization.Invitation, error) {
+	invitation, err := gorm.G[organization.Invitation](conn(ctx, r.db)).
+		Preload("Role", nil).
+		Preload("Organization", nil).
+		Where("id = ?", id).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// ListByOrganization returns an organization's invitations, newest first
+func (r *InvitationRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]organization.Invitation, error) {
+	return gorm.G[organization.Invitation](conn(ctx, r.db)).
+		Preload("Role", nil).
+		Where("organization_id = ?", organizationID).
+		Order("created_at DESC").
+		Find(ctx)
+}
+
+// FindPending finds an unexpired invitation for an email that was neither accepted nor revoked
+func (r *InvitationRepository) FindPending(ctx context.Context, organizationID uuid.UUID, email string) (*organization.Invitation, error) {
+	invitation, err := gorm.G[organization.Invitation](conn(ctx, r.db)).
+		Where("organization_id = ? AND email = ? AND accepted_at IS NULL AND revoked_at IS NULL AND expires_at > ?", organizationID, email, time.Now()).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &invitation, nil
+}
+
+// Revoke revokes an invitation
+func (r *InvitationRepository) Revoke(ctx context.Context, id uuid.UUID) error {
+	now := time.Now()
+	return conn(ctx, r.db).Model(&organization.Invitation{}).
+		Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", id).
+		Update("revoked_at", &now).Error
+}
+
+// Accept marks an invitation accepted and creates the membership in one transaction
+func (r *InvitationRepository) Accept(ctx context.Context, invitation *organization.Invitation, membership *organization.Membership) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		now := time.Now()
+		result := tx.Model(&organization.Invitation{}).
+			Where("id = ? AND accepted_at IS NULL AND revoked_at IS NULL", invitation.ID).
+			Update("accepted_at", &now)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return organization.ErrInvitationNotPending
+		}
+		if err := tx.Omit("User", "Role").Create(membership).Error; err != nil {
+			return err
+		}
+		invitation.AcceptedAt = &now
+		return nil
+	})
+}
@@ -0,0 +1,105 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OrganizationRepository implements organization.Repository interface
+type OrganizationRepository struct {
+	db *gorm.DB
+}
+
+// NewOrganizationRepository creates a new organization repository
+func NewOrganizationRepository(db *gorm.DB) *OrganizationRepository {
+	return &OrganizationRepository{db: db}
+}
+
+// Create stores an organization, its roles and the owner's membership in one transaction
+func (r *OrganizationRepository) Create(ctx context.Context, org *organization.Organization, roles []*role.Role, owner *organization.Membership) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Create(org).Error; err != nil {
+			return err
+		}
+		for _, ro := range roles {
+			if err := tx.Create(ro).Error; err != nil {
+				return err
+			}
+		}
+		return tx.Omit("User", "Role").Create(owner).Error
+	})
+}
+
+// FindByID finds an organization by its ID
+func (r *OrganizationRepository) FindByID(ctx context.Context, id uuid.UUID) (*organization.Organization, error) {
+	org, err := gorm.G[organization.Organization](conn(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// FindBySlug finds an organization by its slug
+func (r *OrganizationRepository) FindBySlug(ctx context.Context, slug string) (*organization.Organization, error) {
+	org, err := gorm.G[organization.Organization](conn(ctx, r.db)).Where("slug = ?", slug).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &org, nil
+}
+
+// ListByUser returns the organizations a user is a member of
+func (r *OrganizationRepository) ListByUser(ctx context.Context, userID uuid.UUID) ([]organization.Organization, error) {
+	memberOf := r.db.Model(&organization.Membership{}).Select("organization_id").Where("user_id = ?", userID)
+	return gorm.G[organization.Organization](conn(ctx, r.db)).
+		Where("id IN (?)", memberOf).
+		Order("name").
+		Find(ctx)
+}
+
+// AddMember creates a membership
+func (r *OrganizationRepository) AddMember(ctx context.Context, membership *organization.Membership) error {
+	return conn(ctx, r.db).Omit("User", "Role").Create(membership).Error
+}
+
+// RemoveMember deletes a user's membership in an organization
+func (r *OrganizationRepository) RemoveMember(ctx context.Context, organizationID, userID uuid.UUID) error {
+	_, err := gorm.G[organization.Membership](conn(ctx, r.db)).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		Delete(ctx)
+	return err
+}
+
+// FindMembership finds a user's membership with role permissions preloaded
+func (r *OrganizationRepository) FindMembership(ctx context.Context, organizationID, userID uuid.UUID) (*organization.Membership, error) {
+	m, err := gorm.G[organization.Membership](conn(ctx, r.db)).
+		Preload("Role", nil).
+		Preload("Role.Permissions", nil).
+		Where("organization_id = ? AND user_id = ?", organizationID, userID).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// ListMembers returns an organization's memberships with users and roles preloaded
+func (r *OrganizationRepository) ListMembers(ctx context.Context, organizationID uuid.UUID) ([]organization.Membership, error) {
+	return gorm.G[organization.Membership](conn(ctx, r.db)).
+		Preload("User", nil).
+		Preload("Role", nil).
+		Where("organization_id = ?", organizationID).
+		Order("created_at").
+		Find(ctx)
+}
+
+// CountMembersWithRole counts the members holding a role in an organization
+func (r *OrganizationRepository) CountMembersWithRole(ctx context.Context, organizationID, roleID uuid.UUID) (int64, error) {
+	return gorm.G[organization.Membership](conn(ctx, r.db)).
+		Where("organization_id = ? AND role_id = ?", organizationID, roleID).
+		Count(ctx, "*")
+}
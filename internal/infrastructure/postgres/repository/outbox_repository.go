@@ -0,0 +1,65 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// OutboxRepository implements outbox.Repository interface
+type OutboxRepository struct {
+	db *gorm.DB
+}
+
+// NewOutboxRepository creates a new outbox repository
+func NewOutboxRepository(db *gorm.DB) *OutboxRepository {
+	return &OutboxRepository{db: db}
+}
+
+// Add stores a message, in the transaction of ctx when there is one
+func (r *OutboxRepository) Add(ctx context.Context, message *outbox.Message) error {
+	return gorm.G[outbox.Message](conn(ctx, r.db)).Create(ctx, message)
+}
+
+// ListUndispatched returns messages not yet dispatched, oldest first
+func (r *OutboxRepository) ListUndispatched(ctx context.Context, limit int) ([]outbox.Message, error) {
+	return gorm.G[outbox.Message](conn(ctx, r.db)).
+		Where("dispatched_at IS NULL").
+		Order("occurred_at ASC, id ASC").
+		Limit(limit).
+		Find(ctx)
+}
+
+// MarkDispatched records when messages were dispatched
+func (r *OutboxRepository) MarkDispatched(ctx context.Context, ids []uuid.UUID, dispatchedAt time.Time) error {
+	_, err := gorm.G[outbox.Message](conn(ctx, r.db)).Where("id IN ?", ids).Update(ctx, "dispatched_at", dispatchedAt)
+	return err
+}
+
+// FindByIDs returns the messages with the given IDs
+func (r *OutboxRepository) FindByIDs(ctx context.Context, ids []uuid.UUID) ([]outbox.Message, error) {
+	return gorm.G[outbox.Message](conn(ctx, r.db)).Where("id IN ?", ids).Find(ctx)
+}
+
+// DeleteDispatchedBefore removes messages dispatched before the given time, with their deliveries,
+// unless a delivery is still pending
+func (r *OutboxRepository) DeleteDispatchedBefore(ctx context.Context, before time.Time) (int64, error) {
+	var deleted int64
+	err := conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		pending := tx.Model(&webhook.Delivery{}).Select("message_id").Where("status = ?", webhook.StatusPending)
+		expired := tx.Model(&outbox.Message{}).Select("id").
+			Where("dispatched_at < ? AND id NOT IN (?)", before, pending)
+
+		if err := tx.Where("message_id IN (?)", expired).Delete(&webhook.Delivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("dispatched_at < ? AND id NOT IN (?)", before, pending).Delete(&outbox.Message{})
+		deleted = result.RowsAffected
+		return result.Error
+	})
+	return deleted, err
+}
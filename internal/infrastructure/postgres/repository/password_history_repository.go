@@ -0,0 +1,50 @@
+package repository
+
+import (
+	"context"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PasswordHistoryRepository implements user.PasswordHistoryRepository interface
+type PasswordHistoryRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordHistoryRepository creates a new password history repository
+func NewPasswordHistoryRepository(db *gorm.DB) *PasswordHistoryRepository {
+	return &PasswordHistoryRepository{db: db}
+}
+
+// Add records a replaced password hash and prunes all but the newest keep entries in one transaction
+func (r *PasswordHistoryRepository) Add(ctx context.Context, userID uuid.UUID, passwordHash string, keep int) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		entry := &user.PasswordHistoryEntry{
+			ID:           uuid.New(),
+			UserID:       userID,
+			PasswordHash: passwordHash,
+			CreatedAt:    time.Now(),
+		}
+		if err := tx.Create(entry).Error; err != nil {
+			return err
+		}
+
+		newest := tx.Model(&user.PasswordHistoryEntry{}).Select("id").
+			Where("user_id = ?", userID).Order("created_at DESC").Limit(keep)
+		return tx.Where("user_id = ? AND id NOT IN (?)", userID, newest).Delete(&user.PasswordHistoryEntry{}).Error
+	})
+}
+
+// ListRecent returns the user's newest previous password hashes, newest first
+func (r *PasswordHistoryRepository) ListRecent(ctx context.Context, userID uuid.UUID, limit int) ([]string, error) {
+	var hashes []string
+	err := conn(ctx, r.db).Model(&user.PasswordHistoryEntry{}).
+		Where("user_id = ?", userID).
+		Order("created_at DESC").
+		Limit(limit).
+		Pluck("password_hash", &hashes).Error
+	return hashes, err
+}
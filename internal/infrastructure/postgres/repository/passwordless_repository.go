@@ -0,0 +1,89 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PasswordlessRepository implements passwordless.Repository interface
+type PasswordlessRepository struct {
+	db *gorm.DB
+}
+
+// NewPasswordlessRepository creates a new passwordless challenge repository
+func NewPasswordlessRepository(db *gorm.DB) *PasswordlessRepository {
+	return &PasswordlessRepository{db: db}
+}
+
+// Replace deletes the email's earlier challenges and stores the new one in one transaction
+func (r *PasswordlessRepository) Replace(ctx context.Context, challenge *passwordless.Challenge) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("email = ?", challenge.Email).Delete(&passwordless.Challenge{}).Error; err != nil {
+			return err
+		}
+		return tx.Create(challenge).Error
+	})
+}
+
+// FindLatestByEmail finds the email's newest challenge
+func (r *PasswordlessRepository) FindLatestByEmail(ctx context.Context, email string) (*passwordless.Challenge, error) {
+	challenge, err := gorm.G[passwordless.Challenge](conn(ctx, r.db)).Where("email = ?", email).Order("created_at DESC").First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, passwordless.ErrChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// FindBySecretHash finds a challenge by the hash of its link token or code
+func (r *PasswordlessRepository) FindBySecretHash(ctx context.Context, secretHash string) (*passwordless.Challenge, error) {
+	challenge, err := gorm.G[passwordless.Challenge](conn(ctx, r.db)).Where("secret_hash = ?", secretHash).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, passwordless.ErrChallengeNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &challenge, nil
+}
+
+// ReserveAttempt counts an attempt against an unused challenge with attempts left
+func (r *PasswordlessRepository) ReserveAttempt(ctx context.Context, id uuid.UUID, maxAttempts int) error {
+	result := conn(ctx, r.db).Model(&passwordless.Challenge{}).
+		Where("id = ? AND attempts < ? AND consumed_at IS NULL", id, maxAttempts).
+		Update("attempts", gorm.Expr("attempts + 1"))
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return passwordless.ErrChallengeNotFound
+	}
+	return nil
+}
+
+// Consume marks an unused challenge as used
+func (r *PasswordlessRepository) Consume(ctx context.Context, id uuid.UUID) error {
+	rows, err := gorm.G[passwordless.Challenge](conn(ctx, r.db)).
+		Where("id = ? AND consumed_at IS NULL", id).
+		Update(ctx, "consumed_at", time.Now())
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return passwordless.ErrChallengeNotFound
+	}
+	return nil
+}
+
+// DeleteExpired removes challenges past their expiry
+func (r *PasswordlessRepository) DeleteExpired(ctx context.Context) (int64, error) {
+	result := conn(ctx, r.db).Where("expires_at < ?", time.Now()).Delete(&passwordless.Challenge{})
+	return result.RowsAffected, result.Error
+}
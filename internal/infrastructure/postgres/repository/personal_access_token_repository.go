@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PersonalAccessTokenRepository implements token.PersonalAccessTokenRepository interface
+type PersonalAccessTokenRepository struct {
+	db *gorm.DB
+}
+
+// NewPersonalAccessTokenRepository creates a new personal access token repository
+func NewPersonalAccessTokenRepository(db *gorm.DB) *PersonalAccessTokenRepository {
+	return &PersonalAccessTokenRepository{db: db}
+}
+
+// Create stores a new personal access token
+func (r *PersonalAccessTokenRepository) Create(ctx context.Context, pat *token.PersonalAccessToken) error {
+	return gorm.G[token.PersonalAccessToken](conn(ctx, r.db)).Create(ctx, pat)
+}
+
+// FindByTokenHash finds a personal access token by its hash
+func (r *PersonalAccessTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*token.PersonalAccessToken, error) {
+	pat, err := gorm.G[token.PersonalAccessToken](conn(ctx, r.db)).Where("token_hash = ?", tokenHash).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, token.ErrPersonalAccessTokenNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &pat, nil
+}
+
+// ListByUserID returns a user's personal access tokens newest first
+func (r *PersonalAccessTokenRepository) ListByUserID(ctx context.Context, userID uuid.UUID) ([]token.PersonalAccessToken, error) {
+	return gorm.G[token.PersonalAccessToken](conn(ctx, r.db)).Where("user_id = ?", userID).Order("created_at DESC").Find(ctx)
+}
+
+// Revoke marks one of a user's active personal access tokens as revoked
+func (r *PersonalAccessTokenRepository) Revoke(ctx context.Context, userID, tokenID uuid.UUID) error {
+	rows, err := gorm.G[token.PersonalAccessToken](conn(ctx, r.db)).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", tokenID, userID).
+		Update(ctx, "revoked_at", time.Now())
+	if err != nil {
+		return err
+	}
+	if rows == 0 {
+		return token.ErrPersonalAccessTokenNotFound
+	}
+	return nil
+}
+
+// TouchLastUsed records when a personal access token was last used
+func (r *PersonalAccessTokenRepository) TouchLastUsed(ctx context.Context, tokenID uuid.UUID, usedAt time.Time) error {
+	_, err := gorm.G[token.PersonalAccessToken](conn(ctx, r.db)).Where("id = ?", tokenID).Update(ctx, "last_used_at", usedAt)
+	return err
+}
@@ -0,0 +1,63 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// PolicyRepository implements policy.Repository interface
+type PolicyRepository struct {
+	db *gorm.DB
+}
+
+// NewPolicyRepository creates a new policy repository
+func NewPolicyRepository(db *gorm.DB) *PolicyRepository {
+	return &PolicyRepository{db: db}
+}
+
+// Create creates a new policy using GORM generics
+func (r *PolicyRepository) Create(ctx context.Context, p *policy.Policy) error {
+	return gorm.G[policy.Policy](conn(ctx, r.db)).Create(ctx, p)
+}
+
+// Update saves all fields of an existing policy
+func (r *PolicyRepository) Update(ctx context.Context, p *policy.Policy) error {
+	return conn(ctx, r.db).Save(p).Error
+}
+
+// Delete deletes a policy by ID
+func (r *PolicyRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	_, err := gorm.G[policy.Policy](conn(ctx, r.db)).Where("id = ?", id).Delete(ctx)
+	return err
+}
+
+// FindByID finds a policy by its ID
+func (r *PolicyRepository) FindByID(ctx context.Context, id uuid.UUID) (*policy.Policy, error) {
+	p, err := gorm.G[policy.Policy](conn(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// FindByName finds a policy by its name
+func (r *PolicyRepository) FindByName(ctx context.Context, name string) (*policy.Policy, error) {
+	p, err := gorm.G[policy.Policy](conn(ctx, r.db)).Where("name = ?", name).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
+
+// List returns all policies ordered by name
+func (r *PolicyRepository) List(ctx context.Context) ([]policy.Policy, error) {
+	return gorm.G[policy.Policy](conn(ctx, r.db)).Order("name").Find(ctx)
+}
+
+// ListEnabled returns the policies taking part in authorization decisions
+func (r *PolicyRepository) ListEnabled(ctx context.Context) ([]policy.Policy, error) {
+	return gorm.G[policy.Policy](conn(ctx, r.db)).Where("enabled = ?", true).Order("name").Find(ctx)
+}
@@ -23,13 +23,13 @@ func NewRefreshTokenRepository(db *gorm.DB) *RefreshTokenRepository {
 
 // Create creates a new refresh token
 func (r *RefreshTokenRepository) Create(ctx context.Context, t *token.RefreshToken) error {
-	return r.db.WithContext(ctx).Create(t).Error
+	return conn(ctx, r.db).Create(t).Error
 }
 
 // FindByTokenHash finds a refresh token by its hash
 func (r *RefreshTokenRepository) FindByTokenHash(ctx context.Context, tokenHash string) (*token.RefreshToken, error) {
 	var rt token.RefreshToken
-	err := r.db.WithContext(ctx).Where("token_hash = ?", tokenHash).First(&rt).Error
+	err := conn(ctx, r.db).Where("token_hash = ?", tokenHash).First(&rt).Error
 	if err != nil {
 		return nil, err
 	}
@@ -39,14 +39,14 @@ func (r *RefreshTokenRepository) FindByTokenHash(ctx context.Context, tokenHash
 // FindByUserID finds all refresh tokens for a user
 func (r *RefreshTokenRepository) FindByUserID(ctx context.Context, userID uuid.UUID) ([]*token.RefreshToken, error) {
 	var tokens []*token.RefreshToken
-	err := r.db.WithContext(ctx).Where("user_id = ?", userID).Find(&tokens).Error
+	err := conn(ctx, r.db).Where("user_id = ?", userID).Find(&tokens).Error
 	return tokens, err
 }
 
 // Revoke revokes a specific refresh token
 func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenID uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&token.RefreshToken{}).
+	return conn(ctx, r.db).Model(&token.RefreshToken{}).
 		Where("id = ?", tokenID).
 		Update("revoked_at", &now).Error
 }
@@ -54,14 +54,30 @@ func (r *RefreshTokenRepository) Revoke(ctx context.Context, tokenID uuid.UUID)
 // RevokeByUserID revokes all refresh tokens for a user
 func (r *RefreshTokenRepository) RevokeByUserID(ctx context.Context, userID uuid.UUID) error {
 	now := time.Now()
-	return r.db.WithContext(ctx).Model(&token.RefreshToken{}).
+	return conn(ctx, r.db).Model(&token.RefreshToken{}).
 		Where("user_id = ? AND revoked_at IS NULL", userID).
 		Update("revoked_at", &now).Error
 }
 
+// RevokeAll revokes every active refresh token
+func (r *RefreshTokenRepository) RevokeAll(ctx context.Context) (int64, error) {
+	now := time.Now()
+	result := conn(ctx, r.db).Model(&token.RefreshToken{}).
+		Where("revoked_at IS NULL AND expires_at > ?", now).
+		Update("revoked_at", &now)
+	return result.RowsAffected, result.Error
+}
+
 // CleanExpired removes expired refresh tokens
-func (r *RefreshTokenRepository) CleanExpired(ctx context.Context) error {
-	return r.db.WithContext(ctx).Where("expires_at < ?", time.Now()).Delete(&token.RefreshToken{}).Error
+func (r *RefreshTokenRepository) CleanExpired(ctx context.Context) (int64, error) {
+	result := conn(ctx, r.db).Where("expires_at < ?", time.Now()).Delete(&token.RefreshToken{})
+	return result.RowsAffected, result.Error
+}
+
+// DeleteRevokedBefore removes refresh tokens revoked before the given time
+func (r *RefreshTokenRepository) DeleteRevokedBefore(ctx context.Context, before time.Time) (int64, error) {
+	result := conn(ctx, r.db).Where("revoked_at < ?", before).Delete(&token.RefreshToken{})
+	return result.RowsAffected, result.Error
 }
 
 // HashToken creates a SHA256 hash of a token
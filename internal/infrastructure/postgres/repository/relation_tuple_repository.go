@@ -0,0 +1,59 @@
+package repository
+
+import (
+	"context"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// RelationTupleRepository implements relation.Repository interface
+type RelationTupleRepository struct {
+	db *gorm.DB
+}
+
+// NewRelationTupleRepository creates a new relation tuple repository
+func NewRelationTupleRepository(db *gorm.DB) *RelationTupleRepository {
+	return &RelationTupleRepository{db: db}
+}
+
+// Write inserts and deletes tuples in a single transaction, ignoring duplicates
+func (r *RelationTupleRepository) Write(ctx context.Context, writes []*relation.Tuple, deletes []*relation.Tuple) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		for _, t := range deletes {
+			err := tx.Where(
+				"namespace = ? AND object_id = ? AND relation = ? AND subject_namespace = ? AND subject_id = ? AND subject_relation = ?",
+				t.Namespace, t.ObjectID, t.Relation, t.SubjectNamespace, t.SubjectID, t.SubjectRelation,
+			).Delete(&relation.Tuple{}).Error
+			if err != nil {
+				return err
+			}
+		}
+
+		if len(writes) == 0 {
+			return nil
+		}
+		return tx.Clauses(clause.OnConflict{DoNothing: true}).Create(writes).Error
+	})
+}
+
+// FindByObjectRelation returns the tuples for object#relation
+func (r *RelationTupleRepository) FindByObjectRelation(ctx context.Context, object relation.Object, rel string) ([]relation.Tuple, error) {
+	return gorm.G[relation.Tuple](conn(ctx, r.db)).
+		Where("namespace = ? AND object_id = ? AND relation = ?", object.Namespace, object.ID, rel).
+		Order("created_at").
+		Find(ctx)
+}
+
+// ListObjectIDs returns the distinct object IDs with tuples in a namespace
+func (r *RelationTupleRepository) ListObjectIDs(ctx context.Context, namespace string) ([]string, error) {
+	var ids []string
+	err := conn(ctx, r.db).
+		Model(&relation.Tuple{}).
+		Where("namespace = ?", namespace).
+		Distinct("object_id").
+		Order("object_id").
+		Pluck("object_id", &ids).Error
+	return ids, err
+}
@@ -21,23 +21,35 @@ func NewRoleRepository(db *gorm.DB) *RoleRepository {
 
 // Create creates a new role using GORM generics
 func (r *RoleRepository) Create(ctx context.Context, ro *role.Role) error {
-	return gorm.G[role.Role](r.db).Create(ctx, ro)
+	return gorm.G[role.Role](conn(ctx, r.db)).Create(ctx, ro)
 }
 
 // FindByID finds a role by its ID with permissions preloaded
 func (r *RoleRepository) FindByID(ctx context.Context, id uuid.UUID) (*role.Role, error) {
 	var ro role.Role
-	result := r.db.WithContext(ctx).Preload("Permissions").Where("id = ?", id).First(&ro)
+	result := conn(ctx, r.db).Preload("Permissions").Where("id = ?", id).First(&ro)
 	if result.Error != nil {
 		return nil, result.Error
 	}
 	return &ro, nil
 }
 
-// FindByName finds a role by its name with permissions preloaded
+// FindByName finds a global role by its name with permissions preloaded
 func (r *RoleRepository) FindByName(ctx context.Context, name string) (*role.Role, error) {
-	ro, err := gorm.G[role.Role](r.db.WithContext(ctx)).Preload("Permissions", nil).Where("name = ?", name).First(ctx)
-	return &ro, err
+	ro, err := gorm.G[role.Role](conn(ctx, r.db)).Preload("Permissions", nil).Where("organization_id IS NULL AND name = ?", name).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ro, nil
+}
+
+// FindByNameInOrganization finds an organization role by its name with permissions preloaded
+func (r *RoleRepository) FindByNameInOrganization(ctx context.Context, organizationID uuid.UUID, name string) (*role.Role, error) {
+	ro, err := gorm.G[role.Role](conn(ctx, r.db)).Preload("Permissions", nil).Where("organization_id = ? AND name = ?", organizationID, name).First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &ro, nil
 }
 
 // FindOrCreateDefault returns the default user role if RBAC is enabled
@@ -55,16 +67,16 @@ func (r *RoleRepository) FindOrCreateDefault(ctx context.Context) (*role.Role, e
 	return nil, nil
 }
 
-// IsRBACEnabled checks if any roles exist in the database
+// IsRBACEnabled checks if any global roles exist in the database
 func (r *RoleRepository) IsRBACEnabled(ctx context.Context) bool {
 	var count int64
-	r.db.WithContext(ctx).Model(&role.Role{}).Count(&count)
+	conn(ctx, r.db).Model(&role.Role{}).Where("organization_id IS NULL").Count(&count)
 	return count > 0
 }
 
 // Update updates an existing role
 func (r *RoleRepository) Update(ctx context.Context, ro *role.Role) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
 		// Update role basic info
 		if err := tx.Model(ro).Updates(map[string]any{
 			"name":       ro.Name,
@@ -87,8 +99,11 @@ func (r *RoleRepository) Update(ctx context.Context, ro *role.Role) error {
 
 // Delete deletes a role by ID
 func (r *RoleRepository) Delete(ctx context.Context, id uuid.UUID) error {
-	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
-		// Delete permissions first
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		// Delete permissions and group assignments first
+		if err := tx.Exec("DELETE FROM group_roles WHERE role_id = ?", id).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("role_id = ?", id).Delete(&role.Permission{}).Error; err != nil {
 			return err
 		}
@@ -97,10 +112,20 @@ func (r *RoleRepository) Delete(ctx context.Context, id uuid.UUID) error {
 	})
 }
 
-// List returns all roles with their permissions
+// List returns all global roles with their permissions
 func (r *RoleRepository) List(ctx context.Context) ([]role.Role, error) {
 	var roles []role.Role
-	result := r.db.WithContext(ctx).Preload("Permissions").Find(&roles)
+	result := conn(ctx, r.db).Preload("Permissions").Where("organization_id IS NULL").Find(&roles)
+	if result.Error != nil {
+		return nil, result.Error
+	}
+	return roles, nil
+}
+
+// ListByOrganization returns the roles of an organization with their permissions
+func (r *RoleRepository) ListByOrganization(ctx context.Context, organizationID uuid.UUID) ([]role.Role, error) {
+	var roles []role.Role
+	result := conn(ctx, r.db).Preload("Permissions").Where("organization_id = ?", organizationID).Order("name").Find(&roles)
 	if result.Error != nil {
 		return nil, result.Error
 	}
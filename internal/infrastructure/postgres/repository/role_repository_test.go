@@ -0,0 +1,30 @@
+package repository
+
+import (
+	"context"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+func TestRoleRepository_Create_NamesUniquePerScope(t *testing.T) {
+	// Arrange
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+	require.NoError(t, db.AutoMigrate(&role.Role{}, &role.Permission{}))
+	repo := NewRoleRepository(db)
+	ctx := context.Background()
+	acme, globex := uuid.New(), uuid.New()
+	require.NoError(t, repo.Create(ctx, role.NewUserRole()))
+	require.NoError(t, repo.Create(ctx, role.NewOrganizationRole(acme, role.RoleUser, nil)))
+
+	// Act & Assert
+	assert.Error(t, repo.Create(ctx, role.NewUserRole()), "global names are unique")
+	assert.Error(t, repo.Create(ctx, role.NewOrganizationRole(acme, role.RoleUser, nil)), "names are unique within an organization")
+	assert.NoError(t, repo.Create(ctx, role.NewOrganizationRole(globex, role.RoleUser, nil)))
+}
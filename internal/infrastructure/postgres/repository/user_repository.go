@@ -2,7 +2,12 @@ package repository
 
 import (
 	"context"
+	"strings"
+	"time"
 
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/token"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
 	"github.com/google/uuid"
 	"gorm.io/gorm"
@@ -20,23 +25,137 @@ func NewUserRepository(db *gorm.DB) *UserRepository {
 
 // Create creates a new user using GORM generics
 func (r *UserRepository) Create(ctx context.Context, u *user.User) error {
-	return gorm.G[user.User](r.db).Create(ctx, u)
+	return gorm.G[user.User](conn(ctx, r.db)).Create(ctx, u)
 }
 
-// FindByEmail finds a user by their email address
+// FindByEmail finds a user by their email address with role and groups preloaded
 func (r *UserRepository) FindByEmail(ctx context.Context, email string) (*user.User, error) {
-	user, err := gorm.G[user.User](r.db).Preload("Role.Permissions", nil).Where("email = ?", email).First(ctx)
-	return &user, err
+	user, err := gorm.G[user.User](conn(ctx, r.db)).
+		Preload("Role.Permissions", nil).
+		Preload("Groups.Roles.Permissions", nil).
+		Where("email = ?", email).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
-// FindByID finds a user by their ID with role preloaded
+// FindByID finds a user by their ID with role and groups preloaded
 func (r *UserRepository) FindByID(ctx context.Context, id uuid.UUID) (*user.User, error) {
-	user, err := gorm.G[user.User](r.db).Preload("Role", nil).Preload("Role.Permissions", nil).Where("id = ?", id).First(ctx)
-	return &user, err
+	user, err := gorm.G[user.User](conn(ctx, r.db)).
+		Preload("Role", nil).
+		Preload("Role.Permissions", nil).
+		Preload("Groups.Roles.Permissions", nil).
+		Where("id = ?", id).
+		First(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return &user, nil
 }
 
 // UpdateRole updates a user's role assignment
 func (r *UserRepository) UpdateRole(ctx context.Context, userID uuid.UUID, roleID *uuid.UUID) error {
-	_, err := gorm.G[user.User](r.db).Where("id = ?", userID).Update(ctx, "role_id", roleID)
+	_, err := gorm.G[user.User](conn(ctx, r.db)).Where("id = ?", userID).Update(ctx, "role_id", roleID)
 	return err
 }
+
+// UpdateProfile replaces a user's profile fields
+func (r *UserRepository) UpdateProfile(ctx context.Context, userID uuid.UUID, profile user.Profile) error {
+	return conn(ctx, r.db).Model(&user.User{}).Where("id = ?", userID).Updates(map[string]any{
+		"display_name": profile.DisplayName,
+		"avatar_url":   profile.AvatarURL,
+		"locale":       profile.Locale,
+		"timezone":     profile.Timezone,
+	}).Error
+}
+
+// UpdatePassword replaces a user's password hash
+func (r *UserRepository) UpdatePassword(ctx context.Context, userID uuid.UUID, passwordHash string) error {
+	_, err := gorm.G[user.User](conn(ctx, r.db)).Where("id = ?", userID).Update(ctx, "password", passwordHash)
+	return err
+}
+
+// UpdateEmail changes a user's email address
+func (r *UserRepository) UpdateEmail(ctx context.Context, userID uuid.UUID, email string) error {
+	_, err := gorm.G[user.User](conn(ctx, r.db)).Where("id = ?", userID).Update(ctx, "email", email)
+	return err
+}
+
+// ListByGroup returns the members of a group ordered by email
+func (r *UserRepository) ListByGroup(ctx context.Context, groupID uuid.UUID) ([]user.User, error) {
+	members := r.db.Model(&group.Member{}).Select("user_id").Where("group_id = ?", groupID)
+	return gorm.G[user.User](conn(ctx, r.db)).
+		Where("id IN (?)", members).
+		Order("email").
+		Find(ctx)
+}
+
+// List returns one page of users matching the filter using keyset pagination
+func (r *UserRepository) List(ctx context.Context, filter user.ListFilter) ([]user.User, error) {
+	query := conn(ctx, r.db).Preload("Role")
+
+	if filter.EmailPrefix != "" {
+		query = query.Where("LOWER(email) LIKE ? ESCAPE '\\'", escapeLike(strings.ToLower(filter.EmailPrefix))+"%")
+	}
+	if filter.RoleName != "" {
+		query = query.Where("role_id IN (?)", r.db.Table("roles").Select("id").Where("name = ?", filter.RoleName))
+	}
+	switch filter.Status {
+	case user.StatusActive:
+		query = query.Where("disabled_at IS NULL")
+	case user.StatusDisabled:
+		query = query.Where("disabled_at IS NOT NULL")
+	}
+	if filter.CreatedAfter != nil {
+		query = query.Where("created_at >= ?", *filter.CreatedAfter)
+	}
+	if filter.CreatedBefore != nil {
+		query = query.Where("created_at < ?", *filter.CreatedBefore)
+	}
+	if filter.After != nil {
+		query = query.Where("created_at > ? OR (created_at = ? AND id > ?)",
+			filter.After.CreatedAt, filter.After.CreatedAt, filter.After.ID)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var users []user.User
+	err := query.Order("created_at").Order("id").Find(&users).Error
+	return users, err
+}
+
+// SetDisabledAt disables or re-enables a user
+func (r *UserRepository) SetDisabledAt(ctx context.Context, userID uuid.UUID, disabledAt *time.Time) error {
+	_, err := gorm.G[user.User](conn(ctx, r.db)).Where("id = ?", userID).Update(ctx, "disabled_at", disabledAt)
+	return err
+}
+
+// Delete removes a user together with their group and organization memberships, tokens and password history
+func (r *UserRepository) Delete(ctx context.Context, userID uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("user_id = ?", userID).Delete(&group.Member{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&organization.Membership{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&token.RefreshToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&token.PersonalAccessToken{}).Error; err != nil {
+			return err
+		}
+		if err := tx.Where("user_id = ?", userID).Delete(&user.PasswordHistoryEntry{}).Error; err != nil {
+			return err
+		}
+		return tx.Delete(&user.User{}, "id = ?", userID).Error
+	})
+}
+
+// escapeLike escapes the LIKE wildcards in s so it matches literally
+func escapeLike(s string) string {
+	return strings.NewReplacer(`\`, `\\`, "%", `\%`, "_", `\_`).Replace(s)
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
 	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/migrations"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 	"github.com/testcontainers/testcontainers-go"
@@ -38,7 +39,11 @@ func setupIntegrationDB(t *testing.T) (*gorm.DB, func()) {
 	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&role.Role{}, &role.Permission{}, &user.User{})
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	migrator, err := migrations.NewMigrator(sqlDB)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
 	require.NoError(t, err)
 
 	cleanup := func() {
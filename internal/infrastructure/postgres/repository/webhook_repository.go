@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/google/uuid"
+	"gorm.io/gorm"
+)
+
+// WebhookRepository implements webhook.Repository interface
+type WebhookRepository struct {
+	db *gorm.DB
+}
+
+// NewWebhookRepository creates a new webhook repository
+func NewWebhookRepository(db *gorm.DB) *WebhookRepository {
+	return &WebhookRepository{db: db}
+}
+
+// Create stores a new webhook
+func (r *WebhookRepository) Create(ctx context.Context, w *webhook.Webhook) error {
+	return gorm.G[webhook.Webhook](conn(ctx, r.db)).Create(ctx, w)
+}
+
+// FindByID finds a webhook by its ID
+func (r *WebhookRepository) FindByID(ctx context.Context, id uuid.UUID) (*webhook.Webhook, error) {
+	w, err := gorm.G[webhook.Webhook](conn(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, webhook.ErrWebhookNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &w, nil
+}
+
+// List returns all webhooks newest first
+func (r *WebhookRepository) List(ctx context.Context) ([]webhook.Webhook, error) {
+	return gorm.G[webhook.Webhook](conn(ctx, r.db)).Order("created_at DESC").Find(ctx)
+}
+
+// Delete removes a webhook and its deliveries
+func (r *WebhookRepository) Delete(ctx context.Context, id uuid.UUID) error {
+	return conn(ctx, r.db).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("webhook_id = ?", id).Delete(&webhook.Delivery{}).Error; err != nil {
+			return err
+		}
+		result := tx.Where("id = ?", id).Delete(&webhook.Webhook{})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return webhook.ErrWebhookNotFound
+		}
+		return nil
+	})
+}
+
+// CreateDeliveries stores new deliveries
+func (r *WebhookRepository) CreateDeliveries(ctx context.Context, deliveries []webhook.Delivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	return conn(ctx, r.db).Create(&deliveries).Error
+}
+
+// ListDueDeliveries returns pending deliveries whose next attempt is due, oldest first
+func (r *WebhookRepository) ListDueDeliveries(ctx context.Context, limit int) ([]webhook.Delivery, error) {
+	return gorm.G[webhook.Delivery](conn(ctx, r.db)).
+		Where("status = ? AND next_attempt_at <= ?", webhook.StatusPending, time.Now()).
+		Order("next_attempt_at ASC").
+		Limit(limit).
+		Find(ctx)
+}
+
+// ListDeliveries returns deliveries newest first
+func (r *WebhookRepository) ListDeliveries(ctx context.Context, filter webhook.DeliveryFilter) ([]webhook.Delivery, error) {
+	query := conn(ctx, r.db)
+	if filter.WebhookID != uuid.Nil {
+		query = query.Where("webhook_id = ?", filter.WebhookID)
+	}
+	if filter.Status != "" {
+		query = query.Where("status = ?", filter.Status)
+	}
+	if filter.Limit > 0 {
+		query = query.Limit(filter.Limit)
+	}
+
+	var deliveries []webhook.Delivery
+	err := query.Order("created_at DESC").Find(&deliveries).Error
+	return deliveries, err
+}
+
+// FindDelivery finds a delivery by its ID
+func (r *WebhookRepository) FindDelivery(ctx context.Context, id uuid.UUID) (*webhook.Delivery, error) {
+	delivery, err := gorm.G[webhook.Delivery](conn(ctx, r.db)).Where("id = ?", id).First(ctx)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil, webhook.ErrDeliveryNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &delivery, nil
+}
+
+// UpdateDelivery saves the state of a delivery after an attempt or replay
+func (r *WebhookRepository) UpdateDelivery(ctx context.Context, delivery *webhook.Delivery) error {
+	return conn(ctx, r.db).Save(delivery).Error
+}
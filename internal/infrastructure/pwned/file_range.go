@@ -0,0 +1,51 @@
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// FileRange answers range lookups from a local list of SHA-1 hashes, for
+// deployments that can't reach the range API. The file has one hex hash per
+// line, optionally followed by ":COUNT" as in the Pwned Passwords downloads;
+// it is loaded into memory, so use a list of the most common passwords
+// rather than the full corpus.
+type FileRange struct {
+	suffixes map[string][]string
+}
+
+// LoadFileRange reads the hash list at path
+func LoadFileRange(path string) (*FileRange, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to open %s: %w", path, err)
+	}
+	defer f.Close()
+
+	r := &FileRange{suffixes: make(map[string][]string)}
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		hash, _, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if hash == "" || strings.HasPrefix(hash, "#") {
+			continue
+		}
+		if _, err := hex.DecodeString(hash); err != nil || len(hash) != 40 {
+			return nil, fmt.Errorf("%s:%d: not a SHA-1 hash", path, line)
+		}
+		hash = strings.ToUpper(hash)
+		r.suffixes[hash[:5]] = append(r.suffixes[hash[:5]], hash[5:])
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read %s: %w", path, err)
+	}
+	return r, nil
+}
+
+// Range returns the hash suffixes in the list that start with prefix
+func (r *FileRange) Range(_ context.Context, prefix string) ([]string, error) {
+	return r.suffixes[strings.ToUpper(prefix)], nil
+}
@@ -0,0 +1,64 @@
+package pwned
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+)
+
+// DefaultRangeURL is the Pwned Passwords range API.
+const DefaultRangeURL = "https://api.pwnedpasswords.com/range/"
+
+// RangeClient queries a Pwned Passwords compatible range API. Responses are
+// padded with decoy hashes so their size doesn't hint at the prefix.
+type RangeClient struct {
+	baseURL    string
+	httpClient *http.Client
+}
+
+// NewRangeClient creates a client for the range API at baseURL
+func NewRangeClient(baseURL string) *RangeClient {
+	return &RangeClient{
+		baseURL:    strings.TrimSuffix(baseURL, "/") + "/",
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+}
+
+// Range returns the hash suffixes the API lists under prefix
+func (c *RangeClient) Range(ctx context.Context, prefix string) ([]string, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, c.baseURL+prefix, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Add-Padding", "true")
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("range request failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("range request failed with status %d", resp.StatusCode)
+	}
+	return parseRange(resp.Body)
+}
+
+// parseRange reads "SUFFIX:COUNT" lines, skipping the padding entries whose
+// count is 0.
+func parseRange(r io.Reader) ([]string, error) {
+	var suffixes []string
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		suffix, count, _ := strings.Cut(strings.TrimSpace(scanner.Text()), ":")
+		if suffix == "" || count == "0" {
+			continue
+		}
+		suffixes = append(suffixes, suffix)
+	}
+	return suffixes, scanner.Err()
+}
@@ -0,0 +1,52 @@
+package pwned
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRangeClient_Range(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/range/5BAA6", r.URL.Path)
+		assert.Equal(t, "true", r.Header.Get("Add-Padding"))
+		w.Write([]byte("1E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824\r\n011053FD0102E94D6AE2F8B83D76FAF94F6:0\r\n"))
+	}))
+	defer server.Close()
+
+	suffixes, err := NewRangeClient(server.URL+"/range").Range(t.Context(), "5BAA6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, suffixes, "padding entries are dropped")
+}
+
+func TestRangeClient_RangeError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	defer server.Close()
+
+	_, err := NewRangeClient(server.URL).Range(t.Context(), "5BAA6")
+	assert.Error(t, err)
+}
+
+func TestLoadFileRange(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hashes.txt")
+	content := "# common passwords\n5baa61e4c9b93f3f0682250b6cf8331b7ee68fd8:9545824\n7C4A8D09CA3762AF61E59520943DC26494F8941B\n"
+	require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+
+	r, err := LoadFileRange(path)
+	require.NoError(t, err)
+
+	suffixes, err := r.Range(t.Context(), "5baa6")
+	require.NoError(t, err)
+	assert.Equal(t, []string{"1E4C9B93F3F0682250B6CF8331B7EE68FD8"}, suffixes)
+
+	require.NoError(t, os.WriteFile(path, []byte("not-a-hash\n"), 0o600))
+	_, err = LoadFileRange(path)
+	assert.Error(t, err)
+}
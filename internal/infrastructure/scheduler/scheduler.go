@@ -0,0 +1,193 @@
+// Package scheduler runs housekeeping jobs on fixed intervals. When several
+// replicas run, only the one holding leadership runs jobs; the others skip
+// their turns until they take it over.
+package scheduler
+
+import (
+	"context"
+	"log"
+	"slices"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+)
+
+// Job is a task run every Interval. Run returns how many rows it affected.
+type Job struct {
+	Name     string
+	Interval time.Duration
+	Run      func(ctx context.Context) (int64, error)
+}
+
+// Leader decides which instance runs jobs
+type Leader interface {
+	// Acquire reports whether this instance is the leader, taking
+	// leadership when no other instance holds it.
+	Acquire(ctx context.Context) (bool, error)
+	// Release gives up leadership, if held.
+	Release(ctx context.Context) error
+}
+
+// JobStats are the counters of one job since the scheduler started
+type JobStats struct {
+	Name         string        `json:"name"`
+	Interval     time.Duration `json:"interval"`
+	Runs         int64         `json:"runs"`
+	Failures     int64         `json:"failures"`
+	Skipped      int64         `json:"skipped"`
+	Affected     int64         `json:"affected"`
+	LastRunAt    *time.Time    `json:"last_run_at,omitempty"`
+	LastDuration time.Duration `json:"last_duration"`
+	LastError    string        `json:"last_error,omitempty"`
+}
+
+// Scheduler runs jobs while this instance is the leader
+type Scheduler struct {
+	leader Leader
+	jobs   []Job
+
+	mu    sync.Mutex
+	stats map[string]*JobStats
+
+	stop      chan struct{}
+	cancelRun context.CancelFunc
+	wg        sync.WaitGroup
+}
+
+// New creates a scheduler for jobs; jobs with a non-positive interval are left out
+func New(leader Leader, jobs ...Job) *Scheduler {
+	s := &Scheduler{leader: leader, stats: make(map[string]*JobStats)}
+	for _, job := range jobs {
+		if job.Interval <= 0 {
+			continue
+		}
+		s.jobs = append(s.jobs, job)
+		s.stats[job.Name] = &JobStats{Name: job.Name, Interval: job.Interval}
+	}
+	return s
+}
+
+// Start runs each job once and then every interval, until Stop. Jobs keep
+// the values of ctx but not its cancellation, so a job in progress can
+// finish during shutdown.
+func (s *Scheduler) Start(ctx context.Context) {
+	runCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	s.stop = make(chan struct{})
+	s.cancelRun = cancel
+
+	for _, job := range s.jobs {
+		s.wg.Add(1)
+		go s.loop(runCtx, job)
+	}
+}
+
+// Stop stops scheduling jobs and waits for running ones to finish. Jobs
+// still running when ctx is done are cancelled. Leadership is released
+// afterwards, so another instance takes over.
+func (s *Scheduler) Stop(ctx context.Context) error {
+	if s.stop == nil {
+		return nil
+	}
+	close(s.stop)
+
+	done := make(chan struct{})
+	go func() {
+		s.wg.Wait()
+		close(done)
+	}()
+
+	var err error
+	select {
+	case <-done:
+	case <-ctx.Done():
+		err = ctx.Err()
+		s.cancelRun()
+		<-done
+	}
+	s.cancelRun()
+
+	if releaseErr := s.leader.Release(context.WithoutCancel(ctx)); err == nil {
+		err = releaseErr
+	}
+	return err
+}
+
+// Stats returns the counters of every job, ordered by name
+func (s *Scheduler) Stats() []JobStats {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	stats := make([]JobStats, 0, len(s.stats))
+	for _, js := range s.stats {
+		stats = append(stats, *js)
+	}
+	slices.SortFunc(stats, func(a, b JobStats) int { return strings.Compare(a.Name, b.Name) })
+	return stats
+}
+
+func (s *Scheduler) loop(ctx context.Context, job Job) {
+	defer s.wg.Done()
+
+	ticker := time.NewTicker(job.Interval)
+	defer ticker.Stop()
+
+	for {
+		s.tick(ctx, job)
+		select {
+		case <-s.stop:
+			return
+		case <-ticker.C:
+		}
+		// Both may be ready; don't start another run once stopped
+		select {
+		case <-s.stop:
+			return
+		default:
+		}
+	}
+}
+
+func (s *Scheduler) tick(ctx context.Context, job Job) {
+	leader, err := s.leader.Acquire(ctx)
+	if err != nil {
+		log.Printf("Job %s: leader election failed: %v", job.Name, err)
+	}
+	if !leader {
+		s.record(job.Name, func(js *JobStats) { js.Skipped++ })
+		return
+	}
+
+	started := time.Now()
+	affected, err := job.Run(ctx)
+	duration := time.Since(started)
+
+	metrics.JobRuns.WithLabelValues(job.Name).Inc()
+	metrics.JobDuration.WithLabelValues(job.Name).Observe(duration.Seconds())
+	if err != nil {
+		metrics.JobFailures.WithLabelValues(job.Name).Inc()
+	}
+	s.record(job.Name, func(js *JobStats) {
+		js.Runs++
+		js.Affected += affected
+		js.LastRunAt = &started
+		js.LastDuration = duration
+		js.LastError = ""
+		if err != nil {
+			js.Failures++
+			js.LastError = err.Error()
+		}
+	})
+	if err != nil {
+		log.Printf("Job %s failed after %s: %v", job.Name, duration, err)
+	} else if affected > 0 {
+		log.Printf("Job %s affected %d rows in %s", job.Name, affected, duration)
+	}
+}
+
+func (s *Scheduler) record(name string, update func(js *JobStats)) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	update(s.stats[name])
+}
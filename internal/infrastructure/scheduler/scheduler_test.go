@@ -0,0 +1,166 @@
+package scheduler
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	dto "github.com/prometheus/client_model/go"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type stubLeader struct {
+	leader   atomic.Bool
+	released atomic.Bool
+}
+
+func (l *stubLeader) Acquire(ctx context.Context) (bool, error) {
+	return l.leader.Load(), nil
+}
+
+func (l *stubLeader) Release(ctx context.Context) error {
+	l.released.Store(true)
+	return nil
+}
+
+func statsOf(t *testing.T, s *Scheduler, name string) JobStats {
+	for _, js := range s.Stats() {
+		if js.Name == name {
+			return js
+		}
+	}
+	t.Fatalf("no stats for job %s", name)
+	return JobStats{}
+}
+
+func TestScheduler_RunsJobsWhileLeader(t *testing.T) {
+	leader := &stubLeader{}
+	leader.leader.Store(true)
+
+	var runs atomic.Int64
+	s := New(leader,
+		Job{Name: "cleanup", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+			runs.Add(1)
+			return 2, nil
+		}},
+		Job{Name: "failing", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+			return 0, errors.New("database is down")
+		}},
+		Job{Name: "disabled", Interval: 0, Run: func(ctx context.Context) (int64, error) {
+			t.Error("a job without an interval must not run")
+			return 0, nil
+		}},
+	)
+	s.Start(context.Background())
+
+	require.Eventually(t, func() bool { return runs.Load() >= 3 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+	assert.True(t, leader.released.Load())
+
+	stats := s.Stats()
+	require.Len(t, stats, 2)
+	assert.Equal(t, "cleanup", stats[0].Name)
+
+	cleanup := statsOf(t, s, "cleanup")
+	assert.Equal(t, runs.Load(), cleanup.Runs)
+	assert.Equal(t, 2*cleanup.Runs, cleanup.Affected)
+	assert.Zero(t, cleanup.Failures)
+	assert.NotNil(t, cleanup.LastRunAt)
+
+	failing := statsOf(t, s, "failing")
+	assert.Positive(t, failing.Failures)
+	assert.Equal(t, failing.Runs, failing.Failures)
+	assert.Equal(t, "database is down", failing.LastError)
+}
+
+func TestScheduler_SkipsWhileFollower(t *testing.T) {
+	leader := &stubLeader{}
+
+	var runs atomic.Int64
+	s := New(leader, Job{Name: "cleanup", Interval: 10 * time.Millisecond, Run: func(ctx context.Context) (int64, error) {
+		runs.Add(1)
+		return 0, nil
+	}})
+	s.Start(context.Background())
+
+	require.Eventually(t, func() bool { return statsOf(t, s, "cleanup").Skipped >= 2 }, time.Second, 5*time.Millisecond)
+	assert.Zero(t, runs.Load())
+
+	// Taking over leadership, as when the leader goes away
+	leader.leader.Store(true)
+	require.Eventually(t, func() bool { return runs.Load() >= 1 }, time.Second, 5*time.Millisecond)
+	require.NoError(t, s.Stop(context.Background()))
+}
+
+func TestScheduler_StopWaitsForRunningJob(t *testing.T) {
+	leader := &stubLeader{}
+	leader.leader.Store(true)
+
+	started := make(chan struct{})
+	var once sync.Once
+	var finished atomic.Bool
+	s := New(leader, Job{Name: "slow", Interval: time.Hour, Run: func(ctx context.Context) (int64, error) {
+		once.Do(func() { close(started) })
+		select {
+		case <-time.After(50 * time.Millisecond):
+			finished.Store(true)
+			return 1, nil
+		case <-ctx.Done():
+			return 0, ctx.Err()
+		}
+	}})
+	s.Start(context.Background())
+	<-started
+
+	require.NoError(t, s.Stop(context.Background()))
+	assert.True(t, finished.Load(), "the running job finishes before Stop returns")
+	assert.Equal(t, int64(1), statsOf(t, s, "slow").Runs)
+}
+
+func TestScheduler_StopCancelsJobsAfterDeadline(t *testing.T) {
+	leader := &stubLeader{}
+	leader.leader.Store(true)
+
+	started := make(chan struct{})
+	s := New(leader, Job{Name: "stuck", Interval: time.Hour, Run: func(ctx context.Context) (int64, error) {
+		close(started)
+		<-ctx.Done()
+		return 0, ctx.Err()
+	}})
+	s.Start(context.Background())
+	<-started
+
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	assert.ErrorIs(t, s.Stop(ctx), context.DeadlineExceeded)
+	assert.True(t, leader.released.Load())
+	assert.Equal(t, int64(1), statsOf(t, s, "stuck").Failures)
+}
+
+func TestScheduler_ExportsJobMetrics(t *testing.T) {
+	leader := &stubLeader{}
+	leader.leader.Store(true)
+
+	done := make(chan struct{})
+	s := New(leader, Job{Name: "metrics-probe", Interval: time.Hour, Run: func(ctx context.Context) (int64, error) {
+		defer close(done)
+		return 0, errors.New("database is down")
+	}})
+	s.Start(context.Background())
+	<-done
+	require.NoError(t, s.Stop(context.Background()))
+
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.JobRuns.WithLabelValues("metrics-probe")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.JobFailures.WithLabelValues("metrics-probe")))
+
+	var duration dto.Metric
+	require.NoError(t, metrics.JobDuration.WithLabelValues("metrics-probe").(prometheus.Histogram).Write(&duration))
+	assert.Equal(t, uint64(1), duration.GetHistogram().GetSampleCount())
+}
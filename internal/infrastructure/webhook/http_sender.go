@@ -0,0 +1,71 @@
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+)
+
+// AttemptHeader carries the number of the delivery attempt, starting at 1
+const AttemptHeader = "X-Webhook-Attempt"
+
+// HTTPSender posts events to webhooks as signed JSON
+type HTTPSender struct {
+	httpClient *http.Client
+}
+
+// NewHTTPSender creates a sender whose requests time out after timeout
+func NewHTTPSender(timeout time.Duration) *HTTPSender {
+	return &HTTPSender{
+		httpClient: &http.Client{
+			Timeout: timeout,
+			// A redirect would resend the signed body to a URL the admin didn't register
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+	}
+}
+
+// Send posts the message to the webhook; any 2xx response accepts it
+func (s *HTTPSender) Send(ctx context.Context, hook *webhook.Webhook, delivery *webhook.Delivery, message *outbox.Message) (int, error) {
+	body, err := json.Marshal(auth.WebhookEvent{
+		ID:         message.ID.String(),
+		Type:       message.EventType,
+		OccurredAt: message.OccurredAt,
+		Data:       message.Data,
+	})
+	if err != nil {
+		return 0, fmt.Errorf("failed to encode event: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, hook.URL, bytes.NewReader(body))
+	if err != nil {
+		return 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(auth.WebhookEventIDHeader, message.ID.String())
+	req.Header.Set(auth.WebhookEventTypeHeader, message.EventType)
+	req.Header.Set(AttemptHeader, strconv.Itoa(delivery.Attempts+1))
+	req.Header.Set(auth.WebhookSignatureHeader, auth.SignWebhook(hook.Secret, time.Now(), body))
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("request failed: %w", err)
+	}
+	defer resp.Body.Close()
+	// Drain a little of the body so the connection can be reused
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return resp.StatusCode, fmt.Errorf("receiver responded %s", resp.Status)
+	}
+	return resp.StatusCode, nil
+}
@@ -0,0 +1,124 @@
+// Package lifecycle starts the parts of the server and shuts them down in
+// order. On shutdown the instance first reports itself unready, so load
+// balancers stop sending it traffic, then stops its components newest
+// first within a deadline.
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"log"
+	"slices"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// ErrNotReady is returned by Ready before the components have started and
+// once shutdown has begun.
+var ErrNotReady = errors.New("not ready")
+
+// Component is a part of the process the manager starts and stops
+type Component struct {
+	Name string
+	// Start runs the component. It may block until Stop, as servers do, or
+	// return once started. An error shuts the process down.
+	Start func() error
+	// Stop stops the component, finishing in-flight work before ctx is done.
+	Stop func(ctx context.Context) error
+}
+
+// Manager runs components until the context is cancelled or one fails
+type Manager struct {
+	drainDelay time.Duration
+	timeout    time.Duration
+	components []Component
+	checks     []check
+
+	started      atomic.Bool
+	shuttingDown atomic.Bool
+}
+
+type check struct {
+	name string
+	fn   func(ctx context.Context) error
+}
+
+// NewManager creates a manager. On shutdown it stays up but unready for
+// drainDelay, then gives its components timeout to stop.
+func NewManager(drainDelay, timeout time.Duration) *Manager {
+	return &Manager{drainDelay: drainDelay, timeout: timeout}
+}
+
+// Add registers a component. Components start together, so whatever they
+// need must be ready before Run; they stop in the reverse order they were
+// added, so add what others depend on first.
+func (m *Manager) Add(component Component) {
+	m.components = append(m.components, component)
+}
+
+// AddReadinessCheck registers a check that must pass for the instance to be ready
+func (m *Manager) AddReadinessCheck(name string, fn func(ctx context.Context) error) {
+	m.checks = append(m.checks, check{name: name, fn: fn})
+}
+
+// Ready reports whether the instance should receive traffic
+func (m *Manager) Ready(ctx context.Context) error {
+	if !m.started.Load() || m.shuttingDown.Load() {
+		return ErrNotReady
+	}
+	for _, c := range m.checks {
+		if err := c.fn(ctx); err != nil {
+			return fmt.Errorf("%s: %w", c.name, err)
+		}
+	}
+	return nil
+}
+
+// Run starts every component at once and blocks until ctx is cancelled or a
+// component fails, then shuts down. It returns the failure, if any, joined
+// with the errors of stopping.
+func (m *Manager) Run(ctx context.Context) error {
+	failed := make(chan error, len(m.components))
+	var wg sync.WaitGroup
+	for _, component := range m.components {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if err := component.Start(); err != nil {
+				failed <- fmt.Errorf("%s: %w", component.Name, err)
+			}
+		}()
+	}
+	m.started.Store(true)
+
+	var runErr error
+	select {
+	case <-ctx.Done():
+		log.Println("Shutting down")
+	case runErr = <-failed:
+		log.Printf("Shutting down: %v", runErr)
+	}
+
+	err := errors.Join(runErr, m.shutdown())
+	wg.Wait()
+	return err
+}
+
+func (m *Manager) shutdown() error {
+	m.shuttingDown.Store(true)
+	// Give load balancers time to notice the failing readiness check
+	time.Sleep(m.drainDelay)
+
+	ctx, cancel := context.WithTimeout(context.Background(), m.timeout)
+	defer cancel()
+
+	var errs []error
+	for _, component := range slices.Backward(m.components) {
+		if err := component.Stop(ctx); err != nil {
+			errs = append(errs, fmt.Errorf("failed to stop %s: %w", component.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
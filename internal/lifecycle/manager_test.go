@@ -0,0 +1,160 @@
+package lifecycle
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// server is a component that blocks in Start until stopped, like an HTTP server
+type server struct {
+	name    string
+	events  *events
+	stopped chan struct{}
+	once    sync.Once
+}
+
+type events struct {
+	mu   sync.Mutex
+	list []string
+}
+
+func (e *events) add(event string) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.list = append(e.list, event)
+}
+
+func (e *events) all() []string {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]string(nil), e.list...)
+}
+
+func newServer(name string, ev *events) *server {
+	return &server{name: name, events: ev, stopped: make(chan struct{})}
+}
+
+func (s *server) component() Component {
+	return Component{
+		Name: s.name,
+		Start: func() error {
+			<-s.stopped
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			s.events.add("stop " + s.name)
+			s.once.Do(func() { close(s.stopped) })
+			return nil
+		},
+	}
+}
+
+func TestManager_StopsComponentsInReverseOrder(t *testing.T) {
+	ev := &events{}
+	m := NewManager(0, time.Second)
+	for _, name := range []string{"database", "jobs", "http"} {
+		m.Add(newServer(name, ev).component())
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+
+	require.Eventually(t, func() bool { return m.Ready(context.Background()) == nil }, time.Second, time.Millisecond)
+	cancel()
+
+	require.NoError(t, <-done)
+	assert.Equal(t, []string{"stop http", "stop jobs", "stop database"}, ev.all())
+}
+
+func TestManager_ComponentFailureShutsDown(t *testing.T) {
+	ev := &events{}
+	m := NewManager(0, time.Second)
+	m.Add(newServer("database", ev).component())
+	m.Add(Component{
+		Name:  "http",
+		Start: func() error { return errors.New("address already in use") },
+		Stop:  func(ctx context.Context) error { return nil },
+	})
+
+	err := m.Run(context.Background())
+
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "http: address already in use")
+	assert.Equal(t, []string{"stop database"}, ev.all())
+}
+
+func TestManager_Readiness(t *testing.T) {
+	var dbErr error
+	var mu sync.Mutex
+	m := NewManager(50*time.Millisecond, time.Second)
+	m.AddReadinessCheck("database", func(ctx context.Context) error {
+		mu.Lock()
+		defer mu.Unlock()
+		return dbErr
+	})
+
+	stopped := make(chan struct{})
+	var drained time.Duration
+	shutdownStarted := time.Now()
+	m.Add(Component{
+		Name: "http",
+		Start: func() error {
+			<-stopped
+			return nil
+		},
+		Stop: func(ctx context.Context) error {
+			drained = time.Since(shutdownStarted)
+			close(stopped)
+			return nil
+		},
+	})
+
+	assert.ErrorIs(t, m.Ready(context.Background()), ErrNotReady, "not ready before starting")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- m.Run(ctx) }()
+	require.Eventually(t, func() bool { return m.Ready(context.Background()) == nil }, time.Second, time.Millisecond)
+
+	mu.Lock()
+	dbErr = errors.New("connection refused")
+	mu.Unlock()
+	assert.EqualError(t, m.Ready(context.Background()), "database: connection refused")
+
+	mu.Lock()
+	dbErr = nil
+	mu.Unlock()
+	shutdownStarted = time.Now()
+	cancel()
+
+	// Unready during the drain delay, while the server still runs
+	require.Eventually(t, func() bool { return errors.Is(m.Ready(context.Background()), ErrNotReady) }, time.Second, time.Millisecond)
+	require.NoError(t, <-done)
+	assert.GreaterOrEqual(t, drained, 50*time.Millisecond)
+}
+
+func TestManager_StopDeadline(t *testing.T) {
+	m := NewManager(0, 20*time.Millisecond)
+	m.Add(Component{
+		Name:  "jobs",
+		Start: func() error { return nil },
+		Stop: func(ctx context.Context) error {
+			<-ctx.Done()
+			return ctx.Err()
+		},
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	err := m.Run(ctx)
+
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Contains(t, err.Error(), "failed to stop jobs")
+}
@@ -0,0 +1,54 @@
+package metrics
+
+import (
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+type instrumentedHasher struct {
+	next      password.PasswordHasher
+	algorithm string
+}
+
+// InstrumentHasher records the duration of every Hash and Verify of hasher in
+// PasswordHashDuration. algorithm is the one hasher creates new hashes with;
+// verifications are labelled with the algorithm of the stored hash.
+func InstrumentHasher(hasher password.PasswordHasher, algorithm password.Algorithm) password.PasswordHasher {
+	return &instrumentedHasher{next: hasher, algorithm: string(algorithm)}
+}
+
+func (h *instrumentedHasher) Hash(plain string) (string, error) {
+	defer observeSince(PasswordHashDuration.WithLabelValues(h.algorithm, "hash"), time.Now())
+	return h.next.Hash(plain)
+}
+
+func (h *instrumentedHasher) Verify(plain, encoded string) (bool, error) {
+	defer observeSince(PasswordHashDuration.WithLabelValues(hashAlgorithm(encoded), "verify"), time.Now())
+	return h.next.Verify(plain, encoded)
+}
+
+func (h *instrumentedHasher) NeedsRehash(encoded string) bool {
+	return h.next.NeedsRehash(encoded)
+}
+
+// hashAlgorithm names the algorithm of a stored hash: bcrypt's "$2a$" format
+// or the identifier of a PHC string
+func hashAlgorithm(encoded string) string {
+	if strings.HasPrefix(encoded, "$2") {
+		return string(password.Bcrypt)
+	}
+	if id, _, ok := strings.Cut(strings.TrimPrefix(encoded, "$"), "$"); ok && id != "" {
+		switch password.Algorithm(id) {
+		case password.Argon2id, password.Scrypt, password.PBKDF2:
+			return id
+		}
+	}
+	return "unknown"
+}
+
+func observeSince(observer prometheus.Observer, start time.Time) {
+	observer.Observe(time.Since(start).Seconds())
+}
@@ -0,0 +1,63 @@
+package metrics
+
+import (
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestHashAlgorithm(t *testing.T) {
+	tests := map[string]string{
+		"$2a$10$N9qo8uLOickgx2ZMRZoMyeIjZAgcfl7p92ldGxad68LJZdL17lhWy": "bcrypt",
+		"$argon2id$v=19$m=19456,t=2,p=1$c2FsdA$aGFzaA":                 "argon2id",
+		"$scrypt$ln=17,r=8,p=1$c2FsdA$aGFzaA":                          "scrypt",
+		"$pbkdf2-sha256$i=600000$c2FsdA$aGFzaA":                        "pbkdf2-sha256",
+		"$md5$c2FsdA$aGFzaA":                                           "unknown",
+		"plaintext":                                                    "unknown",
+	}
+	for encoded, want := range tests {
+		assert.Equal(t, want, hashAlgorithm(encoded), encoded)
+	}
+}
+
+func TestInstrumentHasher(t *testing.T) {
+	params := password.DefaultParams()
+	params.Algorithm = password.Bcrypt
+	params.BcryptCost = bcrypt.MinCost
+	inner, err := password.NewPasswordHasher(params)
+	require.NoError(t, err)
+	hasher := InstrumentHasher(inner, password.Bcrypt)
+
+	encoded, err := hasher.Hash("correct horse battery staple")
+	require.NoError(t, err)
+	ok, err := hasher.Verify("correct horse battery staple", encoded)
+	require.NoError(t, err)
+	assert.True(t, ok)
+
+	assert.Contains(t, collectedLabels(t), "bcrypt/hash")
+	assert.Contains(t, collectedLabels(t), "bcrypt/verify")
+}
+
+// collectedLabels lists the algorithm/operation pairs PasswordHashDuration has series for
+func collectedLabels(t *testing.T) []string {
+	families, err := Registry.Gather()
+	require.NoError(t, err)
+
+	var labels []string
+	for _, family := range families {
+		if family.GetName() != "auth_password_hash_duration_seconds" {
+			continue
+		}
+		for _, metric := range family.GetMetric() {
+			values := map[string]string{}
+			for _, label := range metric.GetLabel() {
+				values[label.GetName()] = label.GetValue()
+			}
+			labels = append(labels, values["algorithm"]+"/"+values["operation"])
+		}
+	}
+	return labels
+}
@@ -0,0 +1,169 @@
+// Package metrics holds the Prometheus collectors of the service and the
+// registry served at /metrics. The collectors are package level, so any layer
+// can record into them without threading a registry through constructors.
+package metrics
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/collectors"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prometheus/client_golang/prometheus/promhttp"
+)
+
+const namespace = "auth"
+
+// Operations and methods of AuthOperations
+const (
+	OperationRegister = "register"
+	OperationLogin    = "login"
+	OperationRefresh  = "refresh"
+	OperationLogout   = "logout"
+
+	MethodPassword     = "password"
+	MethodGoogle       = "google"
+	MethodPasswordless = "passwordless"
+	MethodRefreshToken = "refresh_token"
+	// MethodAllSessions is a logout from every device
+	MethodAllSessions = "all_sessions"
+)
+
+// Outcomes of AuthOperations: a failure is rejected by the client's fault
+// (4xx), an error by the server's (5xx)
+const (
+	OutcomeSuccess = "success"
+	OutcomeFailure = "failure"
+	OutcomeError   = "error"
+)
+
+// Limiters of RateLimitRejections
+const (
+	LimiterAPIKey     = "api_key"
+	LimiterDevicePoll = "device_poll"
+)
+
+// Registry holds every collector of the service plus the Go runtime and
+// process collectors
+var Registry = prometheus.NewRegistry()
+
+var factory = promauto.With(Registry)
+
+var (
+	AuthOperations = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "operations_total",
+		Help:      "Authentication operations by operation, method and outcome.",
+	}, []string{"operation", "method", "outcome"})
+
+	RefreshTokenReuse = factory.NewCounter(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "refresh_token_reuse_total",
+		Help:      "Refresh requests presenting a refresh token that was already rotated or revoked.",
+	})
+
+	RateLimitRejections = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "rate_limit_rejections_total",
+		Help:      "Requests rejected by a rate limit, by limiter.",
+	}, []string{"limiter"})
+
+	MiddlewareRejections = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "middleware_rejections_total",
+		Help:      "Requests rejected by the pkg/auth middlewares, by error type.",
+	}, []string{"type"})
+
+	PasswordHashDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "password_hash_duration_seconds",
+		Help:      "Time spent hashing and verifying passwords, by algorithm and operation.",
+		Buckets:   []float64{.01, .025, .05, .1, .25, .5, 1, 2.5},
+	}, []string{"algorithm", "operation"})
+
+	DBQueryDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "db_query_duration_seconds",
+		Help:      "Time spent in database statements, by operation and table.",
+		Buckets:   []float64{.0005, .001, .0025, .005, .01, .025, .05, .1, .25, .5, 1},
+	}, []string{"operation", "table"})
+
+	HTTPRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "http_requests_total",
+		Help:      "HTTP requests by method, route template and status code.",
+	}, []string{"method", "route", "status"})
+
+	HTTPRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "http_request_duration_seconds",
+		Help:      "HTTP request latency by method and route template.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method", "route"})
+
+	GRPCRequests = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "grpc_requests_total",
+		Help:      "gRPC calls by full method name and status code.",
+	}, []string{"method", "code"})
+
+	GRPCRequestDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "grpc_request_duration_seconds",
+		Help:      "gRPC call latency by full method name.",
+		Buckets:   prometheus.DefBuckets,
+	}, []string{"method"})
+
+	JobRuns = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_runs_total",
+		Help:      "Scheduled job runs on this instance, by job.",
+	}, []string{"job"})
+
+	JobFailures = factory.NewCounterVec(prometheus.CounterOpts{
+		Namespace: namespace,
+		Name:      "job_failures_total",
+		Help:      "Scheduled job runs that returned an error, by job.",
+	}, []string{"job"})
+
+	JobDuration = factory.NewHistogramVec(prometheus.HistogramOpts{
+		Namespace: namespace,
+		Name:      "job_duration_seconds",
+		Help:      "Time spent running scheduled jobs, by job.",
+		Buckets:   []float64{.01, .05, .1, .5, 1, 5, 10, 30, 60},
+	}, []string{"job"})
+)
+
+func init() {
+	Registry.MustRegister(
+		collectors.NewGoCollector(),
+		collectors.NewProcessCollector(collectors.ProcessCollectorOpts{}),
+	)
+}
+
+// Handler serves the registry in the Prometheus exposition format
+func Handler() http.Handler {
+	return promhttp.HandlerFor(Registry, promhttp.HandlerOpts{Registry: Registry})
+}
+
+// Outcome classifies a response status code
+func Outcome(status int) string {
+	switch {
+	case status >= http.StatusInternalServerError:
+		return OutcomeError
+	case status >= http.StatusBadRequest:
+		return OutcomeFailure
+	}
+	return OutcomeSuccess
+}
+
+// ObserveRejection counts a pkg/auth middleware rejection; pass it to
+// auth.WithRejectionObserver and auth.WithAPIKeyRejectionObserver
+func ObserveRejection(_ context.Context, errorType auth.AuthErrorType) {
+	MiddlewareRejections.WithLabelValues(string(errorType)).Inc()
+	if errorType == auth.ErrorTypeRateLimited {
+		RateLimitRejections.WithLabelValues(LimiterAPIKey).Inc()
+	}
+}
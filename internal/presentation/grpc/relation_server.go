@@ -0,0 +1,111 @@
+package grpc
+
+import (
+	"context"
+
+	authv1 "github.com/EduardoPPCaldas/auth-service/api/proto/auth/v1"
+	relationusecases "github.com/EduardoPPCaldas/auth-service/internal/application/relation/usecases"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// RelationServer exposes the relation use cases as authv1.RelationService.
+type RelationServer struct {
+	authv1.UnimplementedRelationServiceServer
+
+	checkRelationUseCase  *relationusecases.CheckRelationUseCase
+	expandRelationUseCase *relationusecases.ExpandRelationUseCase
+	listObjectsUseCase    *relationusecases.ListObjectsUseCase
+	writeRelationsUseCase *relationusecases.WriteRelationsUseCase
+}
+
+func NewRelationServer(
+	checkRelationUseCase *relationusecases.CheckRelationUseCase,
+	expandRelationUseCase *relationusecases.ExpandRelationUseCase,
+	listObjectsUseCase *relationusecases.ListObjectsUseCase,
+	writeRelationsUseCase *relationusecases.WriteRelationsUseCase,
+) *RelationServer {
+	return &RelationServer{
+		checkRelationUseCase:  checkRelationUseCase,
+		expandRelationUseCase: expandRelationUseCase,
+		listObjectsUseCase:    listObjectsUseCase,
+		writeRelationsUseCase: writeRelationsUseCase,
+	}
+}
+
+func (s *RelationServer) Check(ctx context.Context, req *authv1.CheckRelationRequest) (*authv1.CheckRelationResponse, error) {
+	allowed, err := s.checkRelationUseCase.Execute(ctx, relationusecases.CheckRelationInput{
+		Object:   req.GetObject(),
+		Relation: req.GetRelation(),
+		Subject:  req.GetSubject(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &authv1.CheckRelationResponse{Allowed: allowed}, nil
+}
+
+func (s *RelationServer) Expand(ctx context.Context, req *authv1.ExpandRelationRequest) (*authv1.ExpandRelationResponse, error) {
+	tree, err := s.expandRelationUseCase.Execute(ctx, relationusecases.ExpandRelationInput{
+		Object:   req.GetObject(),
+		Relation: req.GetRelation(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &authv1.ExpandRelationResponse{Tree: toProtoTree(tree)}, nil
+}
+
+func (s *RelationServer) ListObjects(ctx context.Context, req *authv1.ListObjectsRequest) (*authv1.ListObjectsResponse, error) {
+	objects, err := s.listObjectsUseCase.Execute(ctx, relationusecases.ListObjectsInput{
+		Namespace: req.GetNamespace(),
+		Relation:  req.GetRelation(),
+		Subject:   req.GetSubject(),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &authv1.ListObjectsResponse{Objects: objects}, nil
+}
+
+func (s *RelationServer) Write(ctx context.Context, req *authv1.WriteRelationsRequest) (*authv1.WriteRelationsResponse, error) {
+	err := s.writeRelationsUseCase.Execute(ctx, relationusecases.WriteRelationsInput{
+		Writes:  toTupleInputs(req.GetWrites()),
+		Deletes: toTupleInputs(req.GetDeletes()),
+	})
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	return &authv1.WriteRelationsResponse{}, nil
+}
+
+func toProtoTree(tree *relationusecases.Tree) *authv1.RelationTree {
+	pb := &authv1.RelationTree{
+		Object:   tree.Object.String(),
+		Relation: tree.Relation,
+		Subjects: make([]string, len(tree.Subjects)),
+	}
+	for i, subject := range tree.Subjects {
+		pb.Subjects[i] = subject.String()
+	}
+	for _, child := range tree.Children {
+		pb.Children = append(pb.Children, toProtoTree(child))
+	}
+	return pb
+}
+
+func toTupleInputs(tuples []*authv1.RelationTuple) []relationusecases.TupleInput {
+	inputs := make([]relationusecases.TupleInput, len(tuples))
+	for i, t := range tuples {
+		inputs[i] = relationusecases.TupleInput{
+			Object:   t.GetObject(),
+			Relation: t.GetRelation(),
+			Subject:  t.GetSubject(),
+		}
+	}
+	return inputs
+}
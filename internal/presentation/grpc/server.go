@@ -0,0 +1,34 @@
+package grpc
+
+import (
+	authv1 "github.com/EduardoPPCaldas/auth-service/api/proto/auth/v1"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
+	"google.golang.org/grpc"
+)
+
+// NewServer creates a gRPC server that authenticates every call with the
+// bearer token in the "authorization" metadata and registers the services.
+// Calls are recorded in the gRPC metrics, rejected ones included, and traced
+// with the W3C trace context of the incoming metadata.
+func NewServer(authMiddleware *auth.AuthMiddleware, relationServer *RelationServer) *grpc.Server {
+	server := grpc.NewServer(
+		grpc.StatsHandler(otelgrpc.NewServerHandler()),
+		grpc.ChainUnaryInterceptor(
+			metricsUnaryInterceptor(),
+			authMiddleware.GRPCUnaryInterceptor(),
+			authMiddleware.GRPCUnaryRequire(map[string]auth.Requirement{
+				authv1.RelationService_Check_FullMethodName:       auth.Global(auth.Permission(relation.PermissionRead)),
+				authv1.RelationService_Expand_FullMethodName:      auth.Global(auth.Permission(relation.PermissionRead)),
+				authv1.RelationService_ListObjects_FullMethodName: auth.Global(auth.Permission(relation.PermissionRead)),
+				authv1.RelationService_Write_FullMethodName:       auth.Global(auth.Permission(relation.PermissionWrite)),
+			}),
+		),
+		grpc.ChainStreamInterceptor(metricsStreamInterceptor()),
+	)
+
+	authv1.RegisterRelationServiceServer(server, relationServer)
+
+	return server
+}
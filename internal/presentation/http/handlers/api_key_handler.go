@@ -0,0 +1,178 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/apikey/dto"
+	apikeyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/apikey/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type APIKeyHandler struct {
+	createAPIKeyUseCase CreateAPIKeyUseCase
+	listAPIKeysUseCase  ListAPIKeysUseCase
+	revokeAPIKeyUseCase RevokeAPIKeyUseCase
+	verifyAPIKeyUseCase VerifyAPIKeyUseCase
+	serviceTokens       ServiceTokenValidator
+}
+
+type CreateAPIKeyUseCase interface {
+	Execute(ctx context.Context, input apikeyusecases.CreateAPIKeyInput) (*apikeyusecases.CreateAPIKeyOutput, error)
+}
+
+type ListAPIKeysUseCase interface {
+	Execute(ctx context.Context) ([]apikey.APIKey, error)
+}
+
+type RevokeAPIKeyUseCase interface {
+	Execute(ctx context.Context, input apikeyusecases.RevokeAPIKeyInput) error
+}
+
+type VerifyAPIKeyUseCase interface {
+	Execute(ctx context.Context, key string) (*auth.APIKey, error)
+}
+
+func NewAPIKeyHandler(
+	createAPIKeyUseCase CreateAPIKeyUseCase,
+	listAPIKeysUseCase ListAPIKeysUseCase,
+	revokeAPIKeyUseCase RevokeAPIKeyUseCase,
+	verifyAPIKeyUseCase VerifyAPIKeyUseCase,
+	serviceTokens ServiceTokenValidator,
+) *APIKeyHandler {
+	return &APIKeyHandler{
+		createAPIKeyUseCase: createAPIKeyUseCase,
+		listAPIKeysUseCase:  listAPIKeysUseCase,
+		revokeAPIKeyUseCase: revokeAPIKeyUseCase,
+		verifyAPIKeyUseCase: verifyAPIKeyUseCase,
+		serviceTokens:       serviceTokens,
+	}
+}
+
+// CreateAPIKey handles creating an API key; the secret is only returned here
+// POST /api/v1/admin/api-keys
+func (h *APIKeyHandler) CreateAPIKey(c echo.Context) error {
+	var req dto.CreateAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := apikeyusecases.CreateAPIKeyInput{
+		AdminUserID:    adminUserID,
+		Name:           req.Name,
+		ServiceAccount: req.ServiceAccount,
+		Permissions:    req.Permissions,
+		AllowedIPs:     req.AllowedIPs,
+		RateLimit:      req.RateLimit,
+		ExpiresAt:      req.ExpiresAt,
+	}
+	if req.OrganizationID != nil {
+		orgID := uuid.MustParse(*req.OrganizationID)
+		input.OrganizationID = &orgID
+	}
+
+	output, err := h.createAPIKeyUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.ToAPIKeyResponse(output.APIKey)
+	response.Key = output.Key
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ListAPIKeys handles listing all API keys, including revoked ones
+// GET /api/v1/admin/api-keys
+func (h *APIKeyHandler) ListAPIKeys(c echo.Context) error {
+	keys, err := h.listAPIKeysUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.APIKeyResponse, 0, len(keys))
+	for i := range keys {
+		response = append(response, dto.ToAPIKeyResponse(&keys[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// ExportAPIKeys handles exporting the usable API keys, hashed, for services verifying keys locally
+// GET /api/v1/admin/api-keys/export
+func (h *APIKeyHandler) ExportAPIKeys(c echo.Context) error {
+	keys, err := h.listAPIKeysUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToAPIKeyStore(keys))
+}
+
+// RevokeAPIKey handles revoking an API key
+// DELETE /api/v1/admin/api-keys/:id
+func (h *APIKeyHandler) RevokeAPIKey(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid API key ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := apikeyusecases.RevokeAPIKeyInput{AdminUserID: adminUserID, ID: id}
+	if err := h.revokeAPIKeyUseCase.Execute(c.Request().Context(), input); err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "API key revoked"})
+}
+
+// VerifyAPIKey handles resolving an API key for a service presenting its
+// service token as Bearer credentials
+// POST /api/v1/api-keys/verify
+func (h *APIKeyHandler) VerifyAPIKey(c echo.Context) error {
+	serviceToken, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "service token required"})
+	}
+	if _, err := h.serviceTokens.ValidateServiceToken(serviceToken); err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": "invalid service token"})
+	}
+
+	var req dto.VerifyAPIKeyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	grant, err := h.verifyAPIKeyUseCase.Execute(c.Request().Context(), req.Key)
+	if err != nil {
+		if errors.Is(err, apikey.ErrAPIKeyNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, grant)
+}
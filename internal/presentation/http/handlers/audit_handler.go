@@ -0,0 +1,213 @@
+package handlers
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/audit/dto"
+	auditusecases "github.com/EduardoPPCaldas/auth-service/internal/application/audit/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type AuditHandler struct {
+	listEventsUseCase   ListAuditEventsUseCase
+	exportEventsUseCase ExportAuditEventsUseCase
+	verifyChainUseCase  VerifyAuditChainUseCase
+}
+
+type ListAuditEventsUseCase interface {
+	Execute(ctx context.Context, filter audit.Filter) (*auditusecases.EventPage, error)
+}
+
+type ExportAuditEventsUseCase interface {
+	Execute(ctx context.Context, filter audit.Filter, write func(audit.Event) error) error
+}
+
+type VerifyAuditChainUseCase interface {
+	Execute(ctx context.Context, anchor *audit.Anchor) (*auditusecases.VerifyChainResult, error)
+}
+
+func NewAuditHandler(
+	listEventsUseCase ListAuditEventsUseCase,
+	exportEventsUseCase ExportAuditEventsUseCase,
+	verifyChainUseCase VerifyAuditChainUseCase,
+) *AuditHandler {
+	return &AuditHandler{
+		listEventsUseCase:   listEventsUseCase,
+		exportEventsUseCase: exportEventsUseCase,
+		verifyChainUseCase:  verifyChainUseCase,
+	}
+}
+
+// ListEvents handles listing audit events, newest first
+// GET /api/v1/admin/audit?actor_id=&action=&target_id=&outcome=&from=&to=&before=&limit=
+func (h *AuditHandler) ListEvents(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	page, err := h.listEventsUseCase.Execute(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.AuditEventPageResponse{
+		Events:     make([]dto.AuditEventResponse, len(page.Events)),
+		NextBefore: page.NextBefore,
+	}
+	for i := range page.Events {
+		response.Events[i] = dto.ToAuditEventResponse(&page.Events[i])
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// ExportEvents handles streaming every matching audit event, oldest first,
+// as JSON lines or CSV
+// GET /api/v1/admin/audit/export?format=jsonl|csv&actor_id=&action=&target_id=&outcome=&from=&to=
+func (h *AuditHandler) ExportEvents(c echo.Context) error {
+	filter, err := parseAuditFilter(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	format := c.QueryParam("format")
+	contentType, ok := auditExportContentTypes[format]
+	if !ok {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": fmt.Sprintf("unsupported format %q", format)})
+	}
+
+	// Once the status is sent it can't change, so a failure while reading
+	// the events just cuts the export short
+	res := c.Response()
+	res.Header().Set(echo.HeaderContentType, contentType)
+	res.Header().Set(echo.HeaderContentDisposition, "attachment")
+	res.WriteHeader(http.StatusOK)
+	ctx := c.Request().Context()
+
+	if format != "csv" {
+		encoder := json.NewEncoder(res)
+		return h.exportEventsUseCase.Execute(ctx, filter, func(e audit.Event) error {
+			return encoder.Encode(dto.ToAuditEventResponse(&e))
+		})
+	}
+
+	writer := csv.NewWriter(res)
+	if err := writer.Write(dto.AuditCSVHeader); err != nil {
+		return err
+	}
+	err = h.exportEventsUseCase.Execute(ctx, filter, func(e audit.Event) error {
+		return writer.Write(auditCSVRecord(dto.ToAuditEventResponse(&e)))
+	})
+	writer.Flush()
+	return errors.Join(err, writer.Error())
+}
+
+// auditExportContentTypes maps the export formats to their content types;
+// JSON lines is the default
+var auditExportContentTypes = map[string]string{
+	"":      "application/x-ndjson",
+	"jsonl": "application/x-ndjson",
+	"csv":   "text/csv",
+}
+
+// VerifyChain handles checking every audit event against the hash chain and
+// an optional anchor from an earlier check
+// GET /api/v1/admin/audit/verify
+func (h *AuditHandler) VerifyChain(c echo.Context) error {
+	anchor, err := parseAuditAnchor(c)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	result, err := h.verifyChainUseCase.Execute(c.Request().Context(), anchor)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.AuditChainResponse{Valid: result.Broken == nil, Checked: result.Checked}
+	if result.Head != nil {
+		response.HeadSequence = result.Head.Sequence
+		response.HeadHash = result.Head.Hash
+	}
+	if result.Broken != nil {
+		response.BrokenSequence = result.Broken.Sequence
+		response.Reason = result.Broken.Reason
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// parseAuditAnchor reads anchor_sequence and anchor_hash, which go together.
+func parseAuditAnchor(c echo.Context) (*audit.Anchor, error) {
+	sequence, hash := c.QueryParam("anchor_sequence"), c.QueryParam("anchor_hash")
+	if sequence == "" && hash == "" {
+		return nil, nil
+	}
+	if sequence == "" || hash == "" {
+		return nil, fmt.Errorf("anchor_sequence and anchor_hash must be given together")
+	}
+	n, err := strconv.ParseInt(sequence, 10, 64)
+	if err != nil || n < 1 {
+		return nil, fmt.Errorf("invalid anchor sequence")
+	}
+	return &audit.Anchor{Sequence: n, Hash: hash}, nil
+}
+
+func parseAuditFilter(c echo.Context) (audit.Filter, error) {
+	filter := audit.Filter{
+		Action:   c.QueryParam("action"),
+		TargetID: c.QueryParam("target_id"),
+		Outcome:  c.QueryParam("outcome"),
+	}
+
+	var err error
+	if actorID := c.QueryParam("actor_id"); actorID != "" {
+		if filter.ActorID, err = uuid.Parse(actorID); err != nil {
+			return filter, fmt.Errorf("invalid actor ID")
+		}
+	}
+	from, err := parseTimeParam(c, "from")
+	if err != nil {
+		return filter, err
+	}
+	if from != nil {
+		filter.From = *from
+	}
+	to, err := parseTimeParam(c, "to")
+	if err != nil {
+		return filter, err
+	}
+	if to != nil {
+		filter.To = *to
+	}
+	if before := c.QueryParam("before"); before != "" {
+		if filter.BeforeSequence, err = strconv.ParseInt(before, 10, 64); err != nil {
+			return filter, fmt.Errorf("invalid before")
+		}
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return filter, fmt.Errorf("invalid limit")
+		}
+	}
+	return filter, nil
+}
+
+func auditCSVRecord(e dto.AuditEventResponse) []string {
+	metadata := ""
+	if len(e.Metadata) > 0 {
+		encoded, _ := json.Marshal(e.Metadata)
+		metadata = string(encoded)
+	}
+	return []string{
+		e.ID, strconv.FormatInt(e.Sequence, 10), e.OccurredAt, e.ActorID, e.ActorEmail, e.Action, e.TargetType, e.TargetID,
+		e.IP, e.UserAgent, e.Outcome, metadata, e.PrevHash, e.Hash,
+	}
+}
@@ -2,10 +2,15 @@ package handlers
 
 import (
 	"context"
+	"errors"
 	"net/http"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
 	"github.com/google/uuid"
 	"github.com/labstack/echo/v4"
 )
@@ -76,7 +81,7 @@ func (h *AuthHandler) CreateUser(c echo.Context) error {
 
 	token, err := h.createUserUseCase.Execute(c.Request().Context(), req.Email, req.Password)
 	if err != nil {
-		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+		return passwordErrorResponse(c, err)
 	}
 
 	return c.JSON(http.StatusCreated, dto.AuthResponse{AccessToken: token, RefreshToken: "", TokenType: "Bearer", ExpiresIn: 86400})
@@ -96,7 +101,7 @@ func (h *AuthHandler) LoginUser(c echo.Context) error {
 
 	token, err := h.loginUserUseCase.Execute(c.Request().Context(), req.Email, req.Password)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		return c.JSON(authErrorStatus(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, dto.AuthResponse{AccessToken: token, RefreshToken: "", TokenType: "Bearer", ExpiresIn: 86400})
@@ -116,7 +121,7 @@ func (h *AuthHandler) LoginWithGoogle(c echo.Context) error {
 
 	token, err := h.loginWithGoogleUseCase.Execute(c.Request().Context(), req.IDToken)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		return c.JSON(authErrorStatus(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, dto.AuthResponse{AccessToken: token, RefreshToken: "", TokenType: "Bearer", ExpiresIn: 86400})
@@ -136,7 +141,10 @@ func (h *AuthHandler) RefreshToken(c echo.Context) error {
 
 	response, err := h.refreshTokenUseCase.Execute(c.Request().Context(), req.RefreshToken)
 	if err != nil {
-		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+		if errors.Is(err, token.ErrRefreshTokenReused) {
+			metrics.RefreshTokenReuse.Inc()
+		}
+		return c.JSON(authErrorStatus(err), map[string]string{"error": err.Error()})
 	}
 
 	return c.JSON(http.StatusOK, response)
@@ -189,3 +197,24 @@ func (h *AuthHandler) ChallengeGoogleAuth(c echo.Context) error {
 	authURL := h.googleOAuthService.GetAuthURL()
 	return c.Redirect(http.StatusFound, authURL)
 }
+
+// authErrorStatus distinguishes disabled accounts from failed authentication.
+func authErrorStatus(err error) int {
+	if errors.Is(err, user.ErrUserDisabled) {
+		return http.StatusForbidden
+	}
+	return http.StatusUnauthorized
+}
+
+// passwordErrorResponse reports a failed request that set a password, listing
+// the broken rules when the password policy rejected it.
+func passwordErrorResponse(c echo.Context, err error) error {
+	var policyErr *password.PolicyError
+	if errors.As(err, &policyErr) {
+		return c.JSON(http.StatusBadRequest, map[string]any{"error": err.Error(), "violations": policyErr.Violations})
+	}
+	if errors.Is(err, user.ErrUserDisabled) {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	}
+	return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+}
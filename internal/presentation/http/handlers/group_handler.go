@@ -0,0 +1,351 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/group/dto"
+	groupusecases "github.com/EduardoPPCaldas/auth-service/internal/application/group/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type GroupHandler struct {
+	createGroupUseCase         CreateGroupUseCase
+	listGroupsUseCase          ListGroupsUseCase
+	getGroupUseCase            GetGroupUseCase
+	deleteGroupUseCase         DeleteGroupUseCase
+	addGroupMemberUseCase      GroupMemberUseCase
+	removeGroupMemberUseCase   GroupMemberUseCase
+	listGroupMembersUseCase    ListGroupMembersUseCase
+	assignRoleToGroupUseCase   GroupRoleUseCase
+	removeRoleFromGroupUseCase GroupRoleUseCase
+	listEffectiveRolesUseCase  ListEffectiveRolesUseCase
+}
+
+type CreateGroupUseCase interface {
+	Execute(ctx context.Context, input groupusecases.CreateGroupInput) (*group.Group, error)
+}
+
+type ListGroupsUseCase interface {
+	Execute(ctx context.Context) ([]group.Group, error)
+}
+
+type GetGroupUseCase interface {
+	Execute(ctx context.Context, groupID uuid.UUID) (*group.Group, error)
+}
+
+type DeleteGroupUseCase interface {
+	Execute(ctx context.Context, input groupusecases.DeleteGroupInput) error
+}
+
+// GroupMemberUseCase adds or removes a group member.
+type GroupMemberUseCase interface {
+	Execute(ctx context.Context, input groupusecases.GroupMemberInput) error
+}
+
+type ListGroupMembersUseCase interface {
+	Execute(ctx context.Context, groupID uuid.UUID) ([]user.User, error)
+}
+
+// GroupRoleUseCase assigns a role to or removes a role from a group.
+type GroupRoleUseCase interface {
+	Execute(ctx context.Context, input groupusecases.GroupRoleInput) error
+}
+
+type ListEffectiveRolesUseCase interface {
+	Execute(ctx context.Context, userID uuid.UUID) ([]user.RoleGrant, error)
+}
+
+func NewGroupHandler(
+	createGroupUseCase CreateGroupUseCase,
+	listGroupsUseCase ListGroupsUseCase,
+	getGroupUseCase GetGroupUseCase,
+	deleteGroupUseCase DeleteGroupUseCase,
+	addGroupMemberUseCase GroupMemberUseCase,
+	removeGroupMemberUseCase GroupMemberUseCase,
+	listGroupMembersUseCase ListGroupMembersUseCase,
+	assignRoleToGroupUseCase GroupRoleUseCase,
+	removeRoleFromGroupUseCase GroupRoleUseCase,
+	listEffectiveRolesUseCase ListEffectiveRolesUseCase,
+) *GroupHandler {
+	return &GroupHandler{
+		createGroupUseCase:         createGroupUseCase,
+		listGroupsUseCase:          listGroupsUseCase,
+		getGroupUseCase:            getGroupUseCase,
+		deleteGroupUseCase:         deleteGroupUseCase,
+		addGroupMemberUseCase:      addGroupMemberUseCase,
+		removeGroupMemberUseCase:   removeGroupMemberUseCase,
+		listGroupMembersUseCase:    listGroupMembersUseCase,
+		assignRoleToGroupUseCase:   assignRoleToGroupUseCase,
+		removeRoleFromGroupUseCase: removeRoleFromGroupUseCase,
+		listEffectiveRolesUseCase:  listEffectiveRolesUseCase,
+	}
+}
+
+// CreateGroup handles group creation
+// POST /api/v1/admin/groups
+func (h *GroupHandler) CreateGroup(c echo.Context) error {
+	var req dto.CreateGroupRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	g, err := h.createGroupUseCase.Execute(c.Request().Context(), groupusecases.CreateGroupInput{
+		AdminUserID: adminUserID,
+		Name:        req.Name,
+		Description: req.Description,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ToGroupResponse(g))
+}
+
+// ListGroups handles listing all groups
+// GET /api/v1/admin/groups
+func (h *GroupHandler) ListGroups(c echo.Context) error {
+	groups, err := h.listGroupsUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.GroupResponse, len(groups))
+	for i := range groups {
+		response[i] = dto.ToGroupResponse(&groups[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetGroup handles getting a specific group with its roles
+// GET /api/v1/admin/groups/:id
+func (h *GroupHandler) GetGroup(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	g, err := h.getGroupUseCase.Execute(c.Request().Context(), groupID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToGroupResponse(g))
+}
+
+// DeleteGroup handles group deletion
+// DELETE /api/v1/admin/groups/:id
+func (h *GroupHandler) DeleteGroup(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.deleteGroupUseCase.Execute(c.Request().Context(), groupusecases.DeleteGroupInput{
+		AdminUserID: adminUserID,
+		GroupID:     groupID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "group deleted successfully"})
+}
+
+// ListMembers handles listing the members of a group
+// GET /api/v1/admin/groups/:id/members
+func (h *GroupHandler) ListMembers(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	members, err := h.listGroupMembersUseCase.Execute(c.Request().Context(), groupID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.GroupMemberResponse, len(members))
+	for i := range members {
+		response[i] = dto.ToGroupMemberResponse(&members[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// AddMember handles adding a user to a group
+// POST /api/v1/admin/groups/:id/members
+func (h *GroupHandler) AddMember(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	var req dto.AddGroupMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := uuid.Parse(req.UserID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.addGroupMemberUseCase.Execute(c.Request().Context(), groupusecases.GroupMemberInput{
+		AdminUserID: adminUserID,
+		GroupID:     groupID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, map[string]string{"message": "member added successfully"})
+}
+
+// RemoveMember handles removing a user from a group
+// DELETE /api/v1/admin/groups/:id/members/:userID
+func (h *GroupHandler) RemoveMember(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	userID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.removeGroupMemberUseCase.Execute(c.Request().Context(), groupusecases.GroupMemberInput{
+		AdminUserID: adminUserID,
+		GroupID:     groupID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "member removed successfully"})
+}
+
+// AssignRole handles granting a role to every member of a group
+// POST /api/v1/admin/groups/:id/roles
+func (h *GroupHandler) AssignRole(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	var req dto.AssignGroupRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	roleID, err := uuid.Parse(req.RoleID)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.assignRoleToGroupUseCase.Execute(c.Request().Context(), groupusecases.GroupRoleInput{
+		AdminUserID: adminUserID,
+		GroupID:     groupID,
+		RoleID:      roleID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "role assigned successfully"})
+}
+
+// RemoveRole handles removing a role from a group
+// DELETE /api/v1/admin/groups/:id/roles/:roleID
+func (h *GroupHandler) RemoveRole(c echo.Context) error {
+	groupID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid group ID"})
+	}
+
+	roleID, err := uuid.Parse(c.Param("roleID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid role ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.removeRoleFromGroupUseCase.Execute(c.Request().Context(), groupusecases.GroupRoleInput{
+		AdminUserID: adminUserID,
+		GroupID:     groupID,
+		RoleID:      roleID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "role removed successfully"})
+}
+
+// ListEffectiveRoles handles listing a user's effective roles and where each comes from
+// GET /api/v1/admin/users/:id/roles
+func (h *GroupHandler) ListEffectiveRoles(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	grants, err := h.listEffectiveRolesUseCase.Execute(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.EffectiveRoleResponse, len(grants))
+	for i, grant := range grants {
+		response[i] = dto.ToEffectiveRoleResponse(grant)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
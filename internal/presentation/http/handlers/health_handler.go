@@ -0,0 +1,44 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/labstack/echo/v4"
+)
+
+// readinessTimeout bounds the readiness checks, so a stuck database fails
+// the probe instead of hanging it
+const readinessTimeout = 2 * time.Second
+
+type HealthHandler struct {
+	readiness ReadinessChecker
+}
+
+type ReadinessChecker interface {
+	Ready(ctx context.Context) error
+}
+
+func NewHealthHandler(readiness ReadinessChecker) *HealthHandler {
+	return &HealthHandler{readiness: readiness}
+}
+
+// Live handles the liveness probe; it succeeds while the process can serve requests
+// GET /livez
+func (h *HealthHandler) Live(c echo.Context) error {
+	return c.JSON(http.StatusOK, map[string]string{"status": "ok"})
+}
+
+// Ready handles the readiness probe; it fails while the database is unreachable or
+// migrations are pending, and once shutdown has begun
+// GET /readyz
+func (h *HealthHandler) Ready(c echo.Context) error {
+	ctx, cancel := context.WithTimeout(c.Request().Context(), readinessTimeout)
+	defer cancel()
+
+	if err := h.readiness.Ready(ctx); err != nil {
+		return c.JSON(http.StatusServiceUnavailable, map[string]string{"status": "unavailable", "error": err.Error()})
+	}
+	return c.JSON(http.StatusOK, map[string]string{"status": "ready"})
+}
@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ImpersonationHandler struct {
+	impersonateUserUseCase           ImpersonateUserUseCase
+	listImpersonationSessionsUseCase ListImpersonationSessionsUseCase
+}
+
+type ImpersonateUserUseCase interface {
+	Execute(ctx context.Context, input usecases.ImpersonateUserInput) (*usecases.ImpersonateUserOutput, error)
+}
+
+type ListImpersonationSessionsUseCase interface {
+	Execute(ctx context.Context, filter impersonation.ListFilter) ([]impersonation.Session, error)
+}
+
+func NewImpersonationHandler(
+	impersonateUserUseCase ImpersonateUserUseCase,
+	listImpersonationSessionsUseCase ListImpersonationSessionsUseCase,
+) *ImpersonationHandler {
+	return &ImpersonationHandler{
+		impersonateUserUseCase:           impersonateUserUseCase,
+		listImpersonationSessionsUseCase: listImpersonationSessionsUseCase,
+	}
+}
+
+// ImpersonateUser handles issuing a short-lived token to act as another user
+// POST /api/v1/admin/users/:id/impersonate
+func (h *ImpersonationHandler) ImpersonateUser(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	var req dto.ImpersonateUserRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	output, err := h.impersonateUserUseCase.Execute(c.Request().Context(), usecases.ImpersonateUserInput{
+		AdminUserID: adminUserID,
+		UserID:      userID,
+		Reason:      req.Reason,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ImpersonationResponse{
+		AccessToken: output.AccessToken,
+		TokenType:   "Bearer",
+		ExpiresIn:   int(time.Until(output.Session.ExpiresAt).Seconds()),
+		SessionID:   output.Session.ID.String(),
+	})
+}
+
+// ListSessions handles listing recorded impersonation sessions, newest first
+// GET /api/v1/admin/impersonations?actor_id=&user_id=
+func (h *ImpersonationHandler) ListSessions(c echo.Context) error {
+	var filter impersonation.ListFilter
+	var err error
+	if actorID := c.QueryParam("actor_id"); actorID != "" {
+		if filter.ActorID, err = uuid.Parse(actorID); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid actor ID"})
+		}
+	}
+	if userID := c.QueryParam("user_id"); userID != "" {
+		if filter.UserID, err = uuid.Parse(userID); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+		}
+	}
+
+	sessions, err := h.listImpersonationSessionsUseCase.Execute(c.Request().Context(), filter)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.ImpersonationSessionResponse, len(sessions))
+	for i := range sessions {
+		response[i] = dto.ToImpersonationSessionResponse(&sessions[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,158 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/dto"
+	orgusecases "github.com/EduardoPPCaldas/auth-service/internal/application/organization/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type InvitationHandler struct {
+	createInvitationUseCase CreateInvitationUseCase
+	listInvitationsUseCase  ListInvitationsUseCase
+	revokeInvitationUseCase RevokeInvitationUseCase
+	acceptInvitationUseCase AcceptInvitationUseCase
+}
+
+type CreateInvitationUseCase interface {
+	Execute(ctx context.Context, input orgusecases.CreateInvitationInput) (*organization.Invitation, error)
+}
+
+type ListInvitationsUseCase interface {
+	Execute(ctx context.Context, organizationID uuid.UUID) ([]organization.Invitation, error)
+}
+
+type RevokeInvitationUseCase interface {
+	Execute(ctx context.Context, input orgusecases.RevokeInvitationInput) error
+}
+
+type AcceptInvitationUseCase interface {
+	Execute(ctx context.Context, input orgusecases.AcceptInvitationInput) (*orgusecases.AcceptInvitationOutput, error)
+}
+
+func NewInvitationHandler(
+	createInvitationUseCase CreateInvitationUseCase,
+	listInvitationsUseCase ListInvitationsUseCase,
+	revokeInvitationUseCase RevokeInvitationUseCase,
+	acceptInvitationUseCase AcceptInvitationUseCase,
+) *InvitationHandler {
+	return &InvitationHandler{
+		createInvitationUseCase: createInvitationUseCase,
+		listInvitationsUseCase:  listInvitationsUseCase,
+		revokeInvitationUseCase: revokeInvitationUseCase,
+		acceptInvitationUseCase: acceptInvitationUseCase,
+	}
+}
+
+// CreateInvitation handles inviting an email address to one of the organization's roles
+// POST /api/v1/orgs/:orgID/invitations
+func (h *InvitationHandler) CreateInvitation(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	var req dto.CreateInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	actorID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	invitation, err := h.createInvitationUseCase.Execute(c.Request().Context(), orgusecases.CreateInvitationInput{
+		ActorID:        actorID,
+		OrganizationID: orgID,
+		Email:          req.Email,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ToInvitationResponse(invitation))
+}
+
+// ListInvitations handles listing the organization's invitations
+// GET /api/v1/orgs/:orgID/invitations
+func (h *InvitationHandler) ListInvitations(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	invitations, err := h.listInvitationsUseCase.Execute(c.Request().Context(), orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.InvitationResponse, len(invitations))
+	for i := range invitations {
+		response[i] = dto.ToInvitationResponse(&invitations[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// RevokeInvitation handles revoking a pending invitation
+// DELETE /api/v1/orgs/:orgID/invitations/:invitationID
+func (h *InvitationHandler) RevokeInvitation(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	invitationID, err := uuid.Parse(c.Param("invitationID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid invitation ID"})
+	}
+
+	err = h.revokeInvitationUseCase.Execute(c.Request().Context(), orgusecases.RevokeInvitationInput{
+		OrganizationID: orgID,
+		InvitationID:   invitationID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "invitation revoked successfully"})
+}
+
+// AcceptInvitation handles accepting an invitation with the token from its email,
+// creating the account when the invited email is not registered
+// POST /api/v1/invitations/accept
+func (h *InvitationHandler) AcceptInvitation(c echo.Context) error {
+	var req dto.AcceptInvitationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	output, err := h.acceptInvitationUseCase.Execute(c.Request().Context(), orgusecases.AcceptInvitationInput{
+		Token:    req.Token,
+		Password: req.Password,
+	})
+	if err != nil {
+		return passwordErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.AcceptInvitationResponse{
+		OrganizationID: output.Membership.OrganizationID.String(),
+		UserID:         output.Membership.UserID.String(),
+		Role:           output.Membership.RoleName(),
+		AccessToken:    output.AccessToken,
+	})
+}
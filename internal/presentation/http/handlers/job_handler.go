@@ -0,0 +1,58 @@
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/scheduler"
+	"github.com/labstack/echo/v4"
+)
+
+type JobHandler struct {
+	jobStats JobStatsProvider
+}
+
+type JobStatsProvider interface {
+	Stats() []scheduler.JobStats
+}
+
+func NewJobHandler(jobStats JobStatsProvider) *JobHandler {
+	return &JobHandler{jobStats: jobStats}
+}
+
+// JobResponse reports the counters of a background job on this instance
+type JobResponse struct {
+	Name            string     `json:"name"`
+	IntervalSeconds float64    `json:"interval_seconds"`
+	Runs            int64      `json:"runs"`
+	Failures        int64      `json:"failures"`
+	Skipped         int64      `json:"skipped"`
+	Affected        int64      `json:"affected"`
+	LastRunAt       *time.Time `json:"last_run_at,omitempty"`
+	LastDurationMs  int64      `json:"last_duration_ms"`
+	LastError       string     `json:"last_error,omitempty"`
+}
+
+// ListJobs handles listing the background jobs of this instance with their counters.
+// Skipped counts the turns passed while another instance was the leader.
+// GET /api/v1/admin/jobs
+func (h *JobHandler) ListJobs(c echo.Context) error {
+	stats := h.jobStats.Stats()
+
+	response := make([]JobResponse, len(stats))
+	for i, js := range stats {
+		response[i] = JobResponse{
+			Name:            js.Name,
+			IntervalSeconds: js.Interval.Seconds(),
+			Runs:            js.Runs,
+			Failures:        js.Failures,
+			Skipped:         js.Skipped,
+			Affected:        js.Affected,
+			LastRunAt:       js.LastRunAt,
+			LastDurationMs:  js.LastDuration.Milliseconds(),
+			LastError:       js.LastError,
+		}
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
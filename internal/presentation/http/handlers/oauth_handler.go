@@ -0,0 +1,254 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+)
+
+type OAuthHandler struct {
+	exchangeTokenUseCase              ExchangeTokenUseCase
+	requestDeviceAuthorizationUseCase RequestDeviceAuthorizationUseCase
+	pollDeviceTokenUseCase            PollDeviceTokenUseCase
+	getDeviceAuthorizationUseCase     GetDeviceAuthorizationUseCase
+	decideDeviceAuthorizationUseCase  DecideDeviceAuthorizationUseCase
+	introspectTokenUseCase            IntrospectTokenUseCase
+	serviceTokens                     ServiceTokenValidator
+}
+
+type ExchangeTokenUseCase interface {
+	Execute(ctx context.Context, input usecases.ExchangeTokenInput) (*usecases.ExchangeTokenOutput, error)
+}
+
+type RequestDeviceAuthorizationUseCase interface {
+	Execute(ctx context.Context, clientID string) (*usecases.DeviceAuthorizationOutput, error)
+}
+
+type PollDeviceTokenUseCase interface {
+	Execute(ctx context.Context, input usecases.PollDeviceTokenInput) (*usecases.RefreshTokenResponse, error)
+}
+
+type GetDeviceAuthorizationUseCase interface {
+	Execute(ctx context.Context, userCode string) (*device.Authorization, error)
+}
+
+type DecideDeviceAuthorizationUseCase interface {
+	Execute(ctx context.Context, input usecases.DecideDeviceAuthorizationInput) error
+}
+
+type IntrospectTokenUseCase interface {
+	Execute(ctx context.Context, token string) (*auth.Introspection, error)
+}
+
+// ServiceTokenValidator authenticates the services calling the introspection endpoint.
+type ServiceTokenValidator interface {
+	ValidateServiceToken(tokenString string) (serviceName string, err error)
+}
+
+func NewOAuthHandler(
+	exchangeTokenUseCase ExchangeTokenUseCase,
+	requestDeviceAuthorizationUseCase RequestDeviceAuthorizationUseCase,
+	pollDeviceTokenUseCase PollDeviceTokenUseCase,
+	getDeviceAuthorizationUseCase GetDeviceAuthorizationUseCase,
+	decideDeviceAuthorizationUseCase DecideDeviceAuthorizationUseCase,
+	introspectTokenUseCase IntrospectTokenUseCase,
+	serviceTokens ServiceTokenValidator,
+) *OAuthHandler {
+	return &OAuthHandler{
+		exchangeTokenUseCase:              exchangeTokenUseCase,
+		requestDeviceAuthorizationUseCase: requestDeviceAuthorizationUseCase,
+		pollDeviceTokenUseCase:            pollDeviceTokenUseCase,
+		getDeviceAuthorizationUseCase:     getDeviceAuthorizationUseCase,
+		decideDeviceAuthorizationUseCase:  decideDeviceAuthorizationUseCase,
+		introspectTokenUseCase:            introspectTokenUseCase,
+		serviceTokens:                     serviceTokens,
+	}
+}
+
+// Token handles the OAuth token endpoint; parameters are form encoded
+// POST /oauth/token
+func (h *OAuthHandler) Token(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	switch grantType := c.FormValue("grant_type"); grantType {
+	case usecases.GrantTypeTokenExchange:
+		return h.exchangeToken(c)
+	case usecases.GrantTypeDeviceCode:
+		return h.pollDeviceToken(c)
+	case "":
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthInvalidRequest, Description: "grant_type is required"})
+	default:
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthUnsupportedGrantType, Description: grantType})
+	}
+}
+
+func (h *OAuthHandler) exchangeToken(c echo.Context) error {
+	output, err := h.exchangeTokenUseCase.Execute(c.Request().Context(), usecases.ExchangeTokenInput{
+		SubjectToken:     c.FormValue("subject_token"),
+		SubjectTokenType: c.FormValue("subject_token_type"),
+		ActorToken:       c.FormValue("actor_token"),
+		ActorTokenType:   c.FormValue("actor_token_type"),
+		Audience:         c.FormValue("audience"),
+		Scopes:           strings.Fields(c.FormValue("scope")),
+	})
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.OAuthTokenResponse{
+		AccessToken:     output.AccessToken,
+		IssuedTokenType: output.IssuedTokenType,
+		TokenType:       "Bearer",
+		ExpiresIn:       output.ExpiresIn,
+		Scope:           strings.Join(output.Scopes, " "),
+	})
+}
+
+func (h *OAuthHandler) pollDeviceToken(c echo.Context) error {
+	output, err := h.pollDeviceTokenUseCase.Execute(c.Request().Context(), usecases.PollDeviceTokenInput{
+		DeviceCode: c.FormValue("device_code"),
+		ClientID:   c.FormValue("client_id"),
+	})
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.OAuthTokenResponse{
+		AccessToken:  output.AccessToken,
+		RefreshToken: output.RefreshToken,
+		TokenType:    "Bearer",
+		ExpiresIn:    int(time.Until(output.ExpiresAt).Seconds()),
+	})
+}
+
+// DeviceAuthorization handles starting a device login; parameters are form encoded
+// POST /oauth/device_authorization
+func (h *OAuthHandler) DeviceAuthorization(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	output, err := h.requestDeviceAuthorizationUseCase.Execute(c.Request().Context(), c.FormValue("client_id"))
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, dto.DeviceAuthorizationResponse{
+		DeviceCode:              output.DeviceCode,
+		UserCode:                output.UserCode,
+		VerificationURI:         output.VerificationURI,
+		VerificationURIComplete: output.VerificationURIComplete,
+		ExpiresIn:               output.ExpiresIn,
+		Interval:                output.Interval,
+	})
+}
+
+// Introspect handles describing an opaque token to a service presenting its
+// service token as Bearer credentials; parameters are form encoded
+// POST /oauth/introspect
+func (h *OAuthHandler) Introspect(c echo.Context) error {
+	c.Response().Header().Set("Cache-Control", "no-store")
+
+	serviceToken, ok := strings.CutPrefix(c.Request().Header.Get("Authorization"), "Bearer ")
+	if !ok {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthInvalidClient, Description: "service token is required"})
+	}
+	if _, err := h.serviceTokens.ValidateServiceToken(serviceToken); err != nil {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthInvalidClient, Description: "invalid service token"})
+	}
+
+	tokenString := c.FormValue("token")
+	if tokenString == "" {
+		return oauthErrorResponse(c, &usecases.OAuthError{Code: usecases.OAuthInvalidRequest, Description: "token is required"})
+	}
+
+	introspection, err := h.introspectTokenUseCase.Execute(c.Request().Context(), tokenString)
+	if err != nil {
+		return oauthErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, introspection)
+}
+
+// GetDeviceAuthorization handles showing a pending device login to the user
+// GET /api/v1/device?user_code=
+func (h *OAuthHandler) GetDeviceAuthorization(c echo.Context) error {
+	authorization, err := h.getDeviceAuthorizationUseCase.Execute(c.Request().Context(), c.QueryParam("user_code"))
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.DeviceAuthorizationInfoResponse{
+		UserCode:  authorization.UserCode,
+		ClientID:  authorization.ClientID,
+		ExpiresAt: authorization.ExpiresAt.Format("2006-01-02T15:04:05Z"),
+	})
+}
+
+// ApproveDevice handles the current user approving a device login
+// POST /api/v1/device/approve
+func (h *OAuthHandler) ApproveDevice(c echo.Context) error {
+	return h.decideDevice(c, true)
+}
+
+// DenyDevice handles the current user denying a device login
+// POST /api/v1/device/deny
+func (h *OAuthHandler) DenyDevice(c echo.Context) error {
+	return h.decideDevice(c, false)
+}
+
+func (h *OAuthHandler) decideDevice(c echo.Context, approve bool) error {
+	var req dto.DeviceUserCodeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.decideDeviceAuthorizationUseCase.Execute(c.Request().Context(), usecases.DecideDeviceAuthorizationInput{
+		UserID:   userID,
+		UserCode: req.UserCode,
+		Approve:  approve,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	message := "device login denied"
+	if approve {
+		message = "device login approved"
+	}
+	return c.JSON(http.StatusOK, map[string]string{"message": message})
+}
+
+// oauthErrorResponse writes err in the RFC 6749 error format. Errors without
+// an OAuth code are reported as server errors.
+func oauthErrorResponse(c echo.Context, err error) error {
+	var oauthErr *usecases.OAuthError
+	if !errors.As(err, &oauthErr) {
+		return c.JSON(http.StatusInternalServerError, dto.OAuthErrorResponse{Error: "server_error"})
+	}
+
+	status := http.StatusBadRequest
+	switch oauthErr.Code {
+	case usecases.OAuthInvalidClient:
+		status = http.StatusUnauthorized
+	case usecases.OAuthSlowDown:
+		metrics.RateLimitRejections.WithLabelValues(metrics.LimiterDevicePoll).Inc()
+	}
+	return c.JSON(status, dto.OAuthErrorResponse{Error: oauthErr.Code, ErrorDescription: oauthErr.Description})
+}
@@ -0,0 +1,296 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/dto"
+	orgusecases "github.com/EduardoPPCaldas/auth-service/internal/application/organization/usecases"
+	roledto "github.com/EduardoPPCaldas/auth-service/internal/application/role/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type OrganizationHandler struct {
+	createOrganizationUseCase     CreateOrganizationUseCase
+	listOrganizationsUseCase      ListOrganizationsUseCase
+	switchOrganizationUseCase     SwitchOrganizationUseCase
+	addMemberUseCase              AddMemberUseCase
+	removeMemberUseCase           RemoveMemberUseCase
+	listMembersUseCase            ListMembersUseCase
+	createOrganizationRoleUseCase CreateOrganizationRoleUseCase
+	listOrganizationRolesUseCase  ListOrganizationRolesUseCase
+}
+
+type CreateOrganizationUseCase interface {
+	Execute(ctx context.Context, input orgusecases.CreateOrganizationInput) (*organization.Organization, error)
+}
+
+type ListOrganizationsUseCase interface {
+	Execute(ctx context.Context, userID uuid.UUID) ([]organization.Organization, error)
+}
+
+type SwitchOrganizationUseCase interface {
+	Execute(ctx context.Context, userID, organizationID uuid.UUID) (string, error)
+}
+
+type AddMemberUseCase interface {
+	Execute(ctx context.Context, input orgusecases.AddMemberInput) (*organization.Membership, error)
+}
+
+type RemoveMemberUseCase interface {
+	Execute(ctx context.Context, input orgusecases.RemoveMemberInput) error
+}
+
+type ListMembersUseCase interface {
+	Execute(ctx context.Context, organizationID uuid.UUID) ([]organization.Membership, error)
+}
+
+type CreateOrganizationRoleUseCase interface {
+	Execute(ctx context.Context, input orgusecases.CreateOrganizationRoleInput) (*role.Role, error)
+}
+
+type ListOrganizationRolesUseCase interface {
+	Execute(ctx context.Context, organizationID uuid.UUID) ([]role.Role, error)
+}
+
+func NewOrganizationHandler(
+	createOrganizationUseCase CreateOrganizationUseCase,
+	listOrganizationsUseCase ListOrganizationsUseCase,
+	switchOrganizationUseCase SwitchOrganizationUseCase,
+	addMemberUseCase AddMemberUseCase,
+	removeMemberUseCase RemoveMemberUseCase,
+	listMembersUseCase ListMembersUseCase,
+	createOrganizationRoleUseCase CreateOrganizationRoleUseCase,
+	listOrganizationRolesUseCase ListOrganizationRolesUseCase,
+) *OrganizationHandler {
+	return &OrganizationHandler{
+		createOrganizationUseCase:     createOrganizationUseCase,
+		listOrganizationsUseCase:      listOrganizationsUseCase,
+		switchOrganizationUseCase:     switchOrganizationUseCase,
+		addMemberUseCase:              addMemberUseCase,
+		removeMemberUseCase:           removeMemberUseCase,
+		listMembersUseCase:            listMembersUseCase,
+		createOrganizationRoleUseCase: createOrganizationRoleUseCase,
+		listOrganizationRolesUseCase:  listOrganizationRolesUseCase,
+	}
+}
+
+// CreateOrganization handles organization creation; the caller becomes its owner
+// POST /api/v1/orgs
+func (h *OrganizationHandler) CreateOrganization(c echo.Context) error {
+	var req dto.CreateOrganizationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	org, err := h.createOrganizationUseCase.Execute(c.Request().Context(), orgusecases.CreateOrganizationInput{
+		OwnerID: userID,
+		Name:    req.Name,
+		Slug:    req.Slug,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ToOrganizationResponse(org))
+}
+
+// ListOrganizations handles listing the caller's organizations
+// GET /api/v1/orgs
+func (h *OrganizationHandler) ListOrganizations(c echo.Context) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	orgs, err := h.listOrganizationsUseCase.Execute(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.OrganizationResponse, len(orgs))
+	for i := range orgs {
+		response[i] = dto.ToOrganizationResponse(&orgs[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// SwitchOrganization issues an access token scoped to one of the caller's organizations
+// POST /api/v1/orgs/:orgID/token
+func (h *OrganizationHandler) SwitchOrganization(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	accessToken, err := h.switchOrganizationUseCase.Execute(c.Request().Context(), userID, orgID)
+	if err != nil {
+		return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.OrganizationTokenResponse{
+		AccessToken:    accessToken,
+		TokenType:      "Bearer",
+		OrganizationID: orgID.String(),
+	})
+}
+
+// ListMembers handles listing organization members
+// GET /api/v1/orgs/:orgID/members
+func (h *OrganizationHandler) ListMembers(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	members, err := h.listMembersUseCase.Execute(c.Request().Context(), orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.MemberResponse, len(members))
+	for i := range members {
+		response[i] = dto.ToMemberResponse(&members[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// AddMember handles adding an existing user to the organization
+// POST /api/v1/orgs/:orgID/members
+func (h *OrganizationHandler) AddMember(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	var req dto.AddMemberRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	actorID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	membership, err := h.addMemberUseCase.Execute(c.Request().Context(), orgusecases.AddMemberInput{
+		ActorID:        actorID,
+		OrganizationID: orgID,
+		Email:          req.Email,
+		Role:           req.Role,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ToMemberResponse(membership))
+}
+
+// RemoveMember handles removing a member from the organization
+// DELETE /api/v1/orgs/:orgID/members/:userID
+func (h *OrganizationHandler) RemoveMember(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	memberID, err := uuid.Parse(c.Param("userID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	actorID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.removeMemberUseCase.Execute(c.Request().Context(), orgusecases.RemoveMemberInput{
+		ActorID:        actorID,
+		OrganizationID: orgID,
+		UserID:         memberID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "member removed successfully"})
+}
+
+// ListRoles handles listing the organization's roles
+// GET /api/v1/orgs/:orgID/roles
+func (h *OrganizationHandler) ListRoles(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	roles, err := h.listOrganizationRolesUseCase.Execute(c.Request().Context(), orgID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]roledto.RoleResponse, len(roles))
+	for i := range roles {
+		response[i] = roledto.ToRoleResponse(&roles[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateRole handles creating a role within the organization
+// POST /api/v1/orgs/:orgID/roles
+func (h *OrganizationHandler) CreateRole(c echo.Context) error {
+	orgID, err := uuid.Parse(c.Param("orgID"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid organization ID"})
+	}
+
+	var req dto.CreateOrganizationRoleRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	actorID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	r, err := h.createOrganizationRoleUseCase.Execute(c.Request().Context(), orgusecases.CreateOrganizationRoleInput{
+		ActorID:        actorID,
+		OrganizationID: orgID,
+		Name:           req.Name,
+		Permissions:    req.Permissions,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, roledto.ToRoleResponse(r))
+}
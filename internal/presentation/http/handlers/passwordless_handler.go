@@ -0,0 +1,86 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/labstack/echo/v4"
+)
+
+type PasswordlessHandler struct {
+	startPasswordlessLoginUseCase  StartPasswordlessLoginUseCase
+	verifyPasswordlessLoginUseCase VerifyPasswordlessLoginUseCase
+}
+
+type StartPasswordlessLoginUseCase interface {
+	Execute(ctx context.Context, input usecases.StartPasswordlessLoginInput) error
+}
+
+type VerifyPasswordlessLoginUseCase interface {
+	Execute(ctx context.Context, input usecases.VerifyPasswordlessLoginInput) (*usecases.RefreshTokenResponse, error)
+}
+
+func NewPasswordlessHandler(
+	startPasswordlessLoginUseCase StartPasswordlessLoginUseCase,
+	verifyPasswordlessLoginUseCase VerifyPasswordlessLoginUseCase,
+) *PasswordlessHandler {
+	return &PasswordlessHandler{
+		startPasswordlessLoginUseCase:  startPasswordlessLoginUseCase,
+		verifyPasswordlessLoginUseCase: verifyPasswordlessLoginUseCase,
+	}
+}
+
+// Start handles emailing a sign-in link or code; the response is the same whether or not the address can sign in
+// POST /api/v1/auth/passwordless/start
+func (h *PasswordlessHandler) Start(c echo.Context) error {
+	var req dto.PasswordlessStartRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	err := h.startPasswordlessLoginUseCase.Execute(c.Request().Context(), usecases.StartPasswordlessLoginInput{
+		Email:  req.Email,
+		Method: passwordless.Method(req.Method),
+	})
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "if the address can sign in, an email is on its way"})
+}
+
+// Verify handles redeeming a sign-in link or code for a token pair
+// POST /api/v1/auth/passwordless/verify
+func (h *PasswordlessHandler) Verify(c echo.Context) error {
+	var req dto.PasswordlessVerifyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response, err := h.verifyPasswordlessLoginUseCase.Execute(c.Request().Context(), usecases.VerifyPasswordlessLoginInput{
+		Token: req.Token,
+		Email: req.Email,
+		Code:  req.Code,
+	})
+	if err != nil {
+		if errors.Is(err, usecases.ErrPasswordlessLoginFailed) || errors.Is(err, user.ErrUserDisabled) {
+			return c.JSON(authErrorStatus(err), map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
@@ -0,0 +1,284 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/dto"
+	policyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/policy/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type PolicyHandler struct {
+	createPolicyUseCase       CreatePolicyUseCase
+	updatePolicyUseCase       UpdatePolicyUseCase
+	deletePolicyUseCase       DeletePolicyUseCase
+	listPoliciesUseCase       ListPoliciesUseCase
+	getPolicyUseCase          GetPolicyUseCase
+	checkAuthorizationUseCase CheckAuthorizationUseCase
+}
+
+type CreatePolicyUseCase interface {
+	Execute(ctx context.Context, input policyusecases.CreatePolicyInput) (*policy.Policy, error)
+}
+
+type UpdatePolicyUseCase interface {
+	Execute(ctx context.Context, input policyusecases.UpdatePolicyInput) (*policy.Policy, error)
+}
+
+type DeletePolicyUseCase interface {
+	Execute(ctx context.Context, input policyusecases.DeletePolicyInput) error
+}
+
+type ListPoliciesUseCase interface {
+	Execute(ctx context.Context) ([]policy.Policy, error)
+}
+
+type GetPolicyUseCase interface {
+	Execute(ctx context.Context, policyID uuid.UUID) (*policy.Policy, error)
+}
+
+type CheckAuthorizationUseCase interface {
+	Execute(ctx context.Context, input policyusecases.CheckAuthorizationInput) (*policyusecases.Decision, error)
+}
+
+func NewPolicyHandler(
+	createPolicyUseCase CreatePolicyUseCase,
+	updatePolicyUseCase UpdatePolicyUseCase,
+	deletePolicyUseCase DeletePolicyUseCase,
+	listPoliciesUseCase ListPoliciesUseCase,
+	getPolicyUseCase GetPolicyUseCase,
+	checkAuthorizationUseCase CheckAuthorizationUseCase,
+) *PolicyHandler {
+	return &PolicyHandler{
+		createPolicyUseCase:       createPolicyUseCase,
+		updatePolicyUseCase:       updatePolicyUseCase,
+		deletePolicyUseCase:       deletePolicyUseCase,
+		listPoliciesUseCase:       listPoliciesUseCase,
+		getPolicyUseCase:          getPolicyUseCase,
+		checkAuthorizationUseCase: checkAuthorizationUseCase,
+	}
+}
+
+// CreatePolicy handles policy creation
+// POST /api/v1/admin/policies
+func (h *PolicyHandler) CreatePolicy(c echo.Context) error {
+	var req dto.CreatePolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := policyusecases.CreatePolicyInput{
+		AdminUserID:  adminUserID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		Effect:       policy.Effect(req.Effect),
+		Condition:    req.Condition,
+		Enabled:      req.Enabled,
+	}
+
+	p, err := h.createPolicyUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusCreated, dto.ToPolicyResponse(p))
+}
+
+// UpdatePolicy handles policy updates
+// PUT /api/v1/admin/policies/:id
+func (h *PolicyHandler) UpdatePolicy(c echo.Context) error {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid policy ID"})
+	}
+
+	var req dto.UpdatePolicyRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := policyusecases.UpdatePolicyInput{
+		AdminUserID:  adminUserID,
+		PolicyID:     policyID,
+		Name:         req.Name,
+		Description:  req.Description,
+		Action:       req.Action,
+		ResourceType: req.ResourceType,
+		Condition:    req.Condition,
+		Enabled:      req.Enabled,
+	}
+
+	if req.Effect != nil {
+		effect := policy.Effect(*req.Effect)
+		input.Effect = &effect
+	}
+
+	p, err := h.updatePolicyUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToPolicyResponse(p))
+}
+
+// DeletePolicy handles policy deletion
+// DELETE /api/v1/admin/policies/:id
+func (h *PolicyHandler) DeletePolicy(c echo.Context) error {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid policy ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := policyusecases.DeletePolicyInput{
+		AdminUserID: adminUserID,
+		PolicyID:    policyID,
+	}
+
+	if err := h.deletePolicyUseCase.Execute(c.Request().Context(), input); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "policy deleted successfully"})
+}
+
+// ListPolicies handles listing all policies
+// GET /api/v1/admin/policies
+func (h *PolicyHandler) ListPolicies(c echo.Context) error {
+	policies, err := h.listPoliciesUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.PolicyResponse, len(policies))
+	for i := range policies {
+		response[i] = dto.ToPolicyResponse(&policies[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetPolicy handles getting a specific policy
+// GET /api/v1/admin/policies/:id
+func (h *PolicyHandler) GetPolicy(c echo.Context) error {
+	policyID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid policy ID"})
+	}
+
+	p, err := h.getPolicyUseCase.Execute(c.Request().Context(), policyID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToPolicyResponse(p))
+}
+
+// Check handles authorization decisions
+// POST /api/v1/authz/check
+func (h *PolicyHandler) Check(c echo.Context) error {
+	var req dto.CheckRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	callerID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+	caller, _ := auth.GetUserFromEchoContext(c)
+
+	input := policyusecases.CheckAuthorizationInput{
+		SubjectID:          callerID,
+		OrganizationID:     caller.OrgID,
+		Action:             req.Action,
+		ResourceType:       req.Resource.Type,
+		ResourceID:         req.Resource.ID,
+		ResourceAttributes: req.Resource.Attributes,
+		Environment:        req.Environment,
+	}
+
+	// Only trusted callers may name or describe the subject; everyone else is
+	// judged on their stored identity and token.
+	if req.Subject != nil {
+		otherSubject := req.Subject.ID != "" && req.Subject.ID != callerID.String()
+		if (otherSubject || len(req.Subject.Attributes) > 0) && !auth.HasPermission(caller.Permissions, policy.PermissionCheckOthers) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": "setting the subject requires " + policy.PermissionCheckOthers})
+		}
+		input.SubjectAttributes = req.Subject.Attributes
+		if otherSubject {
+			input.SubjectID = uuid.MustParse(req.Subject.ID)
+			input.OrganizationID = uuid.Nil
+		}
+	}
+
+	if input.Environment == nil {
+		input.Environment = map[string]any{}
+	}
+	input.Environment["ip"] = c.RealIP()
+
+	decision, err := h.checkAuthorizationUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.CheckResponse{
+		Allowed:  decision.Allowed,
+		Decision: string(decision.Effect),
+		Reason:   decision.Reason,
+	}
+	if decision.Policy != nil {
+		response.PolicyID = decision.Policy.ID.String()
+		response.PolicyName = decision.Policy.Name
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// currentUserID returns the authenticated user's ID set by the auth middleware.
+func currentUserID(c echo.Context) (uuid.UUID, error) {
+	userIDStr, ok := c.Get("user_id").(string)
+	if !ok {
+		return uuid.Nil, errors.New("user not authenticated")
+	}
+
+	userID, err := uuid.Parse(userIDStr)
+	if err != nil {
+		return uuid.Nil, errors.New("invalid user ID format")
+	}
+
+	return userID, nil
+}
@@ -0,0 +1,278 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type ProfileHandler struct {
+	getUserUseCase            GetUserUseCase
+	updateProfileUseCase      UpdateProfileUseCase
+	changePasswordUseCase     ChangePasswordUseCase
+	requestEmailChangeUseCase RequestEmailChangeUseCase
+	confirmEmailChangeUseCase ConfirmEmailChangeUseCase
+	createTokenUseCase        CreatePersonalAccessTokenUseCase
+	listTokensUseCase         ListPersonalAccessTokensUseCase
+	revokeTokenUseCase        RevokePersonalAccessTokenUseCase
+}
+
+type UpdateProfileUseCase interface {
+	Execute(ctx context.Context, input usecases.UpdateProfileInput) (*user.User, error)
+}
+
+type ChangePasswordUseCase interface {
+	Execute(ctx context.Context, input usecases.ChangePasswordInput) (*usecases.RefreshTokenResponse, error)
+}
+
+type RequestEmailChangeUseCase interface {
+	Execute(ctx context.Context, input usecases.RequestEmailChangeInput) error
+}
+
+type ConfirmEmailChangeUseCase interface {
+	Execute(ctx context.Context, token string) (*user.User, error)
+}
+
+type CreatePersonalAccessTokenUseCase interface {
+	Execute(ctx context.Context, input usecases.CreatePersonalAccessTokenInput) (*usecases.CreatePersonalAccessTokenOutput, error)
+}
+
+type ListPersonalAccessTokensUseCase interface {
+	Execute(ctx context.Context, userID uuid.UUID) ([]domaintoken.PersonalAccessToken, error)
+}
+
+type RevokePersonalAccessTokenUseCase interface {
+	Execute(ctx context.Context, userID, tokenID uuid.UUID) error
+}
+
+func NewProfileHandler(
+	getUserUseCase GetUserUseCase,
+	updateProfileUseCase UpdateProfileUseCase,
+	changePasswordUseCase ChangePasswordUseCase,
+	requestEmailChangeUseCase RequestEmailChangeUseCase,
+	confirmEmailChangeUseCase ConfirmEmailChangeUseCase,
+	createTokenUseCase CreatePersonalAccessTokenUseCase,
+	listTokensUseCase ListPersonalAccessTokensUseCase,
+	revokeTokenUseCase RevokePersonalAccessTokenUseCase,
+) *ProfileHandler {
+	return &ProfileHandler{
+		getUserUseCase:            getUserUseCase,
+		updateProfileUseCase:      updateProfileUseCase,
+		changePasswordUseCase:     changePasswordUseCase,
+		requestEmailChangeUseCase: requestEmailChangeUseCase,
+		confirmEmailChangeUseCase: confirmEmailChangeUseCase,
+		createTokenUseCase:        createTokenUseCase,
+		listTokensUseCase:         listTokensUseCase,
+		revokeTokenUseCase:        revokeTokenUseCase,
+	}
+}
+
+// GetProfile handles getting the authenticated user's profile
+// GET /api/v1/me
+func (h *ProfileHandler) GetProfile(c echo.Context) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	u, err := h.getUserUseCase.Execute(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToProfileResponse(u))
+}
+
+// UpdateProfile handles partial updates of the authenticated user's profile
+// PATCH /api/v1/me
+func (h *ProfileHandler) UpdateProfile(c echo.Context) error {
+	var req dto.UpdateProfileRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	u, err := h.updateProfileUseCase.Execute(c.Request().Context(), usecases.UpdateProfileInput{
+		UserID:      userID,
+		DisplayName: req.DisplayName,
+		AvatarURL:   req.AvatarURL,
+		Locale:      req.Locale,
+		Timezone:    req.Timezone,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToProfileResponse(u))
+}
+
+// ChangePassword handles changing the password; other sessions are signed out
+// POST /api/v1/me/password
+func (h *ProfileHandler) ChangePassword(c echo.Context) error {
+	var req dto.ChangePasswordRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	response, err := h.changePasswordUseCase.Execute(c.Request().Context(), usecases.ChangePasswordInput{
+		UserID:          userID,
+		CurrentPassword: req.CurrentPassword,
+		NewPassword:     req.NewPassword,
+	})
+	if err != nil {
+		return passwordErrorResponse(c, err)
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// ChangeEmail handles starting an email change by mailing a confirmation link to the new address
+// POST /api/v1/me/email
+func (h *ProfileHandler) ChangeEmail(c echo.Context) error {
+	var req dto.ChangeEmailRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = h.requestEmailChangeUseCase.Execute(c.Request().Context(), usecases.RequestEmailChangeInput{
+		UserID:          userID,
+		NewEmail:        req.NewEmail,
+		CurrentPassword: req.CurrentPassword,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusAccepted, map[string]string{"message": "confirmation email sent to the new address"})
+}
+
+// ConfirmEmailChange handles applying an email change from its confirmation link
+// POST /api/v1/me/email/confirm
+func (h *ProfileHandler) ConfirmEmailChange(c echo.Context) error {
+	var req dto.ConfirmEmailChangeRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	u, err := h.confirmEmailChangeUseCase.Execute(c.Request().Context(), req.Token)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToProfileResponse(u))
+}
+
+// ListTokens handles listing the authenticated user's personal access tokens
+// GET /api/v1/me/tokens
+func (h *ProfileHandler) ListTokens(c echo.Context) error {
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	tokens, err := h.listTokensUseCase.Execute(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.PersonalAccessTokenResponse, 0, len(tokens))
+	for i := range tokens {
+		response = append(response, dto.ToPersonalAccessTokenResponse(&tokens[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// CreateToken handles creating a personal access token; the secret is only returned here
+// POST /api/v1/me/tokens
+func (h *ProfileHandler) CreateToken(c echo.Context) error {
+	var req dto.CreatePersonalAccessTokenRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	output, err := h.createTokenUseCase.Execute(c.Request().Context(), usecases.CreatePersonalAccessTokenInput{
+		UserID:    userID,
+		Name:      req.Name,
+		Scopes:    req.Scopes,
+		ExpiresAt: req.ExpiresAt,
+	})
+	if err != nil {
+		if errors.Is(err, user.ErrUserDisabled) {
+			return c.JSON(http.StatusForbidden, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.ToPersonalAccessTokenResponse(output.PersonalAccessToken)
+	response.Token = output.Token
+	return c.JSON(http.StatusCreated, response)
+}
+
+// RevokeToken handles revoking one of the authenticated user's personal access tokens
+// DELETE /api/v1/me/tokens/:id
+func (h *ProfileHandler) RevokeToken(c echo.Context) error {
+	tokenID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid token ID"})
+	}
+
+	userID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	if err := h.revokeTokenUseCase.Execute(c.Request().Context(), userID, tokenID); err != nil {
+		if errors.Is(err, domaintoken.ErrPersonalAccessTokenNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "token revoked"})
+}
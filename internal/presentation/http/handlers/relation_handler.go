@@ -0,0 +1,141 @@
+package handlers
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/relation/dto"
+	relationusecases "github.com/EduardoPPCaldas/auth-service/internal/application/relation/usecases"
+	"github.com/labstack/echo/v4"
+)
+
+type RelationHandler struct {
+	checkRelationUseCase  CheckRelationUseCase
+	expandRelationUseCase ExpandRelationUseCase
+	listObjectsUseCase    ListObjectsUseCase
+	writeRelationsUseCase WriteRelationsUseCase
+}
+
+type CheckRelationUseCase interface {
+	Execute(ctx context.Context, input relationusecases.CheckRelationInput) (bool, error)
+}
+
+type ExpandRelationUseCase interface {
+	Execute(ctx context.Context, input relationusecases.ExpandRelationInput) (*relationusecases.Tree, error)
+}
+
+type ListObjectsUseCase interface {
+	Execute(ctx context.Context, input relationusecases.ListObjectsInput) ([]string, error)
+}
+
+type WriteRelationsUseCase interface {
+	Execute(ctx context.Context, input relationusecases.WriteRelationsInput) error
+}
+
+func NewRelationHandler(
+	checkRelationUseCase CheckRelationUseCase,
+	expandRelationUseCase ExpandRelationUseCase,
+	listObjectsUseCase ListObjectsUseCase,
+	writeRelationsUseCase WriteRelationsUseCase,
+) *RelationHandler {
+	return &RelationHandler{
+		checkRelationUseCase:  checkRelationUseCase,
+		expandRelationUseCase: expandRelationUseCase,
+		listObjectsUseCase:    listObjectsUseCase,
+		writeRelationsUseCase: writeRelationsUseCase,
+	}
+}
+
+// Check handles relation checks
+// POST /api/v1/relations/check
+func (h *RelationHandler) Check(c echo.Context) error {
+	var req dto.CheckRelationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	allowed, err := h.checkRelationUseCase.Execute(c.Request().Context(), relationusecases.CheckRelationInput{
+		Object:   req.Object,
+		Relation: req.Relation,
+		Subject:  req.Subject,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.CheckRelationResponse{Allowed: allowed})
+}
+
+// Expand handles expanding a relation into the subjects that hold it
+// POST /api/v1/relations/expand
+func (h *RelationHandler) Expand(c echo.Context) error {
+	var req dto.ExpandRelationRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	tree, err := h.expandRelationUseCase.Execute(c.Request().Context(), relationusecases.ExpandRelationInput{
+		Object:   req.Object,
+		Relation: req.Relation,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToRelationTreeResponse(tree))
+}
+
+// ListObjects handles listing the objects a subject holds a relation on
+// POST /api/v1/relations/list-objects
+func (h *RelationHandler) ListObjects(c echo.Context) error {
+	var req dto.ListObjectsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	objects, err := h.listObjectsUseCase.Execute(c.Request().Context(), relationusecases.ListObjectsInput{
+		Namespace: req.Namespace,
+		Relation:  req.Relation,
+		Subject:   req.Subject,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ListObjectsResponse{Objects: objects})
+}
+
+// Write handles inserting and deleting relation tuples
+// POST /api/v1/relations/write
+func (h *RelationHandler) Write(c echo.Context) error {
+	var req dto.WriteRelationsRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	err := h.writeRelationsUseCase.Execute(c.Request().Context(), relationusecases.WriteRelationsInput{
+		Writes:  dto.ToTupleInputs(req.Writes),
+		Deletes: dto.ToTupleInputs(req.Deletes),
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "relations written successfully"})
+}
@@ -0,0 +1,160 @@
+package handlers
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type UserHandler struct {
+	listUsersUseCase   ListUsersUseCase
+	getUserUseCase     GetUserUseCase
+	disableUserUseCase ManageUserUseCase
+	enableUserUseCase  ManageUserUseCase
+	deleteUserUseCase  ManageUserUseCase
+}
+
+type ListUsersUseCase interface {
+	Execute(ctx context.Context, input usecases.ListUsersInput) (*usecases.ListUsersOutput, error)
+}
+
+type GetUserUseCase interface {
+	Execute(ctx context.Context, userID uuid.UUID) (*user.User, error)
+}
+
+// ManageUserUseCase disables, enables or deletes a user.
+type ManageUserUseCase interface {
+	Execute(ctx context.Context, input usecases.ManageUserInput) error
+}
+
+func NewUserHandler(
+	listUsersUseCase ListUsersUseCase,
+	getUserUseCase GetUserUseCase,
+	disableUserUseCase ManageUserUseCase,
+	enableUserUseCase ManageUserUseCase,
+	deleteUserUseCase ManageUserUseCase,
+) *UserHandler {
+	return &UserHandler{
+		listUsersUseCase:   listUsersUseCase,
+		getUserUseCase:     getUserUseCase,
+		disableUserUseCase: disableUserUseCase,
+		enableUserUseCase:  enableUserUseCase,
+		deleteUserUseCase:  deleteUserUseCase,
+	}
+}
+
+// ListUsers handles listing users one page at a time
+// GET /api/v1/admin/users?email=&role=&status=&created_after=&created_before=&cursor=&limit=
+func (h *UserHandler) ListUsers(c echo.Context) error {
+	input := usecases.ListUsersInput{
+		EmailPrefix: c.QueryParam("email"),
+		Role:        c.QueryParam("role"),
+		Status:      c.QueryParam("status"),
+		Cursor:      c.QueryParam("cursor"),
+	}
+
+	var err error
+	if input.CreatedAfter, err = parseTimeParam(c, "created_after"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if input.CreatedBefore, err = parseTimeParam(c, "created_before"); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if input.Limit, err = strconv.Atoi(limit); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+
+	page, err := h.listUsersUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.ListUsersResponse{
+		Users:      make([]dto.UserResponse, len(page.Users)),
+		NextCursor: page.NextCursor,
+	}
+	for i := range page.Users {
+		response.Users[i] = dto.ToUserResponse(&page.Users[i])
+	}
+
+	return c.JSON(http.StatusOK, response)
+}
+
+// GetUser handles getting a specific user
+// GET /api/v1/admin/users/:id
+func (h *UserHandler) GetUser(c echo.Context) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	u, err := h.getUserUseCase.Execute(c.Request().Context(), userID)
+	if err != nil {
+		return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToUserResponse(u))
+}
+
+// DisableUser handles disabling a user and revoking their refresh tokens
+// POST /api/v1/admin/users/:id/disable
+func (h *UserHandler) DisableUser(c echo.Context) error {
+	return h.manageUser(c, h.disableUserUseCase, "user disabled successfully")
+}
+
+// EnableUser handles re-enabling a disabled user
+// POST /api/v1/admin/users/:id/enable
+func (h *UserHandler) EnableUser(c echo.Context) error {
+	return h.manageUser(c, h.enableUserUseCase, "user enabled successfully")
+}
+
+// DeleteUser handles user deletion
+// DELETE /api/v1/admin/users/:id
+func (h *UserHandler) DeleteUser(c echo.Context) error {
+	return h.manageUser(c, h.deleteUserUseCase, "user deleted successfully")
+}
+
+func (h *UserHandler) manageUser(c echo.Context, useCase ManageUserUseCase, message string) error {
+	userID, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid user ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	err = useCase.Execute(c.Request().Context(), usecases.ManageUserInput{
+		AdminUserID: adminUserID,
+		UserID:      userID,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": message})
+}
+
+// parseTimeParam reads an optional RFC 3339 query parameter.
+func parseTimeParam(c echo.Context, name string) (*time.Time, error) {
+	value := c.QueryParam(name)
+	if value == "" {
+		return nil, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: expected an RFC 3339 timestamp", name)
+	}
+	return &t, nil
+}
@@ -0,0 +1,183 @@
+package handlers
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strconv"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/webhook/dto"
+	webhookusecases "github.com/EduardoPPCaldas/auth-service/internal/application/webhook/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+type WebhookHandler struct {
+	createWebhookUseCase  CreateWebhookUseCase
+	listWebhooksUseCase   ListWebhooksUseCase
+	deleteWebhookUseCase  DeleteWebhookUseCase
+	listDeliveriesUseCase ListWebhookDeliveriesUseCase
+	replayDeliveryUseCase ReplayWebhookDeliveryUseCase
+}
+
+type CreateWebhookUseCase interface {
+	Execute(ctx context.Context, input webhookusecases.CreateWebhookInput) (*webhook.Webhook, error)
+}
+
+type ListWebhooksUseCase interface {
+	Execute(ctx context.Context) ([]webhook.Webhook, error)
+}
+
+type DeleteWebhookUseCase interface {
+	Execute(ctx context.Context, input webhookusecases.DeleteWebhookInput) error
+}
+
+type ListWebhookDeliveriesUseCase interface {
+	Execute(ctx context.Context, filter webhook.DeliveryFilter) ([]webhook.Delivery, error)
+}
+
+type ReplayWebhookDeliveryUseCase interface {
+	Execute(ctx context.Context, input webhookusecases.ReplayDeliveryInput) (*webhook.Delivery, error)
+}
+
+func NewWebhookHandler(
+	createWebhookUseCase CreateWebhookUseCase,
+	listWebhooksUseCase ListWebhooksUseCase,
+	deleteWebhookUseCase DeleteWebhookUseCase,
+	listDeliveriesUseCase ListWebhookDeliveriesUseCase,
+	replayDeliveryUseCase ReplayWebhookDeliveryUseCase,
+) *WebhookHandler {
+	return &WebhookHandler{
+		createWebhookUseCase:  createWebhookUseCase,
+		listWebhooksUseCase:   listWebhooksUseCase,
+		deleteWebhookUseCase:  deleteWebhookUseCase,
+		listDeliveriesUseCase: listDeliveriesUseCase,
+		replayDeliveryUseCase: replayDeliveryUseCase,
+	}
+}
+
+// CreateWebhook handles registering a webhook; the signing secret is only returned here
+// POST /api/v1/admin/webhooks
+func (h *WebhookHandler) CreateWebhook(c echo.Context) error {
+	var req dto.CreateWebhookRequest
+	if err := c.Bind(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid request body"})
+	}
+
+	if err := c.Validate(&req); err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	hook, err := h.createWebhookUseCase.Execute(c.Request().Context(), webhookusecases.CreateWebhookInput{
+		AdminUserID: adminUserID,
+		URL:         req.URL,
+		EventTypes:  req.EventTypes,
+	})
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := dto.ToWebhookResponse(hook)
+	response.Secret = hook.Secret
+	return c.JSON(http.StatusCreated, response)
+}
+
+// ListWebhooks handles listing all webhooks
+// GET /api/v1/admin/webhooks
+func (h *WebhookHandler) ListWebhooks(c echo.Context) error {
+	hooks, err := h.listWebhooksUseCase.Execute(c.Request().Context())
+	if err != nil {
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.WebhookResponse, 0, len(hooks))
+	for i := range hooks {
+		response = append(response, dto.ToWebhookResponse(&hooks[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// DeleteWebhook handles deleting a webhook and its deliveries
+// DELETE /api/v1/admin/webhooks/:id
+func (h *WebhookHandler) DeleteWebhook(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := webhookusecases.DeleteWebhookInput{AdminUserID: adminUserID, ID: id}
+	if err := h.deleteWebhookUseCase.Execute(c.Request().Context(), input); err != nil {
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusInternalServerError, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, map[string]string{"message": "Webhook deleted"})
+}
+
+// ListDeliveries handles listing the deliveries of a webhook, newest first
+// GET /api/v1/admin/webhooks/:id/deliveries?status=&limit=
+func (h *WebhookHandler) ListDeliveries(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid webhook ID"})
+	}
+
+	filter := webhook.DeliveryFilter{WebhookID: id, Status: c.QueryParam("status")}
+	if limit := c.QueryParam("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil {
+			return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid limit"})
+		}
+	}
+
+	deliveries, err := h.listDeliveriesUseCase.Execute(c.Request().Context(), filter)
+	if err != nil {
+		if errors.Is(err, webhook.ErrWebhookNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	response := make([]dto.WebhookDeliveryResponse, 0, len(deliveries))
+	for i := range deliveries {
+		response = append(response, dto.ToWebhookDeliveryResponse(&deliveries[i]))
+	}
+	return c.JSON(http.StatusOK, response)
+}
+
+// ReplayDelivery handles sending a dead or delivered delivery again
+// POST /api/v1/admin/webhooks/deliveries/:id/replay
+func (h *WebhookHandler) ReplayDelivery(c echo.Context) error {
+	id, err := uuid.Parse(c.Param("id"))
+	if err != nil {
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": "invalid delivery ID"})
+	}
+
+	adminUserID, err := currentUserID(c)
+	if err != nil {
+		return c.JSON(http.StatusUnauthorized, map[string]string{"error": err.Error()})
+	}
+
+	input := webhookusecases.ReplayDeliveryInput{AdminUserID: adminUserID, ID: id}
+	delivery, err := h.replayDeliveryUseCase.Execute(c.Request().Context(), input)
+	if err != nil {
+		if errors.Is(err, webhook.ErrDeliveryNotFound) {
+			return c.JSON(http.StatusNotFound, map[string]string{"error": err.Error()})
+		}
+		return c.JSON(http.StatusBadRequest, map[string]string{"error": err.Error()})
+	}
+
+	return c.JSON(http.StatusOK, dto.ToWebhookDeliveryResponse(delivery))
+}
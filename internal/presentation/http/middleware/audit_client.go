@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	"github.com/labstack/echo/v4"
+)
+
+// AuditClient stores the client IP and user agent of the request in its
+// context, so the audit events recorded while serving it say where it came from.
+func AuditClient() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			req := c.Request()
+			ctx := auditservices.WithClient(req.Context(), auditservices.Client{
+				IP:        c.RealIP(),
+				UserAgent: req.UserAgent(),
+			})
+			c.SetRequest(req.WithContext(ctx))
+			return next(c)
+		}
+	}
+}
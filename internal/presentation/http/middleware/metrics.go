@@ -0,0 +1,57 @@
+package middleware
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+	"github.com/labstack/echo/v4"
+)
+
+// Metrics records every request in the HTTP metrics. Requests are labelled
+// with the route template, e.g. "/api/v1/admin/users/:id", so IDs in paths
+// don't create a series each.
+func Metrics() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			start := time.Now()
+			err := next(c)
+
+			route := c.Path()
+			if route == "" {
+				route = "unmatched"
+			}
+			method := c.Request().Method
+			metrics.HTTPRequests.WithLabelValues(method, route, strconv.Itoa(responseStatus(c, err))).Inc()
+			metrics.HTTPRequestDuration.WithLabelValues(method, route).Observe(time.Since(start).Seconds())
+			return err
+		}
+	}
+}
+
+// AuthOperation counts the responses of an authentication route in
+// metrics.AuthOperations, with the outcome given by the status code
+func AuthOperation(operation, method string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			err := next(c)
+			metrics.AuthOperations.WithLabelValues(operation, method, metrics.Outcome(responseStatus(c, err))).Inc()
+			return err
+		}
+	}
+}
+
+// responseStatus is the status the request is answered with, including
+// errors the handler returned for Echo's error handler to write
+func responseStatus(c echo.Context, err error) int {
+	if err == nil || c.Response().Committed {
+		return c.Response().Status
+	}
+	var httpErr *echo.HTTPError
+	if errors.As(err, &httpErr) {
+		return httpErr.Code
+	}
+	return http.StatusInternalServerError
+}
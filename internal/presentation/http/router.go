@@ -1,59 +1,317 @@
 package http
 
 import (
+	"fmt"
+	"net"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
 	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	appmiddleware "github.com/EduardoPPCaldas/auth-service/internal/presentation/http/middleware"
+	authpkg "github.com/EduardoPPCaldas/auth-service/pkg/auth"
 	"github.com/labstack/echo/v4"
 	"github.com/labstack/echo/v4/middleware"
 )
 
+// Routes holds what SetupRoutes mounts. Every field is optional: the routes
+// of a nil handler are left out, as are protected routes whose middleware is
+// missing.
+type Routes struct {
+	Auth          *handlers.AuthHandler
+	Role          *handlers.RoleHandler
+	Policy        *handlers.PolicyHandler
+	Relation      *handlers.RelationHandler
+	Organization  *handlers.OrganizationHandler
+	Invitation    *handlers.InvitationHandler
+	Group         *handlers.GroupHandler
+	User          *handlers.UserHandler
+	Profile       *handlers.ProfileHandler
+	Impersonation *handlers.ImpersonationHandler
+	OAuth         *handlers.OAuthHandler
+	APIKey        *handlers.APIKeyHandler
+	Passwordless  *handlers.PasswordlessHandler
+	Job           *handlers.JobHandler
+	Audit         *handlers.AuditHandler
+	Webhook       *handlers.WebhookHandler
+
+	// AuthMiddleware authenticates the protected routes
+	AuthMiddleware func() echo.MiddlewareFunc
+	// AdminMiddleware guards /admin, after AuthMiddleware
+	AdminMiddleware func() echo.MiddlewareFunc
+	// RequireMiddleware checks a requirement against the authenticated user
+	RequireMiddleware func(requirement authpkg.Requirement) echo.MiddlewareFunc
+	// OrganizationMiddleware checks the token was issued for the organization in the path parameter
+	OrganizationMiddleware func(param string) echo.MiddlewareFunc
+}
+
 // SetupRoutes configures all routes for the Echo instance
-func SetupRoutes(
-	e *echo.Echo,
-	authHandler *handlers.AuthHandler,
-	roleHandler *handlers.RoleHandler,
-	authMiddlewareFunc func() echo.MiddlewareFunc,
-	adminMiddlewareFunc func() echo.MiddlewareFunc,
-) {
+func SetupRoutes(e *echo.Echo, routes Routes) {
 	// Swagger/OpenAPI documentation
 	swaggerHandler := handlers.NewSwaggerHandler()
 	e.GET("/swagger.json", swaggerHandler.GetSwaggerJSON)
 	e.GET("/swagger", swaggerHandler.GetSwaggerUI)
 	e.GET("/swagger/", swaggerHandler.GetSwaggerUI)
 
+	// Routes that mint credentials or change them are for the account owner alone: a personal access token would
+	// trade its scopes for a full token, and impersonators would act beyond their session
+	ownerOnly := []echo.MiddlewareFunc{authpkg.EchoRejectImpersonation(), authpkg.EchoRejectPersonalAccessTokens()}
+
 	// Routes
 	v1 := e.Group("/api/v1")
 
-	// Auth routes (public)
+	// Auth routes (public); each authentication flow is counted in the auth operation metrics
 	auth := v1.Group("/auth")
-	{
-		auth.POST("/register", authHandler.CreateUser)
-		auth.POST("/login", authHandler.LoginUser)
-		auth.POST("/login/google", authHandler.LoginWithGoogle)
-		auth.POST("/refresh", authHandler.RefreshToken)
-		auth.POST("/logout", authHandler.Logout)
-		auth.POST("/logout-all", authHandler.LogoutAll)
-		auth.GET("/google/challenge", authHandler.ChallengeGoogleAuth)
+	if routes.Auth != nil {
+		auth.POST("/register", routes.Auth.CreateUser, appmiddleware.AuthOperation(metrics.OperationRegister, metrics.MethodPassword))
+		auth.POST("/login", routes.Auth.LoginUser, appmiddleware.AuthOperation(metrics.OperationLogin, metrics.MethodPassword))
+		auth.POST("/login/google", routes.Auth.LoginWithGoogle, appmiddleware.AuthOperation(metrics.OperationLogin, metrics.MethodGoogle))
+		auth.POST("/refresh", routes.Auth.RefreshToken, appmiddleware.AuthOperation(metrics.OperationRefresh, metrics.MethodRefreshToken))
+		auth.POST("/logout", routes.Auth.Logout, appmiddleware.AuthOperation(metrics.OperationLogout, metrics.MethodRefreshToken))
+		auth.POST("/logout-all", routes.Auth.LogoutAll, appmiddleware.AuthOperation(metrics.OperationLogout, metrics.MethodAllSessions))
+		auth.GET("/google/challenge", routes.Auth.ChallengeGoogleAuth)
+	}
+
+	// Passwordless login (public); the emailed link or code authenticates the request
+	if routes.Passwordless != nil {
+		auth.POST("/passwordless/start", routes.Passwordless.Start)
+		auth.POST("/passwordless/verify", routes.Passwordless.Verify, appmiddleware.AuthOperation(metrics.OperationLogin, metrics.MethodPasswordless))
+	}
+
+	// OAuth endpoints (public); clients authenticate with the grant's parameters
+	if routes.OAuth != nil {
+		oauth := e.Group("/oauth")
+		oauth.POST("/token", routes.OAuth.Token)
+		oauth.POST("/device_authorization", routes.OAuth.DeviceAuthorization)
+		oauth.POST("/introspect", routes.OAuth.Introspect)
+	}
+
+	// API key verification for other services; they authenticate with their service token
+	if routes.APIKey != nil {
+		v1.POST("/api-keys/verify", routes.APIKey.VerifyAPIKey)
+	}
+
+	// Device login approval (protected); the approved device gets a full token pair, so only the account owner
+	// can sign it in
+	if routes.AuthMiddleware != nil && routes.OAuth != nil {
+		device := v1.Group("/device")
+		device.Use(routes.AuthMiddleware())
+		{
+			device.GET("", routes.OAuth.GetDeviceAuthorization)
+			device.POST("/approve", routes.OAuth.ApproveDevice, ownerOnly...)
+			device.POST("/deny", routes.OAuth.DenyDevice)
+		}
+	}
+
+	// Self-service profile (protected); credentials can only be changed by the account owner, not while impersonating
+	// or with a personal access token
+	if routes.AuthMiddleware != nil && routes.Profile != nil {
+		me := v1.Group("/me")
+		me.Use(routes.AuthMiddleware())
+		{
+			me.GET("", routes.Profile.GetProfile)
+			me.PATCH("", routes.Profile.UpdateProfile)
+			me.POST("/password", routes.Profile.ChangePassword, ownerOnly...)
+			me.POST("/email", routes.Profile.ChangeEmail, ownerOnly...)
+			me.GET("/tokens", routes.Profile.ListTokens)
+			me.POST("/tokens", routes.Profile.CreateToken, ownerOnly...)
+			me.DELETE("/tokens/:id", routes.Profile.RevokeToken)
+		}
+	}
+
+	// Email change confirmation (public); the signed token from the confirmation email authenticates the request
+	if routes.Profile != nil {
+		v1.POST("/me/email/confirm", routes.Profile.ConfirmEmailChange)
+	}
+
+	// Authorization decisions (protected)
+	if routes.AuthMiddleware != nil && routes.Policy != nil {
+		authz := v1.Group("/authz")
+		authz.Use(routes.AuthMiddleware())
+		authz.POST("/check", routes.Policy.Check)
+	}
+
+	// Relationship tuples (protected)
+	if routes.AuthMiddleware != nil && routes.RequireMiddleware != nil && routes.Relation != nil {
+		canRead := routes.RequireMiddleware(authpkg.Global(authpkg.Permission(relation.PermissionRead)))
+		canWrite := routes.RequireMiddleware(authpkg.Global(authpkg.Permission(relation.PermissionWrite)))
+
+		relations := v1.Group("/relations")
+		relations.Use(routes.AuthMiddleware())
+		{
+			relations.POST("/check", routes.Relation.Check, canRead)
+			relations.POST("/expand", routes.Relation.Expand, canRead)
+			relations.POST("/list-objects", routes.Relation.ListObjects, canRead)
+			relations.POST("/write", routes.Relation.Write, canWrite)
+		}
+	}
+
+	// Organizations (protected); routes below /:orgID need a token issued for that organization
+	if routes.AuthMiddleware != nil && routes.RequireMiddleware != nil && routes.OrganizationMiddleware != nil && routes.Organization != nil {
+		require := func(permission string) echo.MiddlewareFunc {
+			return routes.RequireMiddleware(authpkg.InOrganization(authpkg.Permission(permission)))
+		}
+
+		orgs := v1.Group("/orgs")
+		orgs.Use(routes.AuthMiddleware())
+		{
+			orgs.POST("", routes.Organization.CreateOrganization)
+			orgs.GET("", routes.Organization.ListOrganizations)
+			// Organization tokens are full tokens and don't carry the act claim
+			orgs.POST("/:orgID/token", routes.Organization.SwitchOrganization, ownerOnly...)
+		}
+
+		org := orgs.Group("/:orgID")
+		org.Use(routes.OrganizationMiddleware("orgID"))
+		{
+			org.GET("/members", routes.Organization.ListMembers, require(organization.PermissionMembersRead))
+			org.POST("/members", routes.Organization.AddMember, require(organization.PermissionMembersWrite))
+			org.DELETE("/members/:userID", routes.Organization.RemoveMember, require(organization.PermissionMembersWrite))
+			org.GET("/roles", routes.Organization.ListRoles, require(organization.PermissionRolesRead))
+			org.POST("/roles", routes.Organization.CreateRole, require(organization.PermissionRolesWrite))
+		}
+
+		if routes.Invitation != nil {
+			org.GET("/invitations", routes.Invitation.ListInvitations, require(organization.PermissionMembersRead))
+			org.POST("/invitations", routes.Invitation.CreateInvitation, require(organization.PermissionMembersWrite))
+			org.DELETE("/invitations/:invitationID", routes.Invitation.RevokeInvitation, require(organization.PermissionMembersWrite))
+		}
+	}
+
+	// Invitation acceptance (public); the signed token from the invitation email authenticates the request
+	if routes.Invitation != nil {
+		v1.POST("/invitations/accept", routes.Invitation.AcceptInvitation)
 	}
 
 	// Admin routes (protected)
-	if authMiddlewareFunc != nil && adminMiddlewareFunc != nil {
+	if routes.AuthMiddleware != nil && routes.AdminMiddleware != nil {
 		admin := v1.Group("/admin")
-		admin.Use(authMiddlewareFunc())
-		admin.Use(adminMiddlewareFunc())
-		{
+		admin.Use(routes.AuthMiddleware())
+		admin.Use(routes.AdminMiddleware())
+
+		if routes.Role != nil {
 			// Role management
-			admin.GET("/roles", roleHandler.ListRoles)
-			admin.POST("/roles", roleHandler.CreateRole)
-			admin.GET("/roles/:id", roleHandler.GetRole)
-			admin.PUT("/roles/:id", roleHandler.UpdateRole)
-			admin.DELETE("/roles/:id", roleHandler.DeleteRole)
-			admin.POST("/roles/assign", roleHandler.AssignRoleToUser)
+			admin.GET("/roles", routes.Role.ListRoles)
+			admin.POST("/roles", routes.Role.CreateRole)
+			admin.GET("/roles/:id", routes.Role.GetRole)
+			admin.PUT("/roles/:id", routes.Role.UpdateRole)
+			admin.DELETE("/roles/:id", routes.Role.DeleteRole)
+			admin.POST("/roles/assign", routes.Role.AssignRoleToUser)
+		}
+
+		if routes.Policy != nil {
+			// Policy management
+			admin.GET("/policies", routes.Policy.ListPolicies)
+			admin.POST("/policies", routes.Policy.CreatePolicy)
+			admin.GET("/policies/:id", routes.Policy.GetPolicy)
+			admin.PUT("/policies/:id", routes.Policy.UpdatePolicy)
+			admin.DELETE("/policies/:id", routes.Policy.DeletePolicy)
+		}
+
+		if routes.Group != nil {
+			// Group management
+			admin.GET("/groups", routes.Group.ListGroups)
+			admin.POST("/groups", routes.Group.CreateGroup)
+			admin.GET("/groups/:id", routes.Group.GetGroup)
+			admin.DELETE("/groups/:id", routes.Group.DeleteGroup)
+			admin.GET("/groups/:id/members", routes.Group.ListMembers)
+			admin.POST("/groups/:id/members", routes.Group.AddMember)
+			admin.DELETE("/groups/:id/members/:userID", routes.Group.RemoveMember)
+			admin.POST("/groups/:id/roles", routes.Group.AssignRole)
+			admin.DELETE("/groups/:id/roles/:roleID", routes.Group.RemoveRole)
+
+			// Effective roles of a user, direct and inherited from groups
+			admin.GET("/users/:id/roles", routes.Group.ListEffectiveRoles)
+		}
+
+		if routes.User != nil {
+			// User management
+			admin.GET("/users", routes.User.ListUsers)
+			admin.GET("/users/:id", routes.User.GetUser)
+			admin.DELETE("/users/:id", routes.User.DeleteUser)
+			admin.POST("/users/:id/disable", routes.User.DisableUser)
+			admin.POST("/users/:id/enable", routes.User.EnableUser)
+		}
+
+		if routes.Impersonation != nil {
+			// Impersonation; every session is recorded
+			admin.POST("/users/:id/impersonate", routes.Impersonation.ImpersonateUser, ownerOnly...)
+			admin.GET("/impersonations", routes.Impersonation.ListSessions)
+		}
+
+		if routes.APIKey != nil {
+			// API keys for machine clients
+			admin.GET("/api-keys", routes.APIKey.ListAPIKeys)
+			admin.POST("/api-keys", routes.APIKey.CreateAPIKey, ownerOnly...)
+			admin.GET("/api-keys/export", routes.APIKey.ExportAPIKeys)
+			admin.DELETE("/api-keys/:id", routes.APIKey.RevokeAPIKey)
+		}
+
+		if routes.Job != nil {
+			// Background jobs of the instance serving the request
+			admin.GET("/jobs", routes.Job.ListJobs)
+		}
+
+		if routes.Audit != nil {
+			// Audit log; read-only, events are recorded by the actions themselves
+			admin.GET("/audit", routes.Audit.ListEvents)
+			admin.GET("/audit/export", routes.Audit.ExportEvents)
+			admin.GET("/audit/verify", routes.Audit.VerifyChain)
+		}
+
+		if routes.Webhook != nil {
+			// Webhooks receiving domain events; deliveries are made by the webhook-dispatch job
+			admin.GET("/webhooks", routes.Webhook.ListWebhooks)
+			admin.POST("/webhooks", routes.Webhook.CreateWebhook)
+			admin.DELETE("/webhooks/:id", routes.Webhook.DeleteWebhook)
+			admin.GET("/webhooks/:id/deliveries", routes.Webhook.ListDeliveries)
+			admin.POST("/webhooks/deliveries/:id/replay", routes.Webhook.ReplayDelivery)
+		}
+	}
+}
+
+// SetupHealthRoutes configures the liveness and readiness probes. They are
+// public and live outside /api so they don't need a token.
+func SetupHealthRoutes(e *echo.Echo, healthHandler *handlers.HealthHandler) {
+	e.GET("/livez", healthHandler.Live)
+	e.GET("/readyz", healthHandler.Ready)
+}
+
+// SetupMetricsRoutes serves the Prometheus metrics. Like the probes it is
+// public; keep it off the public ingress.
+func SetupMetricsRoutes(e *echo.Echo) {
+	e.GET("/metrics", echo.WrapHandler(metrics.Handler()))
+}
+
+// IPExtractor returns how c.RealIP() finds the client address, which feeds
+// policy decisions, audit events and API key allow-lists. With no trusted
+// proxies it is the connection's peer address and forwarding headers are
+// ignored; otherwise it is the X-Forwarded-For entry added by the nearest
+// trusted proxy.
+func IPExtractor(trustedProxies []string) (echo.IPExtractor, error) {
+	if len(trustedProxies) == 0 {
+		return echo.ExtractIPDirect(), nil
+	}
+
+	options := []echo.TrustOption{echo.TrustLoopback(false), echo.TrustLinkLocal(false), echo.TrustPrivateNet(false)}
+	for _, entry := range trustedProxies {
+		prefix, err := authpkg.ParseIPPrefix(entry)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted proxy: %w", err)
 		}
+		options = append(options, echo.TrustIPRange(&net.IPNet{
+			IP:   prefix.Addr().AsSlice(),
+			Mask: net.CIDRMask(prefix.Bits(), prefix.Addr().BitLen()),
+		}))
 	}
+	return echo.ExtractIPFromXFFHeader(options...), nil
 }
 
 // SetupMiddleware configures middleware for the Echo instance
 func SetupMiddleware(e *echo.Echo) {
 	e.Use(middleware.RequestLogger())
+	e.Use(appmiddleware.Tracing())
+	e.Use(appmiddleware.Metrics())
 	e.Use(middleware.Recover())
+	e.Use(appmiddleware.AuditClient())
 }
@@ -0,0 +1,56 @@
+package tracing
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/codes"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestStartWithoutProviderKeepsContext(t *testing.T) {
+	ctx := context.Background()
+	spanCtx, span := Start(ctx, "untraced")
+	defer span.End()
+
+	assert.Equal(t, ctx, spanCtx)
+	assert.False(t, span.IsRecording())
+}
+
+func TestEndRecordsError(t *testing.T) {
+	recorder := tracetest.NewSpanRecorder()
+	previous := otel.GetTracerProvider()
+	otel.SetTracerProvider(sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)))
+	t.Cleanup(func() { otel.SetTracerProvider(previous) })
+
+	run := func(ctx context.Context, fail bool) (err error) {
+		ctx, span := Start(ctx, "run")
+		defer End(span, &err)
+
+		_, child := Start(ctx, "child")
+		child.End()
+		if fail {
+			return errors.New("boom")
+		}
+		return nil
+	}
+	require.NoError(t, run(context.Background(), false))
+	require.Error(t, run(context.Background(), true))
+
+	ended := recorder.Ended()
+	require.Len(t, ended, 4)
+	child, succeeded, failed := ended[0], ended[1], ended[3]
+	assert.Equal(t, succeeded.SpanContext().SpanID(), child.Parent().SpanID())
+	assert.Equal(t, codes.Unset, succeeded.Status().Code)
+	assert.Equal(t, codes.Error, failed.Status().Code)
+	assert.Equal(t, "boom", failed.Status().Description)
+	require.Len(t, failed.Events(), 1)
+	assert.Equal(t, "exception", failed.Events()[0].Name)
+	assert.Equal(t, trace.SpanKindInternal, failed.SpanKind())
+}
@@ -0,0 +1,322 @@
+package auth
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"net/http"
+	"net/netip"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"golang.org/x/time/rate"
+)
+
+// APIKeyPrefix starts every API key, so secret scanners can recognize leaked ones.
+const APIKeyPrefix = "askey_"
+
+// DefaultAPIKeyHeader is the header APIKeyMiddleware reads keys from.
+const DefaultAPIKeyHeader = "X-API-Key"
+
+// TokenTypeAPIKey is the token_type claim of requests authenticated with an API key.
+const TokenTypeAPIKey = "api_key"
+
+// ErrInvalidAPIKey is returned by verifiers for unknown, revoked and expired keys.
+var ErrInvalidAPIKey = errors.New("invalid API key")
+
+// APIKey is what a verified key grants. A key is bound either to a service
+// account or to an organization.
+type APIKey struct {
+	ID             string     `json:"id"`
+	ServiceAccount string     `json:"service_account,omitempty"`
+	OrgID          *uuid.UUID `json:"org_id,omitempty"`
+	Permissions    []string   `json:"permissions"`
+	// AllowedIPs are addresses or CIDR ranges; empty allows any address.
+	AllowedIPs []string `json:"allowed_ips,omitempty"`
+	// RateLimit is the number of requests allowed per minute; 0 is unlimited.
+	RateLimit int        `json:"rate_limit,omitempty"`
+	ExpiresAt *time.Time `json:"expires_at,omitempty"`
+}
+
+// APIKeyVerifier resolves API keys. APIKeyStore verifies against hashes held
+// in memory; AuthzClient asks the auth-service.
+type APIKeyVerifier interface {
+	VerifyAPIKey(ctx context.Context, key string) (*APIKey, error)
+}
+
+// HashAPIKey returns the hex SHA-256 of a key, as kept by APIKeyStore and the auth-service.
+func HashAPIKey(key string) string {
+	hash := sha256.Sum256([]byte(key))
+	return hex.EncodeToString(hash[:])
+}
+
+// ParseIPPrefix parses an allow-list entry: a CIDR range or a single address.
+func ParseIPPrefix(entry string) (netip.Prefix, error) {
+	if strings.Contains(entry, "/") {
+		prefix, err := netip.ParsePrefix(entry)
+		if err != nil {
+			return netip.Prefix{}, fmt.Errorf("invalid CIDR %q", entry)
+		}
+		return prefix.Masked(), nil
+	}
+	addr, err := netip.ParseAddr(entry)
+	if err != nil {
+		return netip.Prefix{}, fmt.Errorf("invalid IP address %q", entry)
+	}
+	return netip.PrefixFrom(addr.Unmap(), addr.Unmap().BitLen()), nil
+}
+
+// IsExpired reports whether the key is past its expiry.
+func (k *APIKey) IsExpired() bool {
+	return k.ExpiresAt != nil && time.Now().After(*k.ExpiresAt)
+}
+
+// AllowsIP reports whether requests from ip may use the key.
+func (k *APIKey) AllowsIP(ip string) bool {
+	if len(k.AllowedIPs) == 0 {
+		return true
+	}
+	addr, err := netip.ParseAddr(ip)
+	if err != nil {
+		return false
+	}
+	addr = addr.Unmap()
+	for _, entry := range k.AllowedIPs {
+		prefix, err := ParseIPPrefix(entry)
+		if err == nil && prefix.Contains(addr) {
+			return true
+		}
+	}
+	return false
+}
+
+// UserContext returns the context handlers see for the key. UserID is
+// uuid.Nil. The permissions of an organization key are organization
+// permissions, so Global requirements are never satisfied by them.
+func (k *APIKey) UserContext() UserContext {
+	subject := k.ServiceAccount
+	if k.OrgID != nil {
+		subject = "org:" + k.OrgID.String()
+	}
+
+	userCtx := UserContext{
+		Claims: jwt.MapClaims{
+			"sub":        subject,
+			"jti":        k.ID,
+			"token_type": TokenTypeAPIKey,
+		},
+	}
+	if k.ExpiresAt != nil {
+		userCtx.Claims["exp"] = jwt.NewNumericDate(*k.ExpiresAt)
+	}
+	if k.OrgID != nil {
+		userCtx.OrgID = *k.OrgID
+		userCtx.OrgPermissions = k.Permissions
+		userCtx.Claims["org_id"] = k.OrgID.String()
+	} else {
+		userCtx.Permissions = k.Permissions
+	}
+	return userCtx
+}
+
+// IsAPIKey reports whether the request was authenticated with an API key.
+func (u UserContext) IsAPIKey() bool {
+	return u.Claims["token_type"] == TokenTypeAPIKey
+}
+
+// StoredAPIKey is an APIKeyStore entry: the grant and the hash of its secret.
+type StoredAPIKey struct {
+	APIKey
+	Hash string `json:"hash"`
+}
+
+// APIKeyStore verifies keys against a fixed set of hashes, for services that
+// shouldn't depend on the auth-service being reachable.
+type APIKeyStore struct {
+	keys map[string]*APIKey
+}
+
+func NewAPIKeyStore(keys []StoredAPIKey) (*APIKeyStore, error) {
+	s := &APIKeyStore{keys: make(map[string]*APIKey, len(keys))}
+
+	for i := range keys {
+		key := &keys[i]
+		if key.ID == "" || key.Hash == "" {
+			return nil, errors.New("API key id and hash are required")
+		}
+		if (key.ServiceAccount == "") == (key.OrgID == nil) {
+			return nil, fmt.Errorf("API key %s must be bound to a service account or an organization", key.ID)
+		}
+		for _, entry := range key.AllowedIPs {
+			if _, err := ParseIPPrefix(entry); err != nil {
+				return nil, fmt.Errorf("API key %s: %w", key.ID, err)
+			}
+		}
+		s.keys[key.Hash] = &key.APIKey
+	}
+
+	return s, nil
+}
+
+// ParseAPIKeyStore reads a JSON array of StoredAPIKey, such as the admin API's key listing.
+func ParseAPIKeyStore(data []byte) (*APIKeyStore, error) {
+	var keys []StoredAPIKey
+	if err := json.Unmarshal(data, &keys); err != nil {
+		return nil, fmt.Errorf("invalid API key store: %w", err)
+	}
+	return NewAPIKeyStore(keys)
+}
+
+func (s *APIKeyStore) VerifyAPIKey(_ context.Context, key string) (*APIKey, error) {
+	apiKey, ok := s.keys[HashAPIKey(key)]
+	if !ok || apiKey.IsExpired() {
+		return nil, ErrInvalidAPIKey
+	}
+	return apiKey, nil
+}
+
+// APIKeyMiddleware authenticates requests carrying an API key and enforces
+// each key's IP allow-list and rate limit. Authenticated requests get the
+// same "user" context as AuthMiddleware sets, so the Require middlewares
+// apply to them too.
+type APIKeyMiddleware struct {
+	verifier APIKeyVerifier
+	header   string
+	observer RejectionObserver
+
+	mu       sync.Mutex
+	limiters map[string]*rate.Limiter
+}
+
+type APIKeyOption func(*APIKeyMiddleware)
+
+// WithAPIKeyHeader reads keys from the named header instead of X-API-Key.
+func WithAPIKeyHeader(name string) APIKeyOption {
+	return func(m *APIKeyMiddleware) {
+		m.header = name
+	}
+}
+
+func NewAPIKeyMiddleware(verifier APIKeyVerifier, opts ...APIKeyOption) *APIKeyMiddleware {
+	m := &APIKeyMiddleware{
+		verifier: verifier,
+		header:   DefaultAPIKeyHeader,
+		limiters: make(map[string]*rate.Limiter),
+	}
+
+	for _, opt := range opts {
+		opt(m)
+	}
+
+	return m
+}
+
+// apiKeyError is an authentication failure with its HTTP status.
+type apiKeyError struct {
+	status     int
+	errorType  AuthErrorType
+	message    string
+	retryAfter time.Duration
+}
+
+func (m *APIKeyMiddleware) authenticate(ctx context.Context, key, remoteIP string) (UserContext, *apiKeyError) {
+	if key == "" {
+		return UserContext{}, &apiKeyError{status: http.StatusUnauthorized, errorType: ErrorTypeMissing, message: m.header + " header required"}
+	}
+
+	apiKey, err := m.verifier.VerifyAPIKey(ctx, key)
+	if err != nil || apiKey.IsExpired() {
+		return UserContext{}, &apiKeyError{status: http.StatusUnauthorized, errorType: ErrorTypeInvalid, message: "Invalid API key"}
+	}
+	if !apiKey.AllowsIP(remoteIP) {
+		return UserContext{}, &apiKeyError{status: http.StatusForbidden, errorType: ErrorTypePermission, message: "API key not allowed from this address"}
+	}
+	if wait, ok := m.allow(apiKey); !ok {
+		return UserContext{}, &apiKeyError{status: http.StatusTooManyRequests, errorType: ErrorTypeRateLimited, message: "API key rate limit exceeded", retryAfter: wait}
+	}
+
+	return apiKey.UserContext(), nil
+}
+
+// allow takes a token from the key's bucket, which refills at RateLimit per
+// minute and holds up to RateLimit requests.
+func (m *APIKeyMiddleware) allow(apiKey *APIKey) (time.Duration, bool) {
+	if apiKey.RateLimit <= 0 {
+		return 0, true
+	}
+
+	limit := rate.Limit(float64(apiKey.RateLimit) / time.Minute.Seconds())
+	m.mu.Lock()
+	limiter, ok := m.limiters[apiKey.ID]
+	if !ok || limiter.Burst() != apiKey.RateLimit {
+		limiter = rate.NewLimiter(limit, apiKey.RateLimit)
+		m.limiters[apiKey.ID] = limiter
+	}
+	m.mu.Unlock()
+
+	reservation := limiter.Reserve()
+	if delay := reservation.Delay(); delay > 0 {
+		reservation.Cancel()
+		return delay, false
+	}
+	return 0, true
+}
+
+// Handler is the net/http middleware. The client address is the connection's
+// remote address; put the middleware behind one that rewrites RemoteAddr when
+// running behind a proxy.
+func (m *APIKeyMiddleware) Handler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		remoteIP, _, err := net.SplitHostPort(r.RemoteAddr)
+		if err != nil {
+			remoteIP = r.RemoteAddr
+		}
+
+		userCtx, authErr := m.authenticate(r.Context(), r.Header.Get(m.header), remoteIP)
+		if authErr != nil {
+			m.reject(r.Context(), authErr.errorType)
+			if authErr.retryAfter > 0 {
+				w.Header().Set("Retry-After", retryAfterSeconds(authErr.retryAfter))
+			}
+			http.Error(w, authErr.message, authErr.status)
+			return
+		}
+
+		annotateAllowed(r.Context(), userCtx)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "user", userCtx)))
+	})
+}
+
+// EchoMiddleware is the Echo counterpart of Handler. The client address is
+// c.RealIP(), so configure the Echo instance's IPExtractor to match the proxies in front of it.
+func (m *APIKeyMiddleware) EchoMiddleware() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userCtx, authErr := m.authenticate(c.Request().Context(), c.Request().Header.Get(m.header), c.RealIP())
+			if authErr != nil {
+				m.reject(c.Request().Context(), authErr.errorType)
+				if authErr.retryAfter > 0 {
+					c.Response().Header().Set("Retry-After", retryAfterSeconds(authErr.retryAfter))
+				}
+				return c.JSON(authErr.status, map[string]string{"error": authErr.message})
+			}
+
+			annotateAllowed(c.Request().Context(), userCtx)
+			c.Set("user", userCtx)
+			return next(c)
+		}
+	}
+}
+
+func retryAfterSeconds(wait time.Duration) string {
+	return strconv.Itoa(int((wait + time.Second - 1) / time.Second))
+}
@@ -0,0 +1,108 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestAPIKeyMiddleware(t *testing.T) {
+	orgID := uuid.New()
+	past := time.Now().Add(-time.Hour)
+	store, err := NewAPIKeyStore([]StoredAPIKey{
+		{APIKey: APIKey{ID: "partner", ServiceAccount: "partner-sync", Permissions: []string{"orders:read"}, AllowedIPs: []string{"203.0.113.0/24"}}, Hash: HashAPIKey("askey_partner")},
+		{APIKey: APIKey{ID: "tenant", OrgID: &orgID, Permissions: []string{"reports:read"}, RateLimit: 2}, Hash: HashAPIKey("askey_tenant")},
+		{APIKey: APIKey{ID: "old", ServiceAccount: "legacy", Permissions: []string{"orders:read"}, ExpiresAt: &past}, Hash: HashAPIKey("askey_old")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API key store: %v", err)
+	}
+	m := NewAPIKeyMiddleware(store, WithAPIKeyHeader("X-Partner-Key"))
+
+	e := echo.New()
+	serve := func(key, remoteAddr string) (int, UserContext) {
+		var user UserContext
+		handler := m.EchoMiddleware()(func(c echo.Context) error {
+			user, _ = GetUserFromEchoContext(c)
+			return c.NoContent(http.StatusOK)
+		})
+
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.RemoteAddr = remoteAddr
+		if key != "" {
+			req.Header.Set("X-Partner-Key", key)
+		}
+		rec := httptest.NewRecorder()
+		if err := handler(e.NewContext(req, rec)); err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		return rec.Code, user
+	}
+
+	tests := []struct {
+		name       string
+		key        string
+		remoteAddr string
+		want       int
+	}{
+		{"allowed address", "askey_partner", "203.0.113.7:4000", http.StatusOK},
+		{"address outside the allow-list", "askey_partner", "198.51.100.7:4000", http.StatusForbidden},
+		{"unknown key", "askey_unknown", "203.0.113.7:4000", http.StatusUnauthorized},
+		{"expired key", "askey_old", "203.0.113.7:4000", http.StatusUnauthorized},
+		{"missing key", "", "203.0.113.7:4000", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code, _ := serve(tt.key, tt.remoteAddr); code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, code)
+			}
+		})
+	}
+
+	code, user := serve("askey_partner", "203.0.113.7:4000")
+	if code != http.StatusOK || !user.IsAPIKey() || user.HasOrganization() {
+		t.Fatalf("unexpected user context %+v", user)
+	}
+	if !slices.Equal(user.Permissions, []string{"orders:read"}) || user.Claims["sub"] != "partner-sync" {
+		t.Errorf("expected the service account's permissions, got %+v", user)
+	}
+
+	// Organization keys carry organization permissions, which global requirements ignore
+	code, user = serve("askey_tenant", "192.0.2.1:4000")
+	if code != http.StatusOK || user.OrgID != orgID || !slices.Equal(user.OrgPermissions, []string{"reports:read"}) {
+		t.Fatalf("unexpected user context %+v", user)
+	}
+	if Global(Permission("reports:read")).SatisfiedBy(permissionsFor(user, Global(Permission("reports:read")))) {
+		t.Error("expected organization permissions not to satisfy a global requirement")
+	}
+
+	// The burst is the per-minute limit; one request was already spent
+	if code, _ := serve("askey_tenant", "192.0.2.1:4000"); code != http.StatusOK {
+		t.Fatalf("expected the second request to pass, got %d", code)
+	}
+	if code, _ := serve("askey_tenant", "192.0.2.1:4000"); code != http.StatusTooManyRequests {
+		t.Fatalf("expected the rate limit to apply, got %d", code)
+	}
+}
+
+func TestNewAPIKeyStore_RejectsInvalidKeys(t *testing.T) {
+	orgID := uuid.New()
+	tests := map[string]StoredAPIKey{
+		"no binding":   {APIKey: APIKey{ID: "a"}, Hash: "h"},
+		"both":         {APIKey: APIKey{ID: "a", ServiceAccount: "s", OrgID: &orgID}, Hash: "h"},
+		"invalid CIDR": {APIKey: APIKey{ID: "a", ServiceAccount: "s", AllowedIPs: []string{"10.0.0.0/33"}}, Hash: "h"},
+		"no hash":      {APIKey: APIKey{ID: "a", ServiceAccount: "s"}},
+	}
+	for name, key := range tests {
+		t.Run(name, func(t *testing.T) {
+			if _, err := NewAPIKeyStore([]StoredAPIKey{key}); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
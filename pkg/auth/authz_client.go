@@ -0,0 +1,189 @@
+package auth
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+)
+
+// AuthzClient asks the auth-service for attribute-based authorization decisions.
+type AuthzClient struct {
+	baseURL      string
+	httpClient   *http.Client
+	serviceToken string
+}
+
+type AuthzClientOption func(*AuthzClient)
+
+func WithHTTPClient(client *http.Client) AuthzClientOption {
+	return func(c *AuthzClient) {
+		c.httpClient = client
+	}
+}
+
+// WithServiceToken authenticates token introspection with the caller's
+// service token, see AuthMiddleware.CreateServiceToken.
+func WithServiceToken(token string) AuthzClientOption {
+	return func(c *AuthzClient) {
+		c.serviceToken = token
+	}
+}
+
+// NewAuthzClient creates a client for the auth-service at baseURL, e.g. "http://auth-service:8080".
+func NewAuthzClient(baseURL string, opts ...AuthzClientOption) *AuthzClient {
+	c := &AuthzClient{
+		baseURL:    strings.TrimRight(baseURL, "/"),
+		httpClient: &http.Client{Timeout: 5 * time.Second},
+	}
+
+	for _, opt := range opts {
+		opt(c)
+	}
+
+	return c
+}
+
+type CheckRequest struct {
+	// Subject defaults to the owner of the bearer token when nil.
+	Subject     *CheckSubject  `json:"subject,omitempty"`
+	Action      string         `json:"action"`
+	Resource    CheckResource  `json:"resource"`
+	Environment map[string]any `json:"environment,omitempty"`
+}
+
+type CheckSubject struct {
+	ID         string         `json:"id,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type CheckResource struct {
+	Type       string         `json:"type"`
+	ID         string         `json:"id,omitempty"`
+	Attributes map[string]any `json:"attributes,omitempty"`
+}
+
+type Decision struct {
+	Allowed    bool   `json:"allowed"`
+	Decision   string `json:"decision"`
+	PolicyID   string `json:"policy_id,omitempty"`
+	PolicyName string `json:"policy_name,omitempty"`
+	Reason     string `json:"reason"`
+}
+
+// Check calls POST /api/v1/authz/check authenticated with the given bearer token.
+func (c *AuthzClient) Check(ctx context.Context, bearerToken string, req CheckRequest) (*Decision, error) {
+	body, err := json.Marshal(req)
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode check request: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/authz/check", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build check request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+bearerToken)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("authz check failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		var errResp struct {
+			Error string `json:"error"`
+		}
+		_ = json.NewDecoder(resp.Body).Decode(&errResp)
+		return nil, fmt.Errorf("authz check returned status %d: %s", resp.StatusCode, errResp.Error)
+	}
+
+	var decision Decision
+	if err := json.NewDecoder(resp.Body).Decode(&decision); err != nil {
+		return nil, fmt.Errorf("failed to decode decision: %w", err)
+	}
+
+	return &decision, nil
+}
+
+// Allowed is a shorthand for Check that only reports the outcome.
+func (c *AuthzClient) Allowed(ctx context.Context, bearerToken string, req CheckRequest) (bool, error) {
+	decision, err := c.Check(ctx, bearerToken, req)
+	if err != nil {
+		return false, err
+	}
+	return decision.Allowed, nil
+}
+
+// IntrospectToken calls POST /oauth/introspect to resolve an opaque token such
+// as a personal access token. It implements TokenIntrospector, so services can
+// pass the client to WithPersonalAccessTokens; it requires WithServiceToken.
+func (c *AuthzClient) IntrospectToken(ctx context.Context, token string) (*Introspection, error) {
+	form := url.Values{"token": {token}}
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/oauth/introspect", strings.NewReader(form.Encode()))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build introspection request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	httpReq.Header.Set("Authorization", "Bearer "+c.serviceToken)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("token introspection failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("token introspection returned status %d", resp.StatusCode)
+	}
+
+	var introspection Introspection
+	if err := json.NewDecoder(resp.Body).Decode(&introspection); err != nil {
+		return nil, fmt.Errorf("failed to decode introspection: %w", err)
+	}
+
+	return &introspection, nil
+}
+
+// VerifyAPIKey calls POST /api/v1/api-keys/verify. It implements
+// APIKeyVerifier, so services can pass the client to NewAPIKeyMiddleware; it
+// requires WithServiceToken.
+func (c *AuthzClient) VerifyAPIKey(ctx context.Context, key string) (*APIKey, error) {
+	body, err := json.Marshal(map[string]string{"key": key})
+	if err != nil {
+		return nil, fmt.Errorf("failed to encode API key verification: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+"/api/v1/api-keys/verify", bytes.NewReader(body))
+	if err != nil {
+		return nil, fmt.Errorf("failed to build API key verification request: %w", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+c.serviceToken)
+
+	resp, err := c.httpClient.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf("API key verification failed: %w", err)
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+	case http.StatusNotFound:
+		return nil, ErrInvalidAPIKey
+	default:
+		return nil, fmt.Errorf("API key verification returned status %d", resp.StatusCode)
+	}
+
+	var apiKey APIKey
+	if err := json.NewDecoder(resp.Body).Decode(&apiKey); err != nil {
+		return nil, fmt.Errorf("failed to decode API key: %w", err)
+	}
+
+	return &apiKey, nil
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestAuthzClient_Check(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/api/v1/authz/check" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer caller-token" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+
+		var req CheckRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			t.Fatalf("failed to decode request: %v", err)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if req.Action == "posts:delete" {
+			w.WriteHeader(http.StatusBadRequest)
+			json.NewEncoder(w).Encode(map[string]string{"error": "subject not found"})
+			return
+		}
+		json.NewEncoder(w).Encode(Decision{Allowed: req.Resource.Attributes["org_id"] == "acme", Decision: "allow", PolicyName: "org-members"})
+	}))
+	defer server.Close()
+
+	client := NewAuthzClient(server.URL + "/")
+
+	decision, err := client.Check(context.Background(), "caller-token", CheckRequest{
+		Action:   "posts:write",
+		Resource: CheckResource{Type: "post", Attributes: map[string]any{"org_id": "acme"}},
+	})
+	if err != nil {
+		t.Fatalf("Check returned error: %v", err)
+	}
+	if !decision.Allowed || decision.PolicyName != "org-members" {
+		t.Errorf("unexpected decision %+v", decision)
+	}
+
+	if _, err := client.Allowed(context.Background(), "caller-token", CheckRequest{Action: "posts:delete"}); err == nil {
+		t.Error("expected error for non-200 response")
+	}
+}
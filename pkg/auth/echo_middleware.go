@@ -4,7 +4,6 @@ import (
 	"net/http"
 	"slices"
 
-	"github.com/golang-jwt/jwt/v5"
 	"github.com/labstack/echo/v4"
 )
 
@@ -13,43 +12,47 @@ func (am *AuthMiddleware) EchoMiddleware() echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			authHeader := c.Request().Header.Get("Authorization")
 			if authHeader == "" {
+				am.reject(c.Request().Context(), ErrorTypeMissing)
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Authorization header required"})
 			}
 
 			tokenString := extractTokenFromHeader(authHeader)
 			if tokenString == "" {
+				am.reject(c.Request().Context(), ErrorTypeMalformed)
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Bearer token required"})
 			}
 
+			if IsPersonalAccessToken(tokenString) {
+				userCtx, err := am.introspect(c.Request().Context(), tokenString)
+				if err != nil {
+					am.reject(c.Request().Context(), ErrorTypeInvalid)
+					return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token: " + err.Error()})
+				}
+				annotateAllowed(c.Request().Context(), userCtx)
+				c.Set("user", userCtx)
+				c.Set("user_id", userCtx.UserID.String())
+				return next(c)
+			}
+
 			token, err := am.parseAndValidateToken(tokenString)
 			if err != nil {
+				am.reject(c.Request().Context(), tokenErrorType(err))
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token: " + err.Error()})
 			}
 
 			claims, ok := token.Claims.(*CustomClaims)
 			if !ok || !token.Valid {
+				am.reject(c.Request().Context(), ErrorTypeInvalid)
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Invalid token claims"})
 			}
 
 			if err := am.validateClaims(claims); err != nil {
+				am.reject(c.Request().Context(), tokenErrorType(err))
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "Token validation failed: " + err.Error()})
 			}
 
-			userCtx := UserContext{
-				UserID: claims.UserID,
-				Claims: jwt.MapClaims{
-					"sub": claims.Subject,
-					"iss": claims.Issuer,
-					"aud": claims.Audience,
-					"exp": claims.ExpiresAt,
-					"nbf": claims.NotBefore,
-					"iat": claims.IssuedAt,
-					"jti": claims.ID,
-				},
-				Permissions: claims.Permissions,
-				Roles:       claims.Roles,
-			}
-
+			userCtx := newUserContext(claims)
+			annotateAllowed(c.Request().Context(), userCtx)
 			c.Set("user", userCtx)
 			c.Set("user_id", claims.UserID.String())
 
@@ -59,14 +62,29 @@ func (am *AuthMiddleware) EchoMiddleware() echo.MiddlewareFunc {
 }
 
 func (am *AuthMiddleware) EchoRequirePermission(permission string) echo.MiddlewareFunc {
+	return am.EchoRequire(Permission(permission))
+}
+
+func (am *AuthMiddleware) EchoRequireAnyPermission(permissions ...string) echo.MiddlewareFunc {
+	return am.EchoRequire(AnyPermission(permissions...))
+}
+
+func (am *AuthMiddleware) EchoRequireAllPermissions(permissions ...string) echo.MiddlewareFunc {
+	return am.EchoRequire(AllPermissions(permissions...))
+}
+
+// EchoRequire rejects requests whose permissions do not satisfy the requirement.
+func (am *AuthMiddleware) EchoRequire(requirement Requirement) echo.MiddlewareFunc {
 	return func(next echo.HandlerFunc) echo.HandlerFunc {
 		return func(c echo.Context) error {
 			userCtx, ok := c.Get("user").(UserContext)
 			if !ok {
+				am.reject(c.Request().Context(), ErrorTypeUserNotFound)
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
 			}
 
-			if !slices.Contains(userCtx.Permissions, permission) {
+			if !requirement.SatisfiedBy(permissionsFor(userCtx, requirement)) {
+				am.reject(c.Request().Context(), ErrorTypePermission)
 				return c.JSON(http.StatusForbidden, map[string]string{"error": "Insufficient permissions"})
 			}
 
@@ -80,10 +98,12 @@ func (am *AuthMiddleware) EchoRequireRole(role string) echo.MiddlewareFunc {
 		return func(c echo.Context) error {
 			userCtx, ok := c.Get("user").(UserContext)
 			if !ok {
+				am.reject(c.Request().Context(), ErrorTypeUserNotFound)
 				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
 			}
 
 			if !slices.Contains(userCtx.Roles, role) {
+				am.reject(c.Request().Context(), ErrorTypeRole)
 				return c.JSON(http.StatusForbidden, map[string]string{"error": "Insufficient role"})
 			}
 
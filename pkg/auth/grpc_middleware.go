@@ -0,0 +1,123 @@
+package auth
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+// GRPCUnaryInterceptor authenticates unary calls using the bearer token in the
+// "authorization" metadata and stores the UserContext in the call context.
+func (am *AuthMiddleware) GRPCUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		ctx, err := am.authenticateGRPC(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStreamInterceptor is the streaming counterpart of GRPCUnaryInterceptor.
+func (am *AuthMiddleware) GRPCStreamInterceptor() grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		ctx, err := am.authenticateGRPC(ss.Context())
+		if err != nil {
+			return err
+		}
+		return handler(srv, &contextServerStream{ServerStream: ss, ctx: ctx})
+	}
+}
+
+// GRPCUnaryRequire enforces a requirement per full method name, e.g.
+// "/auth.v1.AuthService/LogoutAll". Methods without an entry are not checked.
+// It must run after GRPCUnaryInterceptor.
+func (am *AuthMiddleware) GRPCUnaryRequire(requirements map[string]Requirement) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req any, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (any, error) {
+		if err := am.checkGRPCRequirement(ctx, requirements[info.FullMethod]); err != nil {
+			return nil, err
+		}
+		return handler(ctx, req)
+	}
+}
+
+// GRPCStreamRequire is the streaming counterpart of GRPCUnaryRequire.
+func (am *AuthMiddleware) GRPCStreamRequire(requirements map[string]Requirement) grpc.StreamServerInterceptor {
+	return func(srv any, ss grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if err := am.checkGRPCRequirement(ss.Context(), requirements[info.FullMethod]); err != nil {
+			return err
+		}
+		return handler(srv, ss)
+	}
+}
+
+func (am *AuthMiddleware) authenticateGRPC(ctx context.Context) (context.Context, error) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		am.reject(ctx, ErrorTypeMissing)
+		return nil, status.Error(codes.Unauthenticated, "Authorization metadata required")
+	}
+
+	values := md.Get("authorization")
+	if len(values) == 0 {
+		am.reject(ctx, ErrorTypeMissing)
+		return nil, status.Error(codes.Unauthenticated, "Authorization metadata required")
+	}
+
+	tokenString := extractTokenFromHeader(values[0])
+	if tokenString == "" {
+		am.reject(ctx, ErrorTypeMalformed)
+		return nil, status.Error(codes.Unauthenticated, "Bearer token required")
+	}
+
+	if IsPersonalAccessToken(tokenString) {
+		userCtx, err := am.introspect(ctx, tokenString)
+		if err != nil {
+			am.reject(ctx, ErrorTypeInvalid)
+			return nil, status.Error(codes.Unauthenticated, err.Error())
+		}
+		annotateAllowed(ctx, userCtx)
+		return context.WithValue(ctx, "user", userCtx), nil
+	}
+
+	claims, err := am.ValidateTokenString(tokenString)
+	if err != nil {
+		am.reject(ctx, authErrorType(err))
+		return nil, status.Error(codes.Unauthenticated, err.Error())
+	}
+
+	userCtx := newUserContext(claims)
+	annotateAllowed(ctx, userCtx)
+	return context.WithValue(ctx, "user", userCtx), nil
+}
+
+func (am *AuthMiddleware) checkGRPCRequirement(ctx context.Context, requirement Requirement) error {
+	if requirement == nil {
+		return nil
+	}
+
+	userCtx, ok := GetUserFromContext(ctx)
+	if !ok {
+		am.reject(ctx, ErrorTypeUserNotFound)
+		return status.Error(codes.Unauthenticated, "User context not found")
+	}
+
+	if !requirement.SatisfiedBy(permissionsFor(userCtx, requirement)) {
+		am.reject(ctx, ErrorTypePermission)
+		return status.Error(codes.PermissionDenied, "Insufficient permissions")
+	}
+
+	return nil
+}
+
+type contextServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *contextServerStream) Context() context.Context {
+	return s.ctx
+}
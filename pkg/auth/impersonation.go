@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/labstack/echo/v4"
+)
+
+// Actor is the RFC 8693 "act" claim: the party acting on behalf of the
+// token's subject. Nested actors record earlier links of a delegation chain.
+type Actor struct {
+	Subject string `json:"sub"`
+	Actor   *Actor `json:"act,omitempty"`
+}
+
+// IsImpersonated reports whether someone other than the subject is acting
+// with the token, such as an admin impersonating the user.
+func (u UserContext) IsImpersonated() bool {
+	return u.Actor != nil
+}
+
+// RejectImpersonation refuses requests made with impersonation tokens, for
+// actions only the account owner should take.
+func RejectImpersonation(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userCtx, ok := r.Context().Value("user").(UserContext)
+		if !ok {
+			http.Error(w, "User context not found", http.StatusUnauthorized)
+			return
+		}
+
+		if userCtx.IsImpersonated() {
+			http.Error(w, "Not allowed while impersonating", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// EchoRejectImpersonation is the Echo counterpart of RejectImpersonation.
+func EchoRejectImpersonation() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userCtx, ok := c.Get("user").(UserContext)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
+			}
+
+			if userCtx.IsImpersonated() {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "Not allowed while impersonating"})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestActorClaim(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	// Tokens issued by the service carry "act" as a plain map claim
+	adminID := uuid.New()
+	token, err := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{
+		"sub": uuid.NewString(),
+		"exp": time.Now().Add(time.Hour).Unix(),
+		"act": map[string]any{"sub": adminID.String()},
+	}).SignedString([]byte("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to sign token: %v", err)
+	}
+
+	claims, err := am.ValidateTokenString(token)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+	if claims.Actor == nil || claims.Actor.Subject != adminID.String() {
+		t.Fatalf("expected actor %s, got %+v", adminID, claims.Actor)
+	}
+	if !newUserContext(claims).IsImpersonated() {
+		t.Errorf("expected the user context to be impersonated")
+	}
+
+	if _, err := am.RefreshToken(token, time.Now().Add(time.Hour)); err == nil {
+		t.Errorf("expected impersonation tokens to be non-refreshable")
+	}
+
+	own, err := am.CreateToken(uuid.New(), time.Now().Add(time.Hour), nil)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	claims, err = am.ValidateTokenString(own)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+	if newUserContext(claims).IsImpersonated() {
+		t.Errorf("expected a token without act not to be impersonated")
+	}
+}
+
+func TestEchoRejectImpersonation(t *testing.T) {
+	e := echo.New()
+	handler := EchoRejectImpersonation()(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name string
+		user UserContext
+		want int
+	}{
+		{"own token", UserContext{UserID: uuid.New()}, http.StatusOK},
+		{"impersonated", UserContext{UserID: uuid.New(), Actor: &Actor{Subject: uuid.NewString()}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+			c.Set("user", tt.user)
+
+			if err := handler(c); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
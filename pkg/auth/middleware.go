@@ -14,13 +14,21 @@ import (
 
 type AuthMiddleware struct {
 	jwtSecret       []byte
+	previousSecrets [][]byte
 	tokenValidation TokenValidation
+	introspector    TokenIntrospector
+	observer        RejectionObserver
 }
 
 type TokenValidation struct {
 	SkipExpirationCheck bool
 	RequiredIssuer      string
 	RequiredAudience    []string
+	// AcceptedAudience rejects tokens issued for another audience: a token
+	// with an "aud" claim must name it. Tokens without one are accepted, so
+	// the issuer can accept its own tokens but not those it exchanged for
+	// other services.
+	AcceptedAudience string
 }
 
 type UserContext struct {
@@ -28,17 +36,44 @@ type UserContext struct {
 	Claims      jwt.MapClaims
 	Permissions []string
 	Roles       []string
+	Groups      []string
+	// OrgID is the organization selected for the token, uuid.Nil when none.
+	OrgID          uuid.UUID
+	OrgRole        string
+	OrgPermissions []string
+	// Actor is who is acting on behalf of UserID, nil for the user's own tokens.
+	Actor *Actor
 }
 
 type CustomClaims struct {
-	UserID      uuid.UUID `json:"sub"`
-	Permissions []string  `json:"permissions,omitempty"`
-	Roles       []string  `json:"roles,omitempty"`
-	Issuer      string    `json:"iss,omitempty"`
-	Audience    []string  `json:"aud,omitempty"`
+	UserID         uuid.UUID  `json:"sub"`
+	Permissions    []string   `json:"permissions,omitempty"`
+	Roles          []string   `json:"roles,omitempty"`
+	Groups         []string   `json:"groups,omitempty"`
+	OrgID          *uuid.UUID `json:"org_id,omitempty"`
+	OrgRole        string     `json:"org_role,omitempty"`
+	OrgPermissions []string   `json:"org_permissions,omitempty"`
+	Actor          *Actor     `json:"act,omitempty"`
+	Issuer         string     `json:"iss,omitempty"`
+	Audience       []string   `json:"aud,omitempty"`
 	jwt.RegisteredClaims
 }
 
+// HasOrganization reports whether the token was issued for an organization.
+func (u UserContext) HasOrganization() bool {
+	return u.OrgID != uuid.Nil
+}
+
+// EffectivePermissions returns the permissions InOrganization requirements
+// are checked against: the global permissions plus, when an organization is
+// selected, the permissions of the user's role in that organization.
+func (u UserContext) EffectivePermissions() []string {
+	if !u.HasOrganization() || len(u.OrgPermissions) == 0 {
+		return u.Permissions
+	}
+	return append(slices.Clone(u.Permissions), u.OrgPermissions...)
+}
+
 type MiddlewareOption func(*AuthMiddleware)
 
 func WithJWTSecret(secret string) MiddlewareOption {
@@ -47,6 +82,19 @@ func WithJWTSecret(secret string) MiddlewareOption {
 	}
 }
 
+// WithPreviousJWTSecrets also accepts tokens signed with secrets replaced by
+// WithJWTSecret, so tokens issued before a rotation keep working until they
+// expire. New tokens are always signed with the current secret.
+func WithPreviousJWTSecrets(secrets ...string) MiddlewareOption {
+	return func(am *AuthMiddleware) {
+		for _, secret := range secrets {
+			if secret != "" {
+				am.previousSecrets = append(am.previousSecrets, []byte(secret))
+			}
+		}
+	}
+}
+
 func WithTokenValidation(validation TokenValidation) MiddlewareOption {
 	return func(am *AuthMiddleware) {
 		am.tokenValidation = validation
@@ -101,6 +149,26 @@ func (am *AuthMiddleware) CreateToken(userID uuid.UUID, expiresAt time.Time, cus
 			if roles, ok := value.([]string); ok {
 				claims.Roles = roles
 			}
+		case "groups":
+			if groups, ok := value.([]string); ok {
+				claims.Groups = groups
+			}
+		case "org_id":
+			if orgID, ok := value.(uuid.UUID); ok && orgID != uuid.Nil {
+				claims.OrgID = &orgID
+			}
+		case "org_role":
+			if orgRole, ok := value.(string); ok {
+				claims.OrgRole = orgRole
+			}
+		case "org_permissions":
+			if perms, ok := value.([]string); ok {
+				claims.OrgPermissions = perms
+			}
+		case "act":
+			if actor, ok := value.(*Actor); ok {
+				claims.Actor = actor
+			}
 		default:
 			// Add other custom claims as needed
 		}
@@ -114,66 +182,109 @@ func (am *AuthMiddleware) ValidateToken(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		authHeader := r.Header.Get("Authorization")
 		if authHeader == "" {
+			am.reject(r.Context(), ErrorTypeMissing)
 			http.Error(w, "Authorization header required", http.StatusUnauthorized)
 			return
 		}
 
 		tokenString := strings.TrimPrefix(authHeader, "Bearer ")
 		if tokenString == authHeader {
+			am.reject(r.Context(), ErrorTypeMalformed)
 			http.Error(w, "Bearer token required", http.StatusUnauthorized)
 			return
 		}
 
+		if IsPersonalAccessToken(tokenString) {
+			userCtx, err := am.introspect(r.Context(), tokenString)
+			if err != nil {
+				am.reject(r.Context(), ErrorTypeInvalid)
+				http.Error(w, "Invalid token: "+err.Error(), http.StatusUnauthorized)
+				return
+			}
+			annotateAllowed(r.Context(), userCtx)
+			next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "user", userCtx)))
+			return
+		}
+
 		token, err := am.parseAndValidateToken(tokenString)
 		if err != nil {
+			am.reject(r.Context(), tokenErrorType(err))
 			http.Error(w, "Invalid token: "+err.Error(), http.StatusUnauthorized)
 			return
 		}
 
 		claims, ok := token.Claims.(*CustomClaims)
 		if !ok || !token.Valid {
+			am.reject(r.Context(), ErrorTypeInvalid)
 			http.Error(w, "Invalid token claims", http.StatusUnauthorized)
 			return
 		}
 
 		if err := am.validateClaims(claims); err != nil {
+			am.reject(r.Context(), tokenErrorType(err))
 			http.Error(w, "Token validation failed: "+err.Error(), http.StatusUnauthorized)
 			return
 		}
 
-		userCtx := UserContext{
-			UserID: claims.UserID,
-			Claims: jwt.MapClaims{
-				"sub": claims.Subject,
-				"iss": claims.Issuer,
-				"aud": claims.Audience,
-				"exp": claims.ExpiresAt,
-				"nbf": claims.NotBefore,
-				"iat": claims.IssuedAt,
-				"jti": claims.ID,
-			},
-			Permissions: claims.Permissions,
-			Roles:       claims.Roles,
-		}
-
-		ctx := context.WithValue(r.Context(), "user", userCtx)
-		next.ServeHTTP(w, r.WithContext(ctx))
+		userCtx := newUserContext(claims)
+		annotateAllowed(r.Context(), userCtx)
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), "user", userCtx)))
 	})
 }
 
+func newUserContext(claims *CustomClaims) UserContext {
+	userCtx := UserContext{
+		UserID: claims.UserID,
+		Claims: jwt.MapClaims{
+			"sub": claims.Subject,
+			"iss": claims.Issuer,
+			"aud": claims.Audience,
+			"exp": claims.ExpiresAt,
+			"nbf": claims.NotBefore,
+			"iat": claims.IssuedAt,
+			"jti": claims.ID,
+		},
+		Permissions:    claims.Permissions,
+		Roles:          claims.Roles,
+		Groups:         claims.Groups,
+		OrgRole:        claims.OrgRole,
+		OrgPermissions: claims.OrgPermissions,
+		Actor:          claims.Actor,
+	}
+	if claims.Actor != nil {
+		userCtx.Claims["act"] = claims.Actor
+	}
+	if claims.OrgID != nil {
+		userCtx.OrgID = *claims.OrgID
+		userCtx.Claims["org_id"] = claims.OrgID.String()
+	}
+	return userCtx
+}
+
 func (am *AuthMiddleware) parseAndValidateToken(tokenString string) (*jwt.Token, error) {
-	return jwt.ParseWithClaims(tokenString, &CustomClaims{}, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
+	return jwt.ParseWithClaims(tokenString, &CustomClaims{}, am.verificationKey)
+}
+
+// verificationKey is the jwt.Keyfunc for tokens this middleware signed: the
+// current secret, followed by the previous ones when there are any.
+func (am *AuthMiddleware) verificationKey(token *jwt.Token) (any, error) {
+	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
+		return nil, errors.New("unexpected signing method")
+	}
+	if len(am.previousSecrets) == 0 {
 		return am.jwtSecret, nil
-	})
+	}
+	keys := jwt.VerificationKeySet{Keys: []jwt.VerificationKey{am.jwtSecret}}
+	for _, secret := range am.previousSecrets {
+		keys.Keys = append(keys.Keys, secret)
+	}
+	return keys, nil
 }
 
 func (am *AuthMiddleware) validateClaims(claims *CustomClaims) error {
 	if !am.tokenValidation.SkipExpirationCheck {
 		if claims.ExpiresAt != nil && claims.ExpiresAt.Before(time.Now()) {
-			return errors.New("token has expired")
+			return ErrTokenExpired
 		}
 	}
 
@@ -181,6 +292,10 @@ func (am *AuthMiddleware) validateClaims(claims *CustomClaims) error {
 		return errors.New("invalid issuer")
 	}
 
+	if accepted := am.tokenValidation.AcceptedAudience; accepted != "" && len(claims.Audience) > 0 && !slices.Contains(claims.Audience, accepted) {
+		return errors.New("invalid audience")
+	}
+
 	if len(am.tokenValidation.RequiredAudience) > 0 {
 		for _, requiredAud := range am.tokenValidation.RequiredAudience {
 			found := slices.Contains(claims.Audience, requiredAud)
@@ -194,15 +309,30 @@ func (am *AuthMiddleware) validateClaims(claims *CustomClaims) error {
 }
 
 func (am *AuthMiddleware) RequirePermission(permission string) func(http.Handler) http.Handler {
+	return am.Require(Permission(permission))
+}
+
+func (am *AuthMiddleware) RequireAnyPermission(permissions ...string) func(http.Handler) http.Handler {
+	return am.Require(AnyPermission(permissions...))
+}
+
+func (am *AuthMiddleware) RequireAllPermissions(permissions ...string) func(http.Handler) http.Handler {
+	return am.Require(AllPermissions(permissions...))
+}
+
+// Require rejects requests whose permissions do not satisfy the requirement.
+func (am *AuthMiddleware) Require(requirement Requirement) func(http.Handler) http.Handler {
 	return func(next http.Handler) http.Handler {
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userCtx, ok := r.Context().Value("user").(UserContext)
 			if !ok {
+				am.reject(r.Context(), ErrorTypeUserNotFound)
 				http.Error(w, "User context not found", http.StatusUnauthorized)
 				return
 			}
 
-			if !slices.Contains(userCtx.Permissions, permission) {
+			if !requirement.SatisfiedBy(permissionsFor(userCtx, requirement)) {
+				am.reject(r.Context(), ErrorTypePermission)
 				http.Error(w, "Insufficient permissions", http.StatusForbidden)
 				return
 			}
@@ -217,11 +347,13 @@ func (am *AuthMiddleware) RequireRole(role string) func(http.Handler) http.Handl
 		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 			userCtx, ok := r.Context().Value("user").(UserContext)
 			if !ok {
+				am.reject(r.Context(), ErrorTypeUserNotFound)
 				http.Error(w, "User context not found", http.StatusUnauthorized)
 				return
 			}
 
 			if !slices.Contains(userCtx.Roles, role) {
+				am.reject(r.Context(), ErrorTypeRole)
 				http.Error(w, "Insufficient role", http.StatusForbidden)
 				return
 			}
@@ -47,6 +47,86 @@ func TestAuthMiddleware(t *testing.T) {
 	}
 }
 
+func TestPreviousJWTSecrets(t *testing.T) {
+	before, err := NewAuthMiddleware(WithJWTSecret("old-secret"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	oldToken, err := before.CreateTokenWithDefaults(uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	oldServiceToken, err := before.CreateServiceToken("batch", time.Now().Add(time.Hour))
+	if err != nil {
+		t.Fatalf("Failed to create service token: %v", err)
+	}
+
+	rotated, err := NewAuthMiddleware(WithJWTSecret("new-secret"), WithPreviousJWTSecrets("old-secret"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	if _, err := rotated.ValidateTokenString(oldToken); err != nil {
+		t.Errorf("Expected token signed with the previous secret to validate, got %v", err)
+	}
+	if _, err := rotated.ValidateServiceToken(oldServiceToken); err != nil {
+		t.Errorf("Expected service token signed with the previous secret to validate, got %v", err)
+	}
+
+	// New tokens are signed with the current secret only
+	newToken, err := rotated.CreateTokenWithDefaults(uuid.New())
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	if _, err := before.ValidateTokenString(newToken); err == nil {
+		t.Error("Expected token signed after rotation to fail with the old secret")
+	}
+
+	dropped, err := NewAuthMiddleware(WithJWTSecret("new-secret"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	if _, err := dropped.ValidateTokenString(oldToken); err == nil {
+		t.Error("Expected token signed with a dropped secret to be rejected")
+	}
+}
+
+func TestAcceptedAudience(t *testing.T) {
+	issuer, err := NewAuthMiddleware(WithJWTSecret("test-secret"), WithTokenValidation(TokenValidation{AcceptedAudience: "auth-service"}))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	forOrders, err := NewAuthMiddleware(WithJWTSecret("test-secret"), WithTokenValidation(TokenValidation{RequiredAudience: []string{"orders-service"}}))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	forIssuer, err := NewAuthMiddleware(WithJWTSecret("test-secret"), WithTokenValidation(TokenValidation{RequiredAudience: []string{"auth-service"}}))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		issued  *AuthMiddleware
+		wantErr bool
+	}{
+		{"without audience", issuer, false},
+		{"for the accepted audience", forIssuer, false},
+		{"for another audience", forOrders, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			token, err := tt.issued.CreateTokenWithDefaults(uuid.New())
+			if err != nil {
+				t.Fatalf("Failed to create token: %v", err)
+			}
+			_, err = issuer.ValidateTokenString(token)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ValidateTokenString() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
 func TestServiceToken(t *testing.T) {
 	secret := "test-secret-key"
 	authMiddleware, err := NewAuthMiddleware(WithJWTSecret(secret))
@@ -85,6 +165,7 @@ func TestTokenWithCustomClaims(t *testing.T) {
 	customClaims := map[string]any{
 		"permissions": []string{"read:users", "write:users"},
 		"roles":       []string{"admin", "moderator"},
+		"groups":      []string{"engineering"},
 	}
 
 	token, err := authMiddleware.CreateToken(userID, time.Now().Add(1*time.Hour), customClaims)
@@ -125,6 +206,16 @@ func TestTokenWithCustomClaims(t *testing.T) {
 			t.Errorf("Expected role %s, got %v", role, roles)
 		}
 	}
+
+	// Test group claims
+	claims, err := authMiddleware.ValidateTokenString(token)
+	if err != nil {
+		t.Fatalf("Failed to validate token: %v", err)
+	}
+
+	if len(claims.Groups) != 1 || claims.Groups[0] != "engineering" {
+		t.Errorf("Expected groups [engineering], got %v", claims.Groups)
+	}
 }
 
 func TestTokenRefresh(t *testing.T) {
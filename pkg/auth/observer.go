@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+// RejectionObserver is called with the type of every request the middlewares
+// reject, before the response is written, e.g. to count rejections. It must
+// not block.
+type RejectionObserver func(ctx context.Context, errorType AuthErrorType)
+
+// WithRejectionObserver reports the rejections of the AuthMiddleware
+// authentication, Require, RequireRole, RequireOrganization and
+// RequireOwnedPermission middlewares and their Echo and gRPC counterparts.
+func WithRejectionObserver(observer RejectionObserver) MiddlewareOption {
+	return func(am *AuthMiddleware) {
+		am.observer = observer
+	}
+}
+
+// WithAPIKeyRejectionObserver reports the rejections of the API key middleware.
+// Rate-limited requests are reported as ErrorTypeRateLimited.
+func WithAPIKeyRejectionObserver(observer RejectionObserver) APIKeyOption {
+	return func(m *APIKeyMiddleware) {
+		m.observer = observer
+	}
+}
+
+// reject reports a rejection to the observer and on the request's span
+func (am *AuthMiddleware) reject(ctx context.Context, errorType AuthErrorType) {
+	annotateRejected(ctx, errorType)
+	if am.observer != nil {
+		am.observer(ctx, errorType)
+	}
+}
+
+func (m *APIKeyMiddleware) reject(ctx context.Context, errorType AuthErrorType) {
+	annotateRejected(ctx, errorType)
+	if m.observer != nil {
+		m.observer(ctx, errorType)
+	}
+}
+
+// tokenErrorType classifies an error parsing or validating a token
+func tokenErrorType(err error) AuthErrorType {
+	switch {
+	case errors.Is(err, jwt.ErrTokenExpired), errors.Is(err, ErrTokenExpired):
+		return ErrorTypeExpired
+	case errors.Is(err, jwt.ErrTokenMalformed):
+		return ErrorTypeMalformed
+	}
+	return ErrorTypeInvalid
+}
+
+// authErrorType returns the type of an *AuthError, or ErrorTypeInvalid
+func authErrorType(err error) AuthErrorType {
+	var authErr *AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Type
+	}
+	return ErrorTypeInvalid
+}
@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestRejectionObserver(t *testing.T) {
+	var rejections []AuthErrorType
+	observe := func(_ context.Context, errorType AuthErrorType) {
+		rejections = append(rejections, errorType)
+	}
+
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"), WithRejectionObserver(observe))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	expired, err := am.CreateToken(uuid.New(), time.Now().Add(-time.Minute), nil)
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+	reader, err := am.CreateToken(uuid.New(), time.Now().Add(time.Hour), map[string]any{"permissions": []string{"orders:read"}})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	e := echo.New()
+	handler := am.EchoMiddleware()(am.EchoRequirePermission("orders:write")(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	}))
+
+	tests := []struct {
+		name          string
+		authorization string
+		wantStatus    int
+		wantType      AuthErrorType
+	}{
+		{"missing header", "", http.StatusUnauthorized, ErrorTypeMissing},
+		{"not a bearer token", "Basic dXNlcg==", http.StatusUnauthorized, ErrorTypeMalformed},
+		{"malformed token", "Bearer not-a-jwt", http.StatusUnauthorized, ErrorTypeMalformed},
+		{"expired token", "Bearer " + expired, http.StatusUnauthorized, ErrorTypeExpired},
+		{"missing permission", "Bearer " + reader, http.StatusForbidden, ErrorTypePermission},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rejections = nil
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			rec := httptest.NewRecorder()
+			if err := handler(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+
+			if rec.Code != tt.wantStatus {
+				t.Errorf("Expected status %d, got %d", tt.wantStatus, rec.Code)
+			}
+			if !slices.Equal(rejections, []AuthErrorType{tt.wantType}) {
+				t.Errorf("Expected rejection %q, got %v", tt.wantType, rejections)
+			}
+		})
+	}
+}
+
+func TestAPIKeyRejectionObserver(t *testing.T) {
+	store, err := NewAPIKeyStore([]StoredAPIKey{
+		{APIKey: APIKey{ID: "limited", ServiceAccount: "batch", RateLimit: 1}, Hash: HashAPIKey("askey_limited")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API key store: %v", err)
+	}
+
+	var rejections []AuthErrorType
+	m := NewAPIKeyMiddleware(store, WithAPIKeyRejectionObserver(func(_ context.Context, errorType AuthErrorType) {
+		rejections = append(rejections, errorType)
+	}))
+	handler := m.Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	for _, key := range []string{"", "askey_unknown", "askey_limited", "askey_limited"} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		if key != "" {
+			req.Header.Set(DefaultAPIKeyHeader, key)
+		}
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	want := []AuthErrorType{ErrorTypeMissing, ErrorTypeInvalid, ErrorTypeRateLimited}
+	if !slices.Equal(rejections, want) {
+		t.Errorf("Expected rejections %v, got %v", want, rejections)
+	}
+}
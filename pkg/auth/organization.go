@@ -0,0 +1,57 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// RequireOrganization rejects requests unless the token was issued for the
+// organization named by the net/http path wildcard, e.g. "{orgID}".
+func (am *AuthMiddleware) RequireOrganization(param string) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userCtx, ok := r.Context().Value("user").(UserContext)
+			if !ok {
+				am.reject(r.Context(), ErrorTypeUserNotFound)
+				http.Error(w, "User context not found", http.StatusUnauthorized)
+				return
+			}
+
+			if !userCtx.InOrganization(r.PathValue(param)) {
+				am.reject(r.Context(), ErrorTypePermission)
+				http.Error(w, "Token is not issued for this organization", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// EchoRequireOrganization is the Echo counterpart of RequireOrganization, reading an Echo path parameter.
+func (am *AuthMiddleware) EchoRequireOrganization(param string) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userCtx, ok := c.Get("user").(UserContext)
+			if !ok {
+				am.reject(c.Request().Context(), ErrorTypeUserNotFound)
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
+			}
+
+			if !userCtx.InOrganization(c.Param(param)) {
+				am.reject(c.Request().Context(), ErrorTypePermission)
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "Token is not issued for this organization"})
+			}
+
+			return next(c)
+		}
+	}
+}
+
+// InOrganization reports whether the token was issued for the given organization ID.
+func (u UserContext) InOrganization(orgID string) bool {
+	id, err := uuid.Parse(orgID)
+	return err == nil && u.HasOrganization() && id == u.OrgID
+}
@@ -0,0 +1,141 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestOrganizationClaims(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	orgID := uuid.New()
+	token, err := am.CreateToken(uuid.New(), time.Now().Add(time.Hour), map[string]any{
+		"permissions":     []string{"users:read:self"},
+		"org_id":          orgID,
+		"org_role":        "admin",
+		"org_permissions": []string{"org:members:*"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	e := echo.New()
+	var user UserContext
+	handler := am.EchoMiddleware()(func(c echo.Context) error {
+		user, _ = GetUserFromEchoContext(c)
+		return c.NoContent(http.StatusOK)
+	})
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.Header.Set("Authorization", "Bearer "+token)
+	if err := handler(e.NewContext(req, httptest.NewRecorder())); err != nil {
+		t.Fatalf("handler returned error: %v", err)
+	}
+
+	if user.OrgID != orgID || user.OrgRole != "admin" {
+		t.Fatalf("expected org %s with role admin, got %s with %q", orgID, user.OrgID, user.OrgRole)
+	}
+	if !user.InOrganization(orgID.String()) || user.InOrganization(uuid.NewString()) {
+		t.Errorf("InOrganization does not match the token organization")
+	}
+
+	effective := user.EffectivePermissions()
+	if !HasPermission(effective, "org:members:write") || !HasPermission(effective, "users:read:self") {
+		t.Errorf("expected global and organization permissions, got %v", effective)
+	}
+	if Global(Permission("org:members:write")).SatisfiedBy(user.Permissions) {
+		t.Errorf("organization permission satisfied a global requirement")
+	}
+}
+
+func TestEchoRequireOrganization(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	orgID := uuid.New()
+	e := echo.New()
+	handler := am.EchoRequireOrganization("orgID")(
+		am.EchoRequire(InOrganization(Permission("org:members:write")))(func(c echo.Context) error {
+			return c.NoContent(http.StatusOK)
+		}),
+	)
+
+	tests := []struct {
+		name string
+		user UserContext
+		want int
+	}{
+		{"matching organization", UserContext{OrgID: orgID, OrgPermissions: []string{"org:*"}}, http.StatusOK},
+		{"other organization", UserContext{OrgID: uuid.New(), OrgPermissions: []string{"org:*"}}, http.StatusForbidden},
+		{"no organization", UserContext{Permissions: []string{"*"}}, http.StatusForbidden},
+		{"missing permission", UserContext{OrgID: orgID, OrgPermissions: []string{"org:read"}}, http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPost, "/", nil), rec)
+			c.SetParamNames("orgID")
+			c.SetParamValues(orgID.String())
+			c.Set("user", tt.user)
+
+			if err := handler(c); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRequireIgnoresOrganizationPermissionsByDefault(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	// An organization owner can put any permission in a role they define
+	user := UserContext{OrgID: uuid.New(), OrgPermissions: []string{"users:delete", "admin:access"}}
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusOK) }
+
+	tests := []struct {
+		name        string
+		requirement Requirement
+		want        int
+	}{
+		{"plain requirement", Permission("users:delete"), http.StatusForbidden},
+		{"global requirement", Global(Permission("users:delete")), http.StatusForbidden},
+		{"in organization", InOrganization(Permission("users:delete")), http.StatusOK},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodDelete, "/", nil), rec)
+			c.Set("user", user)
+
+			if err := am.EchoRequire(tt.requirement)(ok)(c); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+
+	if user.CanAccessOwned("users:delete", uuid.Nil) {
+		t.Error("organization permission granted access to an owned resource")
+	}
+}
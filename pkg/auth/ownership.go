@@ -0,0 +1,102 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// SelfScope is the trailing permission segment granting access only to resources the user owns.
+const SelfScope = "self"
+
+// OwnerExtractor resolves the ID of the user owning the resource targeted by a request.
+type OwnerExtractor func(r *http.Request) (uuid.UUID, error)
+
+// EchoOwnerExtractor resolves the ID of the user owning the resource targeted by an Echo request.
+type EchoOwnerExtractor func(c echo.Context) (uuid.UUID, error)
+
+// SelfPermission returns the self-scoped variant of a permission, e.g. "posts:write:self".
+func SelfPermission(permission string) string {
+	return permission + PermissionSeparator + SelfScope
+}
+
+// CanAccessOwned reports whether the user may perform permission on a resource
+// owned by ownerID. The unscoped permission always grants access; the
+// self-scoped one only when the user is the owner. Like the Require
+// middlewares, only global permissions count.
+func (u UserContext) CanAccessOwned(permission string, ownerID uuid.UUID) bool {
+	permissions := u.Permissions
+	if HasPermission(permissions, permission) {
+		return true
+	}
+	return ownerID != uuid.Nil && ownerID == u.UserID && HasPermission(permissions, SelfPermission(permission))
+}
+
+// PathValueOwner reads the owner ID from a net/http path wildcard, e.g. "{userID}".
+func PathValueOwner(name string) OwnerExtractor {
+	return func(r *http.Request) (uuid.UUID, error) {
+		return uuid.Parse(r.PathValue(name))
+	}
+}
+
+// EchoParamOwner reads the owner ID from an Echo path parameter, e.g. ":id".
+func EchoParamOwner(name string) EchoOwnerExtractor {
+	return func(c echo.Context) (uuid.UUID, error) {
+		return uuid.Parse(c.Param(name))
+	}
+}
+
+// RequireOwnedPermission allows the request when the user holds permission, or
+// holds its ":self" variant and owns the resource. If the owner cannot be
+// extracted only the unscoped permission is accepted.
+func (am *AuthMiddleware) RequireOwnedPermission(permission string, owner OwnerExtractor) func(http.Handler) http.Handler {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			userCtx, ok := r.Context().Value("user").(UserContext)
+			if !ok {
+				am.reject(r.Context(), ErrorTypeUserNotFound)
+				http.Error(w, "User context not found", http.StatusUnauthorized)
+				return
+			}
+
+			ownerID, err := owner(r)
+			if err != nil {
+				ownerID = uuid.Nil
+			}
+
+			if !userCtx.CanAccessOwned(permission, ownerID) {
+				am.reject(r.Context(), ErrorTypePermission)
+				http.Error(w, "Insufficient permissions", http.StatusForbidden)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	}
+}
+
+// EchoRequireOwnedPermission is the Echo counterpart of RequireOwnedPermission.
+func (am *AuthMiddleware) EchoRequireOwnedPermission(permission string, owner EchoOwnerExtractor) echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userCtx, ok := c.Get("user").(UserContext)
+			if !ok {
+				am.reject(c.Request().Context(), ErrorTypeUserNotFound)
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
+			}
+
+			ownerID, err := owner(c)
+			if err != nil {
+				ownerID = uuid.Nil
+			}
+
+			if !userCtx.CanAccessOwned(permission, ownerID) {
+				am.reject(c.Request().Context(), ErrorTypePermission)
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "Insufficient permissions"})
+			}
+
+			return next(c)
+		}
+	}
+}
@@ -0,0 +1,135 @@
+package auth
+
+import (
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestUserContext_CanAccessOwned(t *testing.T) {
+	userID := uuid.New()
+	otherID := uuid.New()
+
+	tests := []struct {
+		name        string
+		permissions []string
+		ownerID     uuid.UUID
+		want        bool
+	}{
+		{"self scope on own resource", []string{"posts:write:self"}, userID, true},
+		{"self scope on other resource", []string{"posts:write:self"}, otherID, false},
+		{"unscoped on other resource", []string{"posts:write"}, otherID, true},
+		{"wildcard on other resource", []string{"posts:*"}, otherID, true},
+		{"self scope with unknown owner", []string{"posts:write:self"}, uuid.Nil, false},
+		{"unrelated permission", []string{"users:write:self"}, userID, false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := UserContext{UserID: userID, Permissions: tt.permissions}
+			if got := user.CanAccessOwned("posts:write", tt.ownerID); got != tt.want {
+				t.Errorf("CanAccessOwned() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEchoRequireOwnedPermission(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	userID := uuid.New()
+	e := echo.New()
+	handler := am.EchoRequireOwnedPermission("users:write", EchoParamOwner("id"))(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	tests := []struct {
+		name  string
+		param string
+		want  int
+	}{
+		{"own resource", userID.String(), http.StatusOK},
+		{"other resource", uuid.NewString(), http.StatusForbidden},
+		{"invalid param", "not-a-uuid", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec := httptest.NewRecorder()
+			c := e.NewContext(httptest.NewRequest(http.MethodPatch, "/", nil), rec)
+			c.SetParamNames("id")
+			c.SetParamValues(tt.param)
+			c.Set("user", UserContext{UserID: userID, Permissions: []string{"users:write:self"}})
+
+			if err := handler(c); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
+
+func TestRequireOwnedPermission_HTTP(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	userID := uuid.New()
+	token, err := am.CreateToken(userID, time.Now().Add(time.Hour), map[string]any{
+		"permissions": []string{"posts:write:self"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	ownerFromHeader := func(r *http.Request) (uuid.UUID, error) {
+		if v := r.Header.Get("X-Owner"); v != "" {
+			return uuid.Parse(v)
+		}
+		return uuid.Nil, errors.New("owner unknown")
+	}
+
+	mux := http.NewServeMux()
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+	mux.Handle("/users/{userID}/posts", am.ValidateToken(am.RequireOwnedPermission("posts:write", PathValueOwner("userID"))(ok)))
+	mux.Handle("/posts", am.ValidateToken(am.RequireOwnedPermission("posts:write", ownerFromHeader)(ok)))
+
+	tests := []struct {
+		name  string
+		path  string
+		owner string
+		want  int
+	}{
+		{"path param owner", "/users/" + userID.String() + "/posts", "", http.StatusOK},
+		{"path param other", "/users/" + uuid.NewString() + "/posts", "", http.StatusForbidden},
+		{"callback owner", "/posts", userID.String(), http.StatusOK},
+		{"callback error", "/posts", "", http.StatusForbidden},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tt.path, nil)
+			req.Header.Set("Authorization", "Bearer "+token)
+			if tt.owner != "" {
+				req.Header.Set("X-Owner", tt.owner)
+			}
+			rec := httptest.NewRecorder()
+			mux.ServeHTTP(rec, req)
+
+			if rec.Code != tt.want {
+				t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package auth
+
+import "strings"
+
+const (
+	// PermissionSeparator splits a permission into its segments, e.g. "posts:write:self".
+	PermissionSeparator = ":"
+	// PermissionWildcard matches any single segment, or everything below it when trailing.
+	PermissionWildcard = "*"
+)
+
+// MatchPermission reports whether the granted permission satisfies the required one.
+//
+// Permissions are compared segment by segment. A "*" segment in the granted
+// permission matches any segment in the same position, and a granted permission
+// that is a prefix of the required one also covers it, so "posts:*" and "posts"
+// both satisfy "posts:write:self", while "*" satisfies everything. Wildcards in
+// the required permission are not expanded.
+func MatchPermission(granted, required string) bool {
+	if granted == "" || required == "" {
+		return false
+	}
+	if granted == PermissionWildcard || granted == required {
+		return true
+	}
+
+	grantedSegments := strings.Split(granted, PermissionSeparator)
+	requiredSegments := strings.Split(required, PermissionSeparator)
+	if len(grantedSegments) > len(requiredSegments) {
+		return false
+	}
+
+	for i, segment := range grantedSegments {
+		if segment == PermissionWildcard {
+			continue
+		}
+		if segment != requiredSegments[i] {
+			return false
+		}
+	}
+
+	return true
+}
+
+// HasPermission reports whether any of the granted permissions satisfies required.
+func HasPermission(granted []string, required string) bool {
+	for _, g := range granted {
+		if MatchPermission(g, required) {
+			return true
+		}
+	}
+	return false
+}
+
+// Requirement is a permission check evaluated against the permissions granted to a user.
+type Requirement interface {
+	SatisfiedBy(granted []string) bool
+	String() string
+}
+
+// Permission is a Requirement for a single permission string.
+type Permission string
+
+func (p Permission) SatisfiedBy(granted []string) bool {
+	return HasPermission(granted, string(p))
+}
+
+func (p Permission) String() string {
+	return string(p)
+}
+
+type global struct {
+	Requirement
+}
+
+// Global evaluates the requirement against the user's global permissions
+// only. That is the default, so Global only makes it explicit on platform
+// routes; it only takes effect as the outermost requirement.
+func Global(requirement Requirement) Requirement {
+	return global{requirement}
+}
+
+func (g global) String() string {
+	return "global(" + g.Requirement.String() + ")"
+}
+
+type inOrganization struct {
+	Requirement
+}
+
+// InOrganization evaluates the requirement against the user's global
+// permissions plus those of their role in the token's organization. Without
+// it, organization roles can't satisfy a requirement, so a role an
+// organization owner defines never unlocks routes outside the organization.
+// Pair it with RequireOrganization; it only takes effect as the outermost
+// requirement.
+func InOrganization(requirement Requirement) Requirement {
+	return inOrganization{requirement}
+}
+
+func (o inOrganization) String() string {
+	return "in_organization(" + o.Requirement.String() + ")"
+}
+
+// permissionsFor returns the permissions a requirement is evaluated against.
+func permissionsFor(user UserContext, requirement Requirement) []string {
+	if _, ok := requirement.(inOrganization); ok {
+		return user.EffectivePermissions()
+	}
+	return user.Permissions
+}
+
+type anyOf []Requirement
+
+// AnyOf is satisfied when at least one of the requirements is satisfied.
+func AnyOf(requirements ...Requirement) Requirement {
+	return anyOf(requirements)
+}
+
+// AnyPermission is a shorthand for AnyOf over plain permission strings.
+func AnyPermission(permissions ...string) Requirement {
+	return anyOf(toRequirements(permissions))
+}
+
+func (a anyOf) SatisfiedBy(granted []string) bool {
+	for _, r := range a {
+		if r.SatisfiedBy(granted) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a anyOf) String() string {
+	return joinRequirements("any", a)
+}
+
+type allOf []Requirement
+
+// AllOf is satisfied when every requirement is satisfied. An empty AllOf is
+// never satisfied, so a misconfigured route fails closed.
+func AllOf(requirements ...Requirement) Requirement {
+	return allOf(requirements)
+}
+
+// AllPermissions is a shorthand for AllOf over plain permission strings.
+func AllPermissions(permissions ...string) Requirement {
+	return allOf(toRequirements(permissions))
+}
+
+func (a allOf) SatisfiedBy(granted []string) bool {
+	if len(a) == 0 {
+		return false
+	}
+	for _, r := range a {
+		if !r.SatisfiedBy(granted) {
+			return false
+		}
+	}
+	return true
+}
+
+func (a allOf) String() string {
+	return joinRequirements("all", a)
+}
+
+func toRequirements(permissions []string) []Requirement {
+	requirements := make([]Requirement, len(permissions))
+	for i, p := range permissions {
+		requirements[i] = Permission(p)
+	}
+	return requirements
+}
+
+func joinRequirements(op string, requirements []Requirement) string {
+	parts := make([]string, len(requirements))
+	for i, r := range requirements {
+		parts[i] = r.String()
+	}
+	return op + "(" + strings.Join(parts, ", ") + ")"
+}
@@ -0,0 +1,239 @@
+package auth
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+)
+
+func TestMatchPermission(t *testing.T) {
+	tests := []struct {
+		granted  string
+		required string
+		want     bool
+	}{
+		{"posts:write", "posts:write", true},
+		{"*", "posts:write", true},
+		{"*", "users:read:self", true},
+		{"posts:*", "posts:write", true},
+		{"posts:*", "posts:write:self", true},
+		{"posts", "posts:write", true},
+		{"posts:write", "posts:write:self", true},
+		{"*:read", "posts:read", true},
+		{"*:read", "posts:write", false},
+		{"posts:*", "users:write", false},
+		{"posts:write:self", "posts:write", false},
+		{"posts:read", "posts:write", false},
+		{"posts:write", "posts", false},
+		{"post", "posts:write", false},
+		{"posts:", "posts:write", false},
+		{"", "posts:write", false},
+		{"*", "", false},
+		{"posts:write", "posts:*", false},
+		{"posts:*", "posts:*", true},
+	}
+
+	for _, tt := range tests {
+		if got := MatchPermission(tt.granted, tt.required); got != tt.want {
+			t.Errorf("MatchPermission(%q, %q) = %v, want %v", tt.granted, tt.required, got, tt.want)
+		}
+	}
+}
+
+func TestRequirementCombinators(t *testing.T) {
+	granted := []string{"posts:*", "users:read:self"}
+
+	tests := []struct {
+		name        string
+		requirement Requirement
+		want        bool
+	}{
+		{"single permission", Permission("posts:delete"), true},
+		{"any of with one match", AnyPermission("users:write", "posts:read"), true},
+		{"any of without match", AnyPermission("users:write", "users:delete"), false},
+		{"all of satisfied", AllPermissions("posts:read", "users:read:self"), true},
+		{"all of missing one", AllPermissions("posts:read", "users:read"), false},
+		{"empty any of", AnyOf(), false},
+		{"empty all of", AllOf(), false},
+		{"nested", AllOf(Permission("posts:write"), AnyPermission("users:read", "users:read:self")), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.requirement.SatisfiedBy(granted); got != tt.want {
+				t.Errorf("%s.SatisfiedBy(%v) = %v, want %v", tt.requirement, granted, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestEchoRequirePermission_Wildcard(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	e := echo.New()
+	handler := am.EchoRequirePermission("posts:write")(func(c echo.Context) error {
+		return c.NoContent(http.StatusOK)
+	})
+
+	for granted, want := range map[string]int{
+		"posts:*":    http.StatusOK,
+		"*":          http.StatusOK,
+		"posts:read": http.StatusForbidden,
+	} {
+		rec := httptest.NewRecorder()
+		c := e.NewContext(httptest.NewRequest(http.MethodGet, "/", nil), rec)
+		c.Set("user", UserContext{UserID: uuid.New(), Permissions: []string{granted}})
+
+		if err := handler(c); err != nil {
+			t.Fatalf("handler returned error: %v", err)
+		}
+		if rec.Code != want {
+			t.Errorf("granted %q: expected status %d, got %d", granted, want, rec.Code)
+		}
+	}
+}
+
+func TestRequireAllPermissions_HTTP(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	token, err := am.CreateToken(uuid.New(), time.Now().Add(time.Hour), map[string]any{
+		"permissions": []string{"posts:*"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	ok := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) { w.WriteHeader(http.StatusOK) })
+
+	allowed := am.ValidateToken(am.RequireAllPermissions("posts:read", "posts:write")(ok))
+	denied := am.ValidateToken(am.RequireAllPermissions("posts:read", "users:read")(ok))
+
+	for _, tt := range []struct {
+		handler http.Handler
+		want    int
+	}{
+		{allowed, http.StatusOK},
+		{denied, http.StatusForbidden},
+	} {
+		req := httptest.NewRequest(http.MethodGet, "/", nil)
+		req.Header.Set("Authorization", "Bearer "+token)
+		rec := httptest.NewRecorder()
+		tt.handler.ServeHTTP(rec, req)
+
+		if rec.Code != tt.want {
+			t.Errorf("expected status %d, got %d", tt.want, rec.Code)
+		}
+	}
+}
+
+func TestGRPCInterceptors(t *testing.T) {
+	am, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	userID := uuid.New()
+	token, err := am.CreateToken(userID, time.Now().Add(time.Hour), map[string]any{
+		"permissions": []string{"posts:*"},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create token: %v", err)
+	}
+
+	authn := am.GRPCUnaryInterceptor()
+	authz := am.GRPCUnaryRequire(map[string]Requirement{
+		"/test.Service/Write":  Permission("posts:write"),
+		"/test.Service/Delete": Permission("users:delete"),
+	})
+
+	call := func(ctx context.Context, method string) error {
+		info := &grpc.UnaryServerInfo{FullMethod: method}
+		_, err := authn(ctx, nil, info, func(ctx context.Context, req any) (any, error) {
+			return authz(ctx, req, info, func(ctx context.Context, req any) (any, error) {
+				user, ok := GetUserFromContext(ctx)
+				if !ok || user.UserID != userID {
+					t.Errorf("expected user %s in context", userID)
+				}
+				return nil, nil
+			})
+		})
+		return err
+	}
+
+	authed := metadata.NewIncomingContext(context.Background(), metadata.Pairs("authorization", "Bearer "+token))
+
+	if err := call(authed, "/test.Service/Write"); err != nil {
+		t.Errorf("expected Write to be allowed, got %v", err)
+	}
+	if err := call(authed, "/test.Service/Read"); err != nil {
+		t.Errorf("expected unmapped method to be allowed, got %v", err)
+	}
+	if code := status.Code(call(authed, "/test.Service/Delete")); code != codes.PermissionDenied {
+		t.Errorf("expected PermissionDenied, got %v", code)
+	}
+	if code := status.Code(call(context.Background(), "/test.Service/Write")); code != codes.Unauthenticated {
+		t.Errorf("expected Unauthenticated, got %v", code)
+	}
+}
+
+func FuzzMatchPermission(f *testing.F) {
+	for _, seed := range [][2]string{
+		{"*", "posts:write"},
+		{"posts:*", "posts:write:self"},
+		{"posts:write", "posts:write"},
+		{"*:read", "users:read"},
+		{"posts::", "posts::"},
+		{"", ""},
+	} {
+		f.Add(seed[0], seed[1])
+	}
+
+	f.Fuzz(func(t *testing.T, granted, required string) {
+		got := MatchPermission(granted, required)
+
+		if required == "" || granted == "" {
+			if got {
+				t.Fatalf("empty permission matched: %q, %q", granted, required)
+			}
+			return
+		}
+
+		if !MatchPermission(required, required) {
+			t.Fatalf("permission %q does not match itself", required)
+		}
+		if !MatchPermission(PermissionWildcard, required) {
+			t.Fatalf("wildcard does not match %q", required)
+		}
+
+		if !got {
+			return
+		}
+
+		// A match must stay a match when the required permission is narrowed further.
+		if !MatchPermission(granted, required+PermissionSeparator+"self") {
+			t.Fatalf("%q matches %q but not its sub-permission", granted, required)
+		}
+
+		// Every matching non-wildcard grant has no more segments than the requirement.
+		if granted != PermissionWildcard &&
+			strings.Count(granted, PermissionSeparator) > strings.Count(required, PermissionSeparator) {
+			t.Fatalf("%q is more specific than %q but matched", granted, required)
+		}
+	})
+}
@@ -0,0 +1,133 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+// PersonalAccessTokenPrefix starts every personal access token, so secret
+// scanners can recognize leaked ones and the middlewares can tell them from JWTs.
+const PersonalAccessTokenPrefix = "aspat_"
+
+// TokenTypePersonalAccessToken is the token_type reported for personal access tokens.
+const TokenTypePersonalAccessToken = "personal_access_token"
+
+// IsPersonalAccessToken reports whether token has the personal access token prefix.
+func IsPersonalAccessToken(token string) bool {
+	return strings.HasPrefix(token, PersonalAccessTokenPrefix)
+}
+
+// Introspection describes an opaque token, in the RFC 7662 response format.
+// Inactive tokens carry no other fields.
+type Introspection struct {
+	Active    bool   `json:"active"`
+	Subject   string `json:"sub,omitempty"`
+	Scope     string `json:"scope,omitempty"`
+	ExpiresAt int64  `json:"exp,omitempty"`
+	TokenID   string `json:"jti,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+}
+
+// TokenIntrospector resolves opaque tokens such as personal access tokens.
+// The auth-service implements it in-process; other services can use AuthzClient.
+type TokenIntrospector interface {
+	IntrospectToken(ctx context.Context, token string) (*Introspection, error)
+}
+
+// WithPersonalAccessTokens makes the middlewares accept personal access
+// tokens, resolved by introspector. Without it they are rejected.
+func WithPersonalAccessTokens(introspector TokenIntrospector) MiddlewareOption {
+	return func(am *AuthMiddleware) {
+		am.introspector = introspector
+	}
+}
+
+// UserContext returns the context for the token's owner. Its permissions are
+// the token's scopes; it has no roles.
+func (i *Introspection) UserContext() (UserContext, error) {
+	if !i.Active {
+		return UserContext{}, errors.New("token is not active")
+	}
+	userID, err := uuid.Parse(i.Subject)
+	if err != nil {
+		return UserContext{}, errors.New("invalid token subject")
+	}
+
+	userCtx := UserContext{
+		UserID: userID,
+		Claims: jwt.MapClaims{
+			"sub":        i.Subject,
+			"jti":        i.TokenID,
+			"scope":      i.Scope,
+			"token_type": i.TokenType,
+		},
+		Permissions: strings.Fields(i.Scope),
+	}
+	if i.ExpiresAt != 0 {
+		userCtx.Claims["exp"] = jwt.NewNumericDate(time.Unix(i.ExpiresAt, 0))
+	}
+	return userCtx, nil
+}
+
+// IsPersonalAccessToken reports whether the request was authenticated with a
+// personal access token rather than a JWT.
+func (u UserContext) IsPersonalAccessToken() bool {
+	return u.Claims["token_type"] == TokenTypePersonalAccessToken
+}
+
+// introspect resolves a personal access token to its owner's context.
+func (am *AuthMiddleware) introspect(ctx context.Context, token string) (UserContext, error) {
+	if am.introspector == nil {
+		return UserContext{}, errors.New("personal access tokens are not accepted")
+	}
+	introspection, err := am.introspector.IntrospectToken(ctx, token)
+	if err != nil {
+		return UserContext{}, err
+	}
+	return introspection.UserContext()
+}
+
+// RejectPersonalAccessTokens refuses requests authenticated with a personal
+// access token, for routes that manage credentials: a leaked token must not
+// be usable to mint more tokens or take over the account.
+func RejectPersonalAccessTokens(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		userCtx, ok := r.Context().Value("user").(UserContext)
+		if !ok {
+			http.Error(w, "User context not found", http.StatusUnauthorized)
+			return
+		}
+
+		if userCtx.IsPersonalAccessToken() {
+			http.Error(w, "Not allowed with a personal access token", http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// EchoRejectPersonalAccessTokens is the Echo counterpart of RejectPersonalAccessTokens.
+func EchoRejectPersonalAccessTokens() echo.MiddlewareFunc {
+	return func(next echo.HandlerFunc) echo.HandlerFunc {
+		return func(c echo.Context) error {
+			userCtx, ok := c.Get("user").(UserContext)
+			if !ok {
+				return c.JSON(http.StatusUnauthorized, map[string]string{"error": "User context not found"})
+			}
+
+			if userCtx.IsPersonalAccessToken() {
+				return c.JSON(http.StatusForbidden, map[string]string{"error": "Not allowed with a personal access token"})
+			}
+
+			return next(c)
+		}
+	}
+}
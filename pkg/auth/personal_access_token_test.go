@@ -0,0 +1,94 @@
+package auth
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+)
+
+func TestEchoMiddleware_PersonalAccessToken(t *testing.T) {
+	userID := uuid.New()
+	activeToken := PersonalAccessTokenPrefix + "active"
+
+	// The auth-service side of the introspection
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/oauth/introspect" {
+			t.Errorf("unexpected path %s", r.URL.Path)
+		}
+		if got := r.Header.Get("Authorization"); got != "Bearer service-token" {
+			t.Errorf("unexpected authorization header %q", got)
+		}
+
+		introspection := Introspection{Active: false}
+		if r.FormValue("token") == activeToken {
+			introspection = Introspection{
+				Active:    true,
+				Subject:   userID.String(),
+				Scope:     "posts:read posts:write",
+				TokenType: TokenTypePersonalAccessToken,
+			}
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(introspection)
+	}))
+	defer server.Close()
+
+	withPATs, err := NewAuthMiddleware(
+		WithJWTSecret("test-secret-key"),
+		WithPersonalAccessTokens(NewAuthzClient(server.URL, WithServiceToken("service-token"))),
+	)
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+	jwtOnly, err := NewAuthMiddleware(WithJWTSecret("test-secret-key"))
+	if err != nil {
+		t.Fatalf("Failed to create auth middleware: %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		am    *AuthMiddleware
+		token string
+		want  int
+	}{
+		{"active token", withPATs, activeToken, http.StatusOK},
+		{"revoked token", withPATs, PersonalAccessTokenPrefix + "revoked", http.StatusUnauthorized},
+		{"not accepted", jwtOnly, activeToken, http.StatusUnauthorized},
+	}
+
+	e := echo.New()
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var user UserContext
+			handler := tt.am.EchoMiddleware()(func(c echo.Context) error {
+				user, _ = GetUserFromEchoContext(c)
+				return c.NoContent(http.StatusOK)
+			})
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set("Authorization", "Bearer "+tt.token)
+			rec := httptest.NewRecorder()
+			if err := handler(e.NewContext(req, rec)); err != nil {
+				t.Fatalf("handler returned error: %v", err)
+			}
+
+			if rec.Code != tt.want {
+				t.Fatalf("expected status %d, got %d", tt.want, rec.Code)
+			}
+			if tt.want != http.StatusOK {
+				return
+			}
+			if user.UserID != userID || !user.IsPersonalAccessToken() {
+				t.Errorf("unexpected user context %+v", user)
+			}
+			if !slices.Equal(user.Permissions, []string{"posts:read", "posts:write"}) {
+				t.Errorf("expected the scopes as permissions, got %v", user.Permissions)
+			}
+		})
+	}
+}
@@ -0,0 +1,59 @@
+package auth
+
+import (
+	"context"
+
+	"github.com/google/uuid"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// Attributes the middlewares set on the span of the request context, when
+// the service traces requests with OpenTelemetry. The decision is "allow"
+// once a request is authenticated and "deny" when a middleware rejects it,
+// with the AuthErrorType.
+const (
+	SpanAttributeSubject   = "enduser.id"
+	SpanAttributeActor     = "auth.actor"
+	SpanAttributeDecision  = "auth.decision"
+	SpanAttributeErrorType = "auth.error_type"
+)
+
+// annotateAllowed records who the request is authenticated as
+func annotateAllowed(ctx context.Context, userCtx UserContext) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String(SpanAttributeSubject, userCtx.subject()),
+		attribute.String(SpanAttributeDecision, "allow"),
+	)
+	if userCtx.Actor != nil {
+		span.SetAttributes(attribute.String(SpanAttributeActor, userCtx.Actor.Subject))
+	}
+}
+
+// annotateRejected records why the request was rejected. The span's status is
+// left alone: a rejected request is the client's fault, not a failure.
+func annotateRejected(ctx context.Context, errorType AuthErrorType) {
+	span := trace.SpanFromContext(ctx)
+	if !span.IsRecording() {
+		return
+	}
+
+	span.SetAttributes(
+		attribute.String(SpanAttributeDecision, "deny"),
+		attribute.String(SpanAttributeErrorType, string(errorType)),
+	)
+}
+
+// subject is the user ID, or the "sub" claim for API keys and service tokens
+func (u UserContext) subject() string {
+	if u.UserID != uuid.Nil {
+		return u.UserID.String()
+	}
+	subject, _ := u.Claims["sub"].(string)
+	return subject
+}
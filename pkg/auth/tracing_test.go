@@ -0,0 +1,65 @@
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"go.opentelemetry.io/otel/attribute"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+)
+
+func TestAPIKeySpanAnnotations(t *testing.T) {
+	store, err := NewAPIKeyStore([]StoredAPIKey{
+		{APIKey: APIKey{ID: "batch", ServiceAccount: "batch-jobs"}, Hash: HashAPIKey("askey_batch")},
+	})
+	if err != nil {
+		t.Fatalf("Failed to create API key store: %v", err)
+	}
+	handler := NewAPIKeyMiddleware(store).Handler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	recorder := tracetest.NewSpanRecorder()
+	tracer := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(recorder)).Tracer("test")
+
+	tests := []struct {
+		name string
+		key  string
+		want map[attribute.Key]string
+	}{
+		{"valid key", "askey_batch", map[attribute.Key]string{
+			SpanAttributeDecision: "allow",
+			SpanAttributeSubject:  "batch-jobs",
+		}},
+		{"unknown key", "askey_unknown", map[attribute.Key]string{
+			SpanAttributeDecision:  "deny",
+			SpanAttributeErrorType: string(ErrorTypeInvalid),
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.Header.Set(DefaultAPIKeyHeader, tt.key)
+			ctx, span := tracer.Start(req.Context(), tt.name)
+			handler.ServeHTTP(httptest.NewRecorder(), req.WithContext(ctx))
+			span.End()
+
+			ended := recorder.Ended()
+			got := make(map[attribute.Key]string)
+			for _, attr := range ended[len(ended)-1].Attributes() {
+				got[attr.Key] = attr.Value.AsString()
+			}
+			if len(got) != len(tt.want) {
+				t.Errorf("Expected attributes %v, got %v", tt.want, got)
+			}
+			for key, value := range tt.want {
+				if got[key] != value {
+					t.Errorf("Expected %s=%q, got %q", key, value, got[key])
+				}
+			}
+		})
+	}
+}
@@ -36,6 +36,7 @@ const (
 	ErrorTypePermission   AuthErrorType = "permission_denied"
 	ErrorTypeRole         AuthErrorType = "role_denied"
 	ErrorTypeUserNotFound AuthErrorType = "user_not_found"
+	ErrorTypeRateLimited  AuthErrorType = "rate_limited"
 )
 
 func NewAuthError(errorType AuthErrorType, message string) *AuthError {
@@ -47,6 +48,8 @@ func NewAuthError(errorType AuthErrorType, message string) *AuthError {
 		code = http.StatusUnauthorized
 	case ErrorTypeMalformed:
 		code = http.StatusBadRequest
+	case ErrorTypeRateLimited:
+		code = http.StatusTooManyRequests
 	}
 
 	return &AuthError{
@@ -113,12 +116,7 @@ func (am *AuthMiddleware) ValidateServiceToken(tokenString string) (serviceName
 		return "", NewAuthError(ErrorTypeMissing, "Service token is required")
 	}
 
-	token, err := jwt.Parse(tokenString, func(token *jwt.Token) (any, error) {
-		if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
-			return nil, errors.New("unexpected signing method")
-		}
-		return am.jwtSecret, nil
-	})
+	token, err := jwt.Parse(tokenString, am.verificationKey)
 
 	if err != nil {
 		return "", NewAuthError(ErrorTypeInvalid, "Service token is invalid: "+err.Error())
@@ -147,10 +145,19 @@ func (am *AuthMiddleware) RefreshToken(tokenString string, newExpiry time.Time)
 	if err != nil {
 		return "", err
 	}
+	if claims.Actor != nil {
+		return "", NewAuthError(ErrorTypeInvalid, "Impersonation tokens cannot be refreshed")
+	}
 
 	customClaims := map[string]any{
 		"permissions": claims.Permissions,
 		"roles":       claims.Roles,
+		"groups":      claims.Groups,
+	}
+	if claims.OrgID != nil {
+		customClaims["org_id"] = *claims.OrgID
+		customClaims["org_role"] = claims.OrgRole
+		customClaims["org_permissions"] = claims.OrgPermissions
 	}
 
 	return am.CreateToken(claims.UserID, newExpiry, customClaims)
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"errors"
+	"testing"
+	"time"
+)
+
+func TestVerifyWebhook(t *testing.T) {
+	body := []byte(`{"id":"1","type":"user.created"}`)
+	now := time.Now()
+
+	tests := []struct {
+		name      string
+		secret    string
+		signature string
+		body      []byte
+		wantErr   bool
+	}{
+		{name: "valid", secret: "whsec_a", signature: SignWebhook("whsec_a", now, body), body: body},
+		{name: "wrong secret", secret: "whsec_b", signature: SignWebhook("whsec_a", now, body), body: body, wantErr: true},
+		{name: "tampered body", secret: "whsec_a", signature: SignWebhook("whsec_a", now, body), body: []byte(`{}`), wantErr: true},
+		{name: "stale", secret: "whsec_a", signature: SignWebhook("whsec_a", now.Add(-time.Hour), body), body: body, wantErr: true},
+		{name: "malformed", secret: "whsec_a", signature: "v1=abc", body: body, wantErr: true},
+		{name: "missing", secret: "whsec_a", signature: "", body: body, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := VerifyWebhook(tt.secret, tt.signature, tt.body, 0)
+			if tt.wantErr != (err != nil) {
+				t.Fatalf("VerifyWebhook() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if err != nil && !errors.Is(err, ErrInvalidWebhookSignature) {
+				t.Errorf("VerifyWebhook() error = %v, want ErrInvalidWebhookSignature", err)
+			}
+		})
+	}
+}
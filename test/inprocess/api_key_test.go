@@ -0,0 +1,144 @@
+package inprocess
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	apikeydto "github.com/EduardoPPCaldas/auth-service/internal/application/apikey/dto"
+	apikeyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/apikey/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/apikey"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIKeys_VerifiedRemotelyAndLocally(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&organization.Organization{}, &apikey.APIKey{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	organizationRepo := postgresRepo.NewOrganizationRepository(db)
+	apiKeyRepo := postgresRepo.NewAPIKeyRepository(db)
+
+	apiKeyHandler := handlers.NewAPIKeyHandler(
+		apikeyusecases.NewCreateAPIKeyUseCase(apiKeyRepo, organizationRepo, nil),
+		apikeyusecases.NewListAPIKeysUseCase(apiKeyRepo),
+		apikeyusecases.NewRevokeAPIKeyUseCase(apiKeyRepo, nil),
+		apikeyusecases.NewVerifyAPIKeyUseCase(apiKeyRepo),
+		s.auth,
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		APIKey:          apiKeyHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+	authService := httptest.NewServer(s.echo)
+	t.Cleanup(authService.Close)
+
+	ctx := t.Context()
+	adminRole := role.NewAdminRole()
+	require.NoError(t, roleRepo.Create(ctx, adminRole))
+	admin := user.New("admin@example.com", nil)
+	admin.RoleID = &adminRole.ID
+	require.NoError(t, userRepo.Create(ctx, admin))
+	loaded, err := userRepo.FindByID(ctx, admin.ID)
+	require.NoError(t, err)
+	adminToken, err := token.NewTokenGenerator().GenerateToken(loaded)
+	require.NoError(t, err)
+	acme, err := organization.New("Acme", "acme")
+	require.NoError(t, err)
+	require.NoError(t, db.Create(acme).Error)
+
+	var partnerKey apikeydto.APIKeyResponse
+	require.Equal(t, http.StatusCreated, s.post(t, adminToken, "/api/v1/admin/api-keys", apikeydto.CreateAPIKeyRequest{
+		Name:           "partner sync",
+		ServiceAccount: "partner-sync",
+		Permissions:    []string{"orders:read"},
+		AllowedIPs:     []string{"192.0.2.0/24"},
+		RateLimit:      100,
+	}, &partnerKey))
+	assert.True(t, len(partnerKey.Key) > len(auth.APIKeyPrefix))
+
+	orgID := acme.ID.String()
+	var tenantKey apikeydto.APIKeyResponse
+	require.Equal(t, http.StatusCreated, s.post(t, adminToken, "/api/v1/admin/api-keys", apikeydto.CreateAPIKeyRequest{
+		Name:           "acme reports",
+		OrganizationID: &orgID,
+		Permissions:    []string{"reports:read"},
+	}, &tenantKey))
+
+	for name, req := range map[string]apikeydto.CreateAPIKeyRequest{
+		"no binding":   {Name: "x", Permissions: []string{"orders:read"}},
+		"both":         {Name: "x", ServiceAccount: "svc", OrganizationID: &orgID, Permissions: []string{"orders:read"}},
+		"invalid CIDR": {Name: "x", ServiceAccount: "svc", Permissions: []string{"orders:read"}, AllowedIPs: []string{"not-an-ip"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			assert.Equal(t, http.StatusBadRequest, s.post(t, adminToken, "/api/v1/admin/api-keys", req, nil))
+		})
+	}
+
+	// A partner-facing service verifies keys through the auth-service
+	serviceToken, err := s.auth.CreateServiceToken("partner-api", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	remote := auth.NewAPIKeyMiddleware(auth.NewAuthzClient(authService.URL, auth.WithServiceToken(serviceToken)))
+	partnerAPI := echo.New()
+	partnerAPI.IPExtractor = echo.ExtractIPDirect()
+	partnerAPI.GET("/orders", func(c echo.Context) error { return c.NoContent(http.StatusNoContent) },
+		remote.EchoMiddleware(), s.auth.EchoRequire(auth.Permission("orders:read")))
+	callPartnerAPI := func(key, remoteAddr string) int {
+		req := httptest.NewRequest(http.MethodGet, "/orders", nil)
+		req.Header.Set(auth.DefaultAPIKeyHeader, key)
+		req.RemoteAddr = remoteAddr
+		rec := httptest.NewRecorder()
+		partnerAPI.ServeHTTP(rec, req)
+		return rec.Code
+	}
+
+	assert.Equal(t, http.StatusNoContent, callPartnerAPI(partnerKey.Key, "192.0.2.10:5000"))
+	assert.Equal(t, http.StatusForbidden, callPartnerAPI(partnerKey.Key, "198.51.100.10:5000"))
+	assert.Equal(t, http.StatusForbidden, callPartnerAPI(tenantKey.Key, "192.0.2.10:5000"))
+	assert.Equal(t, http.StatusUnauthorized, callPartnerAPI(auth.APIKeyPrefix+"unknown", "192.0.2.10:5000"))
+
+	// Only services may verify keys
+	assert.Equal(t, http.StatusUnauthorized, s.post(t, adminToken, "/api/v1/api-keys/verify", apikeydto.VerifyAPIKeyRequest{Key: partnerKey.Key}, nil))
+
+	// The export is a local store of the usable keys
+	var exported []auth.StoredAPIKey
+	require.Equal(t, http.StatusOK, s.get(t, adminToken, "/api/v1/admin/api-keys/export", &exported))
+	require.Len(t, exported, 2)
+	store, err := auth.NewAPIKeyStore(exported)
+	require.NoError(t, err)
+	grant, err := store.VerifyAPIKey(ctx, tenantKey.Key)
+	require.NoError(t, err)
+	assert.Equal(t, &acme.ID, grant.OrgID)
+	assert.Equal(t, []string{"reports:read"}, grant.Permissions)
+
+	require.Equal(t, http.StatusOK, s.delete(t, adminToken, "/api/v1/admin/api-keys/"+partnerKey.ID))
+	assert.Equal(t, http.StatusNotFound, s.delete(t, adminToken, "/api/v1/admin/api-keys/"+partnerKey.ID))
+	assert.Equal(t, http.StatusUnauthorized, callPartnerAPI(partnerKey.Key, "192.0.2.10:5000"))
+
+	var listed []apikeydto.APIKeyResponse
+	require.Equal(t, http.StatusOK, s.get(t, adminToken, "/api/v1/admin/api-keys", &listed))
+	require.Len(t, listed, 2)
+	for _, key := range listed {
+		assert.Empty(t, key.Key)
+		if key.ID == partnerKey.ID {
+			assert.NotEmpty(t, key.RevokedAt)
+			assert.NotEmpty(t, key.LastUsedAt)
+		}
+	}
+}
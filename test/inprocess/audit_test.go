@@ -0,0 +1,250 @@
+package inprocess
+
+import (
+	"bufio"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	auditdto "github.com/EduardoPPCaldas/auth-service/internal/application/audit/dto"
+	auditservices "github.com/EduardoPPCaldas/auth-service/internal/application/audit/services"
+	auditusecases "github.com/EduardoPPCaldas/auth-service/internal/application/audit/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/audit"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/middleware"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type auditServer struct {
+	*userAdminServer
+	db *gorm.DB
+}
+
+// setupAuditServer serves sign-in and user management, recording to the
+// audit log, and the audit endpoints.
+func setupAuditServer(t *testing.T) *auditServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &domaintoken.RefreshToken{}, &audit.Event{})
+	s := &auditServer{userAdminServer: &userAdminServer{testServer: newTestServer(t)}, db: db}
+	s.echo.Use(middleware.AuditClient())
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	auditRepo := postgresRepo.NewAuditRepository(db)
+	recorder := auditservices.NewRecorder(auditRepo, func(event *audit.Event, err error) {
+		t.Errorf("failed to record %s: %v", event.Action, err)
+	})
+	tokenGenerator := token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, tokenGenerator, newTestPasswordHasher(t), recorder),
+		nil, nil, nil, nil,
+	)
+	userHandler := handlers.NewUserHandler(
+		nil,
+		nil,
+		usecases.NewDisableUserUseCase(s.userRepo, s.refreshTokenService, recorder, nil),
+		usecases.NewEnableUserUseCase(s.userRepo, recorder, nil),
+		nil,
+	)
+	auditHandler := handlers.NewAuditHandler(
+		auditusecases.NewListEventsUseCase(auditRepo),
+		auditusecases.NewExportEventsUseCase(auditRepo),
+		auditusecases.NewVerifyChainUseCase(auditRepo),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:            authHandler,
+		User:            userHandler,
+		Audit:           auditHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+
+	ctx := t.Context()
+	adminRole := role.NewAdminRole()
+	s.userRole = role.NewUserRole()
+	require.NoError(t, roleRepo.Create(ctx, adminRole))
+	require.NoError(t, roleRepo.Create(ctx, s.userRole))
+
+	s.admin = s.createUser(t, "admin@example.com", adminRole)
+	loaded, err := s.userRepo.FindByID(ctx, s.admin.ID)
+	require.NoError(t, err)
+	s.adminToken, err = tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	return s
+}
+
+func (s *auditServer) listEvents(t *testing.T, query string) auditdto.AuditEventPageResponse {
+	var page auditdto.AuditEventPageResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit?"+query, &page))
+	return page
+}
+
+func (s *auditServer) export(t *testing.T, format string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest(http.MethodGet, "/api/v1/admin/audit/export?format="+format, nil)
+	req.Header.Set("Authorization", "Bearer "+s.adminToken)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec
+}
+
+func actions(events []auditdto.AuditEventResponse) []string {
+	names := make([]string, len(events))
+	for i, e := range events {
+		names[i] = e.Action + ":" + e.Outcome
+	}
+	return names
+}
+
+func TestAuditLog_RecordsSecurityEvents(t *testing.T) {
+	s := setupAuditServer(t)
+	alice := s.createUser(t, "alice@example.com", s.userRole)
+
+	require.Equal(t, http.StatusOK, s.login(t, "alice@example.com"))
+	require.Equal(t, http.StatusUnauthorized, s.post(t, "", "/api/v1/auth/login",
+		dto.LoginUserRequest{Email: "alice@example.com", Password: "wrong-password"}, nil))
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, "/api/v1/admin/users/"+alice.ID.String()+"/disable", nil, nil))
+	require.NotEqual(t, http.StatusOK, s.login(t, "alice@example.com"))
+
+	page := s.listEvents(t, "")
+	assert.Equal(t, []string{
+		"auth.login:failure", "user.disable:success", "auth.login:failure", "auth.login:success",
+	}, actions(page.Events), "newest first")
+	disabled := page.Events[1]
+	assert.Equal(t, s.admin.ID.String(), disabled.ActorID)
+	assert.Equal(t, alice.ID.String(), disabled.TargetID)
+	assert.Equal(t, "192.0.2.1", disabled.IP)
+	assert.Equal(t, "alice@example.com", page.Events[2].ActorEmail)
+	assert.NotEmpty(t, page.Events[2].Metadata["error"])
+
+	failures := s.listEvents(t, "action=auth.login&outcome=failure")
+	assert.Len(t, failures.Events, 2)
+	byAdmin := s.listEvents(t, "actor_id="+s.admin.ID.String())
+	assert.Equal(t, []string{"user.disable:success"}, actions(byAdmin.Events))
+
+	first := s.listEvents(t, "limit=3")
+	require.Len(t, first.Events, 3)
+	require.NotZero(t, first.NextBefore)
+	rest := s.listEvents(t, "limit=3&before="+strconv.FormatInt(first.NextBefore, 10))
+	assert.Equal(t, []string{"auth.login:success"}, actions(rest.Events))
+	assert.Zero(t, rest.NextBefore)
+
+	code := s.get(t, s.adminToken, "/api/v1/admin/audit?from=yesterday", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAuditLog_ExportsOldestFirst(t *testing.T) {
+	s := setupAuditServer(t)
+	s.createUser(t, "alice@example.com", s.userRole)
+	require.Equal(t, http.StatusOK, s.login(t, "alice@example.com"))
+	require.Equal(t, http.StatusOK, s.login(t, "admin@example.com"))
+
+	rec := s.export(t, "jsonl")
+	assert.Equal(t, "application/x-ndjson", rec.Header().Get(echo.HeaderContentType))
+	var exported []auditdto.AuditEventResponse
+	scanner := bufio.NewScanner(rec.Body)
+	for scanner.Scan() {
+		var event auditdto.AuditEventResponse
+		require.NoError(t, json.Unmarshal(scanner.Bytes(), &event))
+		exported = append(exported, event)
+	}
+	require.Len(t, exported, 2)
+	assert.Equal(t, "alice@example.com", exported[0].ActorEmail)
+	assert.Equal(t, exported[0].Hash, exported[1].PrevHash, "chained")
+
+	rec = s.export(t, "csv")
+	records, err := csv.NewReader(strings.NewReader(rec.Body.String())).ReadAll()
+	require.NoError(t, err)
+	require.Len(t, records, 3)
+	assert.Equal(t, auditdto.AuditCSVHeader, records[0])
+	assert.Equal(t, "admin@example.com", records[2][4])
+
+	code := s.get(t, s.adminToken, "/api/v1/admin/audit/export?format=xml", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAuditLog_VerifyDetectsTampering(t *testing.T) {
+	s := setupAuditServer(t)
+	s.createUser(t, "alice@example.com", s.userRole)
+	for range 3 {
+		require.Equal(t, http.StatusOK, s.login(t, "alice@example.com"))
+	}
+
+	var result auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit/verify", &result))
+	assert.True(t, result.Valid)
+	assert.Equal(t, int64(3), result.Checked)
+
+	// Postgres rejects updates; SQLite has no trigger, standing in for direct tampering
+	require.NoError(t, s.db.Exec("UPDATE audit_events SET ip = ? WHERE sequence = 2", "203.0.113.9").Error)
+	var tampered auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit/verify", &tampered))
+	assert.False(t, tampered.Valid)
+	assert.Equal(t, int64(2), tampered.BrokenSequence)
+	assert.NotEmpty(t, tampered.Reason)
+
+	// Pruning the oldest events leaves a chain that still verifies
+	require.NoError(t, s.db.Exec("DELETE FROM audit_events WHERE sequence <= 2").Error)
+	var pruned auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit/verify", &pruned))
+	assert.True(t, pruned.Valid)
+	assert.Equal(t, int64(1), pruned.Checked)
+}
+
+func TestAuditLog_VerifyAgainstAnchor(t *testing.T) {
+	s := setupAuditServer(t)
+	s.createUser(t, "alice@example.com", s.userRole)
+	for range 3 {
+		require.Equal(t, http.StatusOK, s.login(t, "alice@example.com"))
+	}
+
+	var result auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit/verify", &result))
+	require.True(t, result.Valid)
+	require.Equal(t, int64(3), result.HeadSequence)
+	anchored := fmt.Sprintf("/api/v1/admin/audit/verify?anchor_sequence=%d&anchor_hash=%s", result.HeadSequence, result.HeadHash)
+
+	var verified auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, anchored, &verified))
+	assert.True(t, verified.Valid)
+
+	// Removing the newest event leaves a chain that verifies on its own, but not against the anchor
+	require.NoError(t, s.db.Exec("DELETE FROM audit_events WHERE sequence = 3").Error)
+	var truncated auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/audit/verify", &truncated))
+	assert.True(t, truncated.Valid)
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, anchored, &truncated))
+	assert.False(t, truncated.Valid)
+	assert.Equal(t, int64(3), truncated.BrokenSequence)
+
+	// A replacement event can't take the anchored event's place
+	require.Equal(t, http.StatusOK, s.login(t, "alice@example.com"))
+	var replaced auditdto.AuditChainResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, anchored, &replaced))
+	assert.False(t, replaced.Valid)
+	assert.Equal(t, int64(3), replaced.BrokenSequence)
+
+	code := s.get(t, s.adminToken, "/api/v1/admin/audit/verify?anchor_sequence=3", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
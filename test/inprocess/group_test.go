@@ -0,0 +1,114 @@
+package inprocess
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/group/dto"
+	groupusecases "github.com/EduardoPPCaldas/auth-service/internal/application/group/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroups_RolesAreInherited(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	groupRepo := postgresRepo.NewGroupRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+
+	groupHandler := handlers.NewGroupHandler(
+		groupusecases.NewCreateGroupUseCase(groupRepo, userRepo),
+		groupusecases.NewListGroupsUseCase(groupRepo),
+		groupusecases.NewGetGroupUseCase(groupRepo),
+		groupusecases.NewDeleteGroupUseCase(groupRepo, userRepo),
+		groupusecases.NewAddGroupMemberUseCase(groupRepo, userRepo),
+		groupusecases.NewRemoveGroupMemberUseCase(groupRepo, userRepo),
+		groupusecases.NewListGroupMembersUseCase(userRepo),
+		groupusecases.NewAssignRoleToGroupUseCase(groupRepo, roleRepo, userRepo),
+		groupusecases.NewRemoveRoleFromGroupUseCase(groupRepo, userRepo),
+		groupusecases.NewListEffectiveRolesUseCase(userRepo),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Group:           groupHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+
+	ctx := t.Context()
+	adminRole, userRole, moderatorRole := role.NewAdminRole(), role.NewUserRole(), role.NewModeratorRole()
+	for _, r := range []*role.Role{adminRole, userRole, moderatorRole} {
+		require.NoError(t, roleRepo.Create(ctx, r))
+	}
+
+	admin := user.New("admin@example.com", nil)
+	admin.RoleID = &adminRole.ID
+	alice := user.New("alice@example.com", nil)
+	alice.RoleID = &userRole.ID
+	require.NoError(t, userRepo.Create(ctx, admin))
+	require.NoError(t, userRepo.Create(ctx, alice))
+
+	tokenFor := func(u *user.User) string {
+		loaded, err := userRepo.FindByID(ctx, u.ID)
+		require.NoError(t, err)
+		accessToken, err := tokenGenerator.GenerateToken(loaded)
+		require.NoError(t, err)
+		return accessToken
+	}
+	adminToken := tokenFor(admin)
+
+	var support dto.GroupResponse
+	code := s.post(t, adminToken, "/api/v1/admin/groups", dto.CreateGroupRequest{Name: "support"}, &support)
+	require.Equal(t, http.StatusCreated, code)
+	groupPath := "/api/v1/admin/groups/" + support.ID
+
+	code = s.post(t, adminToken, groupPath+"/roles", dto.AssignGroupRoleRequest{RoleID: moderatorRole.ID.String()}, nil)
+	require.Equal(t, http.StatusOK, code)
+	code = s.post(t, adminToken, groupPath+"/members", dto.AddGroupMemberRequest{UserID: alice.ID.String()}, nil)
+	require.Equal(t, http.StatusCreated, code)
+
+	// Regular users cannot manage groups
+	code = s.post(t, tokenFor(alice), groupPath+"/members", dto.AddGroupMemberRequest{UserID: admin.ID.String()}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	claims, err := s.auth.ValidateTokenString(tokenFor(alice))
+	require.NoError(t, err)
+	assert.Equal(t, []string{role.RoleUser, role.RoleModerator}, claims.Roles)
+	assert.Equal(t, []string{"support"}, claims.Groups)
+	assert.Contains(t, claims.Permissions, "posts:delete")
+
+	var effective []dto.EffectiveRoleResponse
+	code = s.get(t, adminToken, "/api/v1/admin/users/"+alice.ID.String()+"/roles", &effective)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, effective, 2)
+	assert.Equal(t, dto.EffectiveRoleResponse{
+		RoleID: userRole.ID.String(), Role: role.RoleUser, Permissions: role.UserPermissions, Source: dto.SourceDirect,
+	}, effective[0])
+	assert.Equal(t, role.RoleModerator, effective[1].Role)
+	assert.Equal(t, dto.SourceGroup, effective[1].Source)
+	assert.Equal(t, "support", effective[1].GroupName)
+
+	var members []dto.GroupMemberResponse
+	code = s.get(t, adminToken, groupPath+"/members", &members)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []dto.GroupMemberResponse{{UserID: alice.ID.String(), Email: alice.Email}}, members)
+
+	// Deleting the group takes its roles away
+	code = s.delete(t, adminToken, groupPath)
+	require.Equal(t, http.StatusOK, code)
+	claims, err = s.auth.ValidateTokenString(tokenFor(alice))
+	require.NoError(t, err)
+	assert.Equal(t, []string{role.RoleUser}, claims.Roles)
+	assert.Empty(t, claims.Groups)
+}
@@ -0,0 +1,131 @@
+package inprocess
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/impersonation"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// setupImpersonationServer reuses the user admin fixtures and adds the
+// impersonation and profile routes.
+func setupImpersonationServer(t *testing.T) *userAdminServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t,
+		&user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&organization.Organization{}, &organization.Membership{}, &domaintoken.RefreshToken{},
+		&impersonation.Session{}, &user.PasswordHistoryEntry{},
+	)
+	s := &userAdminServer{testServer: newTestServer(t)}
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	impersonationRepo := postgresRepo.NewImpersonationRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, tokenGenerator, newTestPasswordHasher(t), nil),
+		nil,
+		usecases.NewRefreshTokenUseCase(s.userRepo, tokenGenerator, s.refreshTokenService, time.Hour),
+		nil,
+		nil,
+	)
+	signer := token.NewEmailChangeSigner(testJWTSecret)
+	profileHandler := handlers.NewProfileHandler(
+		usecases.NewGetUserUseCase(s.userRepo),
+		usecases.NewUpdateProfileUseCase(s.userRepo),
+		usecases.NewChangePasswordUseCase(s.userRepo, postgresRepo.NewPasswordHistoryRepository(db), tokenGenerator, s.refreshTokenService, time.Hour, newTestPasswordHasher(t), newTestPasswordPolicy(t, nil), nil, nil),
+		usecases.NewRequestEmailChangeUseCase(s.userRepo, signer, &mailbox{}, "http://app.example.com/email/confirm", time.Hour, newTestPasswordHasher(t)),
+		usecases.NewConfirmEmailChangeUseCase(s.userRepo, signer, nil, nil),
+		nil,
+		nil,
+		nil,
+	)
+	impersonationHandler := handlers.NewImpersonationHandler(
+		usecases.NewImpersonateUserUseCase(s.userRepo, impersonationRepo, tokenGenerator, 15*time.Minute, nil),
+		usecases.NewListImpersonationSessionsUseCase(impersonationRepo),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:            authHandler,
+		Profile:         profileHandler,
+		Impersonation:   impersonationHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+
+	ctx := t.Context()
+	adminRole := role.NewAdminRole()
+	s.userRole = role.NewUserRole()
+	require.NoError(t, roleRepo.Create(ctx, adminRole))
+	require.NoError(t, roleRepo.Create(ctx, s.userRole))
+
+	s.admin = s.createUser(t, "admin@example.com", adminRole)
+	loaded, err := s.userRepo.FindByID(ctx, s.admin.ID)
+	require.NoError(t, err)
+	s.adminToken, err = tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	return s
+}
+
+func TestImpersonation_ActsAsUserAndIsRecorded(t *testing.T) {
+	s := setupImpersonationServer(t)
+	alice := s.createUser(t, "alice@example.com", s.userRole)
+	impersonate := func(userID, reason string, out any) int {
+		return s.post(t, s.adminToken, "/api/v1/admin/users/"+userID+"/impersonate",
+			dto.ImpersonateUserRequest{Reason: reason}, out)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, impersonate(alice.ID.String(), "", nil), "a reason is required")
+	assert.Equal(t, http.StatusBadRequest, impersonate(s.admin.ID.String(), "support ticket 42", nil))
+
+	var response dto.ImpersonationResponse
+	require.Equal(t, http.StatusCreated, impersonate(alice.ID.String(), "support ticket 42", &response))
+	assert.Positive(t, response.ExpiresIn)
+	assert.LessOrEqual(t, response.ExpiresIn, int((15 * time.Minute).Seconds()))
+
+	claims, err := s.auth.ValidateTokenString(response.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, claims.UserID)
+	require.NotNil(t, claims.Actor)
+	assert.Equal(t, s.admin.ID.String(), claims.Actor.Subject)
+	assert.Equal(t, response.SessionID, claims.ID)
+
+	// The token acts as the user but can't change their credentials
+	var profile dto.ProfileResponse
+	require.Equal(t, http.StatusOK, s.get(t, response.AccessToken, "/api/v1/me", &profile))
+	assert.Equal(t, "alice@example.com", profile.Email)
+	code := s.post(t, response.AccessToken, "/api/v1/me/password",
+		dto.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: "new-password-456"}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	var sessions []dto.ImpersonationSessionResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/impersonations?user_id="+alice.ID.String(), &sessions))
+	require.Len(t, sessions, 1)
+	assert.Equal(t, response.SessionID, sessions[0].ID)
+	assert.Equal(t, "admin@example.com", sessions[0].ActorEmail)
+	assert.Equal(t, "support ticket 42", sessions[0].Reason)
+	assert.True(t, sessions[0].Active)
+
+	// Impersonation tokens carry the user's roles, not the admin's
+	code = s.post(t, response.AccessToken, "/api/v1/admin/users/"+alice.ID.String()+"/impersonate",
+		dto.ImpersonateUserRequest{Reason: "support ticket 42"}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+}
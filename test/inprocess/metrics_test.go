@@ -0,0 +1,135 @@
+package inprocess
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	"github.com/EduardoPPCaldas/auth-service/internal/metrics"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	appmiddleware "github.com/EduardoPPCaldas/auth-service/internal/presentation/http/middleware"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type metricsServer struct {
+	testServer
+	userRepo            *postgresRepo.UserRepository
+	refreshTokenService token.Service
+}
+
+// setupMetricsServer wires the auth routes with the instrumentation main uses
+func setupMetricsServer(t *testing.T) *metricsServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{}, &domaintoken.RefreshToken{})
+	require.NoError(t, db.Use(metrics.GormPlugin{}))
+
+	authMiddleware, err := auth.NewAuthMiddleware(auth.WithJWTSecret(testJWTSecret), auth.WithRejectionObserver(metrics.ObserveRejection))
+	require.NoError(t, err)
+	s := &metricsServer{testServer: newTestServer(t)}
+	s.auth = authMiddleware
+	s.echo.Use(appmiddleware.Metrics())
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+	hasher := metrics.InstrumentHasher(newTestPasswordHasher(t), password.Bcrypt)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, tokenGenerator, hasher, nil),
+		nil,
+		usecases.NewRefreshTokenUseCase(s.userRepo, tokenGenerator, s.refreshTokenService, time.Hour),
+		nil,
+		nil,
+	)
+	profileHandler := handlers.NewProfileHandler(usecases.NewGetUserUseCase(s.userRepo), nil, nil, nil, nil, nil, nil, nil)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:           authHandler,
+		Profile:        profileHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+	httphandler.SetupMetricsRoutes(s.echo)
+	return s
+}
+
+func (s *metricsServer) scrape(t *testing.T) string {
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+	require.Equal(t, http.StatusOK, rec.Code)
+	return rec.Body.String()
+}
+
+func TestMetrics_CountAuthenticationOutcomes(t *testing.T) {
+	s := setupMetricsServer(t)
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	encoded := string(hash)
+	alice := user.New("alice@example.com", &encoded)
+	require.NoError(t, s.userRepo.Create(t.Context(), alice))
+
+	loginSuccess := metrics.AuthOperations.WithLabelValues(metrics.OperationLogin, metrics.MethodPassword, metrics.OutcomeSuccess)
+	loginFailure := metrics.AuthOperations.WithLabelValues(metrics.OperationLogin, metrics.MethodPassword, metrics.OutcomeFailure)
+	refreshSuccess := metrics.AuthOperations.WithLabelValues(metrics.OperationRefresh, metrics.MethodRefreshToken, metrics.OutcomeSuccess)
+	successes, failures, refreshes, reuses := testutil.ToFloat64(loginSuccess), testutil.ToFloat64(loginFailure), testutil.ToFloat64(refreshSuccess), testutil.ToFloat64(metrics.RefreshTokenReuse)
+
+	assert.Equal(t, http.StatusOK, s.post(t, "", "/api/v1/auth/login", dto.LoginUserRequest{Email: "alice@example.com", Password: testPassword}, nil))
+	assert.Equal(t, http.StatusUnauthorized, s.post(t, "", "/api/v1/auth/login", dto.LoginUserRequest{Email: "alice@example.com", Password: "wrong-password"}, nil))
+	assert.Equal(t, successes+1, testutil.ToFloat64(loginSuccess))
+	assert.Equal(t, failures+1, testutil.ToFloat64(loginFailure))
+
+	// Presenting a rotated refresh token again is counted as reuse
+	refreshToken, err := s.refreshTokenService.GenerateRefreshToken(t.Context(), alice)
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, s.post(t, "", "/api/v1/auth/refresh", dto.RefreshTokenRequest{RefreshToken: refreshToken}, nil))
+	assert.Equal(t, http.StatusUnauthorized, s.post(t, "", "/api/v1/auth/refresh", dto.RefreshTokenRequest{RefreshToken: refreshToken}, nil))
+	assert.Equal(t, refreshes+1, testutil.ToFloat64(refreshSuccess))
+	assert.Equal(t, reuses+1, testutil.ToFloat64(metrics.RefreshTokenReuse))
+
+	body := s.scrape(t)
+	assert.Contains(t, body, `auth_http_requests_total{method="POST",route="/api/v1/auth/login",status="401"}`)
+	assert.Contains(t, body, `auth_password_hash_duration_seconds_count{algorithm="bcrypt",operation="verify"}`)
+	assert.Contains(t, body, `auth_db_query_duration_seconds_count{operation="query",table="users"}`)
+	assert.Contains(t, body, `auth_db_query_duration_seconds_count{operation="create",table="refresh_tokens"}`)
+}
+
+func TestMetrics_MiddlewareRejectionsAndRouteTemplates(t *testing.T) {
+	s := setupMetricsServer(t)
+	expired, err := s.auth.CreateToken(uuid.New(), time.Now().Add(-time.Minute), nil)
+	require.NoError(t, err)
+
+	missing := metrics.MiddlewareRejections.WithLabelValues(string(auth.ErrorTypeMissing))
+	expiredRejections := metrics.MiddlewareRejections.WithLabelValues(string(auth.ErrorTypeExpired))
+	missingBefore, expiredBefore := testutil.ToFloat64(missing), testutil.ToFloat64(expiredRejections)
+
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/me", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	assert.Equal(t, http.StatusUnauthorized, s.request(t, http.MethodGet, expired, "/api/v1/me", nil, nil))
+	tokenID := uuid.NewString()
+	assert.Equal(t, http.StatusUnauthorized, s.request(t, http.MethodDelete, expired, "/api/v1/me/tokens/"+tokenID, nil, nil))
+
+	assert.Equal(t, missingBefore+1, testutil.ToFloat64(missing))
+	assert.Equal(t, expiredBefore+2, testutil.ToFloat64(expiredRejections))
+
+	// Paths are reported by their route template, not per ID
+	body := s.scrape(t)
+	assert.Contains(t, body, `auth_http_requests_total{method="DELETE",route="/api/v1/me/tokens/:id",status="401"}`)
+	assert.NotContains(t, body, tokenID)
+}
@@ -0,0 +1,217 @@
+package inprocess
+
+import (
+	"net/http"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTokenExchange_DownScopesForAudience(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	policy, err := domaintoken.NewExchangePolicy([]domaintoken.ExchangeClient{
+		{Client: "api-gateway", Audiences: []string{"orders-service"}, Scopes: []string{"orders:*"}},
+		{Client: "orders-service", Audiences: []string{"inventory-service"}, Scopes: []string{"orders:read"}},
+	})
+	require.NoError(t, err)
+
+	// The service only accepts its own tokens; exchanged ones may be exchanged again
+	subjectTokens := s.auth
+	s.auth, err = auth.NewAuthMiddleware(auth.WithJWTSecret(testJWTSecret), auth.WithTokenValidation(auth.TokenValidation{AcceptedAudience: "auth-service"}))
+	require.NoError(t, err)
+	oauthHandler := handlers.NewOAuthHandler(
+		usecases.NewExchangeTokenUseCase(userRepo, tokenGenerator, subjectTokens, policy, 5*time.Minute),
+		nil, nil, nil, nil, nil, nil,
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		OAuth: oauthHandler,
+	})
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	s.echo.GET("/whoami", ok, s.auth.EchoMiddleware())
+
+	ctx := t.Context()
+	clerk := role.New("clerk", []string{"orders:read", "orders:write", "billing:read"})
+	require.NoError(t, roleRepo.Create(ctx, clerk))
+	alice := user.New("alice@example.com", nil)
+	alice.RoleID = &clerk.ID
+	require.NoError(t, userRepo.Create(ctx, alice))
+	loaded, err := userRepo.FindByID(ctx, alice.ID)
+	require.NoError(t, err)
+	subjectToken, err := tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	serviceToken := func(name string) string {
+		tok, err := s.auth.CreateServiceToken(name, time.Now().Add(time.Hour))
+		require.NoError(t, err)
+		return tok
+	}
+	exchange := func(subject, actor, audience, scope string, out any) int {
+		return s.postForm(t, "/oauth/token", url.Values{
+			"grant_type":         {usecases.GrantTypeTokenExchange},
+			"subject_token":      {subject},
+			"subject_token_type": {usecases.TokenTypeAccessToken},
+			"actor_token":        {actor},
+			"actor_token_type":   {usecases.TokenTypeJWT},
+			"audience":           {audience},
+			"scope":              {scope},
+		}, out)
+	}
+
+	var response dto.OAuthTokenResponse
+	require.Equal(t, http.StatusOK, exchange(subjectToken, serviceToken("api-gateway"), "orders-service", "orders:read", &response))
+	assert.Equal(t, usecases.TokenTypeAccessToken, response.IssuedTokenType)
+	assert.Equal(t, "orders:read", response.Scope)
+	assert.LessOrEqual(t, response.ExpiresIn, int((5 * time.Minute).Seconds()))
+
+	ordersService, err := auth.NewAuthMiddleware(
+		auth.WithJWTSecret(testJWTSecret),
+		auth.WithTokenValidation(auth.TokenValidation{RequiredAudience: []string{"orders-service"}}),
+	)
+	require.NoError(t, err)
+	claims, err := ordersService.ValidateTokenString(response.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, claims.UserID)
+	assert.Equal(t, []string{"orders:read"}, claims.Permissions)
+	assert.Empty(t, claims.Roles)
+	assert.Equal(t, &auth.Actor{Subject: "api-gateway"}, claims.Actor)
+
+	// The full-scope token is not meant for the orders service
+	_, err = ordersService.ValidateTokenString(subjectToken)
+	assert.Error(t, err)
+
+	// And the exchanged token is not meant for this service
+	assert.Equal(t, http.StatusNoContent, s.get(t, subjectToken, "/whoami", nil))
+	assert.Equal(t, http.StatusUnauthorized, s.get(t, response.AccessToken, "/whoami", nil))
+
+	// Delegating further nests the previous actor
+	var chained dto.OAuthTokenResponse
+	require.Equal(t, http.StatusOK, exchange(response.AccessToken, serviceToken("orders-service"), "inventory-service", "orders:read", &chained))
+	claims, err = subjectTokens.ValidateTokenString(chained.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, &auth.Actor{Subject: "orders-service", Actor: &auth.Actor{Subject: "api-gateway"}}, claims.Actor)
+
+	for name, tc := range map[string]struct {
+		subject, actor, audience, scope string
+		status                          int
+		code                            string
+	}{
+		"scope not allowed for the client": {subjectToken, serviceToken("api-gateway"), "orders-service", "billing:read", http.StatusBadRequest, usecases.OAuthInvalidScope},
+		"scope not held by the user":       {response.AccessToken, serviceToken("orders-service"), "inventory-service", "orders:read orders:write", http.StatusBadRequest, usecases.OAuthInvalidScope},
+		"audience not allowed":             {subjectToken, serviceToken("api-gateway"), "billing-service", "orders:read", http.StatusBadRequest, usecases.OAuthInvalidTarget},
+		"client without a policy":          {subjectToken, serviceToken("batch-job"), "orders-service", "orders:read", http.StatusBadRequest, usecases.OAuthUnauthorizedClient},
+		"user token as actor":              {subjectToken, subjectToken, "orders-service", "orders:read", http.StatusUnauthorized, usecases.OAuthInvalidClient},
+		"service token as subject":         {serviceToken("api-gateway"), serviceToken("api-gateway"), "orders-service", "orders:read", http.StatusBadRequest, usecases.OAuthInvalidGrant},
+	} {
+		t.Run(name, func(t *testing.T) {
+			var errResponse dto.OAuthErrorResponse
+			assert.Equal(t, tc.status, exchange(tc.subject, tc.actor, tc.audience, tc.scope, &errResponse))
+			assert.Equal(t, tc.code, errResponse.Error)
+		})
+	}
+
+	var errResponse dto.OAuthErrorResponse
+	code := s.postForm(t, "/oauth/token", url.Values{"grant_type": {"password"}}, &errResponse)
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, usecases.OAuthUnsupportedGrantType, errResponse.Error)
+}
+
+func TestDeviceAuthorization_ApprovedDeviceGetsTokenPair(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&domaintoken.RefreshToken{}, &device.Authorization{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	deviceRepo := postgresRepo.NewDeviceRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	refreshTokenService := token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), userRepo, time.Hour)
+	interval := 20 * time.Millisecond
+
+	oauthHandler := handlers.NewOAuthHandler(
+		nil,
+		usecases.NewRequestDeviceAuthorizationUseCase(deviceRepo, "http://app.example.com/device", time.Minute, interval),
+		usecases.NewPollDeviceTokenUseCase(deviceRepo, userRepo, tokenGenerator, refreshTokenService, time.Hour),
+		usecases.NewGetDeviceAuthorizationUseCase(deviceRepo),
+		usecases.NewDecideDeviceAuthorizationUseCase(deviceRepo),
+		nil,
+		nil,
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		OAuth:          oauthHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+
+	alice := user.New("alice@example.com", nil)
+	require.NoError(t, userRepo.Create(t.Context(), alice))
+	aliceToken, err := tokenGenerator.GenerateToken(alice)
+	require.NoError(t, err)
+
+	var started dto.DeviceAuthorizationResponse
+	require.Equal(t, http.StatusOK, s.postForm(t, "/oauth/device_authorization", url.Values{"client_id": {"cli"}}, &started))
+	assert.Equal(t, "http://app.example.com/device", started.VerificationURI)
+	assert.Contains(t, started.VerificationURIComplete, "user_code=")
+
+	poll := func(clientID string) (int, dto.OAuthTokenResponse, dto.OAuthErrorResponse) {
+		var body struct {
+			dto.OAuthTokenResponse
+			dto.OAuthErrorResponse
+		}
+		code := s.postForm(t, "/oauth/token", url.Values{
+			"grant_type":  {usecases.GrantTypeDeviceCode},
+			"device_code": {started.DeviceCode},
+			"client_id":   {clientID},
+		}, &body)
+		return code, body.OAuthTokenResponse, body.OAuthErrorResponse
+	}
+
+	code, _, errResponse := poll("cli")
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, usecases.OAuthAuthorizationPending, errResponse.Error)
+	_, _, errResponse = poll("other-cli")
+	assert.Equal(t, usecases.OAuthInvalidGrant, errResponse.Error)
+
+	// The user types the code in any case, with or without the dash
+	typed := strings.ToLower(strings.ReplaceAll(started.UserCode, "-", ""))
+	var pending dto.DeviceAuthorizationInfoResponse
+	require.Equal(t, http.StatusOK, s.get(t, aliceToken, "/api/v1/device?user_code="+typed, &pending))
+	assert.Equal(t, "cli", pending.ClientID)
+	require.Equal(t, http.StatusOK, s.post(t, aliceToken, "/api/v1/device/approve", dto.DeviceUserCodeRequest{UserCode: typed}, nil))
+	assert.Equal(t, http.StatusBadRequest, s.post(t, aliceToken, "/api/v1/device/deny", dto.DeviceUserCodeRequest{UserCode: typed}, nil))
+
+	time.Sleep(interval)
+	code, tokens, _ := poll("cli")
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, tokens.AccessToken)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	claims, err := s.auth.ValidateTokenString(tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, claims.UserID)
+
+	// Device codes are single use
+	time.Sleep(interval)
+	_, _, errResponse = poll("cli")
+	assert.Equal(t, usecases.OAuthInvalidGrant, errResponse.Error)
+}
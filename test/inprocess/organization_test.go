@@ -0,0 +1,283 @@
+package inprocess
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/mail"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/organization/dto"
+	orgservices "github.com/EduardoPPCaldas/auth-service/internal/application/organization/services"
+	orgusecases "github.com/EduardoPPCaldas/auth-service/internal/application/organization/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	userusecases "github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type organizationServer struct {
+	testServer
+	users          *postgresRepo.UserRepository
+	tokenGenerator token.TokenGenerator
+	mailbox        *mailbox
+}
+
+// mailbox records outgoing mail so tests can follow invitation links.
+type mailbox struct {
+	mu       sync.Mutex
+	messages []mail.Message
+}
+
+func (m *mailbox) Send(_ context.Context, message mail.Message) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.messages = append(m.messages, message)
+	return nil
+}
+
+var linkPattern = regexp.MustCompile(`https?://\S+`)
+
+// lastToken returns the token from the accept link in the latest message to recipient.
+func (m *mailbox) lastToken(t *testing.T, recipient string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To != recipient {
+			continue
+		}
+		link, err := url.Parse(linkPattern.FindString(m.messages[i].Body))
+		require.NoError(t, err)
+		return link.Query().Get("token")
+	}
+	t.Fatalf("no mail sent to %s", recipient)
+	return ""
+}
+
+func setupOrganizationServer(t *testing.T) *organizationServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &organization.Organization{}, &organization.Membership{}, &organization.Invitation{})
+	s := &organizationServer{testServer: newTestServer(t), mailbox: &mailbox{}}
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	organizationRepo := postgresRepo.NewOrganizationRepository(db)
+	invitationRepo := postgresRepo.NewInvitationRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	signer := orgservices.NewInvitationSigner(testJWTSecret)
+
+	organizationHandler := handlers.NewOrganizationHandler(
+		orgusecases.NewCreateOrganizationUseCase(organizationRepo, userRepo),
+		orgusecases.NewListOrganizationsUseCase(organizationRepo),
+		orgusecases.NewSwitchOrganizationUseCase(organizationRepo, userRepo, tokenGenerator),
+		orgusecases.NewAddMemberUseCase(organizationRepo, userRepo, roleRepo),
+		orgusecases.NewRemoveMemberUseCase(organizationRepo),
+		orgusecases.NewListMembersUseCase(organizationRepo),
+		orgusecases.NewCreateOrganizationRoleUseCase(organizationRepo, roleRepo),
+		orgusecases.NewListOrganizationRolesUseCase(roleRepo),
+	)
+
+	invitationHandler := handlers.NewInvitationHandler(
+		orgusecases.NewCreateInvitationUseCase(organizationRepo, invitationRepo, userRepo, roleRepo, signer, s.mailbox, "https://app.example.com/invitations/accept", time.Hour),
+		orgusecases.NewListInvitationsUseCase(invitationRepo),
+		orgusecases.NewRevokeInvitationUseCase(invitationRepo),
+		orgusecases.NewAcceptInvitationUseCase(invitationRepo, organizationRepo, userRepo, userusecases.NewCreateUserUseCase(userRepo, roleRepo, tokenGenerator, newTestPasswordHasher(t), newTestPasswordPolicy(t, nil), nil, nil), signer),
+	)
+
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Organization:           organizationHandler,
+		Invitation:             invitationHandler,
+		AuthMiddleware:         func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		RequireMiddleware:      s.auth.EchoRequire,
+		OrganizationMiddleware: s.auth.EchoRequireOrganization,
+	})
+
+	s.users = userRepo
+	s.tokenGenerator = tokenGenerator
+	return s
+}
+
+func (s *organizationServer) createUser(t *testing.T, email string) (*user.User, string) {
+	u := user.New(email, nil)
+	require.NoError(t, s.users.Create(t.Context(), u))
+	accessToken, err := s.tokenGenerator.GenerateToken(u)
+	require.NoError(t, err)
+	return u, accessToken
+}
+
+func (s *organizationServer) switchTo(t *testing.T, accessToken, orgID string) (string, int) {
+	var response dto.OrganizationTokenResponse
+	code := s.post(t, accessToken, "/api/v1/orgs/"+orgID+"/token", nil, &response)
+	return response.AccessToken, code
+}
+
+func TestOrganizations_Membership(t *testing.T) {
+	s := setupOrganizationServer(t)
+	_, aliceToken := s.createUser(t, "alice@example.com")
+	bob, bobToken := s.createUser(t, "bob@example.com")
+	_, carolToken := s.createUser(t, "carol@example.com")
+	s.createUser(t, "dave@example.com")
+
+	var org dto.OrganizationResponse
+	code := s.post(t, aliceToken, "/api/v1/orgs", dto.CreateOrganizationRequest{Name: "Acme", Slug: "acme"}, &org)
+	require.Equal(t, http.StatusCreated, code)
+	members := "/api/v1/orgs/" + org.ID + "/members"
+
+	// A token without the organization cannot reach its routes
+	code = s.post(t, aliceToken, members, dto.AddMemberRequest{Email: "bob@example.com", Role: organization.RoleMember}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	aliceOrgToken, code := s.switchTo(t, aliceToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	claims, err := s.auth.ValidateTokenString(aliceOrgToken)
+	require.NoError(t, err)
+	assert.Equal(t, org.ID, claims.OrgID.String())
+	assert.Equal(t, organization.RoleOwner, claims.OrgRole)
+
+	code = s.post(t, aliceOrgToken, members, dto.AddMemberRequest{Email: "bob@example.com", Role: organization.RoleMember}, nil)
+	assert.Equal(t, http.StatusCreated, code)
+	code = s.post(t, aliceOrgToken, members, dto.AddMemberRequest{Email: "carol@example.com", Role: organization.RoleAdmin}, nil)
+	assert.Equal(t, http.StatusCreated, code)
+
+	// Members may not manage members, admins may not hand out ownership
+	bobOrgToken, code := s.switchTo(t, bobToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	code = s.post(t, bobOrgToken, members, dto.AddMemberRequest{Email: "dave@example.com", Role: organization.RoleMember}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	carolOrgToken, code := s.switchTo(t, carolToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	code = s.post(t, carolOrgToken, members, dto.AddMemberRequest{Email: "dave@example.com", Role: organization.RoleOwner}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	var listed []dto.MemberResponse
+	code = s.get(t, bobOrgToken, members, &listed)
+	require.Equal(t, http.StatusOK, code)
+	assert.Len(t, listed, 3)
+
+	code = s.delete(t, carolOrgToken, members+"/"+bob.ID.String())
+	assert.Equal(t, http.StatusOK, code)
+	_, code = s.switchTo(t, bobToken, org.ID)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Role names are only unique within an organization
+	var other dto.OrganizationResponse
+	code = s.post(t, bobToken, "/api/v1/orgs", dto.CreateOrganizationRequest{Name: "Globex", Slug: "globex"}, &other)
+	assert.Equal(t, http.StatusCreated, code)
+
+	var orgs []dto.OrganizationResponse
+	code = s.get(t, bobToken, "/api/v1/orgs", &orgs)
+	require.Equal(t, http.StatusOK, code)
+	require.Len(t, orgs, 1)
+	assert.Equal(t, "globex", orgs[0].Slug)
+}
+
+func TestOrganizations_DisabledUserCannotSwitch(t *testing.T) {
+	s := setupOrganizationServer(t)
+	alice, aliceToken := s.createUser(t, "alice@example.com")
+
+	var org dto.OrganizationResponse
+	require.Equal(t, http.StatusCreated, s.post(t, aliceToken, "/api/v1/orgs", dto.CreateOrganizationRequest{Name: "Acme", Slug: "acme"}, &org))
+	disabledAt := time.Now()
+	require.NoError(t, s.users.SetDisabledAt(t.Context(), alice.ID, &disabledAt))
+
+	orgToken, code := s.switchTo(t, aliceToken, org.ID)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Empty(t, orgToken)
+}
+
+func TestOrganizations_RolesCannotUnlockPlatformRoutes(t *testing.T) {
+	s := setupOrganizationServer(t)
+	_, aliceToken := s.createUser(t, "alice@example.com")
+	_, bobToken := s.createUser(t, "bob@example.com")
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	s.echo.DELETE("/platform/users", ok, s.auth.EchoMiddleware(), s.auth.EchoRequire(auth.Permission("users:delete")))
+
+	// Anyone can create an organization and define its roles
+	var org dto.OrganizationResponse
+	require.Equal(t, http.StatusCreated, s.post(t, aliceToken, "/api/v1/orgs", dto.CreateOrganizationRequest{Name: "Acme", Slug: "acme"}, &org))
+	aliceOrgToken, code := s.switchTo(t, aliceToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, http.StatusCreated, s.post(t, aliceOrgToken, "/api/v1/orgs/"+org.ID+"/roles", dto.CreateOrganizationRoleRequest{Name: "wrecker", Permissions: []string{"users:delete"}}, nil))
+	require.Equal(t, http.StatusCreated, s.post(t, aliceOrgToken, "/api/v1/orgs/"+org.ID+"/members", dto.AddMemberRequest{Email: "bob@example.com", Role: "wrecker"}, nil))
+
+	bobOrgToken, code := s.switchTo(t, bobToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, http.StatusForbidden, s.delete(t, bobOrgToken, "/platform/users"))
+}
+
+func TestOrganizations_Invitations(t *testing.T) {
+	s := setupOrganizationServer(t)
+	_, aliceToken := s.createUser(t, "alice@example.com")
+	bob, bobToken := s.createUser(t, "bob@example.com")
+
+	var org dto.OrganizationResponse
+	code := s.post(t, aliceToken, "/api/v1/orgs", dto.CreateOrganizationRequest{Name: "Acme", Slug: "acme"}, &org)
+	require.Equal(t, http.StatusCreated, code)
+	aliceOrgToken, code := s.switchTo(t, aliceToken, org.ID)
+	require.Equal(t, http.StatusOK, code)
+	invitations := "/api/v1/orgs/" + org.ID + "/invitations"
+
+	// An unregistered invitee gets an account when accepting
+	var invitation dto.InvitationResponse
+	code = s.post(t, aliceOrgToken, invitations, dto.CreateInvitationRequest{Email: "new@example.com", Role: organization.RoleAdmin}, &invitation)
+	require.Equal(t, http.StatusCreated, code)
+	assert.Equal(t, organization.InvitationPending, invitation.Status)
+
+	code = s.post(t, aliceOrgToken, invitations, dto.CreateInvitationRequest{Email: "new@example.com", Role: organization.RoleAdmin}, nil)
+	assert.Equal(t, http.StatusBadRequest, code, "duplicate pending invitation")
+
+	newToken := s.mailbox.lastToken(t, "new@example.com")
+	code = s.post(t, "", "/api/v1/invitations/accept", dto.AcceptInvitationRequest{Token: newToken}, nil)
+	assert.Equal(t, http.StatusBadRequest, code, "password required for new accounts")
+
+	var accepted dto.AcceptInvitationResponse
+	code = s.post(t, "", "/api/v1/invitations/accept", dto.AcceptInvitationRequest{Token: newToken, Password: "password123"}, &accepted)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, organization.RoleAdmin, accepted.Role)
+	require.NotEmpty(t, accepted.AccessToken)
+	_, code = s.switchTo(t, accepted.AccessToken, org.ID)
+	assert.Equal(t, http.StatusOK, code)
+
+	code = s.post(t, "", "/api/v1/invitations/accept", dto.AcceptInvitationRequest{Token: newToken, Password: "password123"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code, "invitations are single use")
+
+	// Revoked invitations cannot be accepted by existing users either
+	code = s.post(t, aliceOrgToken, invitations, dto.CreateInvitationRequest{Email: bob.Email, Role: organization.RoleMember}, &invitation)
+	require.Equal(t, http.StatusCreated, code)
+	bobInvite := s.mailbox.lastToken(t, bob.Email)
+	code = s.delete(t, aliceOrgToken, invitations+"/"+invitation.ID)
+	require.Equal(t, http.StatusOK, code)
+	code = s.post(t, "", "/api/v1/invitations/accept", dto.AcceptInvitationRequest{Token: bobInvite}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	code = s.post(t, aliceOrgToken, invitations, dto.CreateInvitationRequest{Email: bob.Email, Role: organization.RoleMember}, nil)
+	require.Equal(t, http.StatusCreated, code)
+	var bobAccepted dto.AcceptInvitationResponse
+	code = s.post(t, "", "/api/v1/invitations/accept", dto.AcceptInvitationRequest{Token: s.mailbox.lastToken(t, bob.Email)}, &bobAccepted)
+	require.Equal(t, http.StatusOK, code)
+	assert.Empty(t, bobAccepted.AccessToken)
+	_, code = s.switchTo(t, bobToken, org.ID)
+	assert.Equal(t, http.StatusOK, code)
+
+	var listed []dto.InvitationResponse
+	code = s.get(t, aliceOrgToken, invitations, &listed)
+	require.Equal(t, http.StatusOK, code)
+	statuses := map[string]int{}
+	for _, i := range listed {
+		statuses[i.Status]++
+	}
+	assert.Equal(t, map[string]int{organization.InvitationAccepted: 2, organization.InvitationRevoked: 1}, statuses)
+}
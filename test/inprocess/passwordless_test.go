@@ -0,0 +1,227 @@
+package inprocess
+
+import (
+	"context"
+	"net/http"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/passwordless"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+const passwordlessMaxAttempts = 3
+
+type passwordlessServer struct {
+	testServer
+	db       *gorm.DB
+	userRepo user.UserRepository
+	roleRepo role.Repository
+	mailbox  *mailbox
+}
+
+func setupPasswordlessServer(t *testing.T, allowSignup bool) *passwordlessServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&domaintoken.RefreshToken{}, &passwordless.Challenge{})
+	s := &passwordlessServer{testServer: newTestServer(t), db: db, mailbox: &mailbox{}}
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	s.roleRepo = postgresRepo.NewRoleRepository(db)
+	challengeRepo := postgresRepo.NewPasswordlessRepository(db)
+	refreshTokenService := token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+
+	passwordlessHandler := handlers.NewPasswordlessHandler(
+		usecases.NewStartPasswordlessLoginUseCase(challengeRepo, s.userRepo, s.mailbox, "http://app.example.com/passwordless", time.Minute, time.Hour, allowSignup),
+		usecases.NewVerifyPasswordlessLoginUseCase(challengeRepo, s.userRepo, s.roleRepo, token.NewTokenGenerator(), refreshTokenService, time.Hour, passwordlessMaxAttempts, allowSignup, nil, nil),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Passwordless: passwordlessHandler,
+	})
+	return s
+}
+
+func (s *passwordlessServer) createUser(t *testing.T, email string) *user.User {
+	u := user.New(email, nil)
+	require.NoError(t, s.userRepo.Create(t.Context(), u))
+	return u
+}
+
+func (s *passwordlessServer) start(t *testing.T, email, method string) {
+	code := s.post(t, "", "/api/v1/auth/passwordless/start", dto.PasswordlessStartRequest{Email: email, Method: method}, nil)
+	require.Equal(t, http.StatusAccepted, code)
+}
+
+func (s *passwordlessServer) verify(t *testing.T, req dto.PasswordlessVerifyRequest) (int, usecases.RefreshTokenResponse) {
+	var response usecases.RefreshTokenResponse
+	code := s.post(t, "", "/api/v1/auth/passwordless/verify", req, &response)
+	return code, response
+}
+
+var loginCodePattern = regexp.MustCompile(`\b\d{6}\b`)
+
+// lastCode returns the sign-in code from the latest message to recipient.
+func (m *mailbox) lastCode(t *testing.T, recipient string) string {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for i := len(m.messages) - 1; i >= 0; i-- {
+		if m.messages[i].To == recipient {
+			return loginCodePattern.FindString(m.messages[i].Body)
+		}
+	}
+	t.Fatalf("no mail sent to %s", recipient)
+	return ""
+}
+
+func TestPasswordless_CodeLogin(t *testing.T) {
+	s := setupPasswordlessServer(t, false)
+	alice := s.createUser(t, "alice@example.com")
+
+	s.start(t, "alice@example.com", "code")
+	loginCode := s.mailbox.lastCode(t, "alice@example.com")
+	require.Len(t, loginCode, 6)
+
+	// A second request within the resend interval sends nothing
+	s.start(t, "alice@example.com", "code")
+	assert.Len(t, s.mailbox.messages, 1)
+
+	code, tokens := s.verify(t, dto.PasswordlessVerifyRequest{Email: "alice@example.com", Code: loginCode})
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, tokens.RefreshToken)
+	userID, err := s.auth.ExtractUserID(tokens.AccessToken)
+	require.NoError(t, err)
+	assert.Equal(t, alice.ID, userID)
+
+	// Codes are single-use
+	code, _ = s.verify(t, dto.PasswordlessVerifyRequest{Email: "alice@example.com", Code: loginCode})
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestPasswordless_CodeStopsWorkingAfterMaxAttempts(t *testing.T) {
+	s := setupPasswordlessServer(t, false)
+	s.createUser(t, "bob@example.com")
+
+	s.start(t, "bob@example.com", "code")
+	loginCode := s.mailbox.lastCode(t, "bob@example.com")
+	wrongCode := "000000"
+	if loginCode == wrongCode {
+		wrongCode = "111111"
+	}
+
+	for range passwordlessMaxAttempts {
+		code, _ := s.verify(t, dto.PasswordlessVerifyRequest{Email: "bob@example.com", Code: wrongCode})
+		require.Equal(t, http.StatusUnauthorized, code)
+	}
+	code, _ := s.verify(t, dto.PasswordlessVerifyRequest{Email: "bob@example.com", Code: loginCode})
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+// lockstepChallengeRepository holds every FindLatestByEmail caller until all
+// of them have read the challenge, so their attempts race.
+type lockstepChallengeRepository struct {
+	passwordless.Repository
+	readers sync.WaitGroup
+}
+
+func (r *lockstepChallengeRepository) FindLatestByEmail(ctx context.Context, email string) (*passwordless.Challenge, error) {
+	challenge, err := r.Repository.FindLatestByEmail(ctx, email)
+	r.readers.Done()
+	r.readers.Wait()
+	return challenge, err
+}
+
+func TestPasswordless_ConcurrentGuessesCannotExceedMaxAttempts(t *testing.T) {
+	s := setupPasswordlessServer(t, false)
+	s.createUser(t, "carol@example.com")
+
+	s.start(t, "carol@example.com", "code")
+	loginCode := s.mailbox.lastCode(t, "carol@example.com")
+	wrongCode := "000000"
+	if loginCode == wrongCode {
+		wrongCode = "111111"
+	}
+
+	guesses := passwordlessMaxAttempts * 3
+	challengeRepo := &lockstepChallengeRepository{Repository: postgresRepo.NewPasswordlessRepository(s.db)}
+	challengeRepo.readers.Add(guesses)
+	refreshTokenService := token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(s.db), s.userRepo, time.Hour)
+	verify := usecases.NewVerifyPasswordlessLoginUseCase(challengeRepo, s.userRepo, s.roleRepo, token.NewTokenGenerator(), refreshTokenService, time.Hour, passwordlessMaxAttempts, false, nil, nil)
+
+	var wg sync.WaitGroup
+	for range guesses {
+		wg.Go(func() {
+			_, err := verify.Execute(context.Background(), usecases.VerifyPasswordlessLoginInput{Email: "carol@example.com", Code: wrongCode})
+			assert.ErrorIs(t, err, usecases.ErrPasswordlessLoginFailed)
+		})
+	}
+	wg.Wait()
+
+	var challenge passwordless.Challenge
+	require.NoError(t, s.db.Where("email = ?", "carol@example.com").First(&challenge).Error)
+	assert.Equal(t, passwordlessMaxAttempts, challenge.Attempts)
+
+	code, _ := s.verify(t, dto.PasswordlessVerifyRequest{Email: "carol@example.com", Code: loginCode})
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestPasswordless_LinkLogin(t *testing.T) {
+	s := setupPasswordlessServer(t, false)
+	s.createUser(t, "carol@example.com")
+	disabled := s.createUser(t, "dave@example.com")
+	disabledAt := time.Now()
+	require.NoError(t, s.userRepo.SetDisabledAt(t.Context(), disabled.ID, &disabledAt))
+
+	s.start(t, "carol@example.com", "link")
+	linkToken := s.mailbox.lastToken(t, "carol@example.com")
+
+	// A link token isn't a code
+	code, _ := s.verify(t, dto.PasswordlessVerifyRequest{Email: "carol@example.com", Code: linkToken})
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, tokens := s.verify(t, dto.PasswordlessVerifyRequest{Token: linkToken})
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, tokens.AccessToken)
+
+	code, _ = s.verify(t, dto.PasswordlessVerifyRequest{Token: linkToken})
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// Disabled accounts and unknown addresses get the same response, but no mail
+	s.start(t, "dave@example.com", "link")
+	s.start(t, "nobody@example.com", "link")
+	assert.Len(t, s.mailbox.messages, 1)
+
+	assert.Equal(t, http.StatusBadRequest, s.post(t, "", "/api/v1/auth/passwordless/verify", dto.PasswordlessVerifyRequest{}, nil))
+}
+
+func TestPasswordless_SignupGetsDefaultRole(t *testing.T) {
+	s := setupPasswordlessServer(t, true)
+	userRole := role.NewUserRole()
+	require.NoError(t, s.roleRepo.Create(t.Context(), userRole))
+
+	s.start(t, "erin@example.com", "code")
+	_, err := s.userRepo.FindByEmail(t.Context(), "erin@example.com")
+	require.Error(t, err, "the account is only created once the code is verified")
+
+	code, _ := s.verify(t, dto.PasswordlessVerifyRequest{Email: "erin@example.com", Code: s.mailbox.lastCode(t, "erin@example.com")})
+	require.Equal(t, http.StatusOK, code)
+
+	erin, err := s.userRepo.FindByEmail(t.Context(), "erin@example.com")
+	require.NoError(t, err)
+	require.NotNil(t, erin.RoleID)
+	assert.Equal(t, userRole.ID, *erin.RoleID)
+	assert.False(t, erin.HasPassword())
+}
@@ -0,0 +1,204 @@
+package inprocess
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	orgdto "github.com/EduardoPPCaldas/auth-service/internal/application/organization/dto"
+	orgusecases "github.com/EduardoPPCaldas/auth-service/internal/application/organization/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/device"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPersonalAccessToken_ScopedUntilRevoked(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&domaintoken.PersonalAccessToken{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	patRepo := postgresRepo.NewPersonalAccessTokenRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	introspect := usecases.NewIntrospectTokenUseCase(patRepo, userRepo)
+
+	var err error
+	s.auth, err = auth.NewAuthMiddleware(auth.WithJWTSecret(testJWTSecret), auth.WithPersonalAccessTokens(introspect))
+	require.NoError(t, err)
+
+	profileHandler := handlers.NewProfileHandler(
+		usecases.NewGetUserUseCase(userRepo),
+		nil,
+		nil,
+		nil,
+		nil,
+		usecases.NewCreatePersonalAccessTokenUseCase(userRepo, patRepo),
+		usecases.NewListPersonalAccessTokensUseCase(patRepo),
+		usecases.NewRevokePersonalAccessTokenUseCase(patRepo),
+	)
+	oauthHandler := handlers.NewOAuthHandler(nil, nil, nil, nil, nil, introspect, s.auth)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Profile:        profileHandler,
+		OAuth:          oauthHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+	ok := func(c echo.Context) error { return c.NoContent(http.StatusNoContent) }
+	s.echo.GET("/orders", ok, s.auth.EchoMiddleware(), s.auth.EchoRequire(auth.Permission("orders:read")))
+	s.echo.POST("/orders", ok, s.auth.EchoMiddleware(), s.auth.EchoRequire(auth.Permission("orders:write")))
+
+	ctx := t.Context()
+	clerk := role.New("clerk", []string{"orders:read", "orders:write"})
+	require.NoError(t, roleRepo.Create(ctx, clerk))
+	alice := user.New("alice@example.com", nil)
+	alice.RoleID = &clerk.ID
+	require.NoError(t, userRepo.Create(ctx, alice))
+	loaded, err := userRepo.FindByID(ctx, alice.ID)
+	require.NoError(t, err)
+	aliceToken, err := tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	var created dto.PersonalAccessTokenResponse
+	require.Equal(t, http.StatusCreated, s.post(t, aliceToken, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{
+		Name:   "ci",
+		Scopes: []string{"orders:read"},
+	}, &created))
+	assert.True(t, auth.IsPersonalAccessToken(created.Token))
+
+	// Scopes beyond the user's permissions and past expiries are refused
+	past := time.Now().Add(-time.Hour)
+	assert.Equal(t, http.StatusBadRequest, s.post(t, aliceToken, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{Name: "admin", Scopes: []string{"users:delete"}}, nil))
+	assert.Equal(t, http.StatusBadRequest, s.post(t, aliceToken, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{Name: "old", Scopes: []string{"orders:read"}, ExpiresAt: &past}, nil))
+
+	// The token acts as alice, but only within its scopes
+	var profile dto.ProfileResponse
+	require.Equal(t, http.StatusOK, s.get(t, created.Token, "/api/v1/me", &profile))
+	assert.Equal(t, alice.ID.String(), profile.ID)
+	assert.Equal(t, http.StatusNoContent, s.get(t, created.Token, "/orders", nil))
+	assert.Equal(t, http.StatusForbidden, s.post(t, created.Token, "/orders", nil, nil))
+
+	// A token can't mint more tokens
+	assert.Equal(t, http.StatusForbidden, s.post(t, created.Token, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{Name: "more", Scopes: []string{"orders:read"}}, nil))
+
+	var listed []dto.PersonalAccessTokenResponse
+	require.Equal(t, http.StatusOK, s.get(t, aliceToken, "/api/v1/me/tokens", &listed))
+	require.Len(t, listed, 1)
+	assert.Empty(t, listed[0].Token)
+	assert.NotEmpty(t, listed[0].LastUsedAt)
+
+	// Other services introspect with their service token
+	serviceToken, err := s.auth.CreateServiceToken("orders-service", time.Now().Add(time.Hour))
+	require.NoError(t, err)
+	introspectAs := func(bearer string, out any) int {
+		return s.postFormAs(t, bearer, "/oauth/introspect", url.Values{"token": {created.Token}}, out)
+	}
+	var introspection auth.Introspection
+	require.Equal(t, http.StatusOK, introspectAs(serviceToken, &introspection))
+	assert.True(t, introspection.Active)
+	assert.Equal(t, alice.ID.String(), introspection.Subject)
+	assert.Equal(t, "orders:read", introspection.Scope)
+	assert.Equal(t, http.StatusUnauthorized, introspectAs(aliceToken, &auth.Introspection{}))
+
+	require.Equal(t, http.StatusOK, s.delete(t, aliceToken, "/api/v1/me/tokens/"+created.ID))
+	assert.Equal(t, http.StatusNotFound, s.delete(t, aliceToken, "/api/v1/me/tokens/"+created.ID))
+	assert.Equal(t, http.StatusUnauthorized, s.get(t, created.Token, "/api/v1/me", nil))
+	introspection = auth.Introspection{}
+	require.Equal(t, http.StatusOK, introspectAs(serviceToken, &introspection))
+	assert.False(t, introspection.Active)
+
+	// Disabled users can't mint tokens with an access token issued before
+	disabledAt := time.Now()
+	require.NoError(t, userRepo.SetDisabledAt(ctx, alice.ID, &disabledAt))
+	assert.Equal(t, http.StatusForbidden, s.post(t, aliceToken, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{Name: "ci", Scopes: []string{"orders:read"}}, nil))
+}
+
+func TestPersonalAccessToken_CannotMintFullTokens(t *testing.T) {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&domaintoken.PersonalAccessToken{}, &domaintoken.RefreshToken{}, &device.Authorization{},
+		&organization.Organization{}, &organization.Membership{})
+	s := newTestServer(t)
+
+	userRepo := postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	patRepo := postgresRepo.NewPersonalAccessTokenRepository(db)
+	deviceRepo := postgresRepo.NewDeviceRepository(db)
+	organizationRepo := postgresRepo.NewOrganizationRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	refreshTokenService := token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), userRepo, time.Hour)
+	introspect := usecases.NewIntrospectTokenUseCase(patRepo, userRepo)
+
+	var err error
+	s.auth, err = auth.NewAuthMiddleware(auth.WithJWTSecret(testJWTSecret), auth.WithPersonalAccessTokens(introspect))
+	require.NoError(t, err)
+
+	profileHandler := handlers.NewProfileHandler(usecases.NewGetUserUseCase(userRepo), nil, nil, nil, nil,
+		usecases.NewCreatePersonalAccessTokenUseCase(userRepo, patRepo), nil, nil)
+	oauthHandler := handlers.NewOAuthHandler(
+		nil,
+		usecases.NewRequestDeviceAuthorizationUseCase(deviceRepo, "http://app.example.com/device", time.Minute, time.Millisecond),
+		usecases.NewPollDeviceTokenUseCase(deviceRepo, userRepo, tokenGenerator, refreshTokenService, time.Hour),
+		usecases.NewGetDeviceAuthorizationUseCase(deviceRepo),
+		usecases.NewDecideDeviceAuthorizationUseCase(deviceRepo),
+		nil,
+		nil,
+	)
+	organizationHandler := handlers.NewOrganizationHandler(
+		orgusecases.NewCreateOrganizationUseCase(organizationRepo, userRepo),
+		nil,
+		orgusecases.NewSwitchOrganizationUseCase(organizationRepo, userRepo, tokenGenerator),
+		nil, nil, nil, nil, nil,
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Profile:                profileHandler,
+		OAuth:                  oauthHandler,
+		Organization:           organizationHandler,
+		AuthMiddleware:         func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		RequireMiddleware:      s.auth.EchoRequire,
+		OrganizationMiddleware: s.auth.EchoRequireOrganization,
+	})
+
+	ctx := t.Context()
+	reader := role.New("reader", []string{"posts:read"})
+	require.NoError(t, roleRepo.Create(ctx, reader))
+	alice := user.New("alice@example.com", nil)
+	alice.RoleID = &reader.ID
+	require.NoError(t, userRepo.Create(ctx, alice))
+	loaded, err := userRepo.FindByID(ctx, alice.ID)
+	require.NoError(t, err)
+	aliceToken, err := tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+	var pat dto.PersonalAccessTokenResponse
+	require.Equal(t, http.StatusCreated, s.post(t, aliceToken, "/api/v1/me/tokens", dto.CreatePersonalAccessTokenRequest{
+		Name:   "harmless",
+		Scopes: []string{"posts:read"},
+	}, &pat))
+
+	// Approving a device login it started would give the token holder a full token pair
+	var started dto.DeviceAuthorizationResponse
+	require.Equal(t, http.StatusOK, s.postForm(t, "/oauth/device_authorization", url.Values{"client_id": {"cli"}}, &started))
+	approval := dto.DeviceUserCodeRequest{UserCode: started.UserCode}
+	assert.Equal(t, http.StatusForbidden, s.post(t, pat.Token, "/api/v1/device/approve", approval, nil))
+	assert.Equal(t, http.StatusOK, s.post(t, aliceToken, "/api/v1/device/approve", approval, nil))
+
+	// So would switching to an organization
+	var org orgdto.OrganizationResponse
+	require.Equal(t, http.StatusCreated, s.post(t, aliceToken, "/api/v1/orgs", orgdto.CreateOrganizationRequest{Name: "Acme", Slug: "acme"}, &org))
+	assert.Equal(t, http.StatusForbidden, s.post(t, pat.Token, "/api/v1/orgs/"+org.ID+"/token", nil, nil))
+	assert.Equal(t, http.StatusOK, s.post(t, aliceToken, "/api/v1/orgs/"+org.ID+"/token", nil, nil))
+}
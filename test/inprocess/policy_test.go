@@ -0,0 +1,174 @@
+package inprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/policy/dto"
+	policyusecases "github.com/EduardoPPCaldas/auth-service/internal/application/policy/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/policy"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/policy/celeval"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type policyServer struct {
+	testServer
+	userRepo   user.UserRepository
+	groupRepo  group.Repository
+	policyRepo policy.Repository
+}
+
+func setupPolicyServer(t *testing.T) *policyServer {
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{}, &policy.Policy{})
+	s := &policyServer{
+		testServer: newTestServer(t),
+		userRepo:   postgresRepo.NewUserRepository(db),
+		groupRepo:  postgresRepo.NewGroupRepository(db),
+		policyRepo: postgresRepo.NewPolicyRepository(db),
+	}
+
+	ipExtractor, err := httphandler.IPExtractor(nil)
+	require.NoError(t, err)
+	s.echo.IPExtractor = ipExtractor
+
+	evaluator, err := celeval.NewEvaluator()
+	require.NoError(t, err)
+	policyHandler := handlers.NewPolicyHandler(
+		policyusecases.NewCreatePolicyUseCase(s.policyRepo, s.userRepo, evaluator),
+		policyusecases.NewUpdatePolicyUseCase(s.policyRepo, s.userRepo, evaluator),
+		policyusecases.NewDeletePolicyUseCase(s.policyRepo, s.userRepo),
+		policyusecases.NewListPoliciesUseCase(s.policyRepo),
+		policyusecases.NewGetPolicyUseCase(s.policyRepo),
+		policyusecases.NewCheckAuthorizationUseCase(s.policyRepo, s.userRepo, evaluator),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Policy:         policyHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+	return s
+}
+
+// check asks for a decision with the given X-Forwarded-For header, if any.
+func (s *policyServer) check(t *testing.T, token, forwardedFor string, req dto.CheckRequest) (int, dto.CheckResponse) {
+	payload, err := json.Marshal(req)
+	require.NoError(t, err)
+
+	httpReq := httptest.NewRequest(http.MethodPost, "/api/v1/authz/check", bytes.NewReader(payload))
+	httpReq.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	if forwardedFor != "" {
+		httpReq.Header.Set(echo.HeaderXForwardedFor, forwardedFor)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httpReq)
+
+	var response dto.CheckResponse
+	if rec.Code == http.StatusOK {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &response))
+	}
+	return rec.Code, response
+}
+
+func TestAuthzCheck_SubjectComesFromStoredIdentity(t *testing.T) {
+	s := setupPolicyServer(t)
+	ctx := t.Context()
+
+	alice := user.New("alice@example.com", nil)
+	bob := user.New("bob@example.com", nil)
+	require.NoError(t, s.userRepo.Create(ctx, alice))
+	require.NoError(t, s.userRepo.Create(ctx, bob))
+	finance := group.New("finance", "")
+	require.NoError(t, s.groupRepo.Create(ctx, finance))
+	require.NoError(t, s.groupRepo.AddMember(ctx, finance.ID, alice.ID))
+
+	require.NoError(t, s.policyRepo.Create(ctx, policy.New("finance-reads-org-reports", "", "reports:read", "report", policy.EffectAllow,
+		`"finance" in subject.groups && subject.org_id == resource.org_id`)))
+	require.NoError(t, s.policyRepo.Create(ctx, policy.New("deny-blocked-ip", "", "*", "", policy.EffectDeny,
+		`env.ip == "203.0.113.7"`)))
+
+	acme, globex := uuid.New(), uuid.New()
+	orgToken := func(u *user.User, orgID uuid.UUID, permissions ...string) string {
+		token, err := s.auth.CreateToken(u.ID, time.Now().Add(time.Hour), map[string]any{"org_id": orgID, "permissions": permissions})
+		require.NoError(t, err)
+		return token
+	}
+	acmeReport := func(subject *dto.SubjectRequest) dto.CheckRequest {
+		return dto.CheckRequest{
+			Subject:  subject,
+			Action:   "reports:read",
+			Resource: dto.ResourceRequest{Type: "report", ID: "q3", Attributes: map[string]any{"org_id": acme.String()}},
+		}
+	}
+
+	// The organization comes from the token and the groups from the database
+	code, decision := s.check(t, orgToken(alice, acme), "", acmeReport(nil))
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, decision.Allowed, decision.Reason)
+
+	code, decision = s.check(t, orgToken(alice, globex), "", acmeReport(nil))
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, decision.Allowed, decision.Reason)
+
+	code, decision = s.check(t, orgToken(bob, acme), "", acmeReport(nil))
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, decision.Allowed, decision.Reason)
+
+	// Describing yourself requires authz:check
+	code, _ = s.check(t, orgToken(bob, acme), "", acmeReport(&dto.SubjectRequest{Attributes: map[string]any{"groups": []string{"finance"}}}))
+	assert.Equal(t, http.StatusForbidden, code)
+
+	// Trusted callers may add attributes, but stored values still win
+	service := s.token(t, policy.PermissionCheckOthers)
+	code, decision = s.check(t, service, "", acmeReport(&dto.SubjectRequest{ID: alice.ID.String(), Attributes: map[string]any{"org_id": acme.String()}}))
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, decision.Allowed, decision.Reason)
+
+	code, decision = s.check(t, service, "", acmeReport(&dto.SubjectRequest{ID: bob.ID.String(), Attributes: map[string]any{"org_id": acme.String(), "groups": []string{"finance"}}}))
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, decision.Allowed, decision.Reason)
+
+	// A forwarded address from an untrusted peer doesn't change env.ip
+	code, decision = s.check(t, orgToken(alice, acme), "203.0.113.7", acmeReport(nil))
+	require.Equal(t, http.StatusOK, code)
+	assert.True(t, decision.Allowed, decision.Reason)
+}
+
+func TestIPExtractor_TrustsOnlyConfiguredProxies(t *testing.T) {
+	extractor, err := httphandler.IPExtractor([]string{"10.0.0.0/8"})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name         string
+		remoteAddr   string
+		forwardedFor string
+		want         string
+	}{
+		{"trusted proxy", "10.1.2.3:4000", "198.51.100.4", "198.51.100.4"},
+		{"client spoofs through trusted proxy", "10.1.2.3:4000", "203.0.113.7, 198.51.100.4", "198.51.100.4"},
+		{"untrusted peer", "192.0.2.1:4000", "198.51.100.4", "192.0.2.1"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			req.RemoteAddr = tt.remoteAddr
+			req.Header.Set(echo.HeaderXForwardedFor, tt.forwardedFor)
+			assert.Equal(t, tt.want, extractor(req))
+		})
+	}
+
+	_, err = httphandler.IPExtractor([]string{"not-an-ip"})
+	assert.Error(t, err)
+}
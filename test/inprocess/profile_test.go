@@ -0,0 +1,246 @@
+package inprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type profileServer struct {
+	testServer
+	userRepo            *postgresRepo.UserRepository
+	tokenGenerator      token.TokenGenerator
+	refreshTokenService token.Service
+	mailbox             *mailbox
+}
+
+func setupProfileServer(t *testing.T) *profileServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{}, &domaintoken.RefreshToken{}, &user.PasswordHistoryEntry{})
+	s := &profileServer{testServer: newTestServer(t), mailbox: &mailbox{}}
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	s.tokenGenerator = token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+	signer := token.NewEmailChangeSigner(testJWTSecret)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, s.tokenGenerator, newTestPasswordHasher(t), nil),
+		nil,
+		usecases.NewRefreshTokenUseCase(s.userRepo, s.tokenGenerator, s.refreshTokenService, time.Hour),
+		nil,
+		nil,
+	)
+	profileHandler := handlers.NewProfileHandler(
+		usecases.NewGetUserUseCase(s.userRepo),
+		usecases.NewUpdateProfileUseCase(s.userRepo),
+		usecases.NewChangePasswordUseCase(s.userRepo, postgresRepo.NewPasswordHistoryRepository(db), s.tokenGenerator, s.refreshTokenService, time.Hour, newTestPasswordHasher(t), newTestPasswordPolicy(t, nil), nil, nil),
+		usecases.NewRequestEmailChangeUseCase(s.userRepo, signer, s.mailbox, "http://app.example.com/email/confirm", time.Hour, newTestPasswordHasher(t)),
+		usecases.NewConfirmEmailChangeUseCase(s.userRepo, signer, nil, nil),
+		nil,
+		nil,
+		nil,
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:           authHandler,
+		Profile:        profileHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+	return s
+}
+
+// createUser registers a user with testPassword and returns them with an access token.
+func (s *profileServer) createUser(t *testing.T, email string) (*user.User, string) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	password := string(hash)
+
+	u := user.New(email, &password)
+	require.NoError(t, s.userRepo.Create(t.Context(), u))
+	accessToken, err := s.tokenGenerator.GenerateToken(u)
+	require.NoError(t, err)
+	return u, accessToken
+}
+
+func (s *profileServer) login(t *testing.T, email, password string) int {
+	return s.post(t, "", "/api/v1/auth/login", dto.LoginUserRequest{Email: email, Password: password}, nil)
+}
+
+func (s *profileServer) refresh(t *testing.T, refreshToken string) int {
+	return s.post(t, "", "/api/v1/auth/refresh", dto.RefreshTokenRequest{RefreshToken: refreshToken}, nil)
+}
+
+func TestProfile_GetAndUpdate(t *testing.T) {
+	s := setupProfileServer(t)
+	_, accessToken := s.createUser(t, "alice@example.com")
+	patch := func(body map[string]any, out any) int {
+		return s.request(t, http.MethodPatch, accessToken, "/api/v1/me", body, out)
+	}
+
+	var profile dto.ProfileResponse
+	require.Equal(t, http.StatusOK, s.get(t, accessToken, "/api/v1/me", &profile))
+	assert.Equal(t, "alice@example.com", profile.Email)
+	assert.True(t, profile.HasPassword)
+
+	code := patch(map[string]any{
+		"display_name": "Alice",
+		"avatar_url":   "https://example.com/alice.png",
+		"locale":       "pt-BR",
+		"timezone":     "America/Sao_Paulo",
+	}, &profile)
+	require.Equal(t, http.StatusOK, code)
+
+	// Omitted fields are kept and empty strings clear
+	require.Equal(t, http.StatusOK, patch(map[string]any{"locale": ""}, &profile))
+	require.Equal(t, http.StatusOK, s.get(t, accessToken, "/api/v1/me", &profile))
+	assert.Equal(t, "Alice", profile.DisplayName)
+	assert.Equal(t, "https://example.com/alice.png", profile.AvatarURL)
+	assert.Empty(t, profile.Locale)
+	assert.Equal(t, "America/Sao_Paulo", profile.Timezone)
+
+	for _, invalid := range []map[string]any{
+		{"timezone": "Mars/Olympus_Mons"},
+		{"timezone": "Local"},
+		{"locale": "not a locale"},
+		{"avatar_url": "javascript:alert(1)"},
+	} {
+		assert.Equal(t, http.StatusBadRequest, patch(invalid, nil), "%v", invalid)
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, s.get(t, "", "/api/v1/me", nil))
+}
+
+func TestProfile_ChangePasswordRevokesOtherSessions(t *testing.T) {
+	s := setupProfileServer(t)
+	alice, accessToken := s.createUser(t, "alice@example.com")
+	otherSession, err := s.refreshTokenService.GenerateRefreshToken(t.Context(), alice)
+	require.NoError(t, err)
+
+	code := s.post(t, accessToken, "/api/v1/me/password",
+		dto.ChangePasswordRequest{CurrentPassword: "wrong-password", NewPassword: "new-password-456"}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+
+	var tokens usecases.RefreshTokenResponse
+	code = s.post(t, accessToken, "/api/v1/me/password",
+		dto.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: "new-password-456"}, &tokens)
+	require.Equal(t, http.StatusOK, code)
+	assert.NotEmpty(t, tokens.AccessToken)
+
+	assert.Equal(t, http.StatusUnauthorized, s.refresh(t, otherSession))
+	assert.Equal(t, http.StatusOK, s.refresh(t, tokens.RefreshToken))
+	assert.Equal(t, http.StatusUnauthorized, s.login(t, "alice@example.com", testPassword))
+	assert.Equal(t, http.StatusOK, s.login(t, "alice@example.com", "new-password-456"))
+}
+
+func TestProfile_ChangePasswordRejectsDisabledUser(t *testing.T) {
+	s := setupProfileServer(t)
+	alice, accessToken := s.createUser(t, "alice@example.com")
+	disabledAt := time.Now()
+	require.NoError(t, s.userRepo.SetDisabledAt(t.Context(), alice.ID, &disabledAt))
+
+	// The access token is still valid, but it can't be traded for a new pair
+	var tokens usecases.RefreshTokenResponse
+	code := s.post(t, accessToken, "/api/v1/me/password",
+		dto.ChangePasswordRequest{CurrentPassword: testPassword, NewPassword: "new-password-456"}, &tokens)
+	assert.Equal(t, http.StatusForbidden, code)
+	assert.Empty(t, tokens.AccessToken)
+	assert.Equal(t, http.StatusForbidden, s.login(t, "alice@example.com", testPassword))
+}
+
+func TestProfile_ChangeEmailNeedsConfirmation(t *testing.T) {
+	s := setupProfileServer(t)
+	_, accessToken := s.createUser(t, "alice@example.com")
+	s.createUser(t, "bob@example.com")
+	changeEmail := func(newEmail, password string) int {
+		return s.post(t, accessToken, "/api/v1/me/email",
+			dto.ChangeEmailRequest{NewEmail: newEmail, CurrentPassword: password}, nil)
+	}
+
+	assert.Equal(t, http.StatusBadRequest, changeEmail("alice@new.example.com", "wrong-password"))
+	assert.Equal(t, http.StatusBadRequest, changeEmail("bob@example.com", testPassword))
+	require.Equal(t, http.StatusAccepted, changeEmail("alice@new.example.com", testPassword))
+
+	// Nothing changes until the new address confirms
+	assert.Equal(t, http.StatusOK, s.login(t, "alice@example.com", testPassword))
+	require.Len(t, s.mailbox.messages, 2)
+	assert.Equal(t, "alice@example.com", s.mailbox.messages[1].To, "the current address is notified")
+
+	confirmation := s.mailbox.lastToken(t, "alice@new.example.com")
+	var profile dto.ProfileResponse
+	code := s.post(t, "", "/api/v1/me/email/confirm", dto.ConfirmEmailChangeRequest{Token: confirmation}, &profile)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, "alice@new.example.com", profile.Email)
+
+	assert.Equal(t, http.StatusOK, s.login(t, "alice@new.example.com", testPassword))
+	assert.Equal(t, http.StatusUnauthorized, s.login(t, "alice@example.com", testPassword))
+
+	// Links work once
+	code = s.post(t, "", "/api/v1/me/email/confirm", dto.ConfirmEmailChangeRequest{Token: confirmation}, nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestProfile_ChangePasswordEnforcesPolicy(t *testing.T) {
+	s := setupProfileServer(t)
+	_, accessToken := s.createUser(t, "alice@example.com")
+	// Rejections carry a body, which request only decodes on success
+	changePassword := func(current, next string) (int, passwordRejection) {
+		payload, err := json.Marshal(dto.ChangePasswordRequest{CurrentPassword: current, NewPassword: next})
+		require.NoError(t, err)
+		req := httptest.NewRequest(http.MethodPost, "/api/v1/me/password", bytes.NewReader(payload))
+		req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+		req.Header.Set("Authorization", "Bearer "+accessToken)
+		rec := httptest.NewRecorder()
+		s.echo.ServeHTTP(rec, req)
+
+		var body passwordRejection
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), &body))
+		return rec.Code, body
+	}
+
+	code, body := changePassword(testPassword, "alice")
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.ElementsMatch(t, []password.Rule{password.RuleMinLength, password.RuleContainsEmail}, body.rules())
+
+	code, body = changePassword(testPassword, testPassword)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []password.Rule{password.RuleReused}, body.rules())
+
+	// The replaced password goes into the history
+	code, _ = changePassword(testPassword, "new-password-456")
+	require.Equal(t, http.StatusOK, code)
+	code, body = changePassword("new-password-456", testPassword)
+	require.Equal(t, http.StatusBadRequest, code)
+	assert.Equal(t, []password.Rule{password.RuleReused}, body.rules())
+}
+
+type passwordRejection struct {
+	Violations []password.Violation `json:"violations"`
+}
+
+func (r passwordRejection) rules() []password.Rule {
+	rules := make([]password.Rule, len(r.Violations))
+	for i, violation := range r.Violations {
+		rules[i] = violation.Rule
+	}
+	return rules
+}
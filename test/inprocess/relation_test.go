@@ -0,0 +1,272 @@
+package inprocess
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"testing"
+
+	authv1 "github.com/EduardoPPCaldas/auth-service/api/proto/auth/v1"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/relation/dto"
+	relationusecases "github.com/EduardoPPCaldas/auth-service/internal/application/relation/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/relation"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	grpcserver "github.com/EduardoPPCaldas/auth-service/internal/presentation/grpc"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type relationServer struct {
+	testServer
+	grpcClient authv1.RelationServiceClient
+}
+
+func setupRelationServer(t *testing.T) *relationServer {
+	db := newTestDB(t, &relation.Tuple{})
+
+	schema, err := relation.NewSchema(relation.DefaultNamespaces())
+	require.NoError(t, err)
+
+	s := &relationServer{testServer: newTestServer(t)}
+
+	relationRepo := postgresRepo.NewRelationTupleRepository(db)
+	checkRelationUseCase := relationusecases.NewCheckRelationUseCase(relationRepo, schema)
+	expandRelationUseCase := relationusecases.NewExpandRelationUseCase(relationRepo, schema)
+	listObjectsUseCase := relationusecases.NewListObjectsUseCase(relationRepo, schema)
+	writeRelationsUseCase := relationusecases.NewWriteRelationsUseCase(relationRepo, schema)
+
+	// HTTP
+	relationHandler := handlers.NewRelationHandler(checkRelationUseCase, expandRelationUseCase, listObjectsUseCase, writeRelationsUseCase)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Relation:          relationHandler,
+		AuthMiddleware:    func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		RequireMiddleware: s.auth.EchoRequire,
+	})
+
+	// gRPC over an in-memory listener
+	listener := bufconn.Listen(1024 * 1024)
+	server := grpcserver.NewServer(s.auth, grpcserver.NewRelationServer(checkRelationUseCase, expandRelationUseCase, listObjectsUseCase, writeRelationsUseCase))
+	go func() { _ = server.Serve(listener) }()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return listener.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+
+	t.Cleanup(func() {
+		conn.Close()
+		server.Stop()
+	})
+
+	s.grpcClient = authv1.NewRelationServiceClient(conn)
+	return s
+}
+
+func (s *relationServer) grpcContext(token string) context.Context {
+	return metadata.AppendToOutgoingContext(context.Background(), "authorization", "Bearer "+token)
+}
+
+var sharedTuples = []dto.TupleRequest{
+	{Object: "group:eng", Relation: "member", Subject: "user:alice"},
+	{Object: "project:apollo", Relation: "editor", Subject: "group:eng#member"},
+	{Object: "project:apollo", Relation: "owner", Subject: "user:carol"},
+	{Object: "document:design", Relation: "parent", Subject: "project:apollo"},
+	{Object: "document:notes", Relation: "owner", Subject: "user:bob"},
+}
+
+func TestRelationsHTTP_SharedDocuments(t *testing.T) {
+	s := setupRelationServer(t)
+	writer := s.token(t, relation.PermissionWrite)
+	reader := s.token(t, relation.PermissionRead)
+
+	code := s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: sharedTuples}, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	// Writing the same tuples again is idempotent
+	code = s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: sharedTuples}, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	checks := []struct {
+		object   string
+		relation string
+		subject  string
+		want     bool
+	}{
+		{"group:eng", "member", "user:alice", true},
+		{"project:apollo", "editor", "user:alice", true},
+		{"project:apollo", "viewer", "user:alice", true},
+		{"project:apollo", "owner", "user:alice", false},
+		{"project:apollo", "editor", "user:carol", true},
+		{"document:design", "viewer", "user:alice", true},
+		{"document:design", "editor", "user:carol", true},
+		{"document:design", "owner", "user:alice", false},
+		{"document:notes", "viewer", "user:bob", true},
+		{"document:notes", "viewer", "user:alice", false},
+		{"document:design", "viewer", "user:bob", false},
+	}
+
+	for _, c := range checks {
+		var response dto.CheckRelationResponse
+		code := s.post(t, reader, "/api/v1/relations/check", dto.CheckRelationRequest{
+			Object: c.object, Relation: c.relation, Subject: c.subject,
+		}, &response)
+		require.Equal(t, http.StatusOK, code)
+		assert.Equal(t, c.want, response.Allowed, "%s#%s@%s", c.object, c.relation, c.subject)
+	}
+
+	var objects dto.ListObjectsResponse
+	code = s.post(t, reader, "/api/v1/relations/list-objects", dto.ListObjectsRequest{
+		Namespace: "document", Relation: "viewer", Subject: "user:alice",
+	}, &objects)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"document:design"}, objects.Objects)
+
+	var tree dto.RelationTreeResponse
+	code = s.post(t, reader, "/api/v1/relations/expand", dto.ExpandRelationRequest{
+		Object: "project:apollo", Relation: "editor",
+	}, &tree)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, []string{"group:eng#member"}, tree.Subjects)
+	require.Len(t, tree.Children, 2)
+	assert.Equal(t, []string{"user:alice"}, tree.Children[0].Subjects)
+	assert.Equal(t, "owner", tree.Children[1].Relation)
+	assert.Equal(t, []string{"user:carol"}, tree.Children[1].Subjects)
+
+	// Removing alice from the group revokes everything she got through it
+	code = s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{
+		Deletes: []dto.TupleRequest{{Object: "group:eng", Relation: "member", Subject: "user:alice"}},
+	}, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	var response dto.CheckRelationResponse
+	code = s.post(t, reader, "/api/v1/relations/check", dto.CheckRelationRequest{
+		Object: "document:design", Relation: "viewer", Subject: "user:alice",
+	}, &response)
+	require.Equal(t, http.StatusOK, code)
+	assert.False(t, response.Allowed)
+}
+
+func TestRelationsHTTP_CyclicGroups(t *testing.T) {
+	s := setupRelationServer(t)
+	writer := s.token(t, relation.PermissionWrite)
+	reader := s.token(t, relation.PermissionRead)
+
+	// The groups contain each other, and their userset tuples come before dave's
+	code := s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: []dto.TupleRequest{
+		{Object: "group:red", Relation: "member", Subject: "group:blue#member"},
+		{Object: "group:blue", Relation: "member", Subject: "group:red#member"},
+		{Object: "project:apollo", Relation: "viewer", Subject: "group:red#member"},
+	}}, nil)
+	require.Equal(t, http.StatusOK, code)
+	code = s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: []dto.TupleRequest{
+		{Object: "group:blue", Relation: "member", Subject: "user:dave"},
+	}}, nil)
+	require.Equal(t, http.StatusOK, code)
+
+	checks := []struct {
+		object   string
+		relation string
+		subject  string
+		want     bool
+	}{
+		{"group:blue", "member", "user:dave", true},
+		{"group:red", "member", "user:dave", true},
+		{"project:apollo", "viewer", "user:dave", true},
+		{"group:red", "member", "user:erin", false},
+		{"project:apollo", "viewer", "user:erin", false},
+	}
+
+	for _, c := range checks {
+		var response dto.CheckRelationResponse
+		code := s.post(t, reader, "/api/v1/relations/check", dto.CheckRelationRequest{
+			Object: c.object, Relation: c.relation, Subject: c.subject,
+		}, &response)
+		require.Equal(t, http.StatusOK, code, "%s#%s@%s", c.object, c.relation, c.subject)
+		assert.Equal(t, c.want, response.Allowed, "%s#%s@%s", c.object, c.relation, c.subject)
+	}
+}
+
+func TestRelationsHTTP_Validation(t *testing.T) {
+	s := setupRelationServer(t)
+	writer := s.token(t, relation.PermissionWrite)
+
+	tests := []struct {
+		name  string
+		tuple dto.TupleRequest
+	}{
+		{"unknown namespace", dto.TupleRequest{Object: "folder:x", Relation: "viewer", Subject: "user:alice"}},
+		{"unknown relation", dto.TupleRequest{Object: "document:x", Relation: "commenter", Subject: "user:alice"}},
+		{"unknown subject relation", dto.TupleRequest{Object: "document:x", Relation: "viewer", Subject: "group:eng#admin"}},
+		{"malformed object", dto.TupleRequest{Object: "document", Relation: "viewer", Subject: "user:alice"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code := s.post(t, writer, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: []dto.TupleRequest{tt.tuple}}, nil)
+			assert.Equal(t, http.StatusBadRequest, code)
+		})
+	}
+}
+
+func TestRelationsHTTP_RequiresPermissions(t *testing.T) {
+	s := setupRelationServer(t)
+	reader := s.token(t, relation.PermissionRead)
+
+	code := s.post(t, reader, "/api/v1/relations/write", dto.WriteRelationsRequest{Writes: sharedTuples}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code = s.post(t, s.token(t), "/api/v1/relations/check", dto.CheckRelationRequest{
+		Object: "group:eng", Relation: "member", Subject: "user:alice",
+	}, nil)
+	assert.Equal(t, http.StatusForbidden, code)
+
+	code = s.post(t, "invalid", "/api/v1/relations/check", dto.CheckRelationRequest{
+		Object: "group:eng", Relation: "member", Subject: "user:alice",
+	}, nil)
+	assert.Equal(t, http.StatusUnauthorized, code)
+}
+
+func TestRelationsGRPC(t *testing.T) {
+	s := setupRelationServer(t)
+	ctx := s.grpcContext(s.token(t, "relations:*"))
+
+	writes := make([]*authv1.RelationTuple, len(sharedTuples))
+	for i, tuple := range sharedTuples {
+		writes[i] = &authv1.RelationTuple{Object: tuple.Object, Relation: tuple.Relation, Subject: tuple.Subject}
+	}
+	_, err := s.grpcClient.Write(ctx, &authv1.WriteRelationsRequest{Writes: writes})
+	require.NoError(t, err)
+
+	check, err := s.grpcClient.Check(ctx, &authv1.CheckRelationRequest{Object: "document:design", Relation: "viewer", Subject: "user:alice"})
+	require.NoError(t, err)
+	assert.True(t, check.GetAllowed())
+
+	list, err := s.grpcClient.ListObjects(ctx, &authv1.ListObjectsRequest{Namespace: "project", Relation: "viewer", Subject: "user:carol"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"project:apollo"}, list.GetObjects())
+
+	expand, err := s.grpcClient.Expand(ctx, &authv1.ExpandRelationRequest{Object: "document:design", Relation: "editor"})
+	require.NoError(t, err)
+	require.Len(t, expand.GetTree().GetChildren(), 2)
+	assert.Equal(t, "project:apollo", expand.GetTree().GetChildren()[1].GetObject())
+
+	_, err = s.grpcClient.Check(ctx, &authv1.CheckRelationRequest{Object: "document:design", Relation: "commenter", Subject: "user:alice"})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	readOnly := s.grpcContext(s.token(t, relation.PermissionRead))
+	_, err = s.grpcClient.Write(readOnly, &authv1.WriteRelationsRequest{Writes: writes})
+	assert.Equal(t, codes.PermissionDenied, status.Code(err))
+
+	_, err = s.grpcClient.Check(context.Background(), &authv1.CheckRelationRequest{Object: "group:eng", Relation: "member", Subject: "user:alice"})
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+}
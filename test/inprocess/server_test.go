@@ -0,0 +1,148 @@
+package inprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/go-playground/validator/v10"
+	"github.com/google/uuid"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/driver/sqlite"
+	"gorm.io/gorm"
+)
+
+const testJWTSecret = "test-secret-key"
+
+// testServer drives the Echo routes in memory with tokens signed by auth.
+type testServer struct {
+	echo *echo.Echo
+	auth *auth.AuthMiddleware
+}
+
+func newTestServer(t *testing.T) testServer {
+	authMiddleware, err := auth.NewAuthMiddleware(auth.WithJWTSecret(testJWTSecret))
+	require.NoError(t, err)
+
+	e := echo.New()
+	e.Validator = &CustomValidator{validator: validator.New()}
+
+	return testServer{echo: e, auth: authMiddleware}
+}
+
+// newTestDB opens an in-memory SQLite database migrated for the given models.
+func newTestDB(t *testing.T, models ...any) *gorm.DB {
+	db, err := gorm.Open(sqlite.Open(":memory:"), &gorm.Config{})
+	require.NoError(t, err)
+
+	// Every connection to ":memory:" is a separate database
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	sqlDB.SetMaxOpenConns(1)
+	t.Cleanup(func() { sqlDB.Close() })
+
+	require.NoError(t, db.AutoMigrate(models...))
+	return db
+}
+
+// newTestPasswordHasher hashes with bcrypt's minimum cost, like the test users' passwords.
+func newTestPasswordHasher(t *testing.T) password.PasswordHasher {
+	params := password.DefaultParams()
+	params.Algorithm = password.Bcrypt
+	params.BcryptCost = bcrypt.MinCost
+	hasher, err := password.NewPasswordHasher(params)
+	require.NoError(t, err)
+	return hasher
+}
+
+// newTestPasswordPolicy is the default policy, with a shorter history.
+func newTestPasswordPolicy(t *testing.T, breached password.BreachedPasswordRange) *password.Policy {
+	return password.NewPolicy(password.PolicyConfig{
+		MinLength:          8,
+		MaxBytes:           72,
+		RejectPersonalInfo: true,
+		HistorySize:        3,
+	}, newTestPasswordHasher(t), breached)
+}
+
+func (s *testServer) token(t *testing.T, permissions ...string) string {
+	token, err := s.auth.CreateToken(uuid.New(), time.Now().Add(time.Hour), map[string]any{
+		"permissions": permissions,
+	})
+	require.NoError(t, err)
+	return token
+}
+
+// request sends an authenticated JSON request and decodes a 2xx response into out.
+func (s *testServer) request(t *testing.T, method, token, path string, body any, out any) int {
+	var reader io.Reader
+	if body != nil {
+		payload, err := json.Marshal(body)
+		require.NoError(t, err)
+		reader = bytes.NewReader(payload)
+	}
+
+	req := httptest.NewRequest(method, path, reader)
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("Authorization", "Bearer "+token)
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	if out != nil && rec.Code >= 200 && rec.Code < 300 {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out))
+	}
+	return rec.Code
+}
+
+func (s *testServer) post(t *testing.T, token, path string, body any, out any) int {
+	return s.request(t, http.MethodPost, token, path, body, out)
+}
+
+// postForm sends a form-encoded request, as OAuth endpoints expect, and
+// decodes the response into out whatever its status, since OAuth errors
+// carry a JSON body too.
+func (s *testServer) postForm(t *testing.T, path string, form url.Values, out any) int {
+	return s.postFormAs(t, "", path, form, out)
+}
+
+// postFormAs is postForm with Bearer credentials, unless token is empty.
+func (s *testServer) postFormAs(t *testing.T, token, path string, form url.Values, out any) int {
+	req := httptest.NewRequest(http.MethodPost, path, strings.NewReader(form.Encode()))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationForm)
+	if token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, req)
+
+	if out != nil {
+		require.NoError(t, json.Unmarshal(rec.Body.Bytes(), out))
+	}
+	return rec.Code
+}
+
+func (s *testServer) get(t *testing.T, token, path string, out any) int {
+	return s.request(t, http.MethodGet, token, path, nil, out)
+}
+
+func (s *testServer) delete(t *testing.T, token, path string) int {
+	return s.request(t, http.MethodDelete, token, path, nil, nil)
+}
+
+type CustomValidator struct {
+	validator *validator.Validate
+}
+
+func (cv *CustomValidator) Validate(i interface{}) error {
+	return cv.validator.Struct(i)
+}
@@ -0,0 +1,190 @@
+package inprocess
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	appmiddleware "github.com/EduardoPPCaldas/auth-service/internal/presentation/http/middleware"
+	"github.com/EduardoPPCaldas/auth-service/internal/tracing"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/otel"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type tracingServer struct {
+	testServer
+	userRepo *postgresRepo.UserRepository
+	exporter *tracetest.InMemoryExporter
+}
+
+// setupTracingServer wires the auth routes with the instrumentation main
+// uses, exporting spans to memory as soon as they end
+func setupTracingServer(t *testing.T) *tracingServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	exporter := tracetest.NewInMemoryExporter()
+	provider := sdktrace.NewTracerProvider(sdktrace.WithSyncer(exporter))
+	previousProvider, previousPropagator := otel.GetTracerProvider(), otel.GetTextMapPropagator()
+	otel.SetTracerProvider(provider)
+	otel.SetTextMapPropagator(propagation.TraceContext{})
+	t.Cleanup(func() {
+		otel.SetTracerProvider(previousProvider)
+		otel.SetTextMapPropagator(previousPropagator)
+	})
+
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{})
+	require.NoError(t, db.Use(tracing.GormPlugin{}))
+
+	s := &tracingServer{testServer: newTestServer(t), exporter: exporter}
+	s.echo.Use(appmiddleware.Tracing())
+	s.userRepo = postgresRepo.NewUserRepository(db)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, token.NewTokenGenerator(), newTestPasswordHasher(t), nil),
+		nil,
+		nil,
+		nil,
+		nil,
+	)
+	profileHandler := handlers.NewProfileHandler(usecases.NewGetUserUseCase(s.userRepo), nil, nil, nil, nil, nil, nil, nil)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:           authHandler,
+		Profile:        profileHandler,
+		AuthMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+	})
+	return s
+}
+
+// spans returns the exported spans named name
+func (s *tracingServer) spans(name string) []tracetest.SpanStub {
+	var found []tracetest.SpanStub
+	for _, span := range s.exporter.GetSpans() {
+		if span.Name == name {
+			found = append(found, span)
+		}
+	}
+	return found
+}
+
+// span returns the only exported span named name
+func (s *tracingServer) span(t *testing.T, name string) tracetest.SpanStub {
+	found := s.spans(name)
+	require.Len(t, found, 1, "spans named %q", name)
+	return found[0]
+}
+
+// tableQuery returns the gorm.query span reading table
+func (s *tracingServer) tableQuery(t *testing.T, table string) tracetest.SpanStub {
+	for _, span := range s.spans("gorm.query") {
+		if spanAttributes(span)["db.collection.name"].AsString() == table {
+			return span
+		}
+	}
+	require.Failf(t, "no query span", "table %q", table)
+	return tracetest.SpanStub{}
+}
+
+func spanAttributes(span tracetest.SpanStub) map[attribute.Key]attribute.Value {
+	attrs := make(map[attribute.Key]attribute.Value, len(span.Attributes))
+	for _, attr := range span.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+	return attrs
+}
+
+func TestTracing_LoginSpansContinueTheCallersTrace(t *testing.T) {
+	s := setupTracingServer(t)
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	encoded := string(hash)
+	require.NoError(t, s.userRepo.Create(t.Context(), user.New("alice@example.com", &encoded)))
+	s.exporter.Reset()
+
+	const traceID, parentID = "4bf92f3577b34da6a3ce929d0e0e4736", "00f067aa0ba902b7"
+	rec := httptest.NewRecorder()
+	payload, err := json.Marshal(dto.LoginUserRequest{Email: "alice@example.com", Password: testPassword})
+	require.NoError(t, err)
+	req := httptest.NewRequest(http.MethodPost, "/api/v1/auth/login", bytes.NewReader(payload))
+	req.Header.Set(echo.HeaderContentType, echo.MIMEApplicationJSON)
+	req.Header.Set("traceparent", "00-"+traceID+"-"+parentID+"-01")
+	s.echo.ServeHTTP(rec, req)
+	require.Equal(t, http.StatusOK, rec.Code)
+
+	server := s.span(t, "POST /api/v1/auth/login")
+	assert.Equal(t, traceID, server.SpanContext.TraceID().String())
+	assert.Equal(t, parentID, server.Parent.SpanID().String())
+	assert.True(t, server.Parent.IsRemote())
+	assert.Equal(t, int64(http.StatusOK), spanAttributes(server)["http.response.status_code"].AsInt64())
+	assert.Equal(t, "/api/v1/auth/login", spanAttributes(server)["http.route"].AsString())
+
+	execute := s.span(t, "LoginUserUseCase.Execute")
+	assert.Equal(t, server.SpanContext.SpanID(), execute.Parent.SpanID())
+	assert.Equal(t, codes.Unset, execute.Status.Code)
+
+	verify := s.span(t, "password.Verify")
+	assert.Equal(t, execute.SpanContext.SpanID(), verify.Parent.SpanID())
+
+	query := s.tableQuery(t, "users")
+	assert.Equal(t, execute.SpanContext.SpanID(), query.Parent.SpanID())
+	queryAttrs := spanAttributes(query)
+	assert.Equal(t, "sqlite", queryAttrs["db.system.name"].AsString())
+	assert.NotContains(t, queryAttrs["db.query.text"].AsString(), "alice@example.com")
+}
+
+func TestTracing_FailedUseCaseRecordsTheError(t *testing.T) {
+	s := setupTracingServer(t)
+
+	assert.Equal(t, http.StatusUnauthorized, s.post(t, "", "/api/v1/auth/login", dto.LoginUserRequest{Email: "nobody@example.com", Password: testPassword}, nil))
+
+	execute := s.span(t, "LoginUserUseCase.Execute")
+	assert.Equal(t, codes.Error, execute.Status.Code)
+	require.NotEmpty(t, execute.Events)
+	assert.Equal(t, "exception", execute.Events[0].Name)
+
+	// A missing row is an answer, not a failed statement
+	assert.Equal(t, codes.Unset, s.tableQuery(t, "users").Status.Code)
+}
+
+func TestTracing_MiddlewareAnnotatesSubjectAndDecision(t *testing.T) {
+	s := setupTracingServer(t)
+	alice := user.New("alice@example.com", nil)
+	require.NoError(t, s.userRepo.Create(t.Context(), alice))
+	accessToken, err := s.auth.CreateToken(alice.ID, time.Now().Add(time.Hour), nil)
+	require.NoError(t, err)
+
+	s.exporter.Reset()
+	assert.Equal(t, http.StatusOK, s.get(t, accessToken, "/api/v1/me", nil))
+	allowed := spanAttributes(s.span(t, "GET /api/v1/me"))
+	assert.Equal(t, "allow", allowed[auth.SpanAttributeDecision].AsString())
+	assert.Equal(t, alice.ID.String(), allowed[auth.SpanAttributeSubject].AsString())
+
+	s.exporter.Reset()
+	rec := httptest.NewRecorder()
+	s.echo.ServeHTTP(rec, httptest.NewRequest(http.MethodGet, "/api/v1/me", nil))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+	denied := s.span(t, "GET /api/v1/me")
+	assert.Equal(t, "deny", spanAttributes(denied)[auth.SpanAttributeDecision].AsString())
+	assert.Equal(t, string(auth.ErrorTypeMissing), spanAttributes(denied)[auth.SpanAttributeErrorType].AsString())
+	assert.NotContains(t, spanAttributes(denied), attribute.Key(auth.SpanAttributeSubject))
+}
@@ -0,0 +1,192 @@
+package inprocess
+
+import (
+	"net/http"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/group"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/organization"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/bcrypt"
+)
+
+type userAdminServer struct {
+	testServer
+	userRepo            *postgresRepo.UserRepository
+	refreshTokenService token.Service
+	adminToken          string
+	admin               *user.User
+	userRole            *role.Role
+}
+
+const testPassword = "password123"
+
+func setupUserAdminServer(t *testing.T) *userAdminServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t,
+		&user.User{}, &role.Role{}, &role.Permission{}, &group.Group{}, &group.Member{},
+		&organization.Organization{}, &organization.Membership{}, &domaintoken.RefreshToken{},
+		&domaintoken.PersonalAccessToken{}, &user.PasswordHistoryEntry{},
+	)
+	s := &userAdminServer{testServer: newTestServer(t)}
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	tokenGenerator := token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+
+	authHandler := handlers.NewAuthHandler(
+		nil,
+		usecases.NewLoginUserUseCase(s.userRepo, tokenGenerator, newTestPasswordHasher(t), nil),
+		nil,
+		usecases.NewRefreshTokenUseCase(s.userRepo, tokenGenerator, s.refreshTokenService, time.Hour),
+		nil,
+		nil,
+	)
+	userHandler := handlers.NewUserHandler(
+		usecases.NewListUsersUseCase(s.userRepo),
+		usecases.NewGetUserUseCase(s.userRepo),
+		usecases.NewDisableUserUseCase(s.userRepo, s.refreshTokenService, nil, nil),
+		usecases.NewEnableUserUseCase(s.userRepo, nil, nil),
+		usecases.NewDeleteUserUseCase(s.userRepo, nil, nil),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		Auth:            authHandler,
+		User:            userHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+
+	ctx := t.Context()
+	adminRole := role.NewAdminRole()
+	s.userRole = role.NewUserRole()
+	require.NoError(t, roleRepo.Create(ctx, adminRole))
+	require.NoError(t, roleRepo.Create(ctx, s.userRole))
+
+	s.admin = s.createUser(t, "admin@example.com", adminRole)
+	loaded, err := s.userRepo.FindByID(ctx, s.admin.ID)
+	require.NoError(t, err)
+	s.adminToken, err = tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	return s
+}
+
+func (s *userAdminServer) createUser(t *testing.T, email string, r *role.Role) *user.User {
+	hash, err := bcrypt.GenerateFromPassword([]byte(testPassword), bcrypt.MinCost)
+	require.NoError(t, err)
+	password := string(hash)
+
+	u := user.New(email, &password)
+	u.RoleID = &r.ID
+	require.NoError(t, s.userRepo.Create(t.Context(), u))
+	return u
+}
+
+func (s *userAdminServer) login(t *testing.T, email string) int {
+	return s.post(t, "", "/api/v1/auth/login", dto.LoginUserRequest{Email: email, Password: testPassword}, nil)
+}
+
+func (s *userAdminServer) listEmails(t *testing.T, query url.Values) ([]string, string) {
+	var page dto.ListUsersResponse
+	code := s.get(t, s.adminToken, "/api/v1/admin/users?"+query.Encode(), &page)
+	require.Equal(t, http.StatusOK, code)
+
+	emails := make([]string, len(page.Users))
+	for i, u := range page.Users {
+		emails[i] = u.Email
+	}
+	return emails, page.NextCursor
+}
+
+func TestAdminUsers_ListPagesAndFilters(t *testing.T) {
+	s := setupUserAdminServer(t)
+	for _, email := range []string{"alice@example.com", "bob@example.com", "bobby@example.com", "carol@example.com"} {
+		s.createUser(t, email, s.userRole)
+		// Distinct creation times keep the expected order unambiguous
+		time.Sleep(time.Millisecond)
+	}
+
+	var all []string
+	query := url.Values{"limit": {"2"}}
+	for pages := 0; ; pages++ {
+		require.Less(t, pages, 5, "pagination does not terminate")
+		emails, next := s.listEmails(t, query)
+		all = append(all, emails...)
+		if next == "" {
+			break
+		}
+		query.Set("cursor", next)
+	}
+	assert.Equal(t, []string{
+		"admin@example.com", "alice@example.com", "bob@example.com", "bobby@example.com", "carol@example.com",
+	}, all)
+
+	emails, _ := s.listEmails(t, url.Values{"email": {"BOB"}})
+	assert.Equal(t, []string{"bob@example.com", "bobby@example.com"}, emails)
+
+	emails, _ = s.listEmails(t, url.Values{"role": {role.RoleAdmin}})
+	assert.Equal(t, []string{"admin@example.com"}, emails)
+
+	// LIKE wildcards in the prefix match literally
+	emails, _ = s.listEmails(t, url.Values{"email": {"%"}})
+	assert.Empty(t, emails)
+
+	emails, _ = s.listEmails(t, url.Values{"created_after": {time.Now().Add(time.Hour).Format(time.RFC3339)}})
+	assert.Empty(t, emails)
+
+	code := s.get(t, s.adminToken, "/api/v1/admin/users?cursor=not-a-cursor", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+	code = s.get(t, s.adminToken, "/api/v1/admin/users?status=suspended", nil)
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
+func TestAdminUsers_DisableEnableDelete(t *testing.T) {
+	s := setupUserAdminServer(t)
+	ctx := t.Context()
+	bob := s.createUser(t, "bob@example.com", s.userRole)
+	bobPath := "/api/v1/admin/users/" + bob.ID.String()
+
+	refreshToken, err := s.refreshTokenService.GenerateRefreshToken(ctx, bob)
+	require.NoError(t, err)
+
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, bobPath+"/disable", nil, nil))
+
+	var detail dto.UserResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, bobPath, &detail))
+	assert.Equal(t, user.StatusDisabled, detail.Status)
+	assert.NotEmpty(t, detail.DisabledAt)
+
+	emails, _ := s.listEmails(t, url.Values{"status": {user.StatusDisabled}})
+	assert.Equal(t, []string{"bob@example.com"}, emails)
+
+	assert.Equal(t, http.StatusForbidden, s.login(t, "bob@example.com"))
+	code := s.post(t, "", "/api/v1/auth/refresh", dto.RefreshTokenRequest{RefreshToken: refreshToken}, nil)
+	assert.Equal(t, http.StatusUnauthorized, code, "refresh tokens are revoked on disable")
+
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, bobPath+"/enable", nil, nil))
+	assert.Equal(t, http.StatusOK, s.login(t, "bob@example.com"))
+	assert.Equal(t, http.StatusBadRequest, s.post(t, s.adminToken, bobPath+"/enable", nil, nil))
+
+	// Admins cannot lock themselves out
+	adminPath := "/api/v1/admin/users/" + s.admin.ID.String()
+	assert.Equal(t, http.StatusBadRequest, s.post(t, s.adminToken, adminPath+"/disable", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, s.delete(t, s.adminToken, adminPath))
+
+	require.Equal(t, http.StatusOK, s.delete(t, s.adminToken, bobPath))
+	assert.Equal(t, http.StatusNotFound, s.get(t, s.adminToken, bobPath, nil))
+	assert.Equal(t, http.StatusUnauthorized, s.login(t, "bob@example.com"))
+}
@@ -0,0 +1,307 @@
+package inprocess
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	outboxservices "github.com/EduardoPPCaldas/auth-service/internal/application/outbox/services"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
+	webhookdto "github.com/EduardoPPCaldas/auth-service/internal/application/webhook/dto"
+	webhookusecases "github.com/EduardoPPCaldas/auth-service/internal/application/webhook/usecases"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/outbox"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
+	domaintoken "github.com/EduardoPPCaldas/auth-service/internal/domain/token"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
+	"github.com/EduardoPPCaldas/auth-service/internal/domain/webhook"
+	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
+	webhookinfra "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/webhook"
+	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
+	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
+	"github.com/EduardoPPCaldas/auth-service/pkg/auth"
+	"github.com/labstack/echo/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"gorm.io/gorm"
+)
+
+type webhookServer struct {
+	*userAdminServer
+	db        *gorm.DB
+	publisher *outboxservices.Publisher
+	dispatch  *webhookusecases.DispatchWebhooksUseCase
+}
+
+// setupWebhookServer serves user management, publishing to the outbox, and
+// the webhook endpoints. Failed deliveries are retried at once, twice at most.
+func setupWebhookServer(t *testing.T) *webhookServer {
+	t.Setenv("JWT_SECRET", testJWTSecret)
+	db := newTestDB(t, &user.User{}, &role.Role{}, &role.Permission{}, &domaintoken.RefreshToken{},
+		&outbox.Message{}, &webhook.Webhook{}, &webhook.Delivery{})
+	s := &webhookServer{userAdminServer: &userAdminServer{testServer: newTestServer(t)}, db: db}
+
+	s.userRepo = postgresRepo.NewUserRepository(db)
+	roleRepo := postgresRepo.NewRoleRepository(db)
+	outboxRepo := postgresRepo.NewOutboxRepository(db)
+	webhookRepo := postgresRepo.NewWebhookRepository(db)
+	transactor := postgresRepo.NewTransactor(db)
+	s.publisher = outboxservices.NewPublisher(transactor, outboxRepo)
+	s.dispatch = webhookusecases.NewDispatchWebhooksUseCase(outboxRepo, webhookRepo, transactor,
+		webhookinfra.NewHTTPSender(5*time.Second), webhook.RetryPolicy{MaxAttempts: 2})
+	tokenGenerator := token.NewTokenGenerator()
+	s.refreshTokenService = token.NewRefreshTokenService(postgresRepo.NewRefreshTokenRepository(db), s.userRepo, time.Hour)
+
+	userHandler := handlers.NewUserHandler(
+		nil,
+		nil,
+		usecases.NewDisableUserUseCase(s.userRepo, s.refreshTokenService, nil, s.publisher),
+		usecases.NewEnableUserUseCase(s.userRepo, nil, s.publisher),
+		nil,
+	)
+	webhookHandler := handlers.NewWebhookHandler(
+		webhookusecases.NewCreateWebhookUseCase(webhookRepo, nil),
+		webhookusecases.NewListWebhooksUseCase(webhookRepo),
+		webhookusecases.NewDeleteWebhookUseCase(webhookRepo, nil),
+		webhookusecases.NewListDeliveriesUseCase(webhookRepo),
+		webhookusecases.NewReplayDeliveryUseCase(webhookRepo, nil),
+	)
+	httphandler.SetupRoutes(s.echo, httphandler.Routes{
+		User:            userHandler,
+		Webhook:         webhookHandler,
+		AuthMiddleware:  func() echo.MiddlewareFunc { return s.auth.EchoMiddleware() },
+		AdminMiddleware: func() echo.MiddlewareFunc { return s.auth.EchoRequireRole(role.RoleAdmin) },
+	})
+
+	ctx := t.Context()
+	adminRole := role.NewAdminRole()
+	s.userRole = role.NewUserRole()
+	require.NoError(t, roleRepo.Create(ctx, adminRole))
+	require.NoError(t, roleRepo.Create(ctx, s.userRole))
+
+	s.admin = s.createUser(t, "admin@example.com", adminRole)
+	loaded, err := s.userRepo.FindByID(ctx, s.admin.ID)
+	require.NoError(t, err)
+	s.adminToken, err = tokenGenerator.GenerateToken(loaded)
+	require.NoError(t, err)
+
+	return s
+}
+
+func (s *webhookServer) createWebhook(t *testing.T, url string, eventTypes ...string) webhookdto.WebhookResponse {
+	var created webhookdto.WebhookResponse
+	require.Equal(t, http.StatusCreated, s.post(t, s.adminToken, "/api/v1/admin/webhooks",
+		webhookdto.CreateWebhookRequest{URL: url, EventTypes: eventTypes}, &created))
+	return created
+}
+
+func (s *webhookServer) deliveries(t *testing.T, webhookID, status string) []webhookdto.WebhookDeliveryResponse {
+	var deliveries []webhookdto.WebhookDeliveryResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken,
+		"/api/v1/admin/webhooks/"+webhookID+"/deliveries?status="+status, &deliveries))
+	return deliveries
+}
+
+func (s *webhookServer) runDispatch(t *testing.T) int64 {
+	attempted, err := s.dispatch.Execute(t.Context())
+	require.NoError(t, err)
+	return attempted
+}
+
+// receivedWebhook is a request received by a webhookReceiver
+type receivedWebhook struct {
+	header http.Header
+	body   []byte
+}
+
+// webhookReceiver records the requests it receives and answers with status
+type webhookReceiver struct {
+	*httptest.Server
+	status atomic.Int32
+	mu     sync.Mutex
+	got    []receivedWebhook
+}
+
+func newWebhookReceiver(t *testing.T) *webhookReceiver {
+	r := &webhookReceiver{}
+	r.status.Store(http.StatusOK)
+	r.Server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		body, _ := io.ReadAll(req.Body)
+		r.mu.Lock()
+		r.got = append(r.got, receivedWebhook{header: req.Header.Clone(), body: body})
+		r.mu.Unlock()
+		w.WriteHeader(int(r.status.Load()))
+	}))
+	t.Cleanup(r.Close)
+	return r
+}
+
+func (r *webhookReceiver) received() []receivedWebhook {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return append([]receivedWebhook(nil), r.got...)
+}
+
+func TestWebhooks_DeliverSignedEvents(t *testing.T) {
+	s := setupWebhookServer(t)
+	receiver := newWebhookReceiver(t)
+	alice := s.createUser(t, "alice@example.com", s.userRole)
+	alicePath := "/api/v1/admin/users/" + alice.ID.String()
+
+	hook := s.createWebhook(t, receiver.URL, outbox.EventUserDisabled)
+	require.NotEmpty(t, hook.Secret)
+	var listed []webhookdto.WebhookResponse
+	require.Equal(t, http.StatusOK, s.get(t, s.adminToken, "/api/v1/admin/webhooks", &listed))
+	require.Len(t, listed, 1)
+	assert.Empty(t, listed[0].Secret, "the secret is only shown once")
+
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, alicePath+"/disable", nil, nil))
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, alicePath+"/enable", nil, nil))
+	assert.Equal(t, int64(1), s.runDispatch(t), "only the subscribed event is delivered")
+	assert.Zero(t, s.runDispatch(t))
+
+	got := receiver.received()
+	require.Len(t, got, 1)
+	require.NoError(t, auth.VerifyWebhook(hook.Secret, got[0].header.Get(auth.WebhookSignatureHeader), got[0].body, 0))
+	assert.ErrorIs(t, auth.VerifyWebhook("whsec_other", got[0].header.Get(auth.WebhookSignatureHeader), got[0].body, 0),
+		auth.ErrInvalidWebhookSignature)
+
+	var event auth.WebhookEvent
+	require.NoError(t, json.Unmarshal(got[0].body, &event))
+	assert.Equal(t, outbox.EventUserDisabled, event.Type)
+	assert.Equal(t, event.ID, got[0].header.Get(auth.WebhookEventIDHeader))
+	assert.Equal(t, outbox.EventUserDisabled, got[0].header.Get(auth.WebhookEventTypeHeader))
+	var data outbox.UserEvent
+	require.NoError(t, json.Unmarshal(event.Data, &data))
+	assert.Equal(t, outbox.UserEvent{UserID: alice.ID, Email: "alice@example.com"}, data)
+
+	deliveries := s.deliveries(t, hook.ID, "")
+	require.Len(t, deliveries, 1)
+	assert.Equal(t, webhook.StatusDelivered, deliveries[0].Status)
+	assert.Equal(t, event.ID, deliveries[0].EventID)
+	assert.Equal(t, http.StatusOK, deliveries[0].LastStatusCode)
+
+	assert.Equal(t, http.StatusBadRequest, s.post(t, s.adminToken, "/api/v1/admin/webhooks",
+		webhookdto.CreateWebhookRequest{URL: receiver.URL, EventTypes: []string{"user.renamed"}}, nil))
+	assert.Equal(t, http.StatusBadRequest, s.post(t, s.adminToken, "/api/v1/admin/webhooks",
+		webhookdto.CreateWebhookRequest{URL: "ftp://example.com"}, nil))
+
+	require.Equal(t, http.StatusOK, s.delete(t, s.adminToken, "/api/v1/admin/webhooks/"+hook.ID))
+	assert.Equal(t, http.StatusNotFound, s.delete(t, s.adminToken, "/api/v1/admin/webhooks/"+hook.ID))
+	assert.Equal(t, http.StatusNotFound, s.get(t, s.adminToken, "/api/v1/admin/webhooks/"+hook.ID+"/deliveries", nil))
+}
+
+func TestWebhooks_RetryUntilDeadThenReplay(t *testing.T) {
+	s := setupWebhookServer(t)
+	receiver := newWebhookReceiver(t)
+	receiver.status.Store(http.StatusServiceUnavailable)
+	alice := s.createUser(t, "alice@example.com", s.userRole)
+
+	hook := s.createWebhook(t, receiver.URL)
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, "/api/v1/admin/users/"+alice.ID.String()+"/disable", nil, nil))
+
+	assert.Equal(t, int64(1), s.runDispatch(t))
+	pending := s.deliveries(t, hook.ID, webhook.StatusPending)
+	require.Len(t, pending, 1)
+	assert.Equal(t, 1, pending[0].Attempts)
+	assert.Equal(t, http.StatusServiceUnavailable, pending[0].LastStatusCode)
+	assert.NotEmpty(t, pending[0].LastError)
+
+	assert.Equal(t, int64(1), s.runDispatch(t))
+	assert.Zero(t, s.runDispatch(t), "dead deliveries are not retried")
+	dead := s.deliveries(t, hook.ID, webhook.StatusDead)
+	require.Len(t, dead, 1)
+	assert.Equal(t, 2, dead[0].Attempts)
+
+	got := receiver.received()
+	require.Len(t, got, 2)
+	assert.Equal(t, got[0].header.Get(auth.WebhookEventIDHeader), got[1].header.Get(auth.WebhookEventIDHeader),
+		"retries carry the same event ID")
+	assert.Equal(t, "2", got[1].header.Get(webhookinfra.AttemptHeader))
+
+	receiver.status.Store(http.StatusNoContent)
+	replayPath := "/api/v1/admin/webhooks/deliveries/" + dead[0].ID + "/replay"
+	var replayed webhookdto.WebhookDeliveryResponse
+	require.Equal(t, http.StatusOK, s.post(t, s.adminToken, replayPath, nil, &replayed))
+	assert.Equal(t, webhook.StatusPending, replayed.Status)
+	assert.Equal(t, http.StatusBadRequest, s.post(t, s.adminToken, replayPath, nil, nil), "already pending")
+
+	assert.Equal(t, int64(1), s.runDispatch(t))
+	delivered := s.deliveries(t, hook.ID, webhook.StatusDelivered)
+	require.Len(t, delivered, 1)
+	assert.Equal(t, 1, delivered[0].Attempts)
+	assert.Equal(t, "1", receiver.received()[2].header.Get(webhookinfra.AttemptHeader))
+
+	assert.Equal(t, http.StatusNotFound, s.post(t, s.adminToken,
+		"/api/v1/admin/webhooks/deliveries/"+hook.ID+"/replay", nil, nil))
+	assert.Equal(t, http.StatusBadRequest, s.get(t, s.adminToken,
+		"/api/v1/admin/webhooks/"+hook.ID+"/deliveries?status=lost", nil))
+}
+
+func TestOutbox_EventIsCommittedWithTheChange(t *testing.T) {
+	s := setupWebhookServer(t)
+	ctx := t.Context()
+	countMessages := func() int64 {
+		var count int64
+		require.NoError(t, s.db.Model(&outbox.Message{}).Count(&count).Error)
+		return count
+	}
+
+	bob := user.New("bob@example.com", nil)
+	failed := errors.New("failed after the change")
+	err := s.publisher.Publish(ctx, outbox.EventUserCreated, outbox.UserEvent{UserID: bob.ID}, func(ctx context.Context) error {
+		require.NoError(t, s.userRepo.Create(ctx, bob))
+		return failed
+	})
+	require.ErrorIs(t, err, failed)
+	_, err = s.userRepo.FindByID(ctx, bob.ID)
+	assert.Error(t, err, "the change is rolled back")
+	assert.Zero(t, countMessages(), "and so is the event")
+
+	require.NoError(t, s.publisher.Publish(ctx, outbox.EventUserCreated, outbox.UserEvent{UserID: bob.ID}, func(ctx context.Context) error {
+		return s.userRepo.Create(ctx, bob)
+	}))
+	_, err = s.userRepo.FindByID(ctx, bob.ID)
+	assert.NoError(t, err)
+	assert.Equal(t, int64(1), countMessages())
+}
+
+func TestOutbox_BootstrapAdminPublishesEvents(t *testing.T) {
+	s := setupWebhookServer(t)
+	ctx := t.Context()
+	roleRepo := postgresRepo.NewRoleRepository(s.db)
+	bootstrapAdmin := usecases.NewBootstrapAdminUseCase(s.userRepo, roleRepo, newTestPasswordHasher(t), newTestPasswordPolicy(t, nil), s.publisher)
+	events := func() []string {
+		var messages []outbox.Message
+		require.NoError(t, s.db.Order("occurred_at ASC, id ASC").Find(&messages).Error)
+		types := make([]string, len(messages))
+		for i, m := range messages {
+			types[i] = m.EventType
+		}
+		return types
+	}
+
+	root, created, err := bootstrapAdmin.Execute(ctx, "root@example.com", "correct-horse-battery")
+	require.NoError(t, err)
+	require.True(t, created)
+	assert.Equal(t, []string{outbox.EventUserCreated, outbox.EventRoleAssigned}, events())
+
+	alice := user.New("alice@example.com", nil)
+	require.NoError(t, s.userRepo.Create(ctx, alice))
+	_, created, err = bootstrapAdmin.Execute(ctx, alice.Email, "")
+	require.NoError(t, err)
+	require.False(t, created)
+	assert.Equal(t, []string{outbox.EventUserCreated, outbox.EventRoleAssigned, outbox.EventRoleAssigned}, events())
+
+	// Running it again for an admin changes nothing
+	_, _, err = bootstrapAdmin.Execute(ctx, root.Email, "")
+	require.NoError(t, err)
+	assert.Len(t, events(), 3)
+}
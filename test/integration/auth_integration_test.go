@@ -11,11 +11,11 @@ import (
 	"time"
 
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/dto"
+	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/password"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/services/token"
 	"github.com/EduardoPPCaldas/auth-service/internal/application/user/usecases"
-	"github.com/EduardoPPCaldas/auth-service/internal/domain/role"
-	"github.com/EduardoPPCaldas/auth-service/internal/domain/user"
 	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/oauth/google"
+	"github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/migrations"
 	postgresRepo "github.com/EduardoPPCaldas/auth-service/internal/infrastructure/postgres/repository"
 	httphandler "github.com/EduardoPPCaldas/auth-service/internal/presentation/http"
 	"github.com/EduardoPPCaldas/auth-service/internal/presentation/http/handlers"
@@ -52,7 +52,11 @@ func setupIntegrationServer(t *testing.T) (*echo.Echo, func()) {
 	db, err := gorm.Open(postgres.Open(connStr), &gorm.Config{})
 	require.NoError(t, err)
 
-	err = db.AutoMigrate(&user.User{}, &role.Role{}, &role.Permission{})
+	sqlDB, err := db.DB()
+	require.NoError(t, err)
+	migrator, err := migrations.NewMigrator(sqlDB)
+	require.NoError(t, err)
+	_, err = migrator.Up(ctx)
 	require.NoError(t, err)
 
 	// Set JWT secret
@@ -66,11 +70,13 @@ func setupIntegrationServer(t *testing.T) (*echo.Echo, func()) {
 	tokenGenerator := token.NewTokenGenerator()
 	googleValidator := google.NewGoogleTokenValidator("")
 	googleOAuthService := google.NewGoogleOAuthChallengeService("", "", "")
+	passwordHasher, err := password.NewPasswordHasher(password.DefaultParams())
+	require.NoError(t, err)
 
 	// Initialize use cases
-	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, roleRepo, tokenGenerator)
-	loginUserUseCase := usecases.NewLoginUserUseCase(userRepo, tokenGenerator)
-	loginWithGoogleUseCase := usecases.NewLoginWithGoogleUseCase(userRepo, roleRepo, tokenGenerator, googleValidator)
+	createUserUseCase := usecases.NewCreateUserUseCase(userRepo, roleRepo, tokenGenerator, passwordHasher, password.NewPolicy(password.PolicyConfig{MinLength: 8, MaxBytes: 72}, passwordHasher, nil), nil, nil)
+	loginUserUseCase := usecases.NewLoginUserUseCase(userRepo, tokenGenerator, passwordHasher, nil)
+	loginWithGoogleUseCase := usecases.NewLoginWithGoogleUseCase(userRepo, roleRepo, tokenGenerator, googleValidator, nil, nil)
 
 	// Initialize handlers
 	authHandler := handlers.NewAuthHandler(
@@ -86,7 +92,9 @@ func setupIntegrationServer(t *testing.T) (*echo.Echo, func()) {
 	e := echo.New()
 	httphandler.SetupMiddleware(e)
 	e.Validator = &CustomValidator{validator: validator.New()}
-	httphandler.SetupRoutes(e, authHandler, nil, nil, nil)
+	httphandler.SetupRoutes(e, httphandler.Routes{
+		Auth: authHandler,
+	})
 
 	cleanup := func() {
 		os.Unsetenv("JWT_SECRET")